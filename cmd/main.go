@@ -9,6 +9,10 @@ import (
 	"github.com/AleksK1NG/es-microservice/pkg/logger"
 )
 
+const (
+	rebuildCommand = "rebuild"
+)
+
 // @contact.name Alexander Bryksin
 // @contact.url https://github.com/AleksK1NG
 // @contact.email alexander.bryksin@yandex.ru
@@ -23,5 +27,14 @@ func main() {
 	appLogger := logger.NewAppLogger(cfg.Logger)
 	appLogger.InitLogger()
 	appLogger.WithName(server.GetMicroserviceName(cfg))
+
+	// rebuild [mongo elastic] rebuilds read models from the event store and exits
+	if flag.Arg(0) == rebuildCommand {
+		if err := server.NewServer(cfg, appLogger).RunRebuild(flag.Args()[1:]); err != nil {
+			appLogger.Fatal(err)
+		}
+		return
+	}
+
 	appLogger.Fatal(server.NewServer(cfg, appLogger).Run())
 }
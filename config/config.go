@@ -4,14 +4,21 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"time"
 
+	"github.com/AleksK1NG/es-microservice/pkg/auth"
 	"github.com/AleksK1NG/es-microservice/pkg/constants"
 	"github.com/AleksK1NG/es-microservice/pkg/elasticsearch"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/es/subscription"
 	"github.com/AleksK1NG/es-microservice/pkg/eventstroredb"
 	"github.com/AleksK1NG/es-microservice/pkg/logger"
 	"github.com/AleksK1NG/es-microservice/pkg/mongodb"
+	"github.com/AleksK1NG/es-microservice/pkg/outbox"
 	"github.com/AleksK1NG/es-microservice/pkg/probes"
+	"github.com/AleksK1NG/es-microservice/pkg/ratelimit"
 	"github.com/AleksK1NG/es-microservice/pkg/tracing"
+	"github.com/AleksK1NG/es-microservice/pkg/utils"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
@@ -29,12 +36,18 @@ type Config struct {
 	Mongo            *mongodb.Config                `mapstructure:"mongo"`
 	MongoCollections MongoCollections               `mapstructure:"mongoCollections"`
 	Probes           probes.Config                  `mapstructure:"probes"`
-	Jaeger           *tracing.Config                `mapstructure:"jaeger"`
+	Tracing          *tracing.Config                `mapstructure:"tracing"`
 	EventStoreConfig eventstroredb.EventStoreConfig `mapstructure:"eventStoreConfig"`
+	EventSourcing    es.Config                      `mapstructure:"eventSourcing"`
 	Subscriptions    Subscriptions                  `mapstructure:"subscriptions"`
+	Outbox           outbox.Config                  `mapstructure:"outbox"`
 	Elastic          elasticsearch.Config           `mapstructure:"elastic"`
 	ElasticIndexes   ElasticIndexes                 `mapstructure:"elasticIndexes"`
 	Http             Http                           `mapstructure:"http"`
+	Orders           Orders                         `mapstructure:"orders"`
+	Auth             auth.Config                    `mapstructure:"auth"`
+	RateLimit        ratelimit.Config               `mapstructure:"rateLimit"`
+	Cursors          utils.CursorConfig             `mapstructure:"cursors"`
 }
 
 type GRPC struct {
@@ -43,14 +56,33 @@ type GRPC struct {
 }
 
 type MongoCollections struct {
-	Orders string `mapstructure:"orders" validate:"required"`
+	Orders      string `mapstructure:"orders" validate:"required"`
+	Snapshots   string `mapstructure:"snapshots"`
+	DeadLetters string `mapstructure:"deadLetters" validate:"required"`
+	Idempotency string `mapstructure:"idempotency" validate:"required"`
 }
 
 type Subscriptions struct {
-	PoolSize                   int    `mapstructure:"poolSize" validate:"required,gte=0"`
-	OrderPrefix                string `mapstructure:"orderPrefix" validate:"required,gte=0"`
-	MongoProjectionGroupName   string `mapstructure:"mongoProjectionGroupName" validate:"required,gte=0"`
-	ElasticProjectionGroupName string `mapstructure:"elasticProjectionGroupName" validate:"required,gte=0"`
+	PoolSize                   int            `mapstructure:"poolSize" validate:"required,gte=0"`
+	OrderPrefix                string         `mapstructure:"orderPrefix" validate:"required,gte=0"`
+	MongoProjectionGroupName   string         `mapstructure:"mongoProjectionGroupName" validate:"required,gte=0"`
+	ElasticProjectionGroupName string         `mapstructure:"elasticProjectionGroupName" validate:"required,gte=0"`
+	ReconnectDelay             time.Duration  `mapstructure:"reconnectDelay"`
+	ProcessingRetry            es.RetryPolicy `mapstructure:"processingRetry"`
+	// Lag tracking of the projections behind the head of the order streams, readiness fails above its thresholds.
+	Lag subscription.LagConfig `mapstructure:"lag"`
+	// Health checks of the projections subscriptions connections.
+	Health subscription.HealthConfig `mapstructure:"health"`
+}
+
+type Orders struct {
+	// LegacyCurrency currency of the V1 order events float prices, they are upcasted to its minor units.
+	LegacyCurrency string `mapstructure:"legacyCurrency" validate:"required,len=3,alpha"`
+	// SearchPriceInterval width of the search total price histogram buckets in the currency minor units.
+	SearchPriceInterval int64 `mapstructure:"searchPriceInterval" validate:"required,gt=0"`
+	// SearchCursorKeepAlive lifetime of the search point in time between the cursor pages,
+	// the cursor pages are searched without point in time if zero.
+	SearchCursorKeepAlive time.Duration `mapstructure:"searchCursorKeepAlive"`
 }
 
 type ElasticIndexes struct {
@@ -64,6 +96,12 @@ type Http struct {
 	OrdersPath          string   `mapstructure:"ordersPath" validate:"required"`
 	DebugErrorsResponse bool     `mapstructure:"debugErrorsResponse"`
 	IgnoreLogUrls       []string `mapstructure:"ignoreLogUrls"`
+	AdminPath           string   `mapstructure:"adminPath" validate:"required"`
+	// WatchStreamTimeout SSE order updates stream lifetime, it must be less than the http server write timeout,
+	// clients reconnect with the Last-Event-ID header and continue from the last received update.
+	WatchStreamTimeout time.Duration `mapstructure:"watchStreamTimeout" validate:"required"`
+	// WatchHeartbeat interval of the SSE comments keeping idle order updates stream alive.
+	WatchHeartbeat time.Duration `mapstructure:"watchHeartbeat" validate:"required"`
 }
 
 func InitConfig() (*Config, error) {
@@ -102,9 +140,9 @@ func InitConfig() (*Config, error) {
 		//cfg.Mongo.URI = "mongodb://host.docker.internal:27017"
 		cfg.Mongo.URI = mongoURI
 	}
-	jaegerAddr := os.Getenv(constants.JaegerHostPort)
-	if jaegerAddr != "" {
-		cfg.Jaeger.HostPort = jaegerAddr
+	otlpEndpoint := os.Getenv(constants.OtlpEndpoint)
+	if otlpEndpoint != "" {
+		cfg.Tracing.Endpoint = otlpEndpoint
 	}
 	eventStoreConnectionString := os.Getenv(constants.EventStoreConnectionString)
 	if eventStoreConnectionString != "" {
@@ -114,6 +152,10 @@ func InitConfig() (*Config, error) {
 	if elasticUrl != "" {
 		cfg.Elastic.URL = elasticUrl
 	}
+	cursorSecret := os.Getenv(constants.CursorSecret)
+	if cursorSecret != "" {
+		cfg.Cursors.Secret = cursorSecret
+	}
 
 	return cfg, nil
 }
@@ -0,0 +1,6 @@
+package dto
+
+type AccountOrdersResponseDto struct {
+	Pagination Pagination         `json:"pagination"`
+	Orders     []OrderResponseDto `json:"orders"`
+}
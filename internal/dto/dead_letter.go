@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type DeadLetterResponseDto struct {
+	ID             string          `json:"id"`
+	GroupName      string          `json:"groupName"`
+	EventID        string          `json:"eventId"`
+	EventType      string          `json:"eventType"`
+	StreamID       string          `json:"streamId"`
+	EventNumber    uint64          `json:"eventNumber"`
+	CommitPosition uint64          `json:"commitPosition"`
+	Data           json.RawMessage `json:"data,omitempty"`
+	Metadata       json.RawMessage `json:"metadata,omitempty"`
+	Error          string          `json:"error"`
+	Attempts       int             `json:"attempts"`
+	ParkedAt       time.Time       `json:"parkedAt"`
+}
+
+type DeadLettersResponseDto struct {
+	Pagination  Pagination              `json:"pagination"`
+	DeadLetters []DeadLetterResponseDto `json:"deadLetters"`
+}
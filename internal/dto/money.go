@@ -0,0 +1,7 @@
+package dto
+
+// Money amount in the currency minor units, like cents, with ISO 4217 currency code.
+type Money struct {
+	Amount   int64  `json:"amount" bson:"amount"`
+	Currency string `json:"currency" bson:"currency"`
+}
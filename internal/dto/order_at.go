@@ -0,0 +1,11 @@
+package dto
+
+import (
+	"time"
+)
+
+type OrderAtResponseDto struct {
+	Order     OrderResponseDto `json:"order"`
+	Version   int64            `json:"version"`
+	Timestamp time.Time        `json:"timestamp"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type OrderEventResponseDto struct {
+	EventID   string          `json:"eventId"`
+	EventType string          `json:"eventType"`
+	Version   int64           `json:"version"`
+	Timestamp time.Time       `json:"timestamp"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	Metadata  json.RawMessage `json:"metadata,omitempty"`
+}
+
+type OrderHistoryResponseDto struct {
+	Pagination Pagination              `json:"pagination"`
+	Events     []OrderEventResponseDto `json:"events"`
+}
@@ -0,0 +1,6 @@
+package dto
+
+type OrderUpdateResponseDto struct {
+	Event OrderEventResponseDto `json:"event"`
+	Order OrderResponseDto      `json:"order"`
+}
@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+type Refund struct {
+	RefundID   string    `json:"refundId"`
+	Amount     Money     `json:"amount"`
+	Reason     string    `json:"reason"`
+	RefundedAt time.Time `json:"refundedAt"`
+}
+
+// RefundOrderReqDto empty Amount refunds all not refunded paid amount, empty RefundID is generated.
+type RefundOrderReqDto struct {
+	RefundID string `json:"refundId"`
+	Amount   *Money `json:"amount,omitempty"`
+	Reason   string `json:"reason" validate:"required"`
+}
+
+type RefundOrderResponseDto struct {
+	RefundID string `json:"refundId"`
+}
@@ -1,14 +1,36 @@
 package dto
 
 type OrderSearchResponseDto struct {
-	Pagination Pagination         `json:"pagination"`
-	Orders     []OrderResponseDto `json:"orders"`
+	Pagination Pagination           `json:"pagination"`
+	Orders     []OrderResponseDto   `json:"orders"`
+	Facets     OrderSearchFacetsDto `json:"facets"`
 }
 
 type Pagination struct {
+	// TotalCount of the search results is the lower bound if there are more than 10000 matching orders.
 	TotalCount int64 `json:"totalCount"`
 	TotalPages int64 `json:"totalPages"`
 	Page       int64 `json:"page"`
 	Size       int64 `json:"size"`
 	HasMore    bool  `json:"hasMore"`
+	// NextCursor opaque cursor of the next page, empty on the last page.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// OrderSearchFacetsDto aggregations of all orders matching the search filters.
+type OrderSearchFacetsDto struct {
+	// Statuses number of the orders per status, it isn't narrowed by the status filter.
+	Statuses []FacetBucketDto `json:"statuses"`
+	// TotalPrice histogram of the orders total prices in the currency minor units.
+	TotalPrice []HistogramBucketDto `json:"totalPrice"`
+}
+
+type FacetBucketDto struct {
+	Key   string `json:"key"`
+	Count int64  `json:"count"`
+}
+
+type HistogramBucketDto struct {
+	From  int64 `json:"from"`
+	Count int64 `json:"count"`
 }
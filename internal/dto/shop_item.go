@@ -1,9 +1,9 @@
 package dto
 
 type ShopItem struct {
-	ID          string  `json:"id" bson:"id,omitempty"`
-	Title       string  `json:"title" bson:"title,omitempty"`
-	Description string  `json:"description" bson:"description,omitempty"`
-	Quantity    uint64  `json:"quantity" bson:"quantity,omitempty"`
-	Price       float64 `json:"price" bson:"price,omitempty"`
+	ID          string `json:"id" bson:"id,omitempty"`
+	Title       string `json:"title" bson:"title,omitempty"`
+	Description string `json:"description" bson:"description,omitempty"`
+	Quantity    uint64 `json:"quantity" bson:"quantity,omitempty"`
+	Price       Money  `json:"price" bson:"price,omitempty"`
 }
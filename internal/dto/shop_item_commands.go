@@ -0,0 +1,11 @@
+package dto
+
+import "github.com/AleksK1NG/es-microservice/internal/order/models"
+
+type AddShopItemReqDto struct {
+	ShopItem *models.ShopItem `json:"shopItem" validate:"required"`
+}
+
+type ChangeItemQuantityReqDto struct {
+	Quantity uint64 `json:"quantity" validate:"required,gt=0"`
+}
@@ -0,0 +1,29 @@
+package mappers
+
+import (
+	"github.com/AleksK1NG/es-microservice/internal/dto"
+	"github.com/AleksK1NG/es-microservice/internal/order/models"
+	"github.com/AleksK1NG/es-microservice/pkg/utils"
+	orderService "github.com/AleksK1NG/es-microservice/proto/order"
+)
+
+func AccountOrdersResponseFromModel(page *models.AccountOrdersPage, pq *utils.Pagination) *dto.AccountOrdersResponseDto {
+	return &dto.AccountOrdersResponseDto{
+		Pagination: dto.Pagination{
+			TotalCount: page.TotalCount,
+			TotalPages: int64(pq.GetTotalPages(int(page.TotalCount))),
+			Page:       int64(pq.GetPage()),
+			Size:       int64(pq.GetSize()),
+			HasMore:    page.NextCursor != "",
+			NextCursor: page.NextCursor,
+		},
+		Orders: OrdersFromProjections(page.Orders),
+	}
+}
+
+func AccountOrdersResponseToProto(accountOrders *dto.AccountOrdersResponseDto) *orderService.ListOrdersByAccountRes {
+	return &orderService.ListOrdersByAccountRes{
+		Pagination: PaginationToProto(accountOrders.Pagination),
+		Orders:     OrdersResponseDtoToProto(accountOrders.Orders),
+	}
+}
@@ -2,11 +2,11 @@ package mappers
 
 import (
 	"github.com/AleksK1NG/es-microservice/internal/dto"
-	"github.com/AleksK1NG/es-microservice/internal/order/events/v1"
+	"github.com/AleksK1NG/es-microservice/internal/order/events/v2"
 )
 
-func CreateOrderDtoToEventData(createDto dto.CreateOrderReqDto) v1.OrderCreatedEvent {
-	return v1.OrderCreatedEvent{
+func CreateOrderDtoToEventData(createDto dto.CreateOrderReqDto) v2.OrderCreatedEvent {
+	return v2.OrderCreatedEvent{
 		ShopItems:       createDto.ShopItems,
 		AccountEmail:    createDto.AccountEmail,
 		DeliveryAddress: createDto.DeliveryAddress,
@@ -0,0 +1,51 @@
+package mappers
+
+import (
+	"encoding/json"
+
+	"github.com/AleksK1NG/es-microservice/internal/dto"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/utils"
+)
+
+func DeadLetterResponseFromModel(deadLetter es.DeadLetter) dto.DeadLetterResponseDto {
+	return dto.DeadLetterResponseDto{
+		ID:             deadLetter.ID,
+		GroupName:      deadLetter.GroupName,
+		EventID:        deadLetter.Event.GetEventID(),
+		EventType:      deadLetter.Event.GetEventType(),
+		StreamID:       deadLetter.Event.GetAggregateID(),
+		EventNumber:    deadLetter.EventNumber,
+		CommitPosition: deadLetter.CommitPosition,
+		Data:           rawJsonOrNil(deadLetter.Event.GetData()),
+		Metadata:       rawJsonOrNil(deadLetter.Event.GetMetadata()),
+		Error:          deadLetter.Error,
+		Attempts:       deadLetter.Attempts,
+		ParkedAt:       deadLetter.ParkedAt,
+	}
+}
+
+func DeadLettersResponseFromModel(deadLetters []es.DeadLetter, totalCount int64, nextCursor string, pq *utils.Pagination) dto.DeadLettersResponseDto {
+	items := make([]dto.DeadLetterResponseDto, 0, len(deadLetters))
+	for _, deadLetter := range deadLetters {
+		items = append(items, DeadLetterResponseFromModel(deadLetter))
+	}
+	return dto.DeadLettersResponseDto{
+		Pagination: dto.Pagination{
+			TotalCount: totalCount,
+			TotalPages: int64(pq.GetTotalPages(int(totalCount))),
+			Page:       int64(pq.GetPage()),
+			Size:       int64(pq.GetSize()),
+			HasMore:    pq.GetHasMore(int(totalCount)),
+			NextCursor: nextCursor,
+		},
+		DeadLetters: items,
+	}
+}
+
+func rawJsonOrNil(data []byte) json.RawMessage {
+	if !json.Valid(data) {
+		return nil
+	}
+	return data
+}
@@ -0,0 +1,19 @@
+package mappers
+
+import (
+	"github.com/AleksK1NG/es-microservice/internal/dto"
+	"github.com/AleksK1NG/es-microservice/internal/order/models"
+	orderService "github.com/AleksK1NG/es-microservice/proto/order"
+)
+
+func MoneyResponseFromModel(money models.Money) dto.Money {
+	return dto.Money{Amount: money.Amount, Currency: money.Currency}
+}
+
+func MoneyResponseFromProto(money *orderService.Money) dto.Money {
+	return dto.Money{Amount: money.GetAmount(), Currency: money.GetCurrency()}
+}
+
+func MoneyResponseToProto(money dto.Money) *orderService.Money {
+	return &orderService.Money{Amount: money.Amount, Currency: money.Currency}
+}
@@ -11,17 +11,23 @@ import (
 func OrderProjectionFromAggregate(orderAggregate *aggregate.OrderAggregate) *models.OrderProjection {
 	return &models.OrderProjection{
 		OrderID:         aggregate.GetOrderAggregateID(orderAggregate.GetID()),
+		TenantID:        orderAggregate.Order.TenantID,
 		ShopItems:       orderAggregate.Order.ShopItems,
-		Paid:            orderAggregate.Order.Paid,
-		Submitted:       orderAggregate.Order.Submitted,
-		Completed:       orderAggregate.Order.Completed,
-		Canceled:        orderAggregate.Order.Canceled,
+		Status:          orderAggregate.Order.Status,
+		Paid:            orderAggregate.Order.IsPaid(),
+		Submitted:       orderAggregate.Order.IsSubmitted(),
+		Completed:       orderAggregate.Order.IsCompleted(),
+		Canceled:        orderAggregate.Order.IsCanceled(),
 		AccountEmail:    orderAggregate.Order.AccountEmail,
 		TotalPrice:      orderAggregate.Order.TotalPrice,
 		DeliveredTime:   orderAggregate.Order.DeliveredTime,
+		CreatedAt:       orderAggregate.Order.CreatedAt,
 		CancelReason:    orderAggregate.Order.CancelReason,
 		DeliveryAddress: orderAggregate.Order.DeliveryAddress,
 		Payment:         orderAggregate.Order.Payment,
+		RefundedAmount:  orderAggregate.Order.RefundedAmount,
+		Refunds:         orderAggregate.Order.Refunds,
+		Version:         orderAggregate.GetVersion(),
 	}
 }
 
@@ -29,34 +35,44 @@ func OrderResponseFromProjection(projection *models.OrderProjection) dto.OrderRe
 	return dto.OrderResponseDto{
 		ID:              projection.ID,
 		OrderID:         projection.OrderID,
+		TenantID:        projection.TenantID,
 		ShopItems:       ShopItemsResponseFromModels(projection.ShopItems),
 		AccountEmail:    projection.AccountEmail,
 		DeliveryAddress: projection.DeliveryAddress,
 		CancelReason:    projection.CancelReason,
-		TotalPrice:      projection.TotalPrice,
+		TotalPrice:      MoneyResponseFromModel(projection.TotalPrice),
 		DeliveredTime:   projection.DeliveredTime,
+		CreatedAt:       projection.CreatedAt,
 		Paid:            projection.Paid,
 		Submitted:       projection.Submitted,
 		Completed:       projection.Completed,
 		Canceled:        projection.Canceled,
+		Status:          projection.Status.String(),
 		Payment:         PaymentResponseFromModel(projection.Payment),
+		RefundedAmount:  MoneyResponseFromModel(projection.RefundedAmount),
+		Refunds:         RefundsResponseFromModels(projection.Refunds),
 	}
 }
 
 func OrderResponseDtoFromProto(orderProto *orderService.Order) dto.OrderResponseDto {
 	return dto.OrderResponseDto{
 		OrderID:         orderProto.GetID(),
+		TenantID:        orderProto.GetTenantID(),
 		ShopItems:       ShopItemsResponseFromProto(orderProto.GetShopItems()),
 		AccountEmail:    orderProto.GetAccountEmail(),
 		DeliveryAddress: orderProto.GetDeliveryAddress(),
 		CancelReason:    orderProto.GetCancelReason(),
-		TotalPrice:      orderProto.GetTotalPrice(),
+		TotalPrice:      MoneyResponseFromProto(orderProto.GetTotalPrice()),
 		DeliveredTime:   orderProto.GetDeliveryTimestamp().AsTime(),
+		CreatedAt:       orderProto.GetCreatedAt().AsTime(),
 		Paid:            orderProto.GetPaid(),
 		Submitted:       orderProto.GetSubmitted(),
 		Completed:       orderProto.GetCompleted(),
 		Canceled:        orderProto.GetCanceled(),
+		Status:          orderProto.GetStatus(),
 		Payment:         PaymentFromProto(orderProto.GetPayment()),
+		RefundedAmount:  MoneyResponseFromProto(orderProto.GetRefundedAmount()),
+		Refunds:         RefundsResponseFromProto(orderProto.GetRefunds()),
 	}
 }
 
@@ -71,17 +87,22 @@ func OrdersFromProjections(projections []*models.OrderProjection) []dto.OrderRes
 func OrderResponseDtoToProto(orderDto dto.OrderResponseDto) *orderService.Order {
 	return &orderService.Order{
 		ID:                orderDto.OrderID,
+		TenantID:          orderDto.TenantID,
 		ShopItems:         ShopItemsResponseToProto(orderDto.ShopItems),
 		Paid:              orderDto.Paid,
 		Submitted:         orderDto.Submitted,
 		Completed:         orderDto.Completed,
 		Canceled:          orderDto.Canceled,
-		TotalPrice:        orderDto.TotalPrice,
+		Status:            orderDto.Status,
+		TotalPrice:        MoneyResponseToProto(orderDto.TotalPrice),
 		AccountEmail:      orderDto.AccountEmail,
 		CancelReason:      orderDto.CancelReason,
 		DeliveryAddress:   orderDto.DeliveryAddress,
 		DeliveryTimestamp: timestamppb.New(orderDto.DeliveredTime),
+		CreatedAt:         timestamppb.New(orderDto.CreatedAt),
 		Payment:           PaymentToProto(orderDto.Payment),
+		RefundedAmount:    MoneyResponseToProto(orderDto.RefundedAmount),
+		Refunds:           RefundsResponseToProto(orderDto.Refunds),
 	}
 }
 
@@ -0,0 +1,45 @@
+package mappers
+
+import (
+	"github.com/AleksK1NG/es-microservice/internal/dto"
+	"github.com/AleksK1NG/es-microservice/internal/order/models"
+	orderService "github.com/AleksK1NG/es-microservice/proto/order"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func OrderResponseFromModel(order *models.Order) dto.OrderResponseDto {
+	return dto.OrderResponseDto{
+		OrderID:         order.ID,
+		ShopItems:       ShopItemsResponseFromModels(order.ShopItems),
+		AccountEmail:    order.AccountEmail,
+		DeliveryAddress: order.DeliveryAddress,
+		CancelReason:    order.CancelReason,
+		TotalPrice:      MoneyResponseFromModel(order.TotalPrice),
+		DeliveredTime:   order.DeliveredTime,
+		CreatedAt:       order.CreatedAt,
+		Paid:            order.IsPaid(),
+		Submitted:       order.IsSubmitted(),
+		Completed:       order.IsCompleted(),
+		Canceled:        order.IsCanceled(),
+		Status:          order.Status.String(),
+		Payment:         PaymentResponseFromModel(order.Payment),
+		RefundedAmount:  MoneyResponseFromModel(order.RefundedAmount),
+		Refunds:         RefundsResponseFromModels(order.Refunds),
+	}
+}
+
+func OrderAtResponseFromModel(orderAt *models.OrderAt) dto.OrderAtResponseDto {
+	return dto.OrderAtResponseDto{
+		Order:     OrderResponseFromModel(orderAt.Order),
+		Version:   orderAt.Version,
+		Timestamp: orderAt.Timestamp,
+	}
+}
+
+func OrderAtResponseToProto(orderAt *models.OrderAt) *orderService.GetOrderAtRes {
+	return &orderService.GetOrderAtRes{
+		Order:     models.OrderToProto(orderAt.Order, orderAt.Order.ID),
+		Version:   orderAt.Version,
+		Timestamp: timestamppb.New(orderAt.Timestamp),
+	}
+}
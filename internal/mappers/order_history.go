@@ -0,0 +1,59 @@
+package mappers
+
+import (
+	"github.com/AleksK1NG/es-microservice/internal/dto"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/utils"
+	orderService "github.com/AleksK1NG/es-microservice/proto/order"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func OrderEventResponseFromModel(event es.Event) dto.OrderEventResponseDto {
+	return dto.OrderEventResponseDto{
+		EventID:   event.GetEventID(),
+		EventType: event.GetEventType(),
+		Version:   event.GetVersion(),
+		Timestamp: event.GetTimeStamp(),
+		Data:      rawJsonOrNil(event.GetData()),
+		Metadata:  rawJsonOrNil(event.GetMetadata()),
+	}
+}
+
+func OrderHistoryResponseFromModel(events []es.Event, totalCount int64, pq *utils.Pagination) *dto.OrderHistoryResponseDto {
+	items := make([]dto.OrderEventResponseDto, 0, len(events))
+	for _, event := range events {
+		items = append(items, OrderEventResponseFromModel(event))
+	}
+	return &dto.OrderHistoryResponseDto{
+		Pagination: dto.Pagination{
+			TotalCount: totalCount,
+			TotalPages: int64(pq.GetTotalPages(int(totalCount))),
+			Page:       int64(pq.GetPage()),
+			Size:       int64(pq.GetSize()),
+			HasMore:    pq.GetHasMore(int(totalCount)),
+		},
+		Events: items,
+	}
+}
+
+func OrderEventResponseToProto(event dto.OrderEventResponseDto) *orderService.OrderEvent {
+	return &orderService.OrderEvent{
+		EventID:   event.EventID,
+		EventType: event.EventType,
+		Version:   event.Version,
+		Timestamp: timestamppb.New(event.Timestamp),
+		Data:      string(event.Data),
+		Metadata:  string(event.Metadata),
+	}
+}
+
+func OrderHistoryResponseToProto(history *dto.OrderHistoryResponseDto) *orderService.GetOrderHistoryRes {
+	events := make([]*orderService.OrderEvent, 0, len(history.Events))
+	for _, event := range history.Events {
+		events = append(events, OrderEventResponseToProto(event))
+	}
+	return &orderService.GetOrderHistoryRes{
+		Pagination: PaginationToProto(history.Pagination),
+		Events:     events,
+	}
+}
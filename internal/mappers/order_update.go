@@ -0,0 +1,21 @@
+package mappers
+
+import (
+	"github.com/AleksK1NG/es-microservice/internal/dto"
+	"github.com/AleksK1NG/es-microservice/internal/order/models"
+	orderService "github.com/AleksK1NG/es-microservice/proto/order"
+)
+
+func OrderUpdateResponseFromModel(update *models.OrderUpdate) dto.OrderUpdateResponseDto {
+	return dto.OrderUpdateResponseDto{
+		Event: OrderEventResponseFromModel(update.Event),
+		Order: OrderResponseFromModel(update.Order),
+	}
+}
+
+func OrderUpdateToProto(update *models.OrderUpdate) *orderService.OrderUpdate {
+	return &orderService.OrderUpdate{
+		Event: OrderEventResponseToProto(OrderEventResponseFromModel(update.Event)),
+		Order: models.OrderToProto(update.Order, update.Order.ID),
+	}
+}
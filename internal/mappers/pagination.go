@@ -12,6 +12,7 @@ func PaginationFromProto(protoPagination *orderService.Pagination) dto.Paginatio
 		Page:       protoPagination.GetPage(),
 		Size:       protoPagination.GetSize(),
 		HasMore:    protoPagination.GetHasMore(),
+		NextCursor: protoPagination.GetNextCursor(),
 	}
 }
 
@@ -22,5 +23,6 @@ func PaginationToProto(protoPagination dto.Pagination) *orderService.Pagination
 		Page:       protoPagination.Page,
 		Size:       protoPagination.Size,
 		HasMore:    protoPagination.HasMore,
+		NextCursor: protoPagination.NextCursor,
 	}
 }
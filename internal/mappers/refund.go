@@ -0,0 +1,65 @@
+package mappers
+
+import (
+	"github.com/AleksK1NG/es-microservice/internal/dto"
+	"github.com/AleksK1NG/es-microservice/internal/order/models"
+	orderService "github.com/AleksK1NG/es-microservice/proto/order"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func RefundsResponseFromModels(refunds []*models.Refund) []dto.Refund {
+	result := make([]dto.Refund, 0, len(refunds))
+	for _, refund := range refunds {
+		result = append(result, dto.Refund{
+			RefundID:   refund.RefundID,
+			Amount:     MoneyResponseFromModel(refund.Amount),
+			Reason:     refund.Reason,
+			RefundedAt: refund.RefundedAt,
+		})
+	}
+	return result
+}
+
+func RefundsResponseFromProto(refunds []*orderService.Refund) []dto.Refund {
+	result := make([]dto.Refund, 0, len(refunds))
+	for _, refund := range refunds {
+		result = append(result, dto.Refund{
+			RefundID:   refund.GetRefundID(),
+			Amount:     MoneyResponseFromProto(refund.GetAmount()),
+			Reason:     refund.GetReason(),
+			RefundedAt: refund.GetRefundedAt().AsTime(),
+		})
+	}
+	return result
+}
+
+func RefundsResponseToProto(refunds []dto.Refund) []*orderService.Refund {
+	result := make([]*orderService.Refund, 0, len(refunds))
+	for _, refund := range refunds {
+		result = append(result, &orderService.Refund{
+			RefundID:   refund.RefundID,
+			Amount:     MoneyResponseToProto(refund.Amount),
+			Reason:     refund.Reason,
+			RefundedAt: timestamppb.New(refund.RefundedAt),
+		})
+	}
+	return result
+}
+
+// RefundAmountFromDto returns nil for the full refund.
+func RefundAmountFromDto(amount *dto.Money) *models.Money {
+	if amount == nil {
+		return nil
+	}
+	money := models.NewMoney(amount.Amount, amount.Currency)
+	return &money
+}
+
+// RefundAmountFromProto returns nil for the full refund.
+func RefundAmountFromProto(amount *orderService.Money) *models.Money {
+	if amount == nil {
+		return nil
+	}
+	money := models.MoneyFromProto(amount)
+	return &money
+}
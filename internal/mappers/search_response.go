@@ -2,6 +2,7 @@ package mappers
 
 import (
 	"github.com/AleksK1NG/es-microservice/internal/dto"
+	"github.com/AleksK1NG/es-microservice/internal/order/models"
 	orderService "github.com/AleksK1NG/es-microservice/proto/order"
 )
 
@@ -13,6 +14,7 @@ func SearchResponseFromProto(protoSearch *orderService.SearchRes) dto.OrderSearc
 	return dto.OrderSearchResponseDto{
 		Pagination: PaginationFromProto(protoSearch.GetPagination()),
 		Orders:     orders,
+		Facets:     SearchFacetsFromProto(protoSearch.GetFacets()),
 	}
 }
 
@@ -24,5 +26,42 @@ func SearchResponseToProto(protoSearch *dto.OrderSearchResponseDto) *orderServic
 	return &orderService.SearchRes{
 		Pagination: PaginationToProto(protoSearch.Pagination),
 		Orders:     orders,
+		Facets:     SearchFacetsToProto(protoSearch.Facets),
 	}
 }
+
+func SearchFacetsResponseFromModel(facets *models.OrderSearchFacets) dto.OrderSearchFacetsDto {
+	statuses := make([]dto.FacetBucketDto, 0, len(facets.Statuses))
+	for _, bucket := range facets.Statuses {
+		statuses = append(statuses, dto.FacetBucketDto{Key: bucket.Key, Count: bucket.Count})
+	}
+	totalPrice := make([]dto.HistogramBucketDto, 0, len(facets.TotalPrice))
+	for _, bucket := range facets.TotalPrice {
+		totalPrice = append(totalPrice, dto.HistogramBucketDto{From: bucket.From, Count: bucket.Count})
+	}
+	return dto.OrderSearchFacetsDto{Statuses: statuses, TotalPrice: totalPrice}
+}
+
+func SearchFacetsFromProto(facets *orderService.SearchFacets) dto.OrderSearchFacetsDto {
+	statuses := make([]dto.FacetBucketDto, 0, len(facets.GetStatuses()))
+	for _, bucket := range facets.GetStatuses() {
+		statuses = append(statuses, dto.FacetBucketDto{Key: bucket.GetKey(), Count: bucket.GetCount()})
+	}
+	totalPrice := make([]dto.HistogramBucketDto, 0, len(facets.GetTotalPrice()))
+	for _, bucket := range facets.GetTotalPrice() {
+		totalPrice = append(totalPrice, dto.HistogramBucketDto{From: bucket.GetFrom(), Count: bucket.GetCount()})
+	}
+	return dto.OrderSearchFacetsDto{Statuses: statuses, TotalPrice: totalPrice}
+}
+
+func SearchFacetsToProto(facets dto.OrderSearchFacetsDto) *orderService.SearchFacets {
+	statuses := make([]*orderService.SearchFacetBucket, 0, len(facets.Statuses))
+	for _, bucket := range facets.Statuses {
+		statuses = append(statuses, &orderService.SearchFacetBucket{Key: bucket.Key, Count: bucket.Count})
+	}
+	totalPrice := make([]*orderService.SearchHistogramBucket, 0, len(facets.TotalPrice))
+	for _, bucket := range facets.TotalPrice {
+		totalPrice = append(totalPrice, &orderService.SearchHistogramBucket{From: bucket.From, Count: bucket.Count})
+	}
+	return &orderService.SearchFacets{Statuses: statuses, TotalPrice: totalPrice}
+}
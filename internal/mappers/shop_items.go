@@ -12,7 +12,7 @@ func ShopItemResponseFromModel(item *models.ShopItem) dto.ShopItem {
 		Title:       item.Title,
 		Description: item.Description,
 		Quantity:    item.Quantity,
-		Price:       item.Price,
+		Price:       MoneyResponseFromModel(item.Price),
 	}
 }
 
@@ -30,7 +30,7 @@ func ShopItemResponseFromProto(item *orderService.ShopItem) dto.ShopItem {
 		Title:       item.Title,
 		Description: item.Description,
 		Quantity:    item.Quantity,
-		Price:       item.Price,
+		Price:       MoneyResponseFromProto(item.GetPrice()),
 	}
 }
 
@@ -48,7 +48,7 @@ func ShopItemResponseToProto(item dto.ShopItem) *orderService.ShopItem {
 		Title:       item.Title,
 		Description: item.Description,
 		Quantity:    item.Quantity,
-		Price:       item.Price,
+		Price:       MoneyResponseToProto(item.Price),
 	}
 }
 
@@ -2,11 +2,11 @@ package mappers
 
 import (
 	"github.com/AleksK1NG/es-microservice/internal/dto"
-	"github.com/AleksK1NG/es-microservice/internal/order/events/v1"
+	"github.com/AleksK1NG/es-microservice/internal/order/events/v2"
 )
 
-func UpdateOrderReqDtoToEventData(reqDto dto.UpdateShoppingItemsReqDto) v1.ShoppingCartUpdatedEvent {
-	return v1.ShoppingCartUpdatedEvent{
+func UpdateOrderReqDtoToEventData(reqDto dto.UpdateShoppingItemsReqDto) v2.ShoppingCartUpdatedEvent {
+	return v2.ShoppingCartUpdatedEvent{
 		ShopItems: reqDto.ShopItems,
 	}
 }
@@ -18,9 +18,17 @@ type ESMicroserviceMetrics struct {
 	SubmitOrderGrpcRequests        prometheus.Counter
 	GetOrderByIdGrpcRequests       prometheus.Counter
 	SearchOrderGrpcRequests        prometheus.Counter
+	ListAccountOrdersGrpcRequests  prometheus.Counter
 	CancelOrderGrpcRequests        prometheus.Counter
 	CompleteOrderGrpcRequests      prometheus.Counter
 	ChangeAddressOrderGrpcRequests prometheus.Counter
+	GetOrderHistoryGrpcRequests    prometheus.Counter
+	GetOrderAtGrpcRequests         prometheus.Counter
+	WatchOrderGrpcRequests         prometheus.Counter
+	AddShopItemGrpcRequests        prometheus.Counter
+	RemoveShopItemGrpcRequests     prometheus.Counter
+	ChangeItemQuantityGrpcRequests prometheus.Counter
+	RefundOrderGrpcRequests        prometheus.Counter
 
 	SuccessHttpRequests prometheus.Counter
 	ErrorHttpRequests   prometheus.Counter
@@ -31,15 +39,27 @@ type ESMicroserviceMetrics struct {
 	SubmitOrderHttpRequests        prometheus.Counter
 	GetOrderByIdHttpRequests       prometheus.Counter
 	SearchOrderHttpRequests        prometheus.Counter
+	ListAccountOrdersHttpRequests  prometheus.Counter
 	CompleteOrderHttpRequests      prometheus.Counter
 	ChangeAddressOrderHttpRequests prometheus.Counter
+	GetOrderHistoryHttpRequests    prometheus.Counter
+	GetOrderAtHttpRequests         prometheus.Counter
+	WatchOrderHttpRequests         prometheus.Counter
+	AddShopItemHttpRequests        prometheus.Counter
+	RemoveShopItemHttpRequests     prometheus.Counter
+	ChangeItemQuantityHttpRequests prometheus.Counter
+	RefundOrderHttpRequests        prometheus.Counter
 
-	SuccessKafkaMessages prometheus.Counter
-	ErrorKafkaMessages   prometheus.Counter
+	SuccessPublishedMessages prometheus.Counter
+	ErrorPublishedMessages   prometheus.Counter
 
-	CreateProductKafkaMessages prometheus.Counter
-	UpdateProductKafkaMessages prometheus.Counter
-	DeleteProductKafkaMessages prometheus.Counter
+	SuccessProjectedEvents *prometheus.CounterVec
+	ErrorProjectedEvents   *prometheus.CounterVec
+	ProjectionLagEvents    *prometheus.GaugeVec
+	ProjectionLagSeconds   *prometheus.GaugeVec
+
+	RateLimitedHttpRequests *prometheus.CounterVec
+	RateLimitedGrpcRequests *prometheus.CounterVec
 }
 
 func NewESMicroserviceMetrics(cfg *config.Config) *ESMicroserviceMetrics {
@@ -76,6 +96,10 @@ func NewESMicroserviceMetrics(cfg *config.Config) *ESMicroserviceMetrics {
 			Name: fmt.Sprintf("%s_search_order_grpc_requests_total", cfg.ServiceName),
 			Help: "The total number of search order grpc requests",
 		}),
+		ListAccountOrdersGrpcRequests: promauto.NewCounter(prometheus.CounterOpts{
+			Name: fmt.Sprintf("%s_list_account_orders_grpc_requests_total", cfg.ServiceName),
+			Help: "The total number of list account orders grpc requests",
+		}),
 
 		SuccessHttpRequests: promauto.NewCounter(prometheus.CounterOpts{
 			Name: fmt.Sprintf("%s_success_http_requests_total", cfg.ServiceName),
@@ -109,6 +133,10 @@ func NewESMicroserviceMetrics(cfg *config.Config) *ESMicroserviceMetrics {
 			Name: fmt.Sprintf("%s_search_order_http_requests_total", cfg.ServiceName),
 			Help: "The total number of search order http requests",
 		}),
+		ListAccountOrdersHttpRequests: promauto.NewCounter(prometheus.CounterOpts{
+			Name: fmt.Sprintf("%s_list_account_orders_http_requests_total", cfg.ServiceName),
+			Help: "The total number of list account orders http requests",
+		}),
 		CancelOrderGrpcRequests: promauto.NewCounter(prometheus.CounterOpts{
 			Name: fmt.Sprintf("%s_cancel_order_http_requests_total", cfg.ServiceName),
 			Help: "The total number of cancel order http requests",
@@ -125,5 +153,93 @@ func NewESMicroserviceMetrics(cfg *config.Config) *ESMicroserviceMetrics {
 			Name: fmt.Sprintf("%s_change_address_order_http_requests_total", cfg.ServiceName),
 			Help: "The total number of change address order http requests",
 		}),
+		GetOrderHistoryGrpcRequests: promauto.NewCounter(prometheus.CounterOpts{
+			Name: fmt.Sprintf("%s_get_order_history_grpc_requests_total", cfg.ServiceName),
+			Help: "The total number of get order history grpc requests",
+		}),
+		GetOrderHistoryHttpRequests: promauto.NewCounter(prometheus.CounterOpts{
+			Name: fmt.Sprintf("%s_get_order_history_http_requests_total", cfg.ServiceName),
+			Help: "The total number of get order history http requests",
+		}),
+		GetOrderAtGrpcRequests: promauto.NewCounter(prometheus.CounterOpts{
+			Name: fmt.Sprintf("%s_get_order_at_grpc_requests_total", cfg.ServiceName),
+			Help: "The total number of get order at grpc requests",
+		}),
+		GetOrderAtHttpRequests: promauto.NewCounter(prometheus.CounterOpts{
+			Name: fmt.Sprintf("%s_get_order_at_http_requests_total", cfg.ServiceName),
+			Help: "The total number of get order at http requests",
+		}),
+		WatchOrderGrpcRequests: promauto.NewCounter(prometheus.CounterOpts{
+			Name: fmt.Sprintf("%s_watch_order_grpc_requests_total", cfg.ServiceName),
+			Help: "The total number of watch order grpc requests",
+		}),
+		WatchOrderHttpRequests: promauto.NewCounter(prometheus.CounterOpts{
+			Name: fmt.Sprintf("%s_watch_order_http_requests_total", cfg.ServiceName),
+			Help: "The total number of watch order http requests",
+		}),
+		AddShopItemGrpcRequests: promauto.NewCounter(prometheus.CounterOpts{
+			Name: fmt.Sprintf("%s_add_shop_item_grpc_requests_total", cfg.ServiceName),
+			Help: "The total number of add shop item grpc requests",
+		}),
+		AddShopItemHttpRequests: promauto.NewCounter(prometheus.CounterOpts{
+			Name: fmt.Sprintf("%s_add_shop_item_http_requests_total", cfg.ServiceName),
+			Help: "The total number of add shop item http requests",
+		}),
+		RemoveShopItemGrpcRequests: promauto.NewCounter(prometheus.CounterOpts{
+			Name: fmt.Sprintf("%s_remove_shop_item_grpc_requests_total", cfg.ServiceName),
+			Help: "The total number of remove shop item grpc requests",
+		}),
+		RemoveShopItemHttpRequests: promauto.NewCounter(prometheus.CounterOpts{
+			Name: fmt.Sprintf("%s_remove_shop_item_http_requests_total", cfg.ServiceName),
+			Help: "The total number of remove shop item http requests",
+		}),
+		ChangeItemQuantityGrpcRequests: promauto.NewCounter(prometheus.CounterOpts{
+			Name: fmt.Sprintf("%s_change_item_quantity_grpc_requests_total", cfg.ServiceName),
+			Help: "The total number of change item quantity grpc requests",
+		}),
+		ChangeItemQuantityHttpRequests: promauto.NewCounter(prometheus.CounterOpts{
+			Name: fmt.Sprintf("%s_change_item_quantity_http_requests_total", cfg.ServiceName),
+			Help: "The total number of change item quantity http requests",
+		}),
+		RefundOrderGrpcRequests: promauto.NewCounter(prometheus.CounterOpts{
+			Name: fmt.Sprintf("%s_refund_order_grpc_requests_total", cfg.ServiceName),
+			Help: "The total number of refund order grpc requests",
+		}),
+		RefundOrderHttpRequests: promauto.NewCounter(prometheus.CounterOpts{
+			Name: fmt.Sprintf("%s_refund_order_http_requests_total", cfg.ServiceName),
+			Help: "The total number of refund order http requests",
+		}),
+		SuccessPublishedMessages: promauto.NewCounter(prometheus.CounterOpts{
+			Name: fmt.Sprintf("%s_success_published_messages_total", cfg.ServiceName),
+			Help: "The total number of success published integration event messages",
+		}),
+		ErrorPublishedMessages: promauto.NewCounter(prometheus.CounterOpts{
+			Name: fmt.Sprintf("%s_error_published_messages_total", cfg.ServiceName),
+			Help: "The total number of error published integration event messages",
+		}),
+		SuccessProjectedEvents: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name: fmt.Sprintf("%s_success_projected_events_total", cfg.ServiceName),
+			Help: "The total number of success projected events by subscription group",
+		}, []string{"group"}),
+		ErrorProjectedEvents: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name: fmt.Sprintf("%s_error_projected_events_total", cfg.ServiceName),
+			Help: "The total number of parked events by subscription group",
+		}, []string{"group"}),
+		ProjectionLagEvents: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Name: fmt.Sprintf("%s_projection_lag_events", cfg.ServiceName),
+			Help: "The number of events not processed yet by subscription group",
+		}, []string{"group"}),
+		ProjectionLagSeconds: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Name: fmt.Sprintf("%s_projection_lag_seconds", cfg.ServiceName),
+			Help: "The age of the oldest event not processed yet by subscription group",
+		}, []string{"group"}),
+		RateLimitedHttpRequests: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name: fmt.Sprintf("%s_rate_limited_http_requests_total", cfg.ServiceName),
+			Help: "The total number of rejected rate limited http requests by route",
+		}, []string{"route"}),
+		RateLimitedGrpcRequests: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name: fmt.Sprintf("%s_rate_limited_grpc_requests_total", cfg.ServiceName),
+			Help: "The total number of rejected rate limited grpc requests by method",
+		}, []string{"method"}),
 	}
 }
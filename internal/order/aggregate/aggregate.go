@@ -2,6 +2,7 @@ package aggregate
 
 import (
 	"github.com/AleksK1NG/es-microservice/internal/order/events/v1"
+	"github.com/AleksK1NG/es-microservice/internal/order/events/v2"
 	"github.com/AleksK1NG/es-microservice/internal/order/models"
 	"github.com/AleksK1NG/es-microservice/pkg/es"
 	"github.com/pkg/errors"
@@ -9,6 +10,11 @@ import (
 
 const (
 	OrderAggregateType es.AggregateType = "order"
+
+	// orderSnapshotSchemaVersion 1 - shop items prices are models.Money instead of float.
+	// 2 - paid amount and refunds of the order.
+	// 3 - order status instead of the paid, submitted, completed and canceled flags.
+	orderSnapshotSchemaVersion = 3
 )
 
 type OrderAggregate struct {
@@ -16,14 +22,18 @@ type OrderAggregate struct {
 	Order *models.Order
 }
 
-func NewOrderAggregateWithID(id string) *OrderAggregate {
+// NewOrderAggregateWithID creates order aggregate of the tenant, the tenant orders streams are prefixed
+// with the tenant id, for example tenantA-order-<id>, the default empty tenant orders streams are order-<id>.
+func NewOrderAggregateWithID(tenantID string, id string) *OrderAggregate {
 	if id == "" {
 		return nil
 	}
 
 	aggregate := NewOrderAggregate()
 	aggregate.SetID(id)
+	aggregate.ID = es.GetTenantStreamID(tenantID, aggregate.GetID())
 	aggregate.Order.ID = id
+	aggregate.Order.TenantID = tenantID
 	return aggregate
 }
 
@@ -35,11 +45,15 @@ func NewOrderAggregate() *OrderAggregate {
 	return orderAggregate
 }
 
+func (a *OrderAggregate) SnapshotSchemaVersion() int {
+	return orderSnapshotSchemaVersion
+}
+
 func (a *OrderAggregate) When(evt es.Event) error {
 
 	switch evt.GetEventType() {
 
-	case v1.OrderCreated:
+	case v2.OrderCreated:
 		return a.onOrderCreated(evt)
 	case v1.OrderPaid:
 		return a.onOrderPaid(evt)
@@ -49,10 +63,18 @@ func (a *OrderAggregate) When(evt es.Event) error {
 		return a.onOrderCompleted(evt)
 	case v1.OrderCanceled:
 		return a.onOrderCanceled(evt)
-	case v1.ShoppingCartUpdated:
+	case v2.ShoppingCartUpdated:
 		return a.onShoppingCartUpdated(evt)
 	case v1.DeliveryAddressChanged:
 		return a.onChangeDeliveryAddress(evt)
+	case v2.ShopItemAdded:
+		return a.onShopItemAdded(evt)
+	case v2.ShopItemRemoved:
+		return a.onShopItemRemoved(evt)
+	case v2.ShopItemQuantityChanged:
+		return a.onShopItemQuantityChanged(evt)
+	case v2.OrderRefunded:
+		return a.onOrderRefunded(evt)
 
 	default:
 		return es.ErrInvalidEventType
@@ -60,15 +82,22 @@ func (a *OrderAggregate) When(evt es.Event) error {
 }
 
 func (a *OrderAggregate) onOrderCreated(evt es.Event) error {
-	var eventData v1.OrderCreatedEvent
+	var eventData v2.OrderCreatedEvent
 	if err := evt.GetJsonData(&eventData); err != nil {
 		return errors.Wrap(err, "GetJsonData")
 	}
 
+	totalPrice, err := GetShopItemsTotalPrice(eventData.ShopItems)
+	if err != nil {
+		return err
+	}
+
 	a.Order.AccountEmail = eventData.AccountEmail
 	a.Order.ShopItems = eventData.ShopItems
-	a.Order.TotalPrice = GetShopItemsTotalPrice(eventData.ShopItems)
+	a.Order.TotalPrice = totalPrice
 	a.Order.DeliveryAddress = eventData.DeliveryAddress
+	a.Order.CreatedAt = evt.GetTimeStamp()
+	a.setStatusAfter(createOrderCommand)
 	return nil
 }
 
@@ -78,13 +107,14 @@ func (a *OrderAggregate) onOrderPaid(evt es.Event) error {
 		return errors.Wrap(err, "GetJsonData")
 	}
 
-	a.Order.Paid = true
 	a.Order.Payment = payment
+	a.Order.PaidAmount = a.Order.TotalPrice
+	a.setStatusAfter(payOrderCommand)
 	return nil
 }
 
 func (a *OrderAggregate) onOrderSubmitted(evt es.Event) error {
-	a.Order.Submitted = true
+	a.setStatusAfter(submitOrderCommand)
 	return nil
 }
 
@@ -94,9 +124,8 @@ func (a *OrderAggregate) onOrderCompleted(evt es.Event) error {
 		return errors.Wrap(err, "GetJsonData")
 	}
 
-	a.Order.Completed = true
 	a.Order.DeliveredTime = eventData.DeliveryTimestamp
-	a.Order.Canceled = false
+	a.setStatusAfter(completeOrderCommand)
 	return nil
 }
 
@@ -106,20 +135,24 @@ func (a *OrderAggregate) onOrderCanceled(evt es.Event) error {
 		return errors.Wrap(err, "GetJsonData")
 	}
 
-	a.Order.Canceled = true
-	a.Order.Completed = false
 	a.Order.CancelReason = eventData.CancelReason
+	a.setStatusAfter(cancelOrderCommand)
 	return nil
 }
 
 func (a *OrderAggregate) onShoppingCartUpdated(evt es.Event) error {
-	var eventData v1.ShoppingCartUpdatedEvent
+	var eventData v2.ShoppingCartUpdatedEvent
 	if err := evt.GetJsonData(&eventData); err != nil {
 		return errors.Wrap(err, "GetJsonData")
 	}
 
+	totalPrice, err := GetShopItemsTotalPrice(eventData.ShopItems)
+	if err != nil {
+		return err
+	}
+
 	a.Order.ShopItems = eventData.ShopItems
-	a.Order.TotalPrice = GetShopItemsTotalPrice(eventData.ShopItems)
+	a.Order.TotalPrice = totalPrice
 	return nil
 }
 
@@ -132,3 +165,57 @@ func (a *OrderAggregate) onChangeDeliveryAddress(evt es.Event) error {
 	a.Order.DeliveryAddress = eventData.DeliveryAddress
 	return nil
 }
+
+func (a *OrderAggregate) onShopItemAdded(evt es.Event) error {
+	var eventData v2.ShopItemAddedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	return a.setShopItems(append(a.Order.ShopItems, eventData.ShopItem))
+}
+
+func (a *OrderAggregate) onShopItemRemoved(evt es.Event) error {
+	var eventData v2.ShopItemRemovedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	return a.setShopItems(removeShopItem(a.Order.ShopItems, eventData.ShopItemID))
+}
+
+func (a *OrderAggregate) onShopItemQuantityChanged(evt es.Event) error {
+	var eventData v2.ShopItemQuantityChangedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	index := findShopItem(a.Order.ShopItems, eventData.ShopItemID)
+	if index < 0 {
+		return errors.Wrapf(ErrShopItemNotFound, "shop item: {%s}", eventData.ShopItemID)
+	}
+	a.Order.ShopItems[index].Quantity = eventData.Quantity
+	return a.setShopItems(a.Order.ShopItems)
+}
+
+func (a *OrderAggregate) setShopItems(shopItems []*models.ShopItem) error {
+	totalPrice, err := GetShopItemsTotalPrice(shopItems)
+	if err != nil {
+		return err
+	}
+
+	a.Order.ShopItems = shopItems
+	a.Order.TotalPrice = totalPrice
+	return nil
+}
+
+func (a *OrderAggregate) onOrderRefunded(evt es.Event) error {
+	var eventData v2.OrderRefundedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		return errors.Wrap(err, "GetJsonData")
+	}
+
+	a.Order.Refunds = append(a.Order.Refunds, eventData.Refund)
+	a.Order.RefundedAmount = eventData.RefundedAmount
+	return nil
+}
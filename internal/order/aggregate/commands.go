@@ -5,32 +5,38 @@ import (
 	"time"
 
 	eventsV1 "github.com/AleksK1NG/es-microservice/internal/order/events/v1"
+	eventsV2 "github.com/AleksK1NG/es-microservice/internal/order/events/v2"
 	"github.com/AleksK1NG/es-microservice/internal/order/models"
 	"github.com/AleksK1NG/es-microservice/pkg/tracing"
-	"github.com/opentracing/opentracing-go"
-	"github.com/opentracing/opentracing-go/log"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 func (a *OrderAggregate) CreateOrder(ctx context.Context, shopItems []*models.ShopItem, accountEmail, deliveryAddress string) error {
-	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.CreateOrder")
-	defer span.Finish()
-	span.LogFields(log.String("AggregateID", a.GetID()))
+	ctx, span := tracing.StartSpan(ctx, "OrderAggregate.CreateOrder")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", a.GetID()))
 
+	if err := a.checkTransition(createOrderCommand); err != nil {
+		return err
+	}
 	if shopItems == nil {
 		return ErrOrderShopItemsIsRequired
 	}
 	if deliveryAddress == "" {
 		return ErrInvalidDeliveryAddress
 	}
+	if err := ValidateShopItems(shopItems); err != nil {
+		return err
+	}
 
-	event, err := eventsV1.NewOrderCreatedEvent(a, shopItems, accountEmail, deliveryAddress)
+	event, err := eventsV2.NewOrderCreatedEvent(a, shopItems, accountEmail, deliveryAddress)
 	if err != nil {
 		tracing.TraceErr(span, err)
 		return errors.Wrap(err, "NewOrderCreatedEvent")
 	}
 
-	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(ctx)); err != nil {
 		tracing.TraceErr(span, err)
 		return errors.Wrap(err, "SetMetadata")
 	}
@@ -39,18 +45,12 @@ func (a *OrderAggregate) CreateOrder(ctx context.Context, shopItems []*models.Sh
 }
 
 func (a *OrderAggregate) PayOrder(ctx context.Context, payment models.Payment) error {
-	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.PayOrder")
-	defer span.Finish()
-	span.LogFields(log.String("AggregateID", a.GetID()))
+	ctx, span := tracing.StartSpan(ctx, "OrderAggregate.PayOrder")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", a.GetID()))
 
-	if a.Order.Canceled {
-		return ErrOrderAlreadyCancelled
-	}
-	if a.Order.Paid {
-		return ErrAlreadyPaid
-	}
-	if a.Order.Submitted {
-		return ErrAlreadySubmitted
+	if err := a.checkTransition(payOrderCommand); err != nil {
+		return err
 	}
 
 	event, err := eventsV1.NewOrderPaidEvent(a, &payment)
@@ -59,7 +59,7 @@ func (a *OrderAggregate) PayOrder(ctx context.Context, payment models.Payment) e
 		return errors.Wrap(err, "NewOrderPaidEvent")
 	}
 
-	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(ctx)); err != nil {
 		tracing.TraceErr(span, err)
 		return errors.Wrap(err, "SetMetadata")
 	}
@@ -68,18 +68,12 @@ func (a *OrderAggregate) PayOrder(ctx context.Context, payment models.Payment) e
 }
 
 func (a *OrderAggregate) SubmitOrder(ctx context.Context) error {
-	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.SubmitOrder")
-	defer span.Finish()
-	span.LogFields(log.String("AggregateID", a.GetID()))
+	ctx, span := tracing.StartSpan(ctx, "OrderAggregate.SubmitOrder")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", a.GetID()))
 
-	if a.Order.Canceled {
-		return ErrOrderAlreadyCancelled
-	}
-	if !a.Order.Paid {
-		return ErrOrderNotPaid
-	}
-	if a.Order.Submitted {
-		return ErrAlreadySubmitted
+	if err := a.checkTransition(submitOrderCommand); err != nil {
+		return err
 	}
 
 	submitOrderEvent, err := eventsV1.NewSubmitOrderEvent(a)
@@ -88,7 +82,7 @@ func (a *OrderAggregate) SubmitOrder(ctx context.Context) error {
 		return errors.Wrap(err, "NewSubmitOrderEvent")
 	}
 
-	if err := submitOrderEvent.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+	if err := submitOrderEvent.SetMetadata(tracing.ExtractTextMapCarrier(ctx)); err != nil {
 		tracing.TraceErr(span, err)
 		return errors.Wrap(err, "SetMetadata")
 	}
@@ -97,24 +91,24 @@ func (a *OrderAggregate) SubmitOrder(ctx context.Context) error {
 }
 
 func (a *OrderAggregate) UpdateShoppingCart(ctx context.Context, shopItems []*models.ShopItem) error {
-	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.UpdateShoppingCart")
-	defer span.Finish()
-	span.LogFields(log.String("AggregateID", a.GetID()))
+	ctx, span := tracing.StartSpan(ctx, "OrderAggregate.UpdateShoppingCart")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", a.GetID()))
 
-	if a.Order.Canceled {
-		return ErrOrderAlreadyCancelled
+	if err := a.checkTransition(editShoppingCartCommand); err != nil {
+		return err
 	}
-	if a.Order.Submitted {
-		return ErrAlreadySubmitted
+	if err := ValidateShopItems(shopItems); err != nil {
+		return err
 	}
 
-	orderUpdatedEvent, err := eventsV1.NewShoppingCartUpdatedEvent(a, shopItems)
+	orderUpdatedEvent, err := eventsV2.NewShoppingCartUpdatedEvent(a, shopItems)
 	if err != nil {
 		tracing.TraceErr(span, err)
 		return errors.Wrap(err, "NewShoppingCartUpdatedEvent")
 	}
 
-	if err := orderUpdatedEvent.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+	if err := orderUpdatedEvent.SetMetadata(tracing.ExtractTextMapCarrier(ctx)); err != nil {
 		tracing.TraceErr(span, err)
 		return errors.Wrap(err, "SetMetadata")
 	}
@@ -122,13 +116,122 @@ func (a *OrderAggregate) UpdateShoppingCart(ctx context.Context, shopItems []*mo
 	return a.Apply(orderUpdatedEvent)
 }
 
+func (a *OrderAggregate) AddShopItem(ctx context.Context, shopItem *models.ShopItem) error {
+	ctx, span := tracing.StartSpan(ctx, "OrderAggregate.AddShopItem")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", a.GetID()))
+
+	if err := a.checkTransition(editShoppingCartCommand); err != nil {
+		return err
+	}
+	if shopItem == nil {
+		return ErrOrderShopItemsIsRequired
+	}
+	if findShopItem(a.Order.ShopItems, shopItem.ID) >= 0 {
+		return errors.Wrapf(ErrDuplicateShopItemID, "shop item: {%s}", shopItem.ID)
+	}
+
+	shopItems := append(append(make([]*models.ShopItem, 0, len(a.Order.ShopItems)+1), a.Order.ShopItems...), shopItem)
+	if err := ValidateShopItems(shopItems); err != nil {
+		return err
+	}
+	totalPrice, err := GetShopItemsTotalPrice(shopItems)
+	if err != nil {
+		return err
+	}
+
+	event, err := eventsV2.NewShopItemAddedEvent(a, shopItem, totalPrice)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewShopItemAddedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(ctx)); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+func (a *OrderAggregate) RemoveShopItem(ctx context.Context, shopItemID string) error {
+	ctx, span := tracing.StartSpan(ctx, "OrderAggregate.RemoveShopItem")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", a.GetID()), attribute.String("ShopItemID", shopItemID))
+
+	if err := a.checkTransition(editShoppingCartCommand); err != nil {
+		return err
+	}
+	if findShopItem(a.Order.ShopItems, shopItemID) < 0 {
+		return errors.Wrapf(ErrShopItemNotFound, "shop item: {%s}", shopItemID)
+	}
+
+	totalPrice, err := GetShopItemsTotalPrice(removeShopItem(a.Order.ShopItems, shopItemID))
+	if err != nil {
+		return err
+	}
+
+	event, err := eventsV2.NewShopItemRemovedEvent(a, shopItemID, totalPrice)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewShopItemRemovedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(ctx)); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+func (a *OrderAggregate) ChangeItemQuantity(ctx context.Context, shopItemID string, quantity uint64) error {
+	ctx, span := tracing.StartSpan(ctx, "OrderAggregate.ChangeItemQuantity")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", a.GetID()), attribute.String("ShopItemID", shopItemID), attribute.Int64("Quantity", int64(quantity)))
+
+	if err := a.checkTransition(editShoppingCartCommand); err != nil {
+		return err
+	}
+	if quantity == 0 {
+		return errors.Wrapf(ErrInvalidShopItemQuantity, "shop item: {%s}", shopItemID)
+	}
+	index := findShopItem(a.Order.ShopItems, shopItemID)
+	if index < 0 {
+		return errors.Wrapf(ErrShopItemNotFound, "shop item: {%s}", shopItemID)
+	}
+
+	shopItems := append(make([]*models.ShopItem, 0, len(a.Order.ShopItems)), a.Order.ShopItems...)
+	changed := *shopItems[index]
+	changed.Quantity = quantity
+	shopItems[index] = &changed
+
+	totalPrice, err := GetShopItemsTotalPrice(shopItems)
+	if err != nil {
+		return err
+	}
+
+	event, err := eventsV2.NewShopItemQuantityChangedEvent(a, shopItemID, quantity, totalPrice)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewShopItemQuantityChangedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(ctx)); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
 func (a *OrderAggregate) CancelOrder(ctx context.Context, cancelReason string) error {
-	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.CancelOrder")
-	defer span.Finish()
-	span.LogFields(log.String("AggregateID", a.GetID()))
+	ctx, span := tracing.StartSpan(ctx, "OrderAggregate.CancelOrder")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", a.GetID()))
 
-	if a.Order.Completed {
-		return ErrOrderAlreadyCompleted
+	if err := a.checkTransition(cancelOrderCommand); err != nil {
+		return err
 	}
 	if cancelReason == "" {
 		return ErrCancelReasonRequired
@@ -140,7 +243,7 @@ func (a *OrderAggregate) CancelOrder(ctx context.Context, cancelReason string) e
 		return errors.Wrap(err, "NewOrderCanceledEvent")
 	}
 
-	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(ctx)); err != nil {
 		tracing.TraceErr(span, err)
 		return errors.Wrap(err, "SetMetadata")
 	}
@@ -149,18 +252,12 @@ func (a *OrderAggregate) CancelOrder(ctx context.Context, cancelReason string) e
 }
 
 func (a *OrderAggregate) CompleteOrder(ctx context.Context, deliveryTimestamp time.Time) error {
-	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.CompleteOrder")
-	defer span.Finish()
-	span.LogFields(log.String("AggregateID", a.GetID()))
+	ctx, span := tracing.StartSpan(ctx, "OrderAggregate.CompleteOrder")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", a.GetID()))
 
-	if a.Order.Completed {
-		return ErrOrderAlreadyCompleted
-	}
-	if a.Order.Canceled {
-		return ErrOrderAlreadyCanceled
-	}
-	if !a.Order.Paid {
-		return ErrOrderMustBePaidBeforeDelivered
+	if err := a.checkTransition(completeOrderCommand); err != nil {
+		return err
 	}
 
 	event, err := eventsV1.NewOrderCompletedEvent(a, deliveryTimestamp)
@@ -169,7 +266,7 @@ func (a *OrderAggregate) CompleteOrder(ctx context.Context, deliveryTimestamp ti
 		return errors.Wrap(err, "NewOrderCompletedEvent")
 	}
 
-	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(ctx)); err != nil {
 		tracing.TraceErr(span, err)
 		return errors.Wrap(err, "SetMetadata")
 	}
@@ -178,12 +275,15 @@ func (a *OrderAggregate) CompleteOrder(ctx context.Context, deliveryTimestamp ti
 }
 
 func (a *OrderAggregate) ChangeDeliveryAddress(ctx context.Context, deliveryAddress string) error {
-	span, _ := opentracing.StartSpanFromContext(ctx, "OrderAggregate.ChangeDeliveryAddress")
-	defer span.Finish()
-	span.LogFields(log.String("AggregateID", a.GetID()))
+	ctx, span := tracing.StartSpan(ctx, "OrderAggregate.ChangeDeliveryAddress")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", a.GetID()))
 
-	if a.Order.Completed {
-		return ErrOrderAlreadyCompleted
+	if err := a.checkTransition(changeDeliveryAddressCommand); err != nil {
+		return err
+	}
+	if deliveryAddress == "" {
+		return ErrInvalidDeliveryAddress
 	}
 
 	event, err := eventsV1.NewDeliveryAddressChangedEvent(a, deliveryAddress)
@@ -192,7 +292,71 @@ func (a *OrderAggregate) ChangeDeliveryAddress(ctx context.Context, deliveryAddr
 		return errors.Wrap(err, "NewDeliveryAddressChangedEvent")
 	}
 
-	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(span.Context())); err != nil {
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(ctx)); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "SetMetadata")
+	}
+
+	return a.Apply(event)
+}
+
+// RefundOrder refunds the amount of the paid order, nil amount refunds all not refunded paid amount.
+func (a *OrderAggregate) RefundOrder(ctx context.Context, refundID string, amount *models.Money, reason string) error {
+	ctx, span := tracing.StartSpan(ctx, "OrderAggregate.RefundOrder")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", a.GetID()), attribute.String("RefundID", refundID))
+
+	if err := a.checkTransition(refundOrderCommand); err != nil {
+		return err
+	}
+	// canceled order can be not paid
+	if !a.Order.IsPaid() {
+		return ErrOrderNotPaid
+	}
+	if reason == "" {
+		return ErrRefundReasonRequired
+	}
+	for _, refund := range a.Order.Refunds {
+		if refund.RefundID == refundID {
+			return errors.Wrapf(ErrRefundAlreadyExists, "refund: {%s}", refundID)
+		}
+	}
+
+	remaining, err := a.Order.RemainingRefundAmount()
+	if err != nil {
+		return err
+	}
+	if remaining.Amount <= 0 {
+		return ErrOrderFullyRefunded
+	}
+
+	refundAmount := remaining
+	if amount != nil {
+		if amount.Amount <= 0 {
+			return ErrInvalidRefundAmount
+		}
+		if amount.Currency != remaining.Currency {
+			return errors.Wrapf(models.ErrCurrencyMismatch, "paid in %s, refund in %s", remaining.Currency, amount.Currency)
+		}
+		if amount.Amount > remaining.Amount {
+			return errors.Wrapf(ErrRefundExceedsPaidAmount, "refund: {%v}, not refunded: {%v}", *amount, remaining)
+		}
+		refundAmount = *amount
+	}
+
+	refundedAmount, err := a.Order.RefundedAmount.Add(refundAmount)
+	if err != nil {
+		return err
+	}
+
+	refund := &models.Refund{RefundID: refundID, Amount: refundAmount, Reason: reason, RefundedAt: time.Now().UTC()}
+	event, err := eventsV2.NewOrderRefundedEvent(a, refund, refundedAmount)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewOrderRefundedEvent")
+	}
+
+	if err := event.SetMetadata(tracing.ExtractTextMapCarrier(ctx)); err != nil {
 		tracing.TraceErr(span, err)
 		return errors.Wrap(err, "SetMetadata")
 	}
@@ -0,0 +1,114 @@
+package aggregate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AleksK1NG/es-microservice/internal/order/events/v2"
+	"github.com/AleksK1NG/es-microservice/internal/order/models"
+	"github.com/pkg/errors"
+)
+
+func newTestMoney(amount int64) *models.Money {
+	money := models.NewMoney(amount, "USD")
+	return &money
+}
+
+// newTestOrder returns order of 2800 USD, paid if paid is true.
+func newTestOrder(t *testing.T, paid bool) *OrderAggregate {
+	ctx := context.Background()
+	order := NewOrderAggregateWithID("", "8c4f2b1e")
+	shopItems := []*models.ShopItem{
+		{ID: "item-1", Title: "book", Quantity: 2, Price: models.NewMoney(1250, "USD")},
+		{ID: "item-2", Title: "pen", Quantity: 1, Price: models.NewMoney(300, "USD")},
+	}
+	if err := order.CreateOrder(ctx, shopItems, "customer@mail.com", "address"); err != nil {
+		t.Fatalf("CreateOrder() err: %v", err)
+	}
+	if paid {
+		if err := order.PayOrder(ctx, models.Payment{PaymentID: "payment-1", Timestamp: time.Now().UTC()}); err != nil {
+			t.Fatalf("PayOrder() err: %v", err)
+		}
+	}
+	return order
+}
+
+func TestRefundOrder(t *testing.T) {
+	type refund struct {
+		id     string
+		amount *models.Money
+		reason string
+	}
+
+	tests := []struct {
+		name     string
+		paid     bool
+		canceled bool
+		previous []refund
+		refund   refund
+		refunded int64
+		err      error
+	}{
+		{name: "full refund", paid: true, refund: refund{id: "refund-1", reason: "damaged"}, refunded: 2800},
+		{name: "partial refund", paid: true, refund: refund{id: "refund-1", amount: newTestMoney(1000), reason: "damaged"}, refunded: 1000},
+		{name: "rest after partial refund", paid: true, previous: []refund{{id: "refund-1", amount: newTestMoney(1000), reason: "damaged"}}, refund: refund{id: "refund-2", reason: "returned"}, refunded: 2800},
+		{name: "whole remaining amount", paid: true, previous: []refund{{id: "refund-1", amount: newTestMoney(800), reason: "damaged"}}, refund: refund{id: "refund-2", amount: newTestMoney(2000), reason: "returned"}, refunded: 2800},
+		{name: "canceled paid order", paid: true, canceled: true, refund: refund{id: "refund-1", reason: "canceled"}, refunded: 2800},
+		{name: "not paid", refund: refund{id: "refund-1", reason: "damaged"}, err: ErrInvalidStatusTransition},
+		{name: "canceled not paid", canceled: true, refund: refund{id: "refund-1", reason: "canceled"}, err: ErrOrderNotPaid},
+		{name: "no reason", paid: true, refund: refund{id: "refund-1"}, err: ErrRefundReasonRequired},
+		{name: "duplicate refund id", paid: true, previous: []refund{{id: "refund-1", amount: newTestMoney(1000), reason: "damaged"}}, refund: refund{id: "refund-1", amount: newTestMoney(1000), reason: "damaged"}, err: ErrRefundAlreadyExists},
+		{name: "zero amount", paid: true, refund: refund{id: "refund-1", amount: newTestMoney(0), reason: "damaged"}, err: ErrInvalidRefundAmount},
+		{name: "negative amount", paid: true, refund: refund{id: "refund-1", amount: newTestMoney(-100), reason: "damaged"}, err: ErrInvalidRefundAmount},
+		{name: "other currency", paid: true, refund: refund{id: "refund-1", amount: &models.Money{Amount: 100, Currency: "EUR"}, reason: "damaged"}, err: models.ErrCurrencyMismatch},
+		{name: "exceeds paid amount", paid: true, refund: refund{id: "refund-1", amount: newTestMoney(2801), reason: "damaged"}, err: ErrRefundExceedsPaidAmount},
+		{name: "exceeds remaining amount", paid: true, previous: []refund{{id: "refund-1", amount: newTestMoney(1000), reason: "damaged"}}, refund: refund{id: "refund-2", amount: newTestMoney(1801), reason: "returned"}, err: ErrRefundExceedsPaidAmount},
+		{name: "fully refunded", paid: true, previous: []refund{{id: "refund-1", reason: "damaged"}}, refund: refund{id: "refund-2", reason: "returned"}, err: ErrOrderFullyRefunded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			order := newTestOrder(t, tt.paid)
+			if tt.canceled {
+				if err := order.CancelOrder(ctx, "customer request"); err != nil {
+					t.Fatalf("CancelOrder() err: %v", err)
+				}
+			}
+			for _, previous := range tt.previous {
+				if err := order.RefundOrder(ctx, previous.id, previous.amount, previous.reason); err != nil {
+					t.Fatalf("RefundOrder() previous err: %v", err)
+				}
+			}
+			events := len(order.GetUncommittedEvents())
+
+			err := order.RefundOrder(ctx, tt.refund.id, tt.refund.amount, tt.refund.reason)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("RefundOrder() err = %v, want %v", err, tt.err)
+				}
+				if len(order.GetUncommittedEvents()) != events {
+					t.Errorf("RefundOrder() applied the event of the rejected refund")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RefundOrder() err: %v", err)
+			}
+
+			if order.Order.RefundedAmount != models.NewMoney(tt.refunded, "USD") || len(order.Order.Refunds) != len(tt.previous)+1 {
+				t.Errorf("refunded amount = %s, refunds = %d, want %d, %d", order.Order.RefundedAmount, len(order.Order.Refunds), tt.refunded, len(tt.previous)+1)
+			}
+
+			uncommitted := order.GetUncommittedEvents()
+			var eventData v2.OrderRefundedEvent
+			if err := uncommitted[len(uncommitted)-1].GetJsonData(&eventData); err != nil {
+				t.Fatalf("GetJsonData() err: %v", err)
+			}
+			if eventData.Refund.RefundID != tt.refund.id || eventData.RefundedAmount != order.Order.RefundedAmount {
+				t.Errorf("event refund = %s, refunded amount = %s", eventData.Refund.RefundID, eventData.RefundedAmount)
+			}
+		})
+	}
+}
@@ -3,16 +3,22 @@ package aggregate
 import "github.com/pkg/errors"
 
 var (
-	ErrOrderAlreadyCompleted          = errors.New("Order already completed")
-	ErrOrderAlreadyCanceled           = errors.New("Order is already canceled")
-	ErrOrderMustBePaidBeforeDelivered = errors.New("Order must be paid before been delivered")
-	ErrCancelReasonRequired           = errors.New("Cancel reason must be provided")
-	ErrOrderAlreadyCancelled          = errors.New("order already cancelled")
-	ErrAlreadyPaid                    = errors.New("already paid")
-	ErrAlreadySubmitted               = errors.New("already submitted")
-	ErrOrderNotPaid                   = errors.New("order not paid")
-	ErrOrderNotFound                  = errors.New("order not found")
-	ErrAlreadyCreated                 = errors.New("order with given id already created")
-	ErrOrderShopItemsIsRequired       = errors.New("order shop items is required")
-	ErrInvalidDeliveryAddress         = errors.New("Invalid delivery address")
+	ErrCancelReasonRequired     = errors.New("Cancel reason must be provided")
+	ErrOrderNotPaid             = errors.New("order not paid")
+	ErrOrderNotFound            = errors.New("order not found")
+	ErrAlreadyCreated           = errors.New("order with given id already created")
+	ErrOrderShopItemsIsRequired = errors.New("order shop items is required")
+	ErrInvalidDeliveryAddress   = errors.New("Invalid delivery address")
+	ErrMixedCurrencies          = errors.New("order shop items prices must have the same currency")
+	ErrInvalidShopItemPrice     = errors.New("invalid shop item price")
+	ErrInvalidShopItemQuantity  = errors.New("shop item quantity must be positive")
+	ErrShopItemIDRequired       = errors.New("shop item id is required")
+	ErrDuplicateShopItemID      = errors.New("shop item id must be unique in the order")
+	ErrShopItemNotFound         = errors.New("shop item not found")
+	ErrRefundReasonRequired     = errors.New("refund reason must be provided")
+	ErrRefundAlreadyExists      = errors.New("refund with given id already exists")
+	ErrInvalidRefundAmount      = errors.New("refund amount must be positive")
+	ErrRefundExceedsPaidAmount  = errors.New("refund amount exceeds not refunded paid amount")
+	ErrOrderFullyRefunded       = errors.New("order is fully refunded")
+	ErrInvalidStatusTransition  = errors.New("invalid order status transition")
 )
@@ -0,0 +1,76 @@
+package aggregate
+
+import (
+	"github.com/AleksK1NG/es-microservice/internal/order/models"
+	"github.com/pkg/errors"
+)
+
+type orderCommand string
+
+const (
+	createOrderCommand           orderCommand = "create"
+	payOrderCommand              orderCommand = "pay"
+	submitOrderCommand           orderCommand = "submit"
+	completeOrderCommand         orderCommand = "complete"
+	cancelOrderCommand           orderCommand = "cancel"
+	editShoppingCartCommand      orderCommand = "edit shopping cart of"
+	changeDeliveryAddressCommand orderCommand = "change delivery address of"
+	refundOrderCommand           orderCommand = "refund"
+)
+
+// orderTransition the statuses in which the command is allowed and the status after it, empty to keeps the current status.
+type orderTransition struct {
+	from []models.OrderStatus
+	to   models.OrderStatus
+}
+
+// orderTransitions is the order status state machine, every command checks it before raising the event.
+var orderTransitions = map[orderCommand]orderTransition{
+	createOrderCommand: {
+		from: []models.OrderStatus{models.OrderStatusNew},
+		to:   models.OrderStatusPending,
+	},
+	payOrderCommand: {
+		from: []models.OrderStatus{models.OrderStatusPending},
+		to:   models.OrderStatusPaid,
+	},
+	submitOrderCommand: {
+		from: []models.OrderStatus{models.OrderStatusPaid},
+		to:   models.OrderStatusSubmitted,
+	},
+	completeOrderCommand: {
+		from: []models.OrderStatus{models.OrderStatusPaid, models.OrderStatusSubmitted},
+		to:   models.OrderStatusCompleted,
+	},
+	cancelOrderCommand: {
+		from: []models.OrderStatus{models.OrderStatusPending, models.OrderStatusPaid, models.OrderStatusSubmitted},
+		to:   models.OrderStatusCanceled,
+	},
+	editShoppingCartCommand: {
+		from: []models.OrderStatus{models.OrderStatusPending},
+	},
+	changeDeliveryAddressCommand: {
+		from: []models.OrderStatus{models.OrderStatusPending, models.OrderStatusPaid, models.OrderStatusSubmitted},
+	},
+	refundOrderCommand: {
+		from: []models.OrderStatus{models.OrderStatusPaid, models.OrderStatusSubmitted, models.OrderStatusCompleted, models.OrderStatusCanceled},
+	},
+}
+
+// checkTransition returns ErrInvalidStatusTransition if the command is not allowed in the current order status.
+func (a *OrderAggregate) checkTransition(command orderCommand) error {
+	for _, status := range orderTransitions[command].from {
+		if status == a.Order.Status {
+			return nil
+		}
+	}
+	return errors.Wrapf(ErrInvalidStatusTransition, "can't %s order in status {%s}", command, a.Order.Status)
+}
+
+// setStatusAfter applies the status transition of the command, events are applied without the check,
+// so the replayed history is never rejected.
+func (a *OrderAggregate) setStatusAfter(command orderCommand) {
+	if to := orderTransitions[command].to; to != "" {
+		a.Order.Status = to
+	}
+}
@@ -0,0 +1,72 @@
+package aggregate
+
+import (
+	"testing"
+
+	"github.com/AleksK1NG/es-microservice/internal/order/models"
+	"github.com/pkg/errors"
+)
+
+var testOrderStatuses = []models.OrderStatus{
+	models.OrderStatusNew,
+	models.OrderStatusPending,
+	models.OrderStatusPaid,
+	models.OrderStatusSubmitted,
+	models.OrderStatusCompleted,
+	models.OrderStatusCanceled,
+}
+
+func TestOrderTransitions(t *testing.T) {
+	tests := []struct {
+		command orderCommand
+		allowed []models.OrderStatus
+		to      models.OrderStatus
+	}{
+		{command: createOrderCommand, allowed: []models.OrderStatus{models.OrderStatusNew}, to: models.OrderStatusPending},
+		{command: payOrderCommand, allowed: []models.OrderStatus{models.OrderStatusPending}, to: models.OrderStatusPaid},
+		{command: submitOrderCommand, allowed: []models.OrderStatus{models.OrderStatusPaid}, to: models.OrderStatusSubmitted},
+		{command: completeOrderCommand, allowed: []models.OrderStatus{models.OrderStatusPaid, models.OrderStatusSubmitted}, to: models.OrderStatusCompleted},
+		{command: cancelOrderCommand, allowed: []models.OrderStatus{models.OrderStatusPending, models.OrderStatusPaid, models.OrderStatusSubmitted}, to: models.OrderStatusCanceled},
+		{command: editShoppingCartCommand, allowed: []models.OrderStatus{models.OrderStatusPending}},
+		{command: changeDeliveryAddressCommand, allowed: []models.OrderStatus{models.OrderStatusPending, models.OrderStatusPaid, models.OrderStatusSubmitted}},
+		{command: refundOrderCommand, allowed: []models.OrderStatus{models.OrderStatusPaid, models.OrderStatusSubmitted, models.OrderStatusCompleted, models.OrderStatusCanceled}},
+	}
+
+	if len(tests) != len(orderTransitions) {
+		t.Fatalf("%d commands are tested, orderTransitions has %d", len(tests), len(orderTransitions))
+	}
+
+	for _, tt := range tests {
+		for _, status := range testOrderStatuses {
+			allowed := false
+			for _, allowedStatus := range tt.allowed {
+				allowed = allowed || allowedStatus == status
+			}
+
+			t.Run(string(tt.command)+" "+string(status), func(t *testing.T) {
+				order := NewOrderAggregateWithID("", "order-1")
+				order.Order.Status = status
+
+				err := order.checkTransition(tt.command)
+				if allowed && err != nil {
+					t.Fatalf("checkTransition() err: %v", err)
+				}
+				if !allowed {
+					if !errors.Is(err, ErrInvalidStatusTransition) {
+						t.Fatalf("checkTransition() err = %v, want %v", err, ErrInvalidStatusTransition)
+					}
+					return
+				}
+
+				order.setStatusAfter(tt.command)
+				want := tt.to
+				if want == "" {
+					want = status
+				}
+				if order.Order.Status != want {
+					t.Errorf("status after = %s, want %s", order.Order.Status, want)
+				}
+			})
+		}
+	}
+}
@@ -2,39 +2,118 @@ package aggregate
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/AleksK1NG/es-microservice/internal/order/models"
 	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
 	"github.com/EventStore/EventStore-Client-Go/esdb"
-	"github.com/opentracing/opentracing-go"
-	"github.com/opentracing/opentracing-go/log"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 )
 
-func GetShopItemsTotalPrice(shopItems []*models.ShopItem) float64 {
-	var totalPrice float64 = 0
+// GetShopItemsTotalPrice returns sum of the shop items prices multiplied by quantity,
+// returns ErrMixedCurrencies if the prices have different currencies and ErrInvalidShopItemPrice if the sum overflows.
+func GetShopItemsTotalPrice(shopItems []*models.ShopItem) (models.Money, error) {
+	var totalPrice models.Money
 	for _, item := range shopItems {
-		totalPrice += item.Price * float64(item.Quantity)
+		price, err := item.Price.Multiply(item.Quantity)
+		if err != nil {
+			return models.Money{}, errors.Wrapf(ErrInvalidShopItemPrice, "shop item: {%s}, err: {%v}", item.ID, err)
+		}
+		sum, err := totalPrice.Add(price)
+		if errors.Is(err, models.ErrAmountOverflow) {
+			return models.Money{}, errors.Wrapf(ErrInvalidShopItemPrice, "shop item: {%s}, err: {%v}", item.ID, err)
+		}
+		if err != nil {
+			return models.Money{}, errors.Wrapf(ErrMixedCurrencies, "shop item: {%s}, err: {%v}", item.ID, err)
+		}
+		totalPrice = sum
 	}
-	return totalPrice
+	return totalPrice, nil
+}
+
+// ValidateShopItems check the shop items invariants, items ids are unique, quantities are positive
+// and prices are non negative amounts of the same valid currency.
+func ValidateShopItems(shopItems []*models.ShopItem) error {
+	ids := make(map[string]struct{}, len(shopItems))
+	for _, item := range shopItems {
+		if item.ID == "" {
+			return ErrShopItemIDRequired
+		}
+		if _, ok := ids[item.ID]; ok {
+			return errors.Wrapf(ErrDuplicateShopItemID, "shop item: {%s}", item.ID)
+		}
+		ids[item.ID] = struct{}{}
+
+		if item.Quantity == 0 {
+			return errors.Wrapf(ErrInvalidShopItemQuantity, "shop item: {%s}", item.ID)
+		}
+		if err := models.ValidateCurrency(item.Price.Currency); err != nil {
+			return errors.Wrapf(ErrInvalidShopItemPrice, "shop item: {%s}, err: {%v}", item.ID, err)
+		}
+		if item.Price.Amount < 0 {
+			return errors.Wrapf(ErrInvalidShopItemPrice, "shop item: {%s}, negative amount: {%d}", item.ID, item.Price.Amount)
+		}
+	}
+	_, err := GetShopItemsTotalPrice(shopItems)
+	return err
+}
+
+// findShopItem returns index of the shop item with id or -1.
+func findShopItem(shopItems []*models.ShopItem, id string) int {
+	for i, item := range shopItems {
+		if item.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// removeShopItem returns copy of the shop items without the item with id.
+func removeShopItem(shopItems []*models.ShopItem, id string) []*models.ShopItem {
+	items := make([]*models.ShopItem, 0, len(shopItems))
+	for _, item := range shopItems {
+		if item.ID != id {
+			items = append(items, item)
+		}
+	}
+	return items
 }
 
 // GetOrderAggregateID get order aggregate id for eventstoredb
 func GetOrderAggregateID(eventAggregateID string) string {
-	return strings.ReplaceAll(eventAggregateID, "order-", "")
+	_, orderID := parseOrderStreamID(eventAggregateID)
+	return orderID
+}
+
+// GetOrderTenantID get tenant id of the order stream, empty for the default tenant streams.
+func GetOrderTenantID(eventAggregateID string) string {
+	tenantID, _ := parseOrderStreamID(eventAggregateID)
+	return tenantID
+}
+
+// parseOrderStreamID splits tenantA-order-<id> stream id to the tenant and order ids,
+// tenant ids are alphanumeric, so the first -order- separates them.
+func parseOrderStreamID(streamID string) (tenantID string, orderID string) {
+	prefix := fmt.Sprintf("%s-", OrderAggregateType)
+	if i := strings.Index(streamID, "-"+prefix); i > 0 {
+		return streamID[:i], streamID[i+len(prefix)+1:]
+	}
+	return "", strings.TrimPrefix(streamID, prefix)
 }
 
 func IsAggregateNotFound(aggregate es.Aggregate) bool {
 	return aggregate.GetVersion() == 0
 }
 
-func LoadOrderAggregate(ctx context.Context, eventStore es.AggregateStore, aggregateID string) (*OrderAggregate, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "LoadOrderAggregate")
-	defer span.Finish()
-	span.LogFields(log.String("AggregateID", aggregateID))
+func LoadOrderAggregate(ctx context.Context, eventStore es.AggregateStore, tenantID string, aggregateID string) (*OrderAggregate, error) {
+	ctx, span := tracing.StartSpan(ctx, "LoadOrderAggregate")
+	defer span.End()
+	span.SetAttributes(attribute.String("TenantID", tenantID), attribute.String("AggregateID", aggregateID))
 
-	order := NewOrderAggregateWithID(aggregateID)
+	order := NewOrderAggregateWithID(tenantID, aggregateID)
 
 	err := eventStore.Exists(ctx, order.GetID())
 	if err != nil && !errors.Is(err, esdb.ErrStreamNotFound) {
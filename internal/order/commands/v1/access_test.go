@@ -0,0 +1,129 @@
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AleksK1NG/es-microservice/config"
+	"github.com/AleksK1NG/es-microservice/internal/order/aggregate"
+	"github.com/AleksK1NG/es-microservice/internal/order/models"
+	"github.com/AleksK1NG/es-microservice/pkg/auth"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/es/memory"
+	"github.com/pkg/errors"
+)
+
+func TestCommandHandlersOrderAccess(t *testing.T) {
+	var (
+		owner    = &auth.Identity{Subject: "customer-1", Email: "Customer@mail.com"}
+		customer = &auth.Identity{Subject: "customer-2", Email: "other@mail.com"}
+		staff    = &auth.Identity{Subject: "staff-1", Email: "support@mail.com", Staff: true}
+	)
+	payment := models.Payment{PaymentID: "payment-1", Timestamp: time.Now().UTC()}
+	shopItem := &models.ShopItem{ID: "item-3", Title: "pencil", Quantity: 1, Price: models.NewMoney(100, "USD")}
+
+	commands := []struct {
+		name      string
+		paid      bool
+		submitted bool
+		handle    func(ctx context.Context, cfg *config.Config, store es.AggregateStore) error
+		staff     bool
+	}{
+		{name: "create", handle: func(ctx context.Context, cfg *config.Config, store es.AggregateStore) error {
+			return NewCreateOrderHandler(newTestLogger(), cfg, store).Handle(ctx, NewCreateOrderCommand("", "order-2", newTestShopItems(), "customer@mail.com", "address"))
+		}},
+		{name: "pay", handle: func(ctx context.Context, cfg *config.Config, store es.AggregateStore) error {
+			return NewOrderPaidHandler(newTestLogger(), cfg, store).Handle(ctx, NewPayOrderCommand("", payment, "order-1"))
+		}},
+		{name: "submit", paid: true, handle: func(ctx context.Context, cfg *config.Config, store es.AggregateStore) error {
+			return NewSubmitOrderHandler(newTestLogger(), cfg, store).Handle(ctx, NewSubmitOrderCommand("", "order-1"))
+		}},
+		{name: "update shopping cart", handle: func(ctx context.Context, cfg *config.Config, store es.AggregateStore) error {
+			return NewUpdateShoppingCartCmdHandler(newTestLogger(), cfg, store).Handle(ctx, NewUpdateShoppingCartCommand("", "order-1", newTestShopItems()[:1]))
+		}},
+		{name: "add shop item", handle: func(ctx context.Context, cfg *config.Config, store es.AggregateStore) error {
+			return NewAddShopItemCmdHandler(newTestLogger(), cfg, store).Handle(ctx, NewAddShopItemCommand("", "order-1", shopItem))
+		}},
+		{name: "remove shop item", handle: func(ctx context.Context, cfg *config.Config, store es.AggregateStore) error {
+			return NewRemoveShopItemCmdHandler(newTestLogger(), cfg, store).Handle(ctx, NewRemoveShopItemCommand("", "order-1", "item-2"))
+		}},
+		{name: "change item quantity", handle: func(ctx context.Context, cfg *config.Config, store es.AggregateStore) error {
+			return NewChangeItemQuantityCmdHandler(newTestLogger(), cfg, store).Handle(ctx, NewChangeItemQuantityCommand("", "order-1", "item-2", 3))
+		}},
+		{name: "change delivery address", handle: func(ctx context.Context, cfg *config.Config, store es.AggregateStore) error {
+			return NewChangeDeliveryAddressCmdHandler(newTestLogger(), cfg, store).Handle(ctx, NewChangeDeliveryAddressCommand("", "order-1", "new address"))
+		}},
+		{name: "cancel", handle: func(ctx context.Context, cfg *config.Config, store es.AggregateStore) error {
+			return NewCancelOrderCommandHandler(newTestLogger(), cfg, store).Handle(ctx, NewCancelOrderCommand("", "order-1", "customer request"))
+		}},
+		{name: "complete", paid: true, submitted: true, handle: func(ctx context.Context, cfg *config.Config, store es.AggregateStore) error {
+			return NewCompleteOrderCommandHandler(newTestLogger(), cfg, store).Handle(ctx, NewCompleteOrderCommand("", "order-1", time.Now().UTC()))
+		}},
+		{name: "refund", paid: true, staff: true, handle: func(ctx context.Context, cfg *config.Config, store es.AggregateStore) error {
+			return NewRefundOrderCmdHandler(newTestLogger(), cfg, store).Handle(ctx, NewRefundOrderCommand("", "order-1", "refund-1", nil, "damaged"))
+		}},
+	}
+
+	identities := []struct {
+		name     string
+		identity *auth.Identity
+		owner    bool
+	}{
+		{name: "owner", identity: owner, owner: true},
+		{name: "other customer", identity: customer},
+		{name: "staff", identity: staff, owner: true},
+		{name: "authentication disabled", owner: true},
+	}
+
+	for _, command := range commands {
+		for _, caller := range identities {
+			t.Run(command.name+" by "+caller.name, func(t *testing.T) {
+				ctx := context.Background()
+				cfg := &config.Config{}
+				store := memory.NewAggregateStore(newTestLogger(), es.Config{}, memory.NewDB())
+
+				if err := NewCreateOrderHandler(newTestLogger(), cfg, store).Handle(ctx, NewCreateOrderCommand("", "order-1", newTestShopItems(), "customer@mail.com", "address")); err != nil {
+					t.Fatalf("create Handle() err: %v", err)
+				}
+				if command.paid {
+					if err := NewOrderPaidHandler(newTestLogger(), cfg, store).Handle(ctx, NewPayOrderCommand("", payment, "order-1")); err != nil {
+						t.Fatalf("pay Handle() err: %v", err)
+					}
+				}
+				if command.submitted {
+					if err := NewSubmitOrderHandler(newTestLogger(), cfg, store).Handle(ctx, NewSubmitOrderCommand("", "order-1")); err != nil {
+						t.Fatalf("submit Handle() err: %v", err)
+					}
+				}
+				before, err := aggregate.LoadOrderAggregate(ctx, store, "", "order-1")
+				if err != nil {
+					t.Fatalf("LoadOrderAggregate() err: %v", err)
+				}
+
+				if caller.identity != nil {
+					ctx = auth.WithIdentity(ctx, caller.identity)
+				}
+				allowed := caller.owner && (!command.staff || caller.identity == nil || caller.identity.Staff)
+				err = command.handle(ctx, cfg, store)
+				if allowed {
+					if err != nil {
+						t.Fatalf("Handle() err: %v", err)
+					}
+					return
+				}
+				if !errors.Is(err, auth.ErrForbidden) {
+					t.Fatalf("Handle() err = %v, want %v", err, auth.ErrForbidden)
+				}
+
+				after, err := aggregate.LoadOrderAggregate(ctx, store, "", "order-1")
+				if err != nil {
+					t.Fatalf("LoadOrderAggregate() err: %v", err)
+				}
+				if after.GetVersion() != before.GetVersion() {
+					t.Errorf("forbidden command saved order version %d, want %d", after.GetVersion(), before.GetVersion())
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/AleksK1NG/es-microservice/config"
+	"github.com/AleksK1NG/es-microservice/internal/order/aggregate"
+	"github.com/AleksK1NG/es-microservice/pkg/auth"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type AddShopItemCommandHandler interface {
+	Handle(ctx context.Context, command *AddShopItemCommand) error
+}
+
+type addShopItemCmdHandler struct {
+	log logger.Logger
+	cfg *config.Config
+	es  es.AggregateStore
+}
+
+func NewAddShopItemCmdHandler(log logger.Logger, cfg *config.Config, es es.AggregateStore) *addShopItemCmdHandler {
+	return &addShopItemCmdHandler{log: log, cfg: cfg, es: es}
+}
+
+func (c *addShopItemCmdHandler) Handle(ctx context.Context, command *AddShopItemCommand) error {
+	ctx, span := tracing.StartSpan(ctx, "addShopItemCmdHandler.Handle")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", command.GetAggregateID()))
+
+	return es.RetryOnConcurrencyConflict(ctx, c.cfg.EventSourcing.ConcurrencyRetry, func(ctx context.Context) error {
+		order, err := aggregate.LoadOrderAggregate(ctx, c.es, command.GetTenantID(), command.GetAggregateID())
+		if err != nil {
+			return err
+		}
+		if err := auth.CheckOrderAccess(ctx, order.Order.AccountEmail); err != nil {
+			return err
+		}
+
+		if err := order.AddShopItem(ctx, command.ShopItem); err != nil {
+			return err
+		}
+
+		return c.es.Save(ctx, order)
+	})
+}
@@ -5,10 +5,11 @@ import (
 
 	"github.com/AleksK1NG/es-microservice/config"
 	"github.com/AleksK1NG/es-microservice/internal/order/aggregate"
+	"github.com/AleksK1NG/es-microservice/pkg/auth"
 	"github.com/AleksK1NG/es-microservice/pkg/es"
 	"github.com/AleksK1NG/es-microservice/pkg/logger"
-	"github.com/opentracing/opentracing-go"
-	"github.com/opentracing/opentracing-go/log"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type ChangeDeliveryAddressCommandHandler interface {
@@ -26,18 +27,23 @@ func NewChangeDeliveryAddressCmdHandler(log logger.Logger, cfg *config.Config, e
 }
 
 func (c *changeDeliveryAddressCmdHandler) Handle(ctx context.Context, command *ChangeDeliveryAddressCommand) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "changeDeliveryAddressCmdHandler.Handle")
-	defer span.Finish()
-	span.LogFields(log.String("AggregateID", command.GetAggregateID()))
-
-	order, err := aggregate.LoadOrderAggregate(ctx, c.es, command.GetAggregateID())
-	if err != nil {
-		return err
-	}
-
-	if err := order.ChangeDeliveryAddress(ctx, command.DeliveryAddress); err != nil {
-		return err
-	}
-
-	return c.es.Save(ctx, order)
+	ctx, span := tracing.StartSpan(ctx, "changeDeliveryAddressCmdHandler.Handle")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", command.GetAggregateID()))
+
+	return es.RetryOnConcurrencyConflict(ctx, c.cfg.EventSourcing.ConcurrencyRetry, func(ctx context.Context) error {
+		order, err := aggregate.LoadOrderAggregate(ctx, c.es, command.GetTenantID(), command.GetAggregateID())
+		if err != nil {
+			return err
+		}
+		if err := auth.CheckOrderAccess(ctx, order.Order.AccountEmail); err != nil {
+			return err
+		}
+
+		if err := order.ChangeDeliveryAddress(ctx, command.DeliveryAddress); err != nil {
+			return err
+		}
+
+		return c.es.Save(ctx, order)
+	})
 }
@@ -0,0 +1,49 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/AleksK1NG/es-microservice/config"
+	"github.com/AleksK1NG/es-microservice/internal/order/aggregate"
+	"github.com/AleksK1NG/es-microservice/pkg/auth"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type ChangeItemQuantityCommandHandler interface {
+	Handle(ctx context.Context, command *ChangeItemQuantityCommand) error
+}
+
+type changeItemQuantityCmdHandler struct {
+	log logger.Logger
+	cfg *config.Config
+	es  es.AggregateStore
+}
+
+func NewChangeItemQuantityCmdHandler(log logger.Logger, cfg *config.Config, es es.AggregateStore) *changeItemQuantityCmdHandler {
+	return &changeItemQuantityCmdHandler{log: log, cfg: cfg, es: es}
+}
+
+func (c *changeItemQuantityCmdHandler) Handle(ctx context.Context, command *ChangeItemQuantityCommand) error {
+	ctx, span := tracing.StartSpan(ctx, "changeItemQuantityCmdHandler.Handle")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", command.GetAggregateID()))
+
+	return es.RetryOnConcurrencyConflict(ctx, c.cfg.EventSourcing.ConcurrencyRetry, func(ctx context.Context) error {
+		order, err := aggregate.LoadOrderAggregate(ctx, c.es, command.GetTenantID(), command.GetAggregateID())
+		if err != nil {
+			return err
+		}
+		if err := auth.CheckOrderAccess(ctx, order.Order.AccountEmail); err != nil {
+			return err
+		}
+
+		if err := order.ChangeItemQuantity(ctx, command.ShopItemID, command.Quantity); err != nil {
+			return err
+		}
+
+		return c.es.Save(ctx, order)
+	})
+}
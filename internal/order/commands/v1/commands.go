@@ -7,15 +7,22 @@ import (
 	"github.com/AleksK1NG/es-microservice/pkg/es"
 )
 
+// operations of the commands executed with idempotency keys
+const (
+	CreateOrderOperation = "CreateOrder"
+	PayOrderOperation    = "PayOrder"
+	RefundOrderOperation = "RefundOrder"
+)
+
 type CreateOrderCommand struct {
 	es.BaseCommand
-	ShopItems       []*models.ShopItem `json:"shopItems" bson:"shopItems,omitempty" validate:"required"`
+	ShopItems       []*models.ShopItem `json:"shopItems" bson:"shopItems,omitempty" validate:"required,dive"`
 	AccountEmail    string             `json:"accountEmail" bson:"accountEmail,omitempty" validate:"required,email"`
 	DeliveryAddress string             `json:"deliveryAddress" bson:"deliveryAddress,omitempty" validate:"required"`
 }
 
-func NewCreateOrderCommand(aggregateID string, shopItems []*models.ShopItem, accountEmail, deliveryAddress string) *CreateOrderCommand {
-	return &CreateOrderCommand{BaseCommand: es.NewBaseCommand(aggregateID), ShopItems: shopItems, AccountEmail: accountEmail, DeliveryAddress: deliveryAddress}
+func NewCreateOrderCommand(tenantID string, aggregateID string, shopItems []*models.ShopItem, accountEmail, deliveryAddress string) *CreateOrderCommand {
+	return &CreateOrderCommand{BaseCommand: es.NewBaseCommand(tenantID, aggregateID), ShopItems: shopItems, AccountEmail: accountEmail, DeliveryAddress: deliveryAddress}
 }
 
 type PayOrderCommand struct {
@@ -23,25 +30,53 @@ type PayOrderCommand struct {
 	es.BaseCommand
 }
 
-func NewPayOrderCommand(payment models.Payment, aggregateID string) *PayOrderCommand {
-	return &PayOrderCommand{Payment: payment, BaseCommand: es.NewBaseCommand(aggregateID)}
+func NewPayOrderCommand(tenantID string, payment models.Payment, aggregateID string) *PayOrderCommand {
+	return &PayOrderCommand{Payment: payment, BaseCommand: es.NewBaseCommand(tenantID, aggregateID)}
 }
 
 type SubmitOrderCommand struct {
 	es.BaseCommand
 }
 
-func NewSubmitOrderCommand(aggregateID string) *SubmitOrderCommand {
-	return &SubmitOrderCommand{BaseCommand: es.NewBaseCommand(aggregateID)}
+func NewSubmitOrderCommand(tenantID string, aggregateID string) *SubmitOrderCommand {
+	return &SubmitOrderCommand{BaseCommand: es.NewBaseCommand(tenantID, aggregateID)}
 }
 
 type UpdateShoppingCartCommand struct {
 	es.BaseCommand
-	ShopItems []*models.ShopItem `json:"shopItems" bson:"shopItems,omitempty" validate:"required"`
+	ShopItems []*models.ShopItem `json:"shopItems" bson:"shopItems,omitempty" validate:"required,dive"`
+}
+
+func NewUpdateShoppingCartCommand(tenantID string, aggregateID string, shopItems []*models.ShopItem) *UpdateShoppingCartCommand {
+	return &UpdateShoppingCartCommand{BaseCommand: es.NewBaseCommand(tenantID, aggregateID), ShopItems: shopItems}
+}
+
+type AddShopItemCommand struct {
+	es.BaseCommand
+	ShopItem *models.ShopItem `json:"shopItem" bson:"shopItem,omitempty" validate:"required"`
+}
+
+func NewAddShopItemCommand(tenantID string, aggregateID string, shopItem *models.ShopItem) *AddShopItemCommand {
+	return &AddShopItemCommand{BaseCommand: es.NewBaseCommand(tenantID, aggregateID), ShopItem: shopItem}
+}
+
+type RemoveShopItemCommand struct {
+	es.BaseCommand
+	ShopItemID string `json:"shopItemId" validate:"required"`
+}
+
+func NewRemoveShopItemCommand(tenantID string, aggregateID string, shopItemID string) *RemoveShopItemCommand {
+	return &RemoveShopItemCommand{BaseCommand: es.NewBaseCommand(tenantID, aggregateID), ShopItemID: shopItemID}
+}
+
+type ChangeItemQuantityCommand struct {
+	es.BaseCommand
+	ShopItemID string `json:"shopItemId" validate:"required"`
+	Quantity   uint64 `json:"quantity" validate:"required,gt=0"`
 }
 
-func NewUpdateShoppingCartCommand(aggregateID string, shopItems []*models.ShopItem) *UpdateShoppingCartCommand {
-	return &UpdateShoppingCartCommand{BaseCommand: es.NewBaseCommand(aggregateID), ShopItems: shopItems}
+func NewChangeItemQuantityCommand(tenantID string, aggregateID string, shopItemID string, quantity uint64) *ChangeItemQuantityCommand {
+	return &ChangeItemQuantityCommand{BaseCommand: es.NewBaseCommand(tenantID, aggregateID), ShopItemID: shopItemID, Quantity: quantity}
 }
 
 type CancelOrderCommand struct {
@@ -49,8 +84,8 @@ type CancelOrderCommand struct {
 	CancelReason string `json:"cancelReason" validate:"required"`
 }
 
-func NewCancelOrderCommand(aggregateID string, cancelReason string) *CancelOrderCommand {
-	return &CancelOrderCommand{BaseCommand: es.NewBaseCommand(aggregateID), CancelReason: cancelReason}
+func NewCancelOrderCommand(tenantID string, aggregateID string, cancelReason string) *CancelOrderCommand {
+	return &CancelOrderCommand{BaseCommand: es.NewBaseCommand(tenantID, aggregateID), CancelReason: cancelReason}
 }
 
 type CompleteOrderCommand struct {
@@ -58,8 +93,8 @@ type CompleteOrderCommand struct {
 	DeliveryTimestamp time.Time `json:"deliveryTimestamp" validate:"required"`
 }
 
-func NewCompleteOrderCommand(aggregateID string, deliveryTimestamp time.Time) *CompleteOrderCommand {
-	return &CompleteOrderCommand{BaseCommand: es.NewBaseCommand(aggregateID), DeliveryTimestamp: deliveryTimestamp}
+func NewCompleteOrderCommand(tenantID string, aggregateID string, deliveryTimestamp time.Time) *CompleteOrderCommand {
+	return &CompleteOrderCommand{BaseCommand: es.NewBaseCommand(tenantID, aggregateID), DeliveryTimestamp: deliveryTimestamp}
 }
 
 type ChangeDeliveryAddressCommand struct {
@@ -67,6 +102,18 @@ type ChangeDeliveryAddressCommand struct {
 	DeliveryAddress string `json:"deliveryAddress" bson:"deliveryAddress,omitempty" validate:"required"`
 }
 
-func NewChangeDeliveryAddressCommand(aggregateID string, deliveryAddress string) *ChangeDeliveryAddressCommand {
-	return &ChangeDeliveryAddressCommand{BaseCommand: es.NewBaseCommand(aggregateID), DeliveryAddress: deliveryAddress}
+func NewChangeDeliveryAddressCommand(tenantID string, aggregateID string, deliveryAddress string) *ChangeDeliveryAddressCommand {
+	return &ChangeDeliveryAddressCommand{BaseCommand: es.NewBaseCommand(tenantID, aggregateID), DeliveryAddress: deliveryAddress}
+}
+
+// RefundOrderCommand nil Amount refunds all not refunded paid amount of the order.
+type RefundOrderCommand struct {
+	es.BaseCommand
+	RefundID string        `json:"refundId" validate:"required"`
+	Amount   *models.Money `json:"amount,omitempty" validate:"omitempty"`
+	Reason   string        `json:"reason" validate:"required"`
+}
+
+func NewRefundOrderCommand(tenantID string, aggregateID string, refundID string, amount *models.Money, reason string) *RefundOrderCommand {
+	return &RefundOrderCommand{BaseCommand: es.NewBaseCommand(tenantID, aggregateID), RefundID: refundID, Amount: amount, Reason: reason}
 }
@@ -5,12 +5,13 @@ import (
 
 	"github.com/AleksK1NG/es-microservice/config"
 	"github.com/AleksK1NG/es-microservice/internal/order/aggregate"
+	"github.com/AleksK1NG/es-microservice/pkg/auth"
 	"github.com/AleksK1NG/es-microservice/pkg/es"
 	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
 	"github.com/EventStore/EventStore-Client-Go/esdb"
-	"github.com/opentracing/opentracing-go"
-	"github.com/opentracing/opentracing-go/log"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type CreateOrderCommandHandler interface {
@@ -28,11 +29,15 @@ func NewCreateOrderHandler(log logger.Logger, cfg *config.Config, es es.Aggregat
 }
 
 func (c *createOrderHandler) Handle(ctx context.Context, command *CreateOrderCommand) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "createOrderHandler.Handle")
-	defer span.Finish()
-	span.LogFields(log.String("AggregateID", command.GetAggregateID()))
+	ctx, span := tracing.StartSpan(ctx, "createOrderHandler.Handle")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", command.GetAggregateID()))
 
-	order := aggregate.NewOrderAggregateWithID(command.AggregateID)
+	if err := auth.CheckOrderAccess(ctx, command.AccountEmail); err != nil {
+		return err
+	}
+
+	order := aggregate.NewOrderAggregateWithID(command.GetTenantID(), command.GetAggregateID())
 	err := c.es.Exists(ctx, order.GetID())
 	if err != nil && !errors.Is(err, esdb.ErrStreamNotFound) {
 		return err
@@ -42,6 +47,6 @@ func (c *createOrderHandler) Handle(ctx context.Context, command *CreateOrderCom
 		return err
 	}
 
-	span.LogFields(log.String("order", order.String()))
+	span.SetAttributes(attribute.String("order", order.String()))
 	return c.es.Save(ctx, order)
 }
@@ -0,0 +1,125 @@
+package v1
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/AleksK1NG/es-microservice/config"
+	"github.com/AleksK1NG/es-microservice/internal/order/aggregate"
+	"github.com/AleksK1NG/es-microservice/internal/order/models"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/es/memory"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/pkg/errors"
+)
+
+func newTestLogger() logger.Logger {
+	appLogger := logger.NewAppLogger(&logger.Config{LogLevel: "error", Encoder: "console"})
+	appLogger.InitLogger()
+	return appLogger
+}
+
+func newTestShopItems() []*models.ShopItem {
+	return []*models.ShopItem{
+		{ID: "item-1", Title: "book", Quantity: 2, Price: models.NewMoney(1250, "USD")},
+		{ID: "item-2", Title: "pen", Quantity: 1, Price: models.NewMoney(300, "USD")},
+	}
+}
+
+func TestCreateOrderHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		tenantID  string
+		existing  bool
+		shopItems []*models.ShopItem
+		address   string
+		err       error
+	}{
+		{name: "created", shopItems: newTestShopItems(), address: "address"},
+		{name: "tenant order", tenantID: "tenantA", shopItems: newTestShopItems(), address: "address"},
+		{name: "already exists", existing: true, shopItems: newTestShopItems(), address: "address", err: es.ErrConcurrencyConflict},
+		{name: "no delivery address", shopItems: newTestShopItems(), err: aggregate.ErrInvalidDeliveryAddress},
+		{name: "mixed currencies", shopItems: []*models.ShopItem{
+			{ID: "item-1", Quantity: 1, Price: models.NewMoney(100, "USD")},
+			{ID: "item-2", Quantity: 1, Price: models.NewMoney(100, "EUR")},
+		}, address: "address", err: aggregate.ErrMixedCurrencies},
+		{name: "total price overflow", shopItems: []*models.ShopItem{
+			{ID: "item-1", Quantity: 2, Price: models.NewMoney(math.MaxInt64/2+1, "USD")},
+		}, address: "address", err: aggregate.ErrInvalidShopItemPrice},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			log := newTestLogger()
+			db := memory.NewDB()
+			store := memory.NewAggregateStore(log, es.Config{}, db)
+			handler := NewCreateOrderHandler(log, &config.Config{}, store)
+
+			if tt.existing {
+				if err := handler.Handle(ctx, NewCreateOrderCommand(tt.tenantID, "order-1", newTestShopItems(), "customer@mail.com", "address")); err != nil {
+					t.Fatalf("Handle() existing err: %v", err)
+				}
+			}
+
+			err := handler.Handle(ctx, NewCreateOrderCommand(tt.tenantID, "order-1", tt.shopItems, "customer@mail.com", tt.address))
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("Handle() err = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Handle() err: %v", err)
+			}
+
+			order, err := aggregate.LoadOrderAggregate(ctx, store, tt.tenantID, "order-1")
+			if err != nil {
+				t.Fatalf("LoadOrderAggregate() err: %v", err)
+			}
+			if order.GetVersion() != 0 || order.Order.Status != models.OrderStatusPending || order.Order.TotalPrice != models.NewMoney(2800, "USD") {
+				t.Errorf("loaded order version = %d, status = %s, total price = %s", order.GetVersion(), order.Order.Status, order.Order.TotalPrice)
+			}
+			if streamID := es.GetTenantStreamID(tt.tenantID, "order-order-1"); !db.StreamExists(streamID) {
+				t.Errorf("stream %s is not saved", streamID)
+			}
+		})
+	}
+}
+
+func TestSubmitOrderHandler(t *testing.T) {
+	ctx := context.Background()
+	log := newTestLogger()
+	store := memory.NewAggregateStore(log, es.Config{}, memory.NewDB())
+	cfg := &config.Config{}
+
+	if err := NewCreateOrderHandler(log, cfg, store).Handle(ctx, NewCreateOrderCommand("", "order-1", newTestShopItems(), "customer@mail.com", "address")); err != nil {
+		t.Fatalf("create Handle() err: %v", err)
+	}
+
+	submit := NewSubmitOrderHandler(log, cfg, store)
+	if err := submit.Handle(ctx, NewSubmitOrderCommand("", "order-1")); !errors.Is(err, aggregate.ErrInvalidStatusTransition) {
+		t.Fatalf("submit not paid Handle() err = %v, want %v", err, aggregate.ErrInvalidStatusTransition)
+	}
+
+	payment := models.Payment{PaymentID: "payment-1", Timestamp: time.Now().UTC()}
+	if err := NewOrderPaidHandler(log, cfg, store).Handle(ctx, NewPayOrderCommand("", payment, "order-1")); err != nil {
+		t.Fatalf("pay Handle() err: %v", err)
+	}
+	if err := submit.Handle(ctx, NewSubmitOrderCommand("", "order-1")); err != nil {
+		t.Fatalf("submit Handle() err: %v", err)
+	}
+	if err := submit.Handle(ctx, NewSubmitOrderCommand("", "order-1")); !errors.Is(err, aggregate.ErrInvalidStatusTransition) {
+		t.Errorf("second submit Handle() err = %v, want %v", err, aggregate.ErrInvalidStatusTransition)
+	}
+
+	order, err := aggregate.LoadOrderAggregate(ctx, store, "", "order-1")
+	if err != nil {
+		t.Fatalf("LoadOrderAggregate() err: %v", err)
+	}
+	if order.GetVersion() != 2 || order.Order.Status != models.OrderStatusSubmitted {
+		t.Errorf("loaded order version = %d, status = %s, want 2 %s", order.GetVersion(), order.Order.Status, models.OrderStatusSubmitted)
+	}
+}
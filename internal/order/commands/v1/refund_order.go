@@ -0,0 +1,51 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/AleksK1NG/es-microservice/config"
+	"github.com/AleksK1NG/es-microservice/internal/order/aggregate"
+	"github.com/AleksK1NG/es-microservice/pkg/auth"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type RefundOrderCommandHandler interface {
+	Handle(ctx context.Context, command *RefundOrderCommand) error
+}
+
+type refundOrderCmdHandler struct {
+	log logger.Logger
+	cfg *config.Config
+	es  es.AggregateStore
+}
+
+func NewRefundOrderCmdHandler(log logger.Logger, cfg *config.Config, es es.AggregateStore) *refundOrderCmdHandler {
+	return &refundOrderCmdHandler{log: log, cfg: cfg, es: es}
+}
+
+func (c *refundOrderCmdHandler) Handle(ctx context.Context, command *RefundOrderCommand) error {
+	ctx, span := tracing.StartSpan(ctx, "refundOrderCmdHandler.Handle")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", command.GetAggregateID()))
+
+	// only staff refunds the orders, the customers request refunds from the support
+	if err := auth.CheckStaffAccess(ctx); err != nil {
+		return err
+	}
+
+	return es.RetryOnConcurrencyConflict(ctx, c.cfg.EventSourcing.ConcurrencyRetry, func(ctx context.Context) error {
+		order, err := aggregate.LoadOrderAggregate(ctx, c.es, command.GetTenantID(), command.GetAggregateID())
+		if err != nil {
+			return err
+		}
+
+		if err := order.RefundOrder(ctx, command.RefundID, command.Amount, command.Reason); err != nil {
+			return err
+		}
+
+		return c.es.Save(ctx, order)
+	})
+}
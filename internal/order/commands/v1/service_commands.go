@@ -8,6 +8,10 @@ type OrderCommands struct {
 	CancelOrder                CancelOrderCommandHandler
 	CompleteOrder              CompleteOrderCommandHandler
 	ChangeOrderDeliveryAddress ChangeDeliveryAddressCommandHandler
+	AddShopItem                AddShopItemCommandHandler
+	RemoveShopItem             RemoveShopItemCommandHandler
+	ChangeItemQuantity         ChangeItemQuantityCommandHandler
+	RefundOrder                RefundOrderCommandHandler
 }
 
 func NewOrderCommands(
@@ -18,6 +22,10 @@ func NewOrderCommands(
 	cancelOrder CancelOrderCommandHandler,
 	deliveryOrder CompleteOrderCommandHandler,
 	changeOrderDeliveryAddress ChangeDeliveryAddressCommandHandler,
+	addShopItem AddShopItemCommandHandler,
+	removeShopItem RemoveShopItemCommandHandler,
+	changeItemQuantity ChangeItemQuantityCommandHandler,
+	refundOrder RefundOrderCommandHandler,
 ) *OrderCommands {
 	return &OrderCommands{
 		CreateOrder:                createOrder,
@@ -27,5 +35,9 @@ func NewOrderCommands(
 		CancelOrder:                cancelOrder,
 		CompleteOrder:              deliveryOrder,
 		ChangeOrderDeliveryAddress: changeOrderDeliveryAddress,
+		AddShopItem:                addShopItem,
+		RemoveShopItem:             removeShopItem,
+		ChangeItemQuantity:         changeItemQuantity,
+		RefundOrder:                refundOrder,
 	}
 }
@@ -10,14 +10,20 @@ import (
 	"github.com/AleksK1NG/es-microservice/internal/order/models"
 	"github.com/AleksK1NG/es-microservice/internal/order/queries"
 	"github.com/AleksK1NG/es-microservice/internal/order/service"
+	"github.com/AleksK1NG/es-microservice/pkg/constants"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
 	grpcErrors "github.com/AleksK1NG/es-microservice/pkg/grpc_errors"
 	"github.com/AleksK1NG/es-microservice/pkg/logger"
 	"github.com/AleksK1NG/es-microservice/pkg/tracing"
 	"github.com/AleksK1NG/es-microservice/pkg/utils"
 	"github.com/AleksK1NG/es-microservice/proto/order"
 	"github.com/go-playground/validator"
-	"github.com/opentracing/opentracing-go/log"
 	uuid "github.com/satori/go.uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type orderGrpcService struct {
@@ -33,19 +39,23 @@ func NewOrderGrpcService(log logger.Logger, os *service.OrderService, v *validat
 
 func (s *orderGrpcService) CreateOrder(ctx context.Context, req *orderService.CreateOrderReq) (*orderService.CreateOrderRes, error) {
 	ctx, span := tracing.StartGrpcServerTracerSpan(ctx, "orderGrpcService.CreateOrder")
-	defer span.Finish()
-	span.LogFields(log.String("req", req.String()))
+	defer span.End()
+	span.SetAttributes(attribute.String("req", req.String()))
 	s.metrics.CreateOrderGrpcRequests.Inc()
 
 	aggregateID := uuid.NewV4().String()
-	command := v1.NewCreateOrderCommand(aggregateID, models.ShopItemsFromProto(req.GetShopItems()), req.GetAccountEmail(), req.GetDeliveryAddress())
+	command := v1.NewCreateOrderCommand(es.GetTenantID(ctx), aggregateID, models.ShopItemsFromProto(req.GetShopItems()), req.GetAccountEmail(), req.GetDeliveryAddress())
 	if err := s.v.StructCtx(ctx, command); err != nil {
 		s.log.Errorf("(validate) aggregateID: {%s}, err: {%v}", aggregateID, err)
 		tracing.TraceErr(span, err)
 		return nil, s.errResponse(err)
 	}
 
-	if err := s.os.Commands.CreateOrder.Handle(ctx, command); err != nil {
+	// retried request with the same idempotency key returns id of the originally created order
+	aggregateID, err := s.os.Idempotency.Execute(ctx, s.getIdempotencyKey(ctx), v1.CreateOrderOperation, req, func(ctx context.Context) (string, error) {
+		return aggregateID, s.os.Commands.CreateOrder.Handle(ctx, command)
+	})
+	if err != nil {
 		s.log.Errorf("(CreateOrder.Handle) orderID: {%s}, err: {%v}", aggregateID, err)
 		return nil, s.errResponse(err)
 	}
@@ -56,19 +66,22 @@ func (s *orderGrpcService) CreateOrder(ctx context.Context, req *orderService.Cr
 
 func (s *orderGrpcService) PayOrder(ctx context.Context, req *orderService.PayOrderReq) (*orderService.PayOrderRes, error) {
 	ctx, span := tracing.StartGrpcServerTracerSpan(ctx, "orderGrpcService.PayOrder")
-	defer span.Finish()
-	span.LogFields(log.String("req", req.String()))
+	defer span.End()
+	span.SetAttributes(attribute.String("req", req.String()))
 	s.metrics.PayOrderGrpcRequests.Inc()
 
 	payment := models.Payment{PaymentID: req.GetPayment().GetID(), Timestamp: time.Now()}
-	command := v1.NewPayOrderCommand(payment, req.GetAggregateID())
+	command := v1.NewPayOrderCommand(es.GetTenantID(ctx), payment, req.GetAggregateID())
 	if err := s.v.StructCtx(ctx, command); err != nil {
 		s.log.Errorf("(validate) err: {%v}", err)
 		tracing.TraceErr(span, err)
 		return nil, s.errResponse(err)
 	}
 
-	if err := s.os.Commands.OrderPaid.Handle(ctx, command); err != nil {
+	_, err := s.os.Idempotency.Execute(ctx, s.getIdempotencyKey(ctx), v1.PayOrderOperation, req, func(ctx context.Context) (string, error) {
+		return command.GetAggregateID(), s.os.Commands.OrderPaid.Handle(ctx, command)
+	})
+	if err != nil {
 		s.log.Errorf("(OrderPaid.Handle) orderID: {%s}, err: {%v}", req.GetAggregateID(), err)
 		return nil, s.errResponse(err)
 	}
@@ -79,11 +92,11 @@ func (s *orderGrpcService) PayOrder(ctx context.Context, req *orderService.PayOr
 
 func (s *orderGrpcService) SubmitOrder(ctx context.Context, req *orderService.SubmitOrderReq) (*orderService.SubmitOrderRes, error) {
 	ctx, span := tracing.StartGrpcServerTracerSpan(ctx, "orderGrpcService.SubmitOrder")
-	defer span.Finish()
-	span.LogFields(log.String("req", req.String()))
+	defer span.End()
+	span.SetAttributes(attribute.String("req", req.String()))
 	s.metrics.SubmitOrderGrpcRequests.Inc()
 
-	command := v1.NewSubmitOrderCommand(req.GetAggregateID())
+	command := v1.NewSubmitOrderCommand(es.GetTenantID(ctx), req.GetAggregateID())
 	if err := s.v.StructCtx(ctx, command); err != nil {
 		s.log.Errorf("(validate) err: {%v}", err)
 		tracing.TraceErr(span, err)
@@ -101,11 +114,11 @@ func (s *orderGrpcService) SubmitOrder(ctx context.Context, req *orderService.Su
 
 func (s *orderGrpcService) GetOrderByID(ctx context.Context, req *orderService.GetOrderByIDReq) (*orderService.GetOrderByIDRes, error) {
 	ctx, span := tracing.StartGrpcServerTracerSpan(ctx, "orderGrpcService.GetOrderByID")
-	defer span.Finish()
-	span.LogFields(log.String("req", req.String()))
+	defer span.End()
+	span.SetAttributes(attribute.String("req", req.String()))
 	s.metrics.GetOrderByIdGrpcRequests.Inc()
 
-	query := queries.NewGetOrderByIDQuery(req.GetAggregateID())
+	query := queries.NewGetOrderByIDQuery(es.GetTenantID(ctx), req.GetAggregateID())
 	if err := s.v.StructCtx(ctx, query); err != nil {
 		s.log.Errorf("(validate) err: {%v}", err)
 		tracing.TraceErr(span, err)
@@ -125,11 +138,11 @@ func (s *orderGrpcService) GetOrderByID(ctx context.Context, req *orderService.G
 
 func (s *orderGrpcService) UpdateShoppingCart(ctx context.Context, req *orderService.UpdateShoppingCartReq) (*orderService.UpdateShoppingCartRes, error) {
 	ctx, span := tracing.StartGrpcServerTracerSpan(ctx, "orderGrpcService.UpdateShoppingCart")
-	defer span.Finish()
-	span.LogFields(log.String("UpdateShoppingCart req", req.String()))
+	defer span.End()
+	span.SetAttributes(attribute.String("UpdateShoppingCart req", req.String()))
 	s.metrics.UpdateOrderGrpcRequests.Inc()
 
-	command := v1.NewUpdateShoppingCartCommand(req.GetAggregateID(), models.ShopItemsFromProto(req.GetShopItems()))
+	command := v1.NewUpdateShoppingCartCommand(es.GetTenantID(ctx), req.GetAggregateID(), models.ShopItemsFromProto(req.GetShopItems()))
 	if err := s.v.StructCtx(ctx, command); err != nil {
 		s.log.Errorf("(validate) err: {%v}", err)
 		tracing.TraceErr(span, err)
@@ -147,11 +160,11 @@ func (s *orderGrpcService) UpdateShoppingCart(ctx context.Context, req *orderSer
 
 func (s *orderGrpcService) CancelOrder(ctx context.Context, req *orderService.CancelOrderReq) (*orderService.CancelOrderRes, error) {
 	ctx, span := tracing.StartGrpcServerTracerSpan(ctx, "orderGrpcService.CancelOrder")
-	defer span.Finish()
-	span.LogFields(log.String("CancelOrder req", req.String()))
+	defer span.End()
+	span.SetAttributes(attribute.String("CancelOrder req", req.String()))
 	s.metrics.CancelOrderGrpcRequests.Inc()
 
-	command := v1.NewCancelOrderCommand(req.GetAggregateID(), req.GetCancelReason())
+	command := v1.NewCancelOrderCommand(es.GetTenantID(ctx), req.GetAggregateID(), req.GetCancelReason())
 	if err := s.v.StructCtx(ctx, command); err != nil {
 		s.log.Errorf("(validate) err: {%v}", err)
 		tracing.TraceErr(span, err)
@@ -169,11 +182,11 @@ func (s *orderGrpcService) CancelOrder(ctx context.Context, req *orderService.Ca
 
 func (s *orderGrpcService) CompleteOrder(ctx context.Context, req *orderService.CompleteOrderReq) (*orderService.CompleteOrderRes, error) {
 	ctx, span := tracing.StartGrpcServerTracerSpan(ctx, "orderGrpcService.CompleteOrder")
-	defer span.Finish()
-	span.LogFields(log.String("CompleteOrder req", req.String()))
+	defer span.End()
+	span.SetAttributes(attribute.String("CompleteOrder req", req.String()))
 	s.metrics.CompleteOrderGrpcRequests.Inc()
 
-	command := v1.NewCompleteOrderCommand(req.GetAggregateID(), time.Now())
+	command := v1.NewCompleteOrderCommand(es.GetTenantID(ctx), req.GetAggregateID(), time.Now())
 	if err := s.v.StructCtx(ctx, command); err != nil {
 		s.log.Errorf("(validate) err: {%v}", err)
 		tracing.TraceErr(span, err)
@@ -191,11 +204,11 @@ func (s *orderGrpcService) CompleteOrder(ctx context.Context, req *orderService.
 
 func (s *orderGrpcService) ChangeDeliveryAddress(ctx context.Context, req *orderService.ChangeDeliveryAddressReq) (*orderService.ChangeDeliveryAddressRes, error) {
 	ctx, span := tracing.StartGrpcServerTracerSpan(ctx, "orderGrpcService.ChangeDeliveryAddress")
-	defer span.Finish()
-	span.LogFields(log.String("ChangeDeliveryAddress req", req.String()))
+	defer span.End()
+	span.SetAttributes(attribute.String("ChangeDeliveryAddress req", req.String()))
 	s.metrics.ChangeAddressOrderGrpcRequests.Inc()
 
-	command := v1.NewChangeDeliveryAddressCommand(req.GetAggregateID(), req.GetDeliveryAddress())
+	command := v1.NewChangeDeliveryAddressCommand(es.GetTenantID(ctx), req.GetAggregateID(), req.GetDeliveryAddress())
 	if err := s.v.StructCtx(ctx, command); err != nil {
 		s.log.Errorf("(validate) err: {%v}", err)
 		tracing.TraceErr(span, err)
@@ -211,18 +224,129 @@ func (s *orderGrpcService) ChangeDeliveryAddress(ctx context.Context, req *order
 	return &orderService.ChangeDeliveryAddressRes{}, nil
 }
 
+func (s *orderGrpcService) AddShopItem(ctx context.Context, req *orderService.AddShopItemReq) (*orderService.AddShopItemRes, error) {
+	ctx, span := tracing.StartGrpcServerTracerSpan(ctx, "orderGrpcService.AddShopItem")
+	defer span.End()
+	span.SetAttributes(attribute.String("AddShopItem req", req.String()))
+	s.metrics.AddShopItemGrpcRequests.Inc()
+
+	var shopItem *models.ShopItem
+	if req.GetShopItem() != nil {
+		shopItem = models.ShopItemFromProto(req.GetShopItem())
+	}
+
+	command := v1.NewAddShopItemCommand(es.GetTenantID(ctx), req.GetAggregateID(), shopItem)
+	if err := s.v.StructCtx(ctx, command); err != nil {
+		s.log.Errorf("(validate) err: {%v}", err)
+		tracing.TraceErr(span, err)
+		return nil, s.errResponse(err)
+	}
+
+	if err := s.os.Commands.AddShopItem.Handle(ctx, command); err != nil {
+		s.log.Errorf("(AddShopItem.Handle) orderID: {%s}, err: {%v}", req.GetAggregateID(), err)
+		return nil, s.errResponse(err)
+	}
+
+	s.log.Infof("(AddShopItem): AggregateID: {%s}", req.GetAggregateID())
+	return &orderService.AddShopItemRes{}, nil
+}
+
+func (s *orderGrpcService) RemoveShopItem(ctx context.Context, req *orderService.RemoveShopItemReq) (*orderService.RemoveShopItemRes, error) {
+	ctx, span := tracing.StartGrpcServerTracerSpan(ctx, "orderGrpcService.RemoveShopItem")
+	defer span.End()
+	span.SetAttributes(attribute.String("RemoveShopItem req", req.String()))
+	s.metrics.RemoveShopItemGrpcRequests.Inc()
+
+	command := v1.NewRemoveShopItemCommand(es.GetTenantID(ctx), req.GetAggregateID(), req.GetShopItemID())
+	if err := s.v.StructCtx(ctx, command); err != nil {
+		s.log.Errorf("(validate) err: {%v}", err)
+		tracing.TraceErr(span, err)
+		return nil, s.errResponse(err)
+	}
+
+	if err := s.os.Commands.RemoveShopItem.Handle(ctx, command); err != nil {
+		s.log.Errorf("(RemoveShopItem.Handle) orderID: {%s}, err: {%v}", req.GetAggregateID(), err)
+		return nil, s.errResponse(err)
+	}
+
+	s.log.Infof("(RemoveShopItem): AggregateID: {%s}", req.GetAggregateID())
+	return &orderService.RemoveShopItemRes{}, nil
+}
+
+func (s *orderGrpcService) ChangeItemQuantity(ctx context.Context, req *orderService.ChangeItemQuantityReq) (*orderService.ChangeItemQuantityRes, error) {
+	ctx, span := tracing.StartGrpcServerTracerSpan(ctx, "orderGrpcService.ChangeItemQuantity")
+	defer span.End()
+	span.SetAttributes(attribute.String("ChangeItemQuantity req", req.String()))
+	s.metrics.ChangeItemQuantityGrpcRequests.Inc()
+
+	command := v1.NewChangeItemQuantityCommand(es.GetTenantID(ctx), req.GetAggregateID(), req.GetShopItemID(), req.GetQuantity())
+	if err := s.v.StructCtx(ctx, command); err != nil {
+		s.log.Errorf("(validate) err: {%v}", err)
+		tracing.TraceErr(span, err)
+		return nil, s.errResponse(err)
+	}
+
+	if err := s.os.Commands.ChangeItemQuantity.Handle(ctx, command); err != nil {
+		s.log.Errorf("(ChangeItemQuantity.Handle) orderID: {%s}, err: {%v}", req.GetAggregateID(), err)
+		return nil, s.errResponse(err)
+	}
+
+	s.log.Infof("(ChangeItemQuantity): AggregateID: {%s}", req.GetAggregateID())
+	return &orderService.ChangeItemQuantityRes{}, nil
+}
+
+func (s *orderGrpcService) RefundOrder(ctx context.Context, req *orderService.RefundOrderReq) (*orderService.RefundOrderRes, error) {
+	ctx, span := tracing.StartGrpcServerTracerSpan(ctx, "orderGrpcService.RefundOrder")
+	defer span.End()
+	span.SetAttributes(attribute.String("req", req.String()))
+	s.metrics.RefundOrderGrpcRequests.Inc()
+
+	refundID := req.GetRefundID()
+	if refundID == "" {
+		refundID = uuid.NewV4().String()
+	}
+
+	command := v1.NewRefundOrderCommand(es.GetTenantID(ctx), req.GetAggregateID(), refundID, mappers.RefundAmountFromProto(req.GetAmount()), req.GetReason())
+	if err := s.v.StructCtx(ctx, command); err != nil {
+		s.log.Errorf("(validate) err: {%v}", err)
+		tracing.TraceErr(span, err)
+		return nil, s.errResponse(err)
+	}
+
+	refundID, err := s.os.Idempotency.Execute(ctx, s.getIdempotencyKey(ctx), v1.RefundOrderOperation, req, func(ctx context.Context) (string, error) {
+		return command.RefundID, s.os.Commands.RefundOrder.Handle(ctx, command)
+	})
+	if err != nil {
+		s.log.Errorf("(RefundOrder.Handle) orderID: {%s}, err: {%v}", req.GetAggregateID(), err)
+		return nil, s.errResponse(err)
+	}
+
+	s.log.Infof("(refunded order): orderID: {%s}, refundID: {%s}", req.GetAggregateID(), refundID)
+	return &orderService.RefundOrderRes{RefundID: refundID}, nil
+}
+
 func (s *orderGrpcService) Search(ctx context.Context, req *orderService.SearchReq) (*orderService.SearchRes, error) {
 	ctx, span := tracing.StartGrpcServerTracerSpan(ctx, "orderGrpcService.Search")
-	defer span.Finish()
-	span.LogFields(log.String("SearchText", req.GetSearchText()), log.Int64("Page", req.GetPage()), log.Int64("Size", req.GetSize()))
+	defer span.End()
+	span.SetAttributes(attribute.String("SearchText", req.GetSearchText()), attribute.Int64("Page", req.GetPage()), attribute.Int64("Size", req.GetSize()), attribute.String("OrderBy", req.GetOrderBy()))
 	s.metrics.SearchOrderGrpcRequests.Inc()
 
-	query := queries.NewSearchOrdersQuery(req.GetSearchText(), utils.NewPaginationQuery(int(req.GetSize()), int(req.GetPage())))
+	pq := utils.NewPaginationQuery(int(req.GetSize()), int(req.GetPage()))
+	pq.SetOrderBy(req.GetOrderBy())
+	pq.SetCursor(req.GetCursor())
+	pq.SetUseCursor(req.GetUseCursor())
+
+	query := queries.NewSearchOrdersQuery(es.GetTenantID(ctx), req.GetSearchText(), getSearchFilterFromReq(req), pq)
 	if err := s.v.StructCtx(ctx, query); err != nil {
 		s.log.Errorf("(validate) err: {%v}", err)
 		tracing.TraceErr(span, err)
 		return nil, s.errResponse(err)
 	}
+	if _, err := models.OrderStatusesFromStrings(query.Filter.Statuses); err != nil {
+		s.log.Errorf("(OrderStatusesFromStrings) err: {%v}", err)
+		tracing.TraceErr(span, err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 
 	searchResult, err := s.os.Queries.SearchOrders.Handle(ctx, query)
 	if err != nil {
@@ -234,6 +358,152 @@ func (s *orderGrpcService) Search(ctx context.Context, req *orderService.SearchR
 	return mappers.SearchResponseToProto(searchResult), nil
 }
 
+func (s *orderGrpcService) ListOrdersByAccount(ctx context.Context, req *orderService.ListOrdersByAccountReq) (*orderService.ListOrdersByAccountRes, error) {
+	ctx, span := tracing.StartGrpcServerTracerSpan(ctx, "orderGrpcService.ListOrdersByAccount")
+	defer span.End()
+	span.SetAttributes(attribute.String("AccountEmail", req.GetAccountEmail()), attribute.Int64("Page", req.GetPage()), attribute.Int64("Size", req.GetSize()), attribute.String("OrderBy", req.GetOrderBy()))
+	s.metrics.ListAccountOrdersGrpcRequests.Inc()
+
+	pq := utils.NewPaginationQuery(int(req.GetSize()), int(req.GetPage()))
+	pq.SetOrderBy(req.GetOrderBy())
+	pq.SetCursor(req.GetCursor())
+
+	query := queries.NewListOrdersByAccountQuery(es.GetTenantID(ctx), req.GetAccountEmail(), req.GetStatuses(), pq)
+	if err := s.v.StructCtx(ctx, query); err != nil {
+		s.log.Errorf("(validate) err: {%v}", err)
+		tracing.TraceErr(span, err)
+		return nil, s.errResponse(err)
+	}
+	if _, err := models.OrderStatusesFromStrings(query.Statuses); err != nil {
+		s.log.Errorf("(OrderStatusesFromStrings) err: {%v}", err)
+		tracing.TraceErr(span, err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	accountOrders, err := s.os.Queries.ListOrdersByAccount.Handle(ctx, query)
+	if err != nil {
+		s.log.Errorf("(ListOrdersByAccount.Handle) accountEmail: {%s}, err: {%v}", req.GetAccountEmail(), err)
+		return nil, s.errResponse(err)
+	}
+
+	s.log.Infof("(ListOrdersByAccount result): accountEmail: {%s}, pagination: {%+v}", req.GetAccountEmail(), accountOrders.Pagination)
+	return mappers.AccountOrdersResponseToProto(accountOrders), nil
+}
+
+func (s *orderGrpcService) GetOrderHistory(ctx context.Context, req *orderService.GetOrderHistoryReq) (*orderService.GetOrderHistoryRes, error) {
+	ctx, span := tracing.StartGrpcServerTracerSpan(ctx, "orderGrpcService.GetOrderHistory")
+	defer span.End()
+	span.SetAttributes(attribute.String("req", req.String()))
+	s.metrics.GetOrderHistoryGrpcRequests.Inc()
+
+	query := queries.NewGetOrderHistoryQuery(es.GetTenantID(ctx), req.GetAggregateID(), req.GetEventTypes(), utils.NewPaginationQuery(int(req.GetSize()), int(req.GetPage())))
+	if err := s.v.StructCtx(ctx, query); err != nil {
+		s.log.Errorf("(validate) err: {%v}", err)
+		tracing.TraceErr(span, err)
+		return nil, s.errResponse(err)
+	}
+
+	history, err := s.os.Queries.GetOrderHistory.Handle(ctx, query)
+	if err != nil {
+		s.log.Errorf("(GetOrderHistory.Handle) orderID: {%s}, err: {%v}", req.GetAggregateID(), err)
+		return nil, s.errResponse(err)
+	}
+
+	s.log.Infof("(order history): orderID: {%s}, pagination: {%+v}", req.GetAggregateID(), history.Pagination)
+	return mappers.OrderHistoryResponseToProto(history), nil
+}
+
+func (s *orderGrpcService) GetOrderAt(ctx context.Context, req *orderService.GetOrderAtReq) (*orderService.GetOrderAtRes, error) {
+	ctx, span := tracing.StartGrpcServerTracerSpan(ctx, "orderGrpcService.GetOrderAt")
+	defer span.End()
+	span.SetAttributes(attribute.String("req", req.String()))
+	s.metrics.GetOrderAtGrpcRequests.Inc()
+
+	var version *int64
+	var timestamp *time.Time
+	switch at := req.GetAt().(type) {
+	case *orderService.GetOrderAtReq_Version:
+		version = &at.Version
+	case *orderService.GetOrderAtReq_Timestamp:
+		asTime := at.Timestamp.AsTime()
+		timestamp = &asTime
+	}
+
+	query := queries.NewGetOrderAtQuery(es.GetTenantID(ctx), req.GetAggregateID(), version, timestamp)
+	if err := s.v.StructCtx(ctx, query); err != nil {
+		s.log.Errorf("(validate) err: {%v}", err)
+		tracing.TraceErr(span, err)
+		return nil, s.errResponse(err)
+	}
+
+	orderAt, err := s.os.Queries.GetOrderAt.Handle(ctx, query)
+	if err != nil {
+		s.log.Errorf("(GetOrderAt.Handle) orderID: {%s}, err: {%v}", req.GetAggregateID(), err)
+		return nil, s.errResponse(err)
+	}
+
+	s.log.Infof("(order at): orderID: {%s}, version: {%d}", req.GetAggregateID(), orderAt.Version)
+	return mappers.OrderAtResponseToProto(orderAt), nil
+}
+
+func (s *orderGrpcService) WatchOrder(req *orderService.WatchOrderReq, stream orderService.OrderService_WatchOrderServer) error {
+	ctx, span := tracing.StartGrpcServerTracerSpan(stream.Context(), "orderGrpcService.WatchOrder")
+	defer span.End()
+	span.SetAttributes(attribute.String("req", req.String()))
+	s.metrics.WatchOrderGrpcRequests.Inc()
+
+	query := queries.NewWatchOrderQuery(es.GetTenantID(ctx), req.GetAggregateID(), req.AfterVersion)
+	if err := s.v.StructCtx(ctx, query); err != nil {
+		s.log.Errorf("(validate) err: {%v}", err)
+		tracing.TraceErr(span, err)
+		return s.errResponse(err)
+	}
+
+	err := s.os.Queries.WatchOrder.Handle(ctx, query, func(ctx context.Context, update *models.OrderUpdate) error {
+		return stream.Send(mappers.OrderUpdateToProto(update))
+	})
+	if err != nil && ctx.Err() == nil {
+		s.log.Errorf("(WatchOrder.Handle) orderID: {%s}, err: {%v}", req.GetAggregateID(), err)
+		return s.errResponse(err)
+	}
+
+	s.log.Infof("(watch order stopped): orderID: {%s}", req.GetAggregateID())
+	return nil
+}
+
 func (s *orderGrpcService) errResponse(err error) error {
 	return grpcErrors.ErrResponse(err)
 }
+
+// getIdempotencyKey returns client supplied idempotency key from the request metadata.
+func (s *orderGrpcService) getIdempotencyKey(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(constants.IdempotencyKeyMetadata); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+func getSearchFilterFromReq(req *orderService.SearchReq) queries.SearchOrdersFilter {
+	return queries.SearchOrdersFilter{
+		Statuses:      req.GetStatuses(),
+		AccountEmail:  req.GetAccountEmail(),
+		Currency:      req.GetCurrency(),
+		MinTotalPrice: req.MinTotalPrice,
+		MaxTotalPrice: req.MaxTotalPrice,
+		CreatedFrom:   getTimeFromProto(req.GetCreatedFrom()),
+		CreatedTo:     getTimeFromProto(req.GetCreatedTo()),
+		DeliveredFrom: getTimeFromProto(req.GetDeliveredFrom()),
+		DeliveredTo:   getTimeFromProto(req.GetDeliveredTo()),
+	}
+}
+
+// getTimeFromProto returns nil if the timestamp is not set.
+func getTimeFromProto(timestamp *timestamppb.Timestamp) *time.Time {
+	if timestamp == nil {
+		return nil
+	}
+	value := timestamp.AsTime()
+	return &value
+}
@@ -0,0 +1,233 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/AleksK1NG/es-microservice/config"
+	"github.com/AleksK1NG/es-microservice/internal/mappers"
+	"github.com/AleksK1NG/es-microservice/internal/order/projection/dead_letters"
+	"github.com/AleksK1NG/es-microservice/internal/order/projection/rebuild"
+	"github.com/AleksK1NG/es-microservice/pkg/constants"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/es/subscription"
+	httpErrors "github.com/AleksK1NG/es-microservice/pkg/http_errors"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/middlewares"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
+	"github.com/AleksK1NG/es-microservice/pkg/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+const (
+	targetParam    = "target"
+	groupNameParam = "groupName"
+)
+
+type adminHandlers struct {
+	group       *echo.Group
+	log         logger.Logger
+	mw          middlewares.MiddlewareManager
+	cfg         *config.Config
+	rebuilder   rebuild.Rebuilder
+	deadLetters dead_letters.DeadLetterService
+	lagMonitor  subscription.LagMonitor
+}
+
+func NewAdminHandlers(
+	group *echo.Group,
+	log logger.Logger,
+	mw middlewares.MiddlewareManager,
+	cfg *config.Config,
+	rebuilder rebuild.Rebuilder,
+	deadLetters dead_letters.DeadLetterService,
+	lagMonitor subscription.LagMonitor,
+) *adminHandlers {
+	return &adminHandlers{group: group, log: log, mw: mw, cfg: cfg, rebuilder: rebuilder, deadLetters: deadLetters, lagMonitor: lagMonitor}
+}
+
+// RebuildProjection
+// @Tags Admin
+// @Summary Rebuild projection
+// @Description Start read model rebuild from the event store in background, target is mongo or elastic
+// @Param target path string true "projection target"
+// @Param Authorization header string false "Bearer token of the staff caller, required if the authentication is enabled"
+// @Produce json
+// @Success 202 {object} rebuild.Status
+// @Router /admin/projections/{target}/rebuild [post]
+func (h *adminHandlers) RebuildProjection() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, span := tracing.StartHttpServerTracerSpan(c, "adminHandlers.RebuildProjection")
+		defer span.End()
+
+		status, err := h.rebuilder.RebuildAsync(c.Param(targetParam))
+		if err != nil {
+			h.log.Errorf("(RebuildAsync) target: {%s}, err: {%v}", c.Param(targetParam), err)
+			tracing.TraceErr(span, err)
+			return h.errorResponse(c, err)
+		}
+
+		h.log.Infof("(rebuild projection started) target: {%s}, shadow: {%s}", status.Target, status.Shadow)
+		return c.JSON(http.StatusAccepted, status)
+	}
+}
+
+// GetRebuildStatus
+// @Tags Admin
+// @Summary Get projection rebuild status
+// @Description Get status of the last read model rebuild, target is mongo or elastic
+// @Param target path string true "projection target"
+// @Param Authorization header string false "Bearer token of the staff caller, required if the authentication is enabled"
+// @Produce json
+// @Success 200 {object} rebuild.Status
+// @Router /admin/projections/{target}/rebuild [get]
+func (h *adminHandlers) GetRebuildStatus() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, span := tracing.StartHttpServerTracerSpan(c, "adminHandlers.GetRebuildStatus")
+		defer span.End()
+
+		status, err := h.rebuilder.GetStatus(c.Param(targetParam))
+		if err != nil {
+			h.log.Errorf("(GetStatus) target: {%s}, err: {%v}", c.Param(targetParam), err)
+			tracing.TraceErr(span, err)
+			return h.errorResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, status)
+	}
+}
+
+// GetProjectionsLag
+// @Tags Admin
+// @Summary Get projections lag
+// @Description Get the last checked lag of the projections behind the head of the order streams in events and seconds
+// @Param Authorization header string false "Bearer token of the staff caller, required if the authentication is enabled"
+// @Produce json
+// @Success 200 {array} subscription.Lag
+// @Router /admin/projections/lag [get]
+func (h *adminHandlers) GetProjectionsLag() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		_, span := tracing.StartHttpServerTracerSpan(c, "adminHandlers.GetProjectionsLag")
+		defer span.End()
+
+		return c.JSON(http.StatusOK, h.lagMonitor.GetLags())
+	}
+}
+
+// ListDeadLetters
+// @Tags Admin
+// @Summary List dead letters
+// @Description List events parked by the projections after all processing retries
+// @Param groupName query string false "projection subscription group name"
+// @Param cursor query string false "nextCursor of the previous page, continues the list from its position instead of the page number"
+// @Param page query string false "page number"
+// @Param size query string false "number of elements"
+// @Param Authorization header string false "Bearer token of the staff caller, required if the authentication is enabled"
+// @Produce json
+// @Success 200 {object} dto.DeadLettersResponseDto
+// @Router /admin/dead-letters [get]
+func (h *adminHandlers) ListDeadLetters() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracing.StartHttpServerTracerSpan(c, "adminHandlers.ListDeadLetters")
+		defer span.End()
+
+		pq := utils.NewPaginationFromQueryParams(c.QueryParam(constants.Size), c.QueryParam(constants.Page))
+		pq.SetCursor(c.QueryParam(constants.CursorQuery))
+
+		page, err := h.deadLetters.List(ctx, c.QueryParam(groupNameParam), pq)
+		if err != nil {
+			h.log.Errorf("(deadLetters.List) err: {%v}", err)
+			tracing.TraceErr(span, err)
+			return h.errorResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, mappers.DeadLettersResponseFromModel(page.DeadLetters, page.TotalCount, page.NextCursor, pq))
+	}
+}
+
+// GetDeadLetter
+// @Tags Admin
+// @Summary Get dead letter
+// @Description Get parked event with the last processing error
+// @Param id path string true "dead letter id"
+// @Param Authorization header string false "Bearer token of the staff caller, required if the authentication is enabled"
+// @Produce json
+// @Success 200 {object} dto.DeadLetterResponseDto
+// @Router /admin/dead-letters/{id} [get]
+func (h *adminHandlers) GetDeadLetter() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracing.StartHttpServerTracerSpan(c, "adminHandlers.GetDeadLetter")
+		defer span.End()
+
+		deadLetter, err := h.deadLetters.Get(ctx, c.Param(constants.ID))
+		if err != nil {
+			h.log.Errorf("(deadLetters.Get) id: {%s}, err: {%v}", c.Param(constants.ID), err)
+			tracing.TraceErr(span, err)
+			return h.errorResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, mappers.DeadLetterResponseFromModel(*deadLetter))
+	}
+}
+
+// ReplayDeadLetter
+// @Tags Admin
+// @Summary Replay dead letter
+// @Description Apply parked event to its projection again and delete it on success, the event is applied out of the original order
+// @Param id path string true "dead letter id"
+// @Param Authorization header string false "Bearer token of the staff caller, required if the authentication is enabled"
+// @Produce json
+// @Success 200 {string} id ""
+// @Router /admin/dead-letters/{id}/replay [post]
+func (h *adminHandlers) ReplayDeadLetter() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracing.StartHttpServerTracerSpan(c, "adminHandlers.ReplayDeadLetter")
+		defer span.End()
+
+		if err := h.deadLetters.Replay(ctx, c.Param(constants.ID)); err != nil {
+			h.log.Errorf("(deadLetters.Replay) id: {%s}, err: {%v}", c.Param(constants.ID), err)
+			tracing.TraceErr(span, err)
+			return h.errorResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, c.Param(constants.ID))
+	}
+}
+
+// DiscardDeadLetter
+// @Tags Admin
+// @Summary Discard dead letter
+// @Description Delete parked event without processing
+// @Param id path string true "dead letter id"
+// @Param Authorization header string false "Bearer token of the staff caller, required if the authentication is enabled"
+// @Produce json
+// @Success 200 {string} id ""
+// @Router /admin/dead-letters/{id} [delete]
+func (h *adminHandlers) DiscardDeadLetter() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracing.StartHttpServerTracerSpan(c, "adminHandlers.DiscardDeadLetter")
+		defer span.End()
+
+		if err := h.deadLetters.Discard(ctx, c.Param(constants.ID)); err != nil {
+			h.log.Errorf("(deadLetters.Discard) id: {%s}, err: {%v}", c.Param(constants.ID), err)
+			tracing.TraceErr(span, err)
+			return h.errorResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, c.Param(constants.ID))
+	}
+}
+
+func (h *adminHandlers) errorResponse(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, rebuild.ErrInvalidTarget), errors.Is(err, dead_letters.ErrUnknownGroupName):
+		return httpErrors.NewBadRequestError(c, err.Error(), h.cfg.Http.DebugErrorsResponse)
+	case errors.Is(err, rebuild.ErrRebuildNotFound), errors.Is(err, es.ErrDeadLetterNotFound):
+		return httpErrors.NewNotFoundError(c, err.Error(), h.cfg.Http.DebugErrorsResponse)
+	case errors.Is(err, rebuild.ErrRebuildInProgress):
+		restErr := httpErrors.NewRestError(http.StatusConflict, httpErrors.ErrConflict, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return c.JSON(restErr.Status(), restErr)
+	default:
+		return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+	}
+}
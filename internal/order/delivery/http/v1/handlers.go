@@ -1,7 +1,10 @@
 package v1
 
 import (
+	"context"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/AleksK1NG/es-microservice/config"
@@ -13,6 +16,7 @@ import (
 	"github.com/AleksK1NG/es-microservice/internal/order/queries"
 	"github.com/AleksK1NG/es-microservice/internal/order/service"
 	"github.com/AleksK1NG/es-microservice/pkg/constants"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
 	httpErrors "github.com/AleksK1NG/es-microservice/pkg/http_errors"
 	"github.com/AleksK1NG/es-microservice/pkg/logger"
 	"github.com/AleksK1NG/es-microservice/pkg/middlewares"
@@ -20,6 +24,7 @@ import (
 	"github.com/AleksK1NG/es-microservice/pkg/utils"
 	"github.com/go-playground/validator"
 	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
 )
 
@@ -50,6 +55,9 @@ func NewOrderHandlers(
 // @Summary Create order
 // @Description Create new order
 // @Param order body dto.CreateOrderReqDto true "create order"
+// @Param Idempotency-Key header string false "client supplied key, retried request with the same key returns the original result"
+// @Param X-Tenant-ID header string false "tenant id, requests without it use the default tenant"
+// @Param Authorization header string false "Bearer token, required if the authentication is enabled"
 // @Accept json
 // @Produce json
 // @Success 201 {string} id ""
@@ -57,7 +65,7 @@ func NewOrderHandlers(
 func (h *orderHandlers) CreateOrder() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		ctx, span := tracing.StartHttpServerTracerSpan(c, "orderHandlers.CreateOrder")
-		defer span.Finish()
+		defer span.End()
 		h.metrics.CreateOrderHttpRequests.Inc()
 
 		var reqDto dto.CreateOrderReqDto
@@ -73,9 +81,13 @@ func (h *orderHandlers) CreateOrder() echo.HandlerFunc {
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		id := uuid.NewV4().String()
-		command := v1.NewCreateOrderCommand(id, reqDto.ShopItems, reqDto.AccountEmail, reqDto.DeliveryAddress)
-		err := h.os.Commands.CreateOrder.Handle(ctx, command)
+		// retried request with the same idempotency key returns id of the originally created order
+		idempotencyKey := c.Request().Header.Get(constants.IdempotencyKeyHeader)
+		id, err := h.os.Idempotency.Execute(ctx, idempotencyKey, v1.CreateOrderOperation, reqDto, func(ctx context.Context) (string, error) {
+			id := uuid.NewV4().String()
+			command := v1.NewCreateOrderCommand(es.GetTenantID(ctx), id, reqDto.ShopItems, reqDto.AccountEmail, reqDto.DeliveryAddress)
+			return id, h.os.Commands.CreateOrder.Handle(ctx, command)
+		})
 		if err != nil {
 			h.log.Errorf("(CreateOrder.Handle) id: {%s}, err: {%v}", id, err)
 			tracing.TraceErr(span, err)
@@ -94,13 +106,16 @@ func (h *orderHandlers) CreateOrder() echo.HandlerFunc {
 // @Accept json
 // @Produce json
 // @Param order body dto.Payment true "create order"
+// @Param Idempotency-Key header string false "client supplied key, retried request with the same key returns the original result"
 // @Param id path string true "Order ID"
+// @Param X-Tenant-ID header string false "tenant id, requests without it use the default tenant"
+// @Param Authorization header string false "Bearer token, required if the authentication is enabled"
 // @Success 200 {string} id ""
 // @Router /orders/pay/{id} [put]
 func (h *orderHandlers) PayOrder() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		ctx, span := tracing.StartHttpServerTracerSpan(c, "orderHandlers.PayOrder")
-		defer span.Finish()
+		defer span.End()
 		h.metrics.PayOrderHttpRequests.Inc()
 
 		orderID, err := uuid.FromString(c.Param(constants.ID))
@@ -117,14 +132,17 @@ func (h *orderHandlers) PayOrder() echo.HandlerFunc {
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		command := v1.NewPayOrderCommand(models.Payment{PaymentID: payment.PaymentID, Timestamp: payment.Timestamp}, orderID.String())
+		command := v1.NewPayOrderCommand(es.GetTenantID(ctx), models.Payment{PaymentID: payment.PaymentID, Timestamp: payment.Timestamp}, orderID.String())
 		if err := h.v.StructCtx(ctx, command); err != nil {
 			h.log.Errorf("(validate) err: {%v}", err)
 			tracing.TraceErr(span, err)
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		err = h.os.Commands.OrderPaid.Handle(ctx, command)
+		idempotencyKey := c.Request().Header.Get(constants.IdempotencyKeyHeader)
+		_, err = h.os.Idempotency.Execute(ctx, idempotencyKey, v1.PayOrderOperation, command, func(ctx context.Context) (string, error) {
+			return command.GetAggregateID(), h.os.Commands.OrderPaid.Handle(ctx, command)
+		})
 		if err != nil {
 			h.log.Errorf("(OrderPaid.Handle) id: {%s}, err: {%v}", orderID.String(), err)
 			tracing.TraceErr(span, err)
@@ -143,12 +161,14 @@ func (h *orderHandlers) PayOrder() echo.HandlerFunc {
 // @Accept json
 // @Produce json
 // @Param id path string true "Order ID"
+// @Param X-Tenant-ID header string false "tenant id, requests without it use the default tenant"
+// @Param Authorization header string false "Bearer token, required if the authentication is enabled"
 // @Success 200 {string} id ""
 // @Router /orders/submit/{id} [put]
 func (h *orderHandlers) SubmitOrder() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		ctx, span := tracing.StartHttpServerTracerSpan(c, "orderHandlers.SubmitOrder")
-		defer span.Finish()
+		defer span.End()
 		h.metrics.SubmitOrderHttpRequests.Inc()
 
 		orderID, err := uuid.FromString(c.Param(constants.ID))
@@ -158,7 +178,7 @@ func (h *orderHandlers) SubmitOrder() echo.HandlerFunc {
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		command := v1.NewSubmitOrderCommand(orderID.String())
+		command := v1.NewSubmitOrderCommand(es.GetTenantID(ctx), orderID.String())
 		if err := h.v.StructCtx(ctx, command); err != nil {
 			h.log.Errorf("(validate) err: {%v}", err)
 			tracing.TraceErr(span, err)
@@ -185,12 +205,14 @@ func (h *orderHandlers) SubmitOrder() echo.HandlerFunc {
 // @Produce json
 // @Param order body dto.CancelOrderReqDto true "cancel order reason"
 // @Param id path string true "Order ID"
+// @Param X-Tenant-ID header string false "tenant id, requests without it use the default tenant"
+// @Param Authorization header string false "Bearer token, required if the authentication is enabled"
 // @Success 200 {string} id ""
 // @Router /orders/cancel/{id} [post]
 func (h *orderHandlers) CancelOrder() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		ctx, span := tracing.StartHttpServerTracerSpan(c, "orderHandlers.CancelOrder")
-		defer span.Finish()
+		defer span.End()
 		h.metrics.SubmitOrderHttpRequests.Inc()
 
 		orderID, err := uuid.FromString(c.Param(constants.ID))
@@ -207,7 +229,7 @@ func (h *orderHandlers) CancelOrder() echo.HandlerFunc {
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		command := v1.NewCancelOrderCommand(orderID.String(), data.CancelReason)
+		command := v1.NewCancelOrderCommand(es.GetTenantID(ctx), orderID.String(), data.CancelReason)
 		if err := h.v.StructCtx(ctx, command); err != nil {
 			h.log.Errorf("(validate) err: {%v}", err)
 			tracing.TraceErr(span, err)
@@ -233,12 +255,14 @@ func (h *orderHandlers) CancelOrder() echo.HandlerFunc {
 // @Accept json
 // @Produce json
 // @Param id path string true "Order ID"
+// @Param X-Tenant-ID header string false "tenant id, requests without it use the default tenant"
+// @Param Authorization header string false "Bearer token, required if the authentication is enabled"
 // @Success 200 {string} id ""
 // @Router /orders/complete/{id} [post]
 func (h *orderHandlers) CompleteOrder() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		ctx, span := tracing.StartHttpServerTracerSpan(c, "orderHandlers.CompleteOrder")
-		defer span.Finish()
+		defer span.End()
 		h.metrics.CompleteOrderHttpRequests.Inc()
 
 		orderID, err := uuid.FromString(c.Param(constants.ID))
@@ -248,7 +272,7 @@ func (h *orderHandlers) CompleteOrder() echo.HandlerFunc {
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		command := v1.NewCompleteOrderCommand(orderID.String(), time.Now())
+		command := v1.NewCompleteOrderCommand(es.GetTenantID(ctx), orderID.String(), time.Now())
 		if err := h.v.StructCtx(ctx, command); err != nil {
 			h.log.Errorf("(validate) err: {%v}", err)
 			tracing.TraceErr(span, err)
@@ -267,6 +291,66 @@ func (h *orderHandlers) CompleteOrder() echo.HandlerFunc {
 	}
 }
 
+// RefundOrder
+// @Tags Orders
+// @Summary Refund order
+// @Description Full or partial refund of the paid order by staff, without amount refunds all not refunded paid amount
+// @Accept json
+// @Produce json
+// @Param order body dto.RefundOrderReqDto true "refund order"
+// @Param Idempotency-Key header string false "client supplied key, retried request with the same key returns the original result"
+// @Param id path string true "Order ID"
+// @Param X-Tenant-ID header string false "tenant id, requests without it use the default tenant"
+// @Param Authorization header string false "Bearer token, required if the authentication is enabled"
+// @Success 200 {object} dto.RefundOrderResponseDto
+// @Router /orders/refund/{id} [post]
+func (h *orderHandlers) RefundOrder() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracing.StartHttpServerTracerSpan(c, "orderHandlers.RefundOrder")
+		defer span.End()
+		h.metrics.RefundOrderHttpRequests.Inc()
+
+		orderID, err := uuid.FromString(c.Param(constants.ID))
+		if err != nil {
+			h.log.Errorf("(uuid.FromString) err: {%v}", err)
+			tracing.TraceErr(span, err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		var reqDto dto.RefundOrderReqDto
+		if err := c.Bind(&reqDto); err != nil {
+			h.log.Errorf("(Bind) err: {%v}", err)
+			tracing.TraceErr(span, err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		refundID := reqDto.RefundID
+		if refundID == "" {
+			refundID = uuid.NewV4().String()
+		}
+
+		command := v1.NewRefundOrderCommand(es.GetTenantID(ctx), orderID.String(), refundID, mappers.RefundAmountFromDto(reqDto.Amount), reqDto.Reason)
+		if err := h.v.StructCtx(ctx, command); err != nil {
+			h.log.Errorf("(validate) err: {%v}", err)
+			tracing.TraceErr(span, err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		idempotencyKey := c.Request().Header.Get(constants.IdempotencyKeyHeader)
+		refundID, err = h.os.Idempotency.Execute(ctx, idempotencyKey, v1.RefundOrderOperation, reqDto, func(ctx context.Context) (string, error) {
+			return command.RefundID, h.os.Commands.RefundOrder.Handle(ctx, command)
+		})
+		if err != nil {
+			h.log.Errorf("(RefundOrder.Handle) id: {%s}, err: {%v}", orderID.String(), err)
+			tracing.TraceErr(span, err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		h.log.Infof("(order refunded) id: {%s}, refundID: {%s}", orderID.String(), refundID)
+		return c.JSON(http.StatusOK, dto.RefundOrderResponseDto{RefundID: refundID})
+	}
+}
+
 // ChangeDeliveryAddress
 // @Tags Orders
 // @Summary Change delivery address order
@@ -275,12 +359,14 @@ func (h *orderHandlers) CompleteOrder() echo.HandlerFunc {
 // @Produce json
 // @Param order body dto.ChangeDeliveryAddressReqDto true "change delivery address"
 // @Param id path string true "Order ID"
+// @Param X-Tenant-ID header string false "tenant id, requests without it use the default tenant"
+// @Param Authorization header string false "Bearer token, required if the authentication is enabled"
 // @Success 200 {string} id ""
 // @Router /orders/address/{id} [put]
 func (h *orderHandlers) ChangeDeliveryAddress() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		ctx, span := tracing.StartHttpServerTracerSpan(c, "orderHandlers.ChangeDeliveryAddress")
-		defer span.Finish()
+		defer span.End()
 		h.metrics.ChangeAddressOrderHttpRequests.Inc()
 
 		param := c.Param(constants.ID)
@@ -298,7 +384,7 @@ func (h *orderHandlers) ChangeDeliveryAddress() echo.HandlerFunc {
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		command := v1.NewChangeDeliveryAddressCommand(orderID.String(), data.DeliveryAddress)
+		command := v1.NewChangeDeliveryAddressCommand(es.GetTenantID(ctx), orderID.String(), data.DeliveryAddress)
 		if err := h.v.StructCtx(ctx, command); err != nil {
 			h.log.Errorf("(validate) err: {%v}", err)
 			tracing.TraceErr(span, err)
@@ -325,12 +411,14 @@ func (h *orderHandlers) ChangeDeliveryAddress() echo.HandlerFunc {
 // @Produce json
 // @Param id path string true "Order ID"
 // @Param order body dto.UpdateShoppingItemsReqDto true "update order"
+// @Param X-Tenant-ID header string false "tenant id, requests without it use the default tenant"
+// @Param Authorization header string false "Bearer token, required if the authentication is enabled"
 // @Success 200 {string} id ""
 // @Router /orders/cart/{id} [put]
 func (h *orderHandlers) UpdateShoppingCart() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		ctx, span := tracing.StartHttpServerTracerSpan(c, "orderHandlers.UpdateShoppingCart")
-		defer span.Finish()
+		defer span.End()
 		h.metrics.UpdateOrderHttpRequests.Inc()
 
 		orderID, err := uuid.FromString(c.Param(constants.ID))
@@ -353,7 +441,7 @@ func (h *orderHandlers) UpdateShoppingCart() echo.HandlerFunc {
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		command := v1.NewUpdateShoppingCartCommand(orderID.String(), reqDto.ShopItems)
+		command := v1.NewUpdateShoppingCartCommand(es.GetTenantID(ctx), orderID.String(), reqDto.ShopItems)
 		err = h.os.Commands.UpdateOrder.Handle(ctx, command)
 		if err != nil {
 			h.log.Errorf("(UpdateShoppingCart.Handle) id: {%s}, err: {%v}", orderID.String(), err)
@@ -366,6 +454,150 @@ func (h *orderHandlers) UpdateShoppingCart() echo.HandlerFunc {
 	}
 }
 
+// AddShopItem
+// @Tags Orders
+// @Summary Add shop item
+// @Description Add shop item to the order shopping cart
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param order body dto.AddShopItemReqDto true "add shop item"
+// @Param X-Tenant-ID header string false "tenant id, requests without it use the default tenant"
+// @Param Authorization header string false "Bearer token, required if the authentication is enabled"
+// @Success 200 {string} id ""
+// @Router /orders/cart/{id}/items [post]
+func (h *orderHandlers) AddShopItem() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracing.StartHttpServerTracerSpan(c, "orderHandlers.AddShopItem")
+		defer span.End()
+		h.metrics.AddShopItemHttpRequests.Inc()
+
+		orderID, err := uuid.FromString(c.Param(constants.ID))
+		if err != nil {
+			h.log.Errorf("(uuid.FromString) err: {%v}", err)
+			tracing.TraceErr(span, err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		var reqDto dto.AddShopItemReqDto
+		if err := c.Bind(&reqDto); err != nil {
+			h.log.Errorf("(Bind) err: {%v}", err)
+			tracing.TraceErr(span, err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		command := v1.NewAddShopItemCommand(es.GetTenantID(ctx), orderID.String(), reqDto.ShopItem)
+		if err := h.v.StructCtx(ctx, command); err != nil {
+			h.log.Errorf("(validate) err: {%v}", err)
+			tracing.TraceErr(span, err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		if err := h.os.Commands.AddShopItem.Handle(ctx, command); err != nil {
+			h.log.Errorf("(AddShopItem.Handle) id: {%s}, err: {%v}", orderID.String(), err)
+			tracing.TraceErr(span, err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		h.log.Infof("(shop item added) id: {%s}, itemID: {%s}", orderID.String(), command.ShopItem.ID)
+		return c.JSON(http.StatusOK, orderID.String())
+	}
+}
+
+// RemoveShopItem
+// @Tags Orders
+// @Summary Remove shop item
+// @Description Remove shop item from the order shopping cart
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param itemId path string true "Shop item ID"
+// @Param X-Tenant-ID header string false "tenant id, requests without it use the default tenant"
+// @Param Authorization header string false "Bearer token, required if the authentication is enabled"
+// @Success 200 {string} id ""
+// @Router /orders/cart/{id}/items/{itemId} [delete]
+func (h *orderHandlers) RemoveShopItem() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracing.StartHttpServerTracerSpan(c, "orderHandlers.RemoveShopItem")
+		defer span.End()
+		h.metrics.RemoveShopItemHttpRequests.Inc()
+
+		orderID, err := uuid.FromString(c.Param(constants.ID))
+		if err != nil {
+			h.log.Errorf("(uuid.FromString) err: {%v}", err)
+			tracing.TraceErr(span, err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		command := v1.NewRemoveShopItemCommand(es.GetTenantID(ctx), orderID.String(), c.Param(constants.ItemID))
+		if err := h.v.StructCtx(ctx, command); err != nil {
+			h.log.Errorf("(validate) err: {%v}", err)
+			tracing.TraceErr(span, err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		if err := h.os.Commands.RemoveShopItem.Handle(ctx, command); err != nil {
+			h.log.Errorf("(RemoveShopItem.Handle) id: {%s}, err: {%v}", orderID.String(), err)
+			tracing.TraceErr(span, err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		h.log.Infof("(shop item removed) id: {%s}, itemID: {%s}", orderID.String(), command.ShopItemID)
+		return c.JSON(http.StatusOK, orderID.String())
+	}
+}
+
+// ChangeItemQuantity
+// @Tags Orders
+// @Summary Change shop item quantity
+// @Description Change quantity of the shop item in the order shopping cart
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param itemId path string true "Shop item ID"
+// @Param order body dto.ChangeItemQuantityReqDto true "change item quantity"
+// @Param X-Tenant-ID header string false "tenant id, requests without it use the default tenant"
+// @Param Authorization header string false "Bearer token, required if the authentication is enabled"
+// @Success 200 {string} id ""
+// @Router /orders/cart/{id}/items/{itemId} [put]
+func (h *orderHandlers) ChangeItemQuantity() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracing.StartHttpServerTracerSpan(c, "orderHandlers.ChangeItemQuantity")
+		defer span.End()
+		h.metrics.ChangeItemQuantityHttpRequests.Inc()
+
+		orderID, err := uuid.FromString(c.Param(constants.ID))
+		if err != nil {
+			h.log.Errorf("(uuid.FromString) err: {%v}", err)
+			tracing.TraceErr(span, err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		var reqDto dto.ChangeItemQuantityReqDto
+		if err := c.Bind(&reqDto); err != nil {
+			h.log.Errorf("(Bind) err: {%v}", err)
+			tracing.TraceErr(span, err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		command := v1.NewChangeItemQuantityCommand(es.GetTenantID(ctx), orderID.String(), c.Param(constants.ItemID), reqDto.Quantity)
+		if err := h.v.StructCtx(ctx, command); err != nil {
+			h.log.Errorf("(validate) err: {%v}", err)
+			tracing.TraceErr(span, err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		if err := h.os.Commands.ChangeItemQuantity.Handle(ctx, command); err != nil {
+			h.log.Errorf("(ChangeItemQuantity.Handle) id: {%s}, err: {%v}", orderID.String(), err)
+			tracing.TraceErr(span, err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		h.log.Infof("(shop item quantity changed) id: {%s}, itemID: {%s}, quantity: {%d}", orderID.String(), command.ShopItemID, command.Quantity)
+		return c.JSON(http.StatusOK, orderID.String())
+	}
+}
+
 // GetOrderByID
 // @Tags Orders
 // @Summary Get order
@@ -373,12 +605,14 @@ func (h *orderHandlers) UpdateShoppingCart() echo.HandlerFunc {
 // @Accept json
 // @Produce json
 // @Param id path string true "Order ID"
+// @Param X-Tenant-ID header string false "tenant id, requests without it use the default tenant"
+// @Param Authorization header string false "Bearer token, required if the authentication is enabled"
 // @Success 200 {object} dto.OrderResponseDto
 // @Router /orders/{id} [get]
 func (h *orderHandlers) GetOrderByID() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		ctx, span := tracing.StartHttpServerTracerSpan(c, "orderHandlers.GetOrderByID")
-		defer span.Finish()
+		defer span.End()
 		h.metrics.GetOrderByIdHttpRequests.Inc()
 
 		param := c.Param(constants.ID)
@@ -389,7 +623,7 @@ func (h *orderHandlers) GetOrderByID() echo.HandlerFunc {
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		query := queries.NewGetOrderByIDQuery(orderID.String())
+		query := queries.NewGetOrderByIDQuery(es.GetTenantID(ctx), orderID.String())
 		if err := h.v.StructCtx(ctx, query); err != nil {
 			h.log.Errorf("(validate) err: {%v}", err)
 			tracing.TraceErr(span, err)
@@ -411,23 +645,54 @@ func (h *orderHandlers) GetOrderByID() echo.HandlerFunc {
 // Search
 // @Tags Orders
 // @Summary Search orders
-// @Description Full text search by title and description
+// @Description Full text search by title and description, filtered by the order statuses, account email, total price,
+// @Description creation and delivery time ranges, with the orders counts per status and the total price histogram
 // @Accept json
 // @Produce json
 // @Param search query string false "search text"
+// @Param status query string false "order statuses filter, repeated or comma separated"
+// @Param accountEmail query string false "account email"
+// @Param currency query string false "total price currency"
+// @Param minTotalPrice query integer false "min total price in the currency minor units, inclusive"
+// @Param maxTotalPrice query integer false "max total price in the currency minor units, inclusive"
+// @Param createdFrom query string false "RFC3339 min creation time, inclusive"
+// @Param createdTo query string false "RFC3339 max creation time, inclusive"
+// @Param deliveredFrom query string false "RFC3339 min delivery time, inclusive"
+// @Param deliveredTo query string false "RFC3339 max delivery time, inclusive"
+// @Param orderBy query string false "createdAt, deliveredTime, totalPrice, status or accountEmail with optional :asc or :desc suffix, by relevance if empty"
+// @Param cursor query string false "nextCursor of the previous page, continues the search from its position instead of the page number"
+// @Param useCursor query boolean false "keeps the first page results consistent on the next pages of nextCursor until the last page"
 // @Param page query string false "page number"
 // @Param size query string false "number of elements"
+// @Param X-Tenant-ID header string false "tenant id, requests without it use the default tenant"
+// @Param Authorization header string false "Bearer token, required if the authentication is enabled"
 // @Success 200 {object} dto.OrderSearchResponseDto
 // @Router /orders/search [get]
 func (h *orderHandlers) Search() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		ctx, span := tracing.StartHttpServerTracerSpan(c, "orderHandlers.Search")
-		defer span.Finish()
+		defer span.End()
 		h.metrics.SearchOrderHttpRequests.Inc()
 
 		pq := utils.NewPaginationFromQueryParams(c.QueryParam(constants.Size), c.QueryParam(constants.Page))
+		pq.SetOrderBy(c.QueryParam(constants.OrderByQuery))
+		pq.SetCursor(c.QueryParam(constants.CursorQuery))
+		useCursor, err := getBoolFromQueryParam(c, constants.UseCursorQuery)
+		if err != nil {
+			h.log.Errorf("(getBoolFromQueryParam) err: {%v}", err)
+			tracing.TraceErr(span, err)
+			return httpErrors.NewBadRequestError(c, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		}
+		pq.SetUseCursor(useCursor)
 
-		query := queries.NewSearchOrdersQuery(c.QueryParam(constants.Search), pq)
+		filter, err := getSearchFilterFromQueryParams(c)
+		if err != nil {
+			h.log.Errorf("(getSearchFilterFromQueryParams) err: {%v}", err)
+			tracing.TraceErr(span, err)
+			return httpErrors.NewBadRequestError(c, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		}
+
+		query := queries.NewSearchOrdersQuery(es.GetTenantID(ctx), c.QueryParam(constants.Search), filter, pq)
 		if err := h.v.StructCtx(ctx, query); err != nil {
 			h.log.Errorf("(validate) err: {%v}", err)
 			tracing.TraceErr(span, err)
@@ -445,3 +710,369 @@ func (h *orderHandlers) Search() echo.HandlerFunc {
 		return c.JSON(http.StatusOK, searchRes)
 	}
 }
+
+// ListOrdersByAccount
+// @Tags Orders
+// @Summary List account orders
+// @Description Orders of the account email filtered by the order statuses, customers can list only their own orders
+// @Accept json
+// @Produce json
+// @Param accountEmail query string true "account email"
+// @Param status query string false "order statuses filter, repeated or comma separated"
+// @Param orderBy query string false "createdAt, deliveredTime, totalPrice or status with optional :asc or :desc suffix, createdAt:desc if empty"
+// @Param page query string false "page number"
+// @Param size query string false "number of elements"
+// @Param cursor query string false "nextCursor of the previous page, continues the list from its position instead of the page number"
+// @Param X-Tenant-ID header string false "tenant id, requests without it use the default tenant"
+// @Param Authorization header string false "Bearer token, required if the authentication is enabled"
+// @Success 200 {object} dto.AccountOrdersResponseDto
+// @Router /orders [get]
+func (h *orderHandlers) ListOrdersByAccount() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracing.StartHttpServerTracerSpan(c, "orderHandlers.ListOrdersByAccount")
+		defer span.End()
+		h.metrics.ListAccountOrdersHttpRequests.Inc()
+
+		pq := utils.NewPaginationFromQueryParams(c.QueryParam(constants.Size), c.QueryParam(constants.Page))
+		pq.SetOrderBy(c.QueryParam(constants.OrderByQuery))
+		pq.SetCursor(c.QueryParam(constants.CursorQuery))
+
+		accountEmail := c.QueryParam(constants.AccountEmailQuery)
+		query := queries.NewListOrdersByAccountQuery(es.GetTenantID(ctx), accountEmail, getListFromQueryParams(c, constants.StatusQuery), pq)
+		if err := h.v.StructCtx(ctx, query); err != nil {
+			h.log.Errorf("(validate) err: {%v}", err)
+			tracing.TraceErr(span, err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+		if _, err := models.OrderStatusesFromStrings(query.Statuses); err != nil {
+			h.log.Errorf("(OrderStatusesFromStrings) err: {%v}", err)
+			tracing.TraceErr(span, err)
+			return httpErrors.NewBadRequestError(c, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		}
+
+		accountOrders, err := h.os.Queries.ListOrdersByAccount.Handle(ctx, query)
+		if err != nil {
+			h.log.Errorf("(ListOrdersByAccount.Handle) accountEmail: {%s}, err: {%v}", accountEmail, err)
+			tracing.TraceErr(span, err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		h.log.Infof("(list orders by account) accountEmail: {%s}, pagination: {%+v}", accountEmail, accountOrders.Pagination)
+		return c.JSON(http.StatusOK, accountOrders)
+	}
+}
+
+// GetOrderHistory
+// @Tags Orders
+// @Summary Get order history
+// @Description Get ordered order stream events with payloads upcasted to the current schema and metadata
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param eventType query string false "current schema event types filter, repeated or comma separated"
+// @Param page query string false "page number"
+// @Param size query string false "number of elements"
+// @Param X-Tenant-ID header string false "tenant id, requests without it use the default tenant"
+// @Param Authorization header string false "Bearer token, required if the authentication is enabled"
+// @Success 200 {object} dto.OrderHistoryResponseDto
+// @Router /orders/{id}/events [get]
+func (h *orderHandlers) GetOrderHistory() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracing.StartHttpServerTracerSpan(c, "orderHandlers.GetOrderHistory")
+		defer span.End()
+		h.metrics.GetOrderHistoryHttpRequests.Inc()
+
+		orderID, err := uuid.FromString(c.Param(constants.ID))
+		if err != nil {
+			h.log.Errorf("(uuid.FromString) err: {%v}", err)
+			tracing.TraceErr(span, err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		pq := utils.NewPaginationFromQueryParams(c.QueryParam(constants.Size), c.QueryParam(constants.Page))
+		query := queries.NewGetOrderHistoryQuery(es.GetTenantID(ctx), orderID.String(), getEventTypesFromQueryParams(c), pq)
+		if err := h.v.StructCtx(ctx, query); err != nil {
+			h.log.Errorf("(validate) err: {%v}", err)
+			tracing.TraceErr(span, err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		history, err := h.os.Queries.GetOrderHistory.Handle(ctx, query)
+		if err != nil {
+			h.log.Errorf("(GetOrderHistory.Handle) id: {%s}, err: {%v}", orderID.String(), err)
+			tracing.TraceErr(span, err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		h.log.Infof("(get order history) orderID: {%s}, pagination: {%+v}", orderID.String(), history.Pagination)
+		return c.JSON(http.StatusOK, history)
+	}
+}
+
+// GetOrderAt
+// @Tags Orders
+// @Summary Get order at point in time
+// @Description Get order state rebuilt from the events up to the version or timestamp
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param version query integer false "stream revision of the last event to apply"
+// @Param timestamp query string false "RFC3339 time of the last event to apply"
+// @Param X-Tenant-ID header string false "tenant id, requests without it use the default tenant"
+// @Param Authorization header string false "Bearer token, required if the authentication is enabled"
+// @Success 200 {object} dto.OrderAtResponseDto
+// @Router /orders/{id}/at [get]
+func (h *orderHandlers) GetOrderAt() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracing.StartHttpServerTracerSpan(c, "orderHandlers.GetOrderAt")
+		defer span.End()
+		h.metrics.GetOrderAtHttpRequests.Inc()
+
+		orderID, err := uuid.FromString(c.Param(constants.ID))
+		if err != nil {
+			h.log.Errorf("(uuid.FromString) err: {%v}", err)
+			tracing.TraceErr(span, err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		version, timestamp, err := getPointInTimeFromQueryParams(c)
+		if err != nil {
+			h.log.Errorf("(getPointInTimeFromQueryParams) err: {%v}", err)
+			tracing.TraceErr(span, err)
+			return httpErrors.NewBadRequestError(c, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		}
+
+		query := queries.NewGetOrderAtQuery(es.GetTenantID(ctx), orderID.String(), version, timestamp)
+		if err := h.v.StructCtx(ctx, query); err != nil {
+			h.log.Errorf("(validate) err: {%v}", err)
+			tracing.TraceErr(span, err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		orderAt, err := h.os.Queries.GetOrderAt.Handle(ctx, query)
+		if err != nil {
+			h.log.Errorf("(GetOrderAt.Handle) id: {%s}, err: {%v}", orderID.String(), err)
+			tracing.TraceErr(span, err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		h.log.Infof("(get order at) orderID: {%s}, version: {%d}", orderID.String(), orderAt.Version)
+		return c.JSON(http.StatusOK, mappers.OrderAtResponseFromModel(orderAt))
+	}
+}
+
+// WatchOrder
+// @Tags Orders
+// @Summary Watch order updates
+// @Description Server-Sent Events stream of the committed order events with the order state after each of them,
+// @Description event id is the order version, without afterVersion the stream starts from the current order state
+// @Produce text/event-stream
+// @Param id path string true "Order ID"
+// @Param afterVersion query integer false "version of the last received update, Last-Event-ID header takes precedence"
+// @Param X-Tenant-ID header string false "tenant id, requests without it use the default tenant"
+// @Param Authorization header string false "Bearer token, required if the authentication is enabled"
+// @Success 200 {object} dto.OrderUpdateResponseDto
+// @Router /orders/{id}/watch [get]
+func (h *orderHandlers) WatchOrder() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, span := tracing.StartHttpServerTracerSpan(c, "orderHandlers.WatchOrder")
+		defer span.End()
+		h.metrics.WatchOrderHttpRequests.Inc()
+
+		orderID, err := uuid.FromString(c.Param(constants.ID))
+		if err != nil {
+			h.log.Errorf("(uuid.FromString) err: {%v}", err)
+			tracing.TraceErr(span, err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		afterVersion, err := getAfterVersionFromRequest(c)
+		if err != nil {
+			h.log.Errorf("(getAfterVersionFromRequest) err: {%v}", err)
+			tracing.TraceErr(span, err)
+			return httpErrors.NewBadRequestError(c, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		}
+
+		query := queries.NewWatchOrderQuery(es.GetTenantID(ctx), orderID.String(), afterVersion)
+		if err := h.v.StructCtx(ctx, query); err != nil {
+			h.log.Errorf("(validate) err: {%v}", err)
+			tracing.TraceErr(span, err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		// the stream is closed before the server write timeout, the client reconnects with Last-Event-ID
+		ctx, cancel := context.WithTimeout(ctx, h.cfg.Http.WatchStreamTimeout)
+		defer cancel()
+
+		updates := make(chan dto.OrderUpdateResponseDto)
+		watchErr := make(chan error, 1)
+		go func() {
+			watchErr <- h.os.Queries.WatchOrder.Handle(ctx, query, func(ctx context.Context, update *models.OrderUpdate) error {
+				select {
+				case updates <- mappers.OrderUpdateResponseFromModel(update):
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			})
+		}()
+
+		heartbeat := time.NewTicker(h.cfg.Http.WatchHeartbeat)
+		defer heartbeat.Stop()
+
+		stream := newSSEStream(c)
+		for {
+			select {
+			case update := <-updates:
+				if err := stream.Send(strconv.FormatInt(update.Event.Version, 10), update.Event.EventType, update); err != nil {
+					h.log.Warnf("(stream.Send) id: {%s}, err: {%v}", orderID.String(), err)
+					return nil
+				}
+			case <-heartbeat.C:
+				if err := stream.Comment("heartbeat"); err != nil {
+					h.log.Warnf("(stream.Comment) id: {%s}, err: {%v}", orderID.String(), err)
+					return nil
+				}
+			case err := <-watchErr:
+				if err != nil && ctx.Err() == nil {
+					h.log.Errorf("(WatchOrder.Handle) id: {%s}, err: {%v}", orderID.String(), err)
+					tracing.TraceErr(span, err)
+					if !stream.Started() {
+						return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+					}
+					return nil
+				}
+
+				h.log.Infof("(watch order stopped) orderID: {%s}", orderID.String())
+				return stream.Start()
+			}
+		}
+	}
+}
+
+// getAfterVersionFromRequest returns the version of the last received update from Last-Event-ID header or afterVersion query param.
+func getAfterVersionFromRequest(c echo.Context) (*int64, error) {
+	param := c.Request().Header.Get(constants.LastEventIDHeader)
+	if param == "" {
+		param = c.QueryParam(constants.AfterVersionQuery)
+	}
+	if param == "" {
+		return nil, nil
+	}
+
+	afterVersion, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "afterVersion")
+	}
+	return &afterVersion, nil
+}
+
+func getPointInTimeFromQueryParams(c echo.Context) (*int64, *time.Time, error) {
+	var version *int64
+	if param := c.QueryParam(constants.VersionQuery); param != "" {
+		parsed, err := strconv.ParseInt(param, 10, 64)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "version")
+		}
+		version = &parsed
+	}
+
+	var timestamp *time.Time
+	if param := c.QueryParam(constants.TimestampQuery); param != "" {
+		parsed, err := time.Parse(time.RFC3339, param)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "timestamp")
+		}
+		timestamp = &parsed
+	}
+
+	return version, timestamp, nil
+}
+
+func getSearchFilterFromQueryParams(c echo.Context) (queries.SearchOrdersFilter, error) {
+	filter := queries.SearchOrdersFilter{
+		Statuses:     getListFromQueryParams(c, constants.StatusQuery),
+		AccountEmail: c.QueryParam(constants.AccountEmailQuery),
+		Currency:     c.QueryParam(constants.CurrencyQuery),
+	}
+	if _, err := models.OrderStatusesFromStrings(filter.Statuses); err != nil {
+		return filter, err
+	}
+
+	var err error
+	if filter.MinTotalPrice, err = getInt64FromQueryParam(c, constants.MinTotalPriceQuery); err != nil {
+		return filter, err
+	}
+	if filter.MaxTotalPrice, err = getInt64FromQueryParam(c, constants.MaxTotalPriceQuery); err != nil {
+		return filter, err
+	}
+	if filter.CreatedFrom, err = getTimeFromQueryParam(c, constants.CreatedFromQuery); err != nil {
+		return filter, err
+	}
+	if filter.CreatedTo, err = getTimeFromQueryParam(c, constants.CreatedToQuery); err != nil {
+		return filter, err
+	}
+	if filter.DeliveredFrom, err = getTimeFromQueryParam(c, constants.DeliveredFromQuery); err != nil {
+		return filter, err
+	}
+	if filter.DeliveredTo, err = getTimeFromQueryParam(c, constants.DeliveredToQuery); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+// getInt64FromQueryParam returns nil if the query param is empty.
+func getInt64FromQueryParam(c echo.Context, name string) (*int64, error) {
+	param := c.QueryParam(name)
+	if param == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, name)
+	}
+	return &value, nil
+}
+
+// getBoolFromQueryParam returns false if the query param is empty.
+func getBoolFromQueryParam(c echo.Context, name string) (bool, error) {
+	param := c.QueryParam(name)
+	if param == "" {
+		return false, nil
+	}
+	value, err := strconv.ParseBool(param)
+	if err != nil {
+		return false, errors.Wrap(err, name)
+	}
+	return value, nil
+}
+
+// getTimeFromQueryParam returns nil if the RFC3339 query param is empty.
+func getTimeFromQueryParam(c echo.Context, name string) (*time.Time, error) {
+	param := c.QueryParam(name)
+	if param == "" {
+		return nil, nil
+	}
+	value, err := time.Parse(time.RFC3339, param)
+	if err != nil {
+		return nil, errors.Wrap(err, name)
+	}
+	return &value, nil
+}
+
+func getEventTypesFromQueryParams(c echo.Context) []string {
+	return getListFromQueryParams(c, constants.EventTypeQuery)
+}
+
+// getListFromQueryParams returns values of the repeated or comma separated query param.
+func getListFromQueryParams(c echo.Context, name string) []string {
+	values := make([]string, 0)
+	for _, param := range c.QueryParams()[name] {
+		for _, value := range strings.Split(param, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
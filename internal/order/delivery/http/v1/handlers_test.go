@@ -0,0 +1,213 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/AleksK1NG/es-microservice/config"
+	"github.com/AleksK1NG/es-microservice/internal/dto"
+	"github.com/AleksK1NG/es-microservice/internal/metrics"
+	"github.com/AleksK1NG/es-microservice/internal/order/models"
+	"github.com/AleksK1NG/es-microservice/internal/order/queries"
+	"github.com/AleksK1NG/es-microservice/internal/order/service"
+	"github.com/AleksK1NG/es-microservice/pkg/auth"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/go-playground/validator"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestGetSearchFilterFromQueryParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		statuses []string
+		err      error
+		invalid  bool
+	}{
+		{name: "without filters", statuses: []string{}},
+		{name: "statuses", query: "status=paid,completed&status=canceled", statuses: []string{"paid", "completed", "canceled"}},
+		{name: "unknown status", query: "status=paid,shipped", err: models.ErrUnknownOrderStatus},
+		{name: "status case mismatch", query: "status=PAID", err: models.ErrUnknownOrderStatus},
+		{name: "invalid total price", query: "minTotalPrice=ten", invalid: true},
+		{name: "invalid created from", query: "createdFrom=yesterday", invalid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/orders/search?"+tt.query, nil)
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+
+			filter, err := getSearchFilterFromQueryParams(c)
+			if tt.invalid {
+				if err == nil {
+					t.Fatalf("getSearchFilterFromQueryParams() = %+v, want err", filter)
+				}
+				return
+			}
+			if !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+				t.Fatalf("getSearchFilterFromQueryParams() err = %v, want %v", err, tt.err)
+			}
+			if tt.err == nil && fmt.Sprint(filter.Statuses) != fmt.Sprint(tt.statuses) {
+				t.Errorf("filter statuses = %v, want %v", filter.Statuses, tt.statuses)
+			}
+		})
+	}
+}
+
+func TestGetSearchFilterFromQueryParamsValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		filter string
+	}{
+		{
+			name:  "account and currency",
+			query: "accountEmail=customer@mail.com&currency=USD",
+			filter: `{"statuses":[],"accountEmail":"customer@mail.com","currency":"USD","minTotalPrice":null,"maxTotalPrice":null,` +
+				`"createdFrom":null,"createdTo":null,"deliveredFrom":null,"deliveredTo":null}`,
+		},
+		{
+			name:  "total price range",
+			query: "minTotalPrice=1000&maxTotalPrice=5000",
+			filter: `{"statuses":[],"accountEmail":"","currency":"","minTotalPrice":1000,"maxTotalPrice":5000,` +
+				`"createdFrom":null,"createdTo":null,"deliveredFrom":null,"deliveredTo":null}`,
+		},
+		{
+			name:  "dates",
+			query: "createdFrom=2026-01-02T03:04:05%2B01:00&createdTo=2026-02-01T00:00:00Z&deliveredFrom=2026-01-03T00:00:00Z&deliveredTo=2026-01-04T00:00:00Z",
+			filter: `{"statuses":[],"accountEmail":"","currency":"","minTotalPrice":null,"maxTotalPrice":null,` +
+				`"createdFrom":"2026-01-02T03:04:05+01:00","createdTo":"2026-02-01T00:00:00Z","deliveredFrom":"2026-01-03T00:00:00Z","deliveredTo":"2026-01-04T00:00:00Z"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/orders/search?"+tt.query, nil)
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+
+			filter, err := getSearchFilterFromQueryParams(c)
+			if err != nil {
+				t.Fatalf("getSearchFilterFromQueryParams() err: %v", err)
+			}
+			data, err := json.Marshal(filter)
+			if err != nil {
+				t.Fatalf("json.Marshal() err: %v", err)
+			}
+			if string(data) != tt.filter {
+				t.Errorf("getSearchFilterFromQueryParams() = %s, want %s", data, tt.filter)
+			}
+		})
+	}
+}
+
+// listOrdersByAccountHandler records the handled query and returns the account orders or err.
+type listOrdersByAccountHandler struct {
+	query *queries.ListOrdersByAccountQuery
+	err   error
+}
+
+func (h *listOrdersByAccountHandler) Handle(ctx context.Context, query *queries.ListOrdersByAccountQuery) (*dto.AccountOrdersResponseDto, error) {
+	h.query = query
+	if h.err != nil {
+		return nil, h.err
+	}
+	return &dto.AccountOrdersResponseDto{
+		Pagination: dto.Pagination{TotalCount: 1, Page: int64(query.Pq.GetPage()), Size: int64(query.Pq.GetSize())},
+		Orders:     []dto.OrderResponseDto{{ID: "order-1", AccountEmail: query.AccountEmail}},
+	}, nil
+}
+
+func newTestOrderHandlers(queryHandler queries.ListOrdersByAccountQueryHandler) *orderHandlers {
+	appLogger := logger.NewAppLogger(&logger.Config{LogLevel: "error", Encoder: "console"})
+	appLogger.InitLogger()
+	return &orderHandlers{
+		log:     appLogger,
+		cfg:     &config.Config{},
+		v:       validator.New(),
+		os:      &service.OrderService{Queries: &queries.OrderQueries{ListOrdersByAccount: queryHandler}},
+		metrics: &metrics.ESMicroserviceMetrics{ListAccountOrdersHttpRequests: prometheus.NewCounter(prometheus.CounterOpts{Name: "list_account_orders_http_requests_total"})},
+	}
+}
+
+func TestListOrdersByAccount(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		tenantID string
+		err      error
+		status   int
+		// handled query, empty if the request is rejected before the query handler
+		handled string
+	}{
+		{
+			name:    "account orders",
+			query:   "accountEmail=customer@mail.com",
+			status:  http.StatusOK,
+			handled: "tenant: , account: customer@mail.com, statuses: [], order by: , page: 1, size: 10",
+		},
+		{
+			name:     "tenant statuses and pagination",
+			query:    "accountEmail=customer@mail.com&status=paid,new&status=canceled&orderBy=totalPrice:desc&page=2&size=5",
+			tenantID: "tenantA",
+			status:   http.StatusOK,
+			handled:  "tenant: tenantA, account: customer@mail.com, statuses: [paid new canceled], order by: totalPrice:desc, page: 2, size: 5",
+		},
+		{name: "account email required", query: "status=paid", status: http.StatusBadRequest},
+		{name: "invalid account email", query: "accountEmail=customer", status: http.StatusBadRequest},
+		{name: "unknown order by", query: "accountEmail=customer@mail.com&orderBy=accountEmail", status: http.StatusBadRequest},
+		{name: "unknown status", query: "accountEmail=customer@mail.com&status=shipped", status: http.StatusBadRequest},
+		{
+			name:    "other account",
+			query:   "accountEmail=other@mail.com",
+			err:     auth.ErrForbidden,
+			status:  http.StatusForbidden,
+			handled: "tenant: , account: other@mail.com, statuses: [], order by: , page: 1, size: 10",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queryHandler := &listOrdersByAccountHandler{err: tt.err}
+			handlers := newTestOrderHandlers(queryHandler)
+
+			req := httptest.NewRequest(http.MethodGet, "/orders?"+tt.query, nil)
+			req = req.WithContext(es.WithTenantID(req.Context(), tt.tenantID))
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			if err := handlers.ListOrdersByAccount()(c); err != nil {
+				t.Fatalf("ListOrdersByAccount() err: %v", err)
+			}
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d, body: %s", rec.Code, tt.status, rec.Body.String())
+			}
+
+			handled := ""
+			if query := queryHandler.query; query != nil {
+				handled = fmt.Sprintf("tenant: %s, account: %s, statuses: %v, order by: %s, page: %d, size: %d",
+					query.TenantID, query.AccountEmail, query.Statuses, query.OrderBy, query.Pq.GetPage(), query.Pq.GetSize())
+			}
+			if handled != tt.handled {
+				t.Errorf("handled query = %q, want %q", handled, tt.handled)
+			}
+
+			if tt.status != http.StatusOK {
+				return
+			}
+			var res dto.AccountOrdersResponseDto
+			if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+				t.Fatalf("json.Unmarshal() err: %v", err)
+			}
+			if len(res.Orders) != 1 || res.Orders[0].AccountEmail != "customer@mail.com" || res.Pagination.TotalCount != 1 {
+				t.Errorf("response = %+v, want the account order", res)
+			}
+		})
+	}
+}
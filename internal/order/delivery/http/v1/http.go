@@ -7,7 +7,26 @@ type OrderHandlers interface {
 	PayOrder() echo.HandlerFunc
 	SubmitOrder() echo.HandlerFunc
 	UpdateShoppingCart() echo.HandlerFunc
+	AddShopItem() echo.HandlerFunc
+	RemoveShopItem() echo.HandlerFunc
+	ChangeItemQuantity() echo.HandlerFunc
+	RefundOrder() echo.HandlerFunc
 
 	GetOrderByID() echo.HandlerFunc
+	GetOrderHistory() echo.HandlerFunc
+	GetOrderAt() echo.HandlerFunc
+	WatchOrder() echo.HandlerFunc
 	Search() echo.HandlerFunc
+	ListOrdersByAccount() echo.HandlerFunc
+}
+
+type AdminHandlers interface {
+	RebuildProjection() echo.HandlerFunc
+	GetRebuildStatus() echo.HandlerFunc
+	GetProjectionsLag() echo.HandlerFunc
+
+	ListDeadLetters() echo.HandlerFunc
+	GetDeadLetter() echo.HandlerFunc
+	ReplayDeadLetter() echo.HandlerFunc
+	DiscardDeadLetter() echo.HandlerFunc
 }
@@ -5,10 +5,29 @@ func (h *orderHandlers) MapRoutes() {
 	h.group.PUT("/pay/:id", h.PayOrder())
 	h.group.PUT("/submit/:id", h.SubmitOrder())
 	h.group.PUT("/cart/:id", h.UpdateShoppingCart())
+	h.group.POST("/cart/:id/items", h.AddShopItem())
+	h.group.DELETE("/cart/:id/items/:itemId", h.RemoveShopItem())
+	h.group.PUT("/cart/:id/items/:itemId", h.ChangeItemQuantity())
 	h.group.POST("/cancel/:id", h.CancelOrder())
 	h.group.POST("/complete/:id", h.CompleteOrder())
+	h.group.POST("/refund/:id", h.RefundOrder())
 	h.group.PUT("/address/:id", h.ChangeDeliveryAddress())
 
+	h.group.GET("", h.ListOrdersByAccount())
 	h.group.GET("/:id", h.GetOrderByID())
+	h.group.GET("/:id/events", h.GetOrderHistory())
+	h.group.GET("/:id/at", h.GetOrderAt())
+	h.group.GET("/:id/watch", h.WatchOrder())
 	h.group.GET("/search", h.Search())
 }
+
+func (h *adminHandlers) MapRoutes() {
+	h.group.POST("/projections/:target/rebuild", h.RebuildProjection())
+	h.group.GET("/projections/:target/rebuild", h.GetRebuildStatus())
+	h.group.GET("/projections/lag", h.GetProjectionsLag())
+
+	h.group.GET("/dead-letters", h.ListDeadLetters())
+	h.group.GET("/dead-letters/:id", h.GetDeadLetter())
+	h.group.POST("/dead-letters/:id/replay", h.ReplayDeadLetter())
+	h.group.DELETE("/dead-letters/:id", h.DiscardDeadLetter())
+}
@@ -0,0 +1,76 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+const (
+	// sseRetry reconnection delay in milliseconds sent to the EventSource clients.
+	sseRetry = 1000
+)
+
+// sseStream writes Server-Sent Events to the response, headers are written lazily by the first event,
+// so the handler can still respond with the regular error before it.
+type sseStream struct {
+	c       echo.Context
+	started bool
+}
+
+func newSSEStream(c echo.Context) *sseStream {
+	return &sseStream{c: c}
+}
+
+// Start writes the event stream headers and reconnection delay if they are not written yet.
+func (s *sseStream) Start() error {
+	if s.started {
+		return nil
+	}
+	s.started = true
+
+	header := s.c.Response().Header()
+	header.Set(echo.HeaderContentType, "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	s.c.Response().WriteHeader(http.StatusOK)
+
+	return s.write(fmt.Sprintf("retry: %d\n\n", sseRetry))
+}
+
+// Started check are the event stream headers written.
+func (s *sseStream) Started() bool {
+	return s.started
+}
+
+// Send writes the event with json encoded data, id is sent back by the client in the Last-Event-ID header on reconnect.
+func (s *sseStream) Send(id string, event string, data interface{}) error {
+	dataBytes, err := json.Marshal(data)
+	if err != nil {
+		return errors.Wrap(err, "json.Marshal")
+	}
+	if err := s.Start(); err != nil {
+		return err
+	}
+	return s.write(fmt.Sprintf("id: %s\nevent: %s\ndata: %s\n\n", id, event, dataBytes))
+}
+
+// Comment writes the comment line ignored by the clients, used as heartbeat.
+func (s *sseStream) Comment(comment string) error {
+	if err := s.Start(); err != nil {
+		return err
+	}
+	return s.write(fmt.Sprintf(": %s\n\n", comment))
+}
+
+func (s *sseStream) write(message string) error {
+	if _, err := s.c.Response().Write([]byte(message)); err != nil {
+		return errors.Wrap(err, "Response.Write")
+	}
+	s.c.Response().Flush()
+	return nil
+}
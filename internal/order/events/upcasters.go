@@ -0,0 +1,39 @@
+package events
+
+import (
+	"github.com/AleksK1NG/es-microservice/internal/order/events/v1"
+	"github.com/AleksK1NG/es-microservice/internal/order/events/v2"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+)
+
+// NewOrderUpcaster upcaster of the order events stored with the previous schema versions,
+// the aggregate and projections handle only the current version.
+// When the event schema changes add the next version event type and register es.UpcastFunc
+// converting the previous version payload.
+//
+// V1 events prices are float amounts without currency, they are converted to legacyCurrency minor units.
+func NewOrderUpcaster(legacyCurrency string) es.Upcaster {
+	return es.NewUpcasterRegistry().
+		Register(v1.OrderCreated, func(event es.Event) (es.Event, error) {
+			var eventV1 v1.OrderCreatedEvent
+			if err := event.GetJsonData(&eventV1); err != nil {
+				return es.Event{}, err
+			}
+			event.EventType = v2.OrderCreated
+			if err := event.SetJsonData(v2.OrderCreatedEventFromV1(eventV1, legacyCurrency)); err != nil {
+				return es.Event{}, err
+			}
+			return event, nil
+		}).
+		Register(v1.ShoppingCartUpdated, func(event es.Event) (es.Event, error) {
+			var eventV1 v1.ShoppingCartUpdatedEvent
+			if err := event.GetJsonData(&eventV1); err != nil {
+				return es.Event{}, err
+			}
+			event.EventType = v2.ShoppingCartUpdated
+			if err := event.SetJsonData(v2.ShoppingCartUpdatedEventFromV1(eventV1, legacyCurrency)); err != nil {
+				return es.Event{}, err
+			}
+			return event, nil
+		})
+}
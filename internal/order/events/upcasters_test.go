@@ -0,0 +1,137 @@
+package events
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/AleksK1NG/es-microservice/internal/order/aggregate"
+	"github.com/AleksK1NG/es-microservice/internal/order/events/v1"
+	"github.com/AleksK1NG/es-microservice/internal/order/events/v2"
+	"github.com/AleksK1NG/es-microservice/internal/order/models"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+)
+
+// newV1OrderEvents returns the order events stored with the V1 events schema.
+func newV1OrderEvents(t *testing.T, order *aggregate.OrderAggregate) []es.Event {
+	created := es.NewBaseEvent(order, v1.OrderCreated)
+	if err := created.SetJsonData(v1.OrderCreatedEvent{
+		ShopItems:       []*v1.ShopItem{{ID: "item-1", Title: "book", Quantity: 2, Price: 10.5}},
+		AccountEmail:    "customer@mail.com",
+		DeliveryAddress: "address",
+	}); err != nil {
+		t.Fatalf("SetJsonData() err: %v", err)
+	}
+	paid, err := v1.NewOrderPaidEvent(order, &models.Payment{PaymentID: "payment-1", Timestamp: time.Now().UTC()})
+	if err != nil {
+		t.Fatalf("NewOrderPaidEvent() err: %v", err)
+	}
+	updated := es.NewBaseEvent(order, v1.ShoppingCartUpdated)
+	if err := updated.SetJsonData(v1.ShoppingCartUpdatedEvent{ShopItems: []*v1.ShopItem{
+		{ID: "item-1", Title: "book", Quantity: 2, Price: 10.5},
+		{ID: "item-2", Title: "pen", Quantity: 3, Price: 0.99},
+	}}); err != nil {
+		t.Fatalf("SetJsonData() err: %v", err)
+	}
+
+	events := []es.Event{created, paid, updated}
+	for i := range events {
+		events[i].Version = int64(i)
+	}
+	return events
+}
+
+func TestOrderUpcasterLoadsV1Events(t *testing.T) {
+	tests := []struct {
+		name           string
+		legacyCurrency string
+		// shop items prices and the order total price after each event
+		createdPrices []models.Money
+		createdTotal  models.Money
+		updatedPrices []models.Money
+		updatedTotal  models.Money
+	}{
+		{
+			name:           "cents",
+			legacyCurrency: "USD",
+			createdPrices:  []models.Money{models.NewMoney(1050, "USD")},
+			createdTotal:   models.NewMoney(2100, "USD"),
+			updatedPrices:  []models.Money{models.NewMoney(1050, "USD"), models.NewMoney(99, "USD")},
+			updatedTotal:   models.NewMoney(2397, "USD"),
+		},
+		{
+			name:           "currency without minor units",
+			legacyCurrency: "JPY",
+			createdPrices:  []models.Money{models.NewMoney(11, "JPY")},
+			createdTotal:   models.NewMoney(22, "JPY"),
+			updatedPrices:  []models.Money{models.NewMoney(11, "JPY"), models.NewMoney(1, "JPY")},
+			updatedTotal:   models.NewMoney(25, "JPY"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upcaster := NewOrderUpcaster(tt.legacyCurrency)
+			stored := newV1OrderEvents(t, aggregate.NewOrderAggregateWithID("", "order-1"))
+
+			upcasted := make([]es.Event, 0, len(stored))
+			for _, event := range stored {
+				upcastedEvent, err := upcaster.Upcast(event)
+				if err != nil {
+					t.Fatalf("Upcast() err: %v", err)
+				}
+				upcasted = append(upcasted, upcastedEvent)
+			}
+
+			types := []string{v2.OrderCreated, v1.OrderPaid, v2.ShoppingCartUpdated}
+			for i, event := range upcasted {
+				if event.GetEventType() != types[i] || event.GetVersion() != stored[i].GetVersion() || event.GetEventID() != stored[i].GetEventID() {
+					t.Errorf("upcasted event %d = %s, want type %s of the stored event %s", i, event.String(), types[i], stored[i].String())
+				}
+			}
+			if string(upcasted[1].GetData()) != string(stored[1].GetData()) {
+				t.Errorf("paid event data = %s, want not changed %s", upcasted[1].GetData(), stored[1].GetData())
+			}
+
+			var created v2.OrderCreatedEvent
+			if err := upcasted[0].GetJsonData(&created); err != nil {
+				t.Fatalf("GetJsonData() err: %v", err)
+			}
+			if prices := getShopItemsPrices(created.ShopItems); fmt.Sprint(prices) != fmt.Sprint(tt.createdPrices) {
+				t.Errorf("created event prices = %v, want %v", prices, tt.createdPrices)
+			}
+			if created.AccountEmail != "customer@mail.com" || created.DeliveryAddress != "address" {
+				t.Errorf("created event = %+v, want V1 account email and delivery address", created)
+			}
+
+			order := aggregate.NewOrderAggregateWithID("", "order-1")
+			if err := order.Load(upcasted[:1]); err != nil {
+				t.Fatalf("Load() created err: %v", err)
+			}
+			if order.Order.TotalPrice != tt.createdTotal {
+				t.Errorf("created order total price = %v, want %v", order.Order.TotalPrice, tt.createdTotal)
+			}
+
+			if err := order.Load(upcasted[1:]); err != nil {
+				t.Fatalf("Load() err: %v", err)
+			}
+			if prices := getShopItemsPrices(order.Order.ShopItems); fmt.Sprint(prices) != fmt.Sprint(tt.updatedPrices) {
+				t.Errorf("order prices = %v, want %v", prices, tt.updatedPrices)
+			}
+			if order.Order.TotalPrice != tt.updatedTotal {
+				t.Errorf("order total price = %v, want %v", order.Order.TotalPrice, tt.updatedTotal)
+			}
+			if order.Order.Payment.PaymentID != "payment-1" || order.GetVersion() != 2 {
+				t.Errorf("order payment = %s, version = %d, want payment-1, 2", order.Order.Payment.PaymentID, order.GetVersion())
+			}
+		})
+	}
+}
+
+func getShopItemsPrices(shopItems []*models.ShopItem) []models.Money {
+	prices := make([]models.Money, 0, len(shopItems))
+	for _, item := range shopItems {
+		prices = append(prices, item.Price)
+	}
+	return prices
+}
@@ -17,23 +17,11 @@ const (
 	DeliveryAddressChanged = "V1_DELIVERY_ADDRESS_CHANGED"
 )
 
+// OrderCreatedEvent is not raised anymore, stored events are upcasted to v2.OrderCreatedEvent.
 type OrderCreatedEvent struct {
-	ShopItems       []*models.ShopItem `json:"shopItems" bson:"shopItems,omitempty"`
-	AccountEmail    string             `json:"accountEmail" bson:"accountEmail,omitempty"`
-	DeliveryAddress string             `json:"deliveryAddress" bson:"deliveryAddress,omitempty"`
-}
-
-func NewOrderCreatedEvent(aggregate es.Aggregate, shopItems []*models.ShopItem, accountEmail, deliveryAddress string) (es.Event, error) {
-	eventData := OrderCreatedEvent{
-		ShopItems:       shopItems,
-		AccountEmail:    accountEmail,
-		DeliveryAddress: deliveryAddress,
-	}
-	event := es.NewBaseEvent(aggregate, OrderCreated)
-	if err := event.SetJsonData(&eventData); err != nil {
-		return es.Event{}, err
-	}
-	return event, nil
+	ShopItems       []*ShopItem `json:"shopItems" bson:"shopItems,omitempty"`
+	AccountEmail    string      `json:"accountEmail" bson:"accountEmail,omitempty"`
+	DeliveryAddress string      `json:"deliveryAddress" bson:"deliveryAddress,omitempty"`
 }
 
 func NewOrderPaidEvent(aggregate es.Aggregate, payment *models.Payment) (es.Event, error) {
@@ -48,17 +36,9 @@ func NewSubmitOrderEvent(aggregate es.Aggregate) (es.Event, error) {
 	return es.NewBaseEvent(aggregate, OrderSubmitted), nil
 }
 
+// ShoppingCartUpdatedEvent is not raised anymore, stored events are upcasted to v2.ShoppingCartUpdatedEvent.
 type ShoppingCartUpdatedEvent struct {
-	ShopItems []*models.ShopItem `json:"shopItems" bson:"shopItems,omitempty"`
-}
-
-func NewShoppingCartUpdatedEvent(aggregate es.Aggregate, shopItems []*models.ShopItem) (es.Event, error) {
-	eventData := ShoppingCartUpdatedEvent{ShopItems: shopItems}
-	event := es.NewBaseEvent(aggregate, ShoppingCartUpdated)
-	if err := event.SetJsonData(&eventData); err != nil {
-		return es.Event{}, err
-	}
-	return event, nil
+	ShopItems []*ShopItem `json:"shopItems" bson:"shopItems,omitempty"`
 }
 
 type OrderDeliveryAddressChangedEvent struct {
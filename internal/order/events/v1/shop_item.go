@@ -0,0 +1,10 @@
+package v1
+
+// ShopItem shop item of the V1 events, Price is float amount in the major units without currency.
+type ShopItem struct {
+	ID          string  `json:"id" bson:"id,omitempty"`
+	Title       string  `json:"title" bson:"title,omitempty"`
+	Description string  `json:"description" bson:"description,omitempty"`
+	Quantity    uint64  `json:"quantity" bson:"quantity,omitempty"`
+	Price       float64 `json:"price" bson:"price,omitempty"`
+}
@@ -0,0 +1,139 @@
+package v2
+
+import (
+	"github.com/AleksK1NG/es-microservice/internal/order/events/v1"
+	"github.com/AleksK1NG/es-microservice/internal/order/models"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+)
+
+// V2 events keep the shop items prices as models.Money instead of float amounts of the V1 events.
+const (
+	OrderCreated            = "V2_ORDER_CREATED"
+	ShoppingCartUpdated     = "V2_SHOPPING_CART_UPDATED"
+	ShopItemAdded           = "V2_SHOP_ITEM_ADDED"
+	ShopItemRemoved         = "V2_SHOP_ITEM_REMOVED"
+	ShopItemQuantityChanged = "V2_SHOP_ITEM_QUANTITY_CHANGED"
+	OrderRefunded           = "V2_ORDER_REFUNDED"
+)
+
+type OrderCreatedEvent struct {
+	ShopItems       []*models.ShopItem `json:"shopItems" bson:"shopItems,omitempty"`
+	AccountEmail    string             `json:"accountEmail" bson:"accountEmail,omitempty"`
+	DeliveryAddress string             `json:"deliveryAddress" bson:"deliveryAddress,omitempty"`
+}
+
+func NewOrderCreatedEvent(aggregate es.Aggregate, shopItems []*models.ShopItem, accountEmail, deliveryAddress string) (es.Event, error) {
+	eventData := OrderCreatedEvent{
+		ShopItems:       shopItems,
+		AccountEmail:    accountEmail,
+		DeliveryAddress: deliveryAddress,
+	}
+	event := es.NewBaseEvent(aggregate, OrderCreated)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+// OrderCreatedEventFromV1 converts V1 event float prices to the currency minor units.
+func OrderCreatedEventFromV1(eventV1 v1.OrderCreatedEvent, currency string) OrderCreatedEvent {
+	return OrderCreatedEvent{
+		ShopItems:       ShopItemsFromV1(eventV1.ShopItems, currency),
+		AccountEmail:    eventV1.AccountEmail,
+		DeliveryAddress: eventV1.DeliveryAddress,
+	}
+}
+
+type ShoppingCartUpdatedEvent struct {
+	ShopItems []*models.ShopItem `json:"shopItems" bson:"shopItems,omitempty"`
+}
+
+func NewShoppingCartUpdatedEvent(aggregate es.Aggregate, shopItems []*models.ShopItem) (es.Event, error) {
+	eventData := ShoppingCartUpdatedEvent{ShopItems: shopItems}
+	event := es.NewBaseEvent(aggregate, ShoppingCartUpdated)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+// ShoppingCartUpdatedEventFromV1 converts V1 event float prices to the currency minor units.
+func ShoppingCartUpdatedEventFromV1(eventV1 v1.ShoppingCartUpdatedEvent, currency string) ShoppingCartUpdatedEvent {
+	return ShoppingCartUpdatedEvent{ShopItems: ShopItemsFromV1(eventV1.ShopItems, currency)}
+}
+
+// ShopItemAddedEvent TotalPrice is the order total price after adding the item,
+// so projections do not have to reload the cart to update it.
+type ShopItemAddedEvent struct {
+	ShopItem   *models.ShopItem `json:"shopItem" bson:"shopItem,omitempty"`
+	TotalPrice models.Money     `json:"totalPrice" bson:"totalPrice,omitempty"`
+}
+
+func NewShopItemAddedEvent(aggregate es.Aggregate, shopItem *models.ShopItem, totalPrice models.Money) (es.Event, error) {
+	eventData := ShopItemAddedEvent{ShopItem: shopItem, TotalPrice: totalPrice}
+	event := es.NewBaseEvent(aggregate, ShopItemAdded)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+// ShopItemRemovedEvent TotalPrice is the order total price after removing the item.
+type ShopItemRemovedEvent struct {
+	ShopItemID string       `json:"shopItemId" bson:"shopItemId,omitempty"`
+	TotalPrice models.Money `json:"totalPrice" bson:"totalPrice,omitempty"`
+}
+
+func NewShopItemRemovedEvent(aggregate es.Aggregate, shopItemID string, totalPrice models.Money) (es.Event, error) {
+	eventData := ShopItemRemovedEvent{ShopItemID: shopItemID, TotalPrice: totalPrice}
+	event := es.NewBaseEvent(aggregate, ShopItemRemoved)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+// ShopItemQuantityChangedEvent TotalPrice is the order total price after changing the item quantity.
+type ShopItemQuantityChangedEvent struct {
+	ShopItemID string       `json:"shopItemId" bson:"shopItemId,omitempty"`
+	Quantity   uint64       `json:"quantity" bson:"quantity,omitempty"`
+	TotalPrice models.Money `json:"totalPrice" bson:"totalPrice,omitempty"`
+}
+
+func NewShopItemQuantityChangedEvent(aggregate es.Aggregate, shopItemID string, quantity uint64, totalPrice models.Money) (es.Event, error) {
+	eventData := ShopItemQuantityChangedEvent{ShopItemID: shopItemID, Quantity: quantity, TotalPrice: totalPrice}
+	event := es.NewBaseEvent(aggregate, ShopItemQuantityChanged)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+// OrderRefundedEvent RefundedAmount is the total refunded amount of the order including this refund.
+type OrderRefundedEvent struct {
+	Refund         *models.Refund `json:"refund"`
+	RefundedAmount models.Money   `json:"refundedAmount"`
+}
+
+func NewOrderRefundedEvent(aggregate es.Aggregate, refund *models.Refund, refundedAmount models.Money) (es.Event, error) {
+	eventData := OrderRefundedEvent{Refund: refund, RefundedAmount: refundedAmount}
+	event := es.NewBaseEvent(aggregate, OrderRefunded)
+	if err := event.SetJsonData(&eventData); err != nil {
+		return es.Event{}, err
+	}
+	return event, nil
+}
+
+func ShopItemsFromV1(shopItems []*v1.ShopItem, currency string) []*models.ShopItem {
+	items := make([]*models.ShopItem, 0, len(shopItems))
+	for _, item := range shopItems {
+		items = append(items, &models.ShopItem{
+			ID:          item.ID,
+			Title:       item.Title,
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			Price:       models.NewMoneyFromFloat(item.Price, currency),
+		})
+	}
+	return items
+}
@@ -0,0 +1,107 @@
+package integration
+
+import (
+	"time"
+)
+
+// Integration events are the public contract of the order service, unlike domain events they are never upcasted,
+// breaking changes must be published as the new Version of the event.
+const (
+	EventsVersion = 1
+
+	OrderCreated            = "order.created"
+	OrderPaid               = "order.paid"
+	OrderSubmitted          = "order.submitted"
+	OrderCompleted          = "order.completed"
+	OrderCanceled           = "order.canceled"
+	ShoppingCartUpdated     = "order.shopping_cart_updated"
+	DeliveryAddressChanged  = "order.delivery_address_changed"
+	ShopItemAdded           = "order.shop_item_added"
+	ShopItemRemoved         = "order.shop_item_removed"
+	ShopItemQuantityChanged = "order.shop_item_quantity_changed"
+	OrderRefunded           = "order.refunded"
+)
+
+// IntegrationEvent envelope of the published integration event, ID is the domain event id,
+// so consumers can deduplicate redelivered events by it.
+type IntegrationEvent struct {
+	ID               string      `json:"id"`
+	Type             string      `json:"type"`
+	Version          int         `json:"version"`
+	OrderID          string      `json:"orderId"`
+	TenantID         string      `json:"tenantId,omitempty"`
+	AggregateVersion int64       `json:"aggregateVersion"`
+	OccurredAt       time.Time   `json:"occurredAt"`
+	Data             interface{} `json:"data,omitempty"`
+}
+
+// ShopItemV1 Price is amount in the major units of the Currency,
+// Currency is added to the V1 events after the prices became money amounts, so it is empty in the earlier published events.
+type ShopItemV1 struct {
+	ID          string  `json:"id"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Quantity    uint64  `json:"quantity"`
+	Price       float64 `json:"price"`
+	Currency    string  `json:"currency,omitempty"`
+}
+
+type OrderCreatedV1 struct {
+	AccountEmail    string        `json:"accountEmail"`
+	DeliveryAddress string        `json:"deliveryAddress"`
+	ShopItems       []*ShopItemV1 `json:"shopItems"`
+	TotalPrice      float64       `json:"totalPrice"`
+	Currency        string        `json:"currency,omitempty"`
+}
+
+type OrderPaidV1 struct {
+	PaymentID string    `json:"paymentId"`
+	PaidAt    time.Time `json:"paidAt"`
+}
+
+type ShoppingCartUpdatedV1 struct {
+	ShopItems  []*ShopItemV1 `json:"shopItems"`
+	TotalPrice float64       `json:"totalPrice"`
+	Currency   string        `json:"currency,omitempty"`
+}
+
+type ShopItemAddedV1 struct {
+	ShopItem   *ShopItemV1 `json:"shopItem"`
+	TotalPrice float64     `json:"totalPrice"`
+	Currency   string      `json:"currency,omitempty"`
+}
+
+type ShopItemRemovedV1 struct {
+	ShopItemID string  `json:"shopItemId"`
+	TotalPrice float64 `json:"totalPrice"`
+	Currency   string  `json:"currency,omitempty"`
+}
+
+type ShopItemQuantityChangedV1 struct {
+	ShopItemID string  `json:"shopItemId"`
+	Quantity   uint64  `json:"quantity"`
+	TotalPrice float64 `json:"totalPrice"`
+	Currency   string  `json:"currency,omitempty"`
+}
+
+// OrderRefundedV1 RefundedAmount is the total refunded amount of the order including this refund.
+type OrderRefundedV1 struct {
+	RefundID       string    `json:"refundId"`
+	Amount         float64   `json:"amount"`
+	Reason         string    `json:"reason"`
+	RefundedAt     time.Time `json:"refundedAt"`
+	RefundedAmount float64   `json:"refundedAmount"`
+	Currency       string    `json:"currency"`
+}
+
+type DeliveryAddressChangedV1 struct {
+	DeliveryAddress string `json:"deliveryAddress"`
+}
+
+type OrderCanceledV1 struct {
+	CancelReason string `json:"cancelReason"`
+}
+
+type OrderCompletedV1 struct {
+	DeliveredAt time.Time `json:"deliveredAt"`
+}
@@ -0,0 +1,204 @@
+package integration
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/AleksK1NG/es-microservice/internal/order/aggregate"
+	"github.com/AleksK1NG/es-microservice/internal/order/events/v1"
+	"github.com/AleksK1NG/es-microservice/internal/order/events/v2"
+	"github.com/AleksK1NG/es-microservice/internal/order/models"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/outbox"
+	"github.com/pkg/errors"
+)
+
+const (
+	HeaderEventType    = "event-type"
+	HeaderEventVersion = "event-version"
+	HeaderContentType  = "content-type"
+	HeaderTenantID     = "tenant-id"
+
+	jsonContentType = "application/json"
+)
+
+// MapOrderEvent outbox.Mapper for the order domain events.
+func MapOrderEvent(event es.Event) (*outbox.Message, error) {
+	eventType, data, err := getIntegrationEventData(event)
+	if err != nil {
+		return nil, err
+	}
+	if eventType == "" {
+		return nil, nil
+	}
+
+	integrationEvent := IntegrationEvent{
+		ID:               event.GetEventID(),
+		Type:             eventType,
+		Version:          EventsVersion,
+		OrderID:          aggregate.GetOrderAggregateID(event.GetAggregateID()),
+		TenantID:         aggregate.GetOrderTenantID(event.GetAggregateID()),
+		AggregateVersion: event.GetVersion(),
+		OccurredAt:       event.GetTimeStamp(),
+		Data:             data,
+	}
+
+	value, err := json.Marshal(&integrationEvent)
+	if err != nil {
+		return nil, errors.Wrap(err, "json.Marshal")
+	}
+
+	headers := map[string]string{
+		HeaderEventType:    eventType,
+		HeaderEventVersion: strconv.Itoa(EventsVersion),
+		HeaderContentType:  jsonContentType,
+	}
+	if integrationEvent.TenantID != "" {
+		headers[HeaderTenantID] = integrationEvent.TenantID
+	}
+
+	return &outbox.Message{
+		Key:       integrationEvent.OrderID,
+		Headers:   headers,
+		Value:     value,
+		Timestamp: integrationEvent.OccurredAt,
+	}, nil
+}
+
+func getIntegrationEventData(event es.Event) (string, interface{}, error) {
+	switch event.GetEventType() {
+
+	case v2.OrderCreated:
+		var eventData v2.OrderCreatedEvent
+		if err := event.GetJsonData(&eventData); err != nil {
+			return "", nil, errors.Wrap(err, "GetJsonData")
+		}
+		totalPrice, err := aggregate.GetShopItemsTotalPrice(eventData.ShopItems)
+		if err != nil {
+			return "", nil, err
+		}
+		return OrderCreated, &OrderCreatedV1{
+			AccountEmail:    eventData.AccountEmail,
+			DeliveryAddress: eventData.DeliveryAddress,
+			ShopItems:       shopItemsToV1(eventData.ShopItems),
+			TotalPrice:      totalPrice.Float64(),
+			Currency:        totalPrice.Currency,
+		}, nil
+
+	case v1.OrderPaid:
+		var payment models.Payment
+		if err := event.GetJsonData(&payment); err != nil {
+			return "", nil, errors.Wrap(err, "GetJsonData")
+		}
+		return OrderPaid, &OrderPaidV1{PaymentID: payment.PaymentID, PaidAt: payment.Timestamp}, nil
+
+	case v1.OrderSubmitted:
+		return OrderSubmitted, nil, nil
+
+	case v2.ShoppingCartUpdated:
+		var eventData v2.ShoppingCartUpdatedEvent
+		if err := event.GetJsonData(&eventData); err != nil {
+			return "", nil, errors.Wrap(err, "GetJsonData")
+		}
+		totalPrice, err := aggregate.GetShopItemsTotalPrice(eventData.ShopItems)
+		if err != nil {
+			return "", nil, err
+		}
+		return ShoppingCartUpdated, &ShoppingCartUpdatedV1{
+			ShopItems:  shopItemsToV1(eventData.ShopItems),
+			TotalPrice: totalPrice.Float64(),
+			Currency:   totalPrice.Currency,
+		}, nil
+
+	case v2.ShopItemAdded:
+		var eventData v2.ShopItemAddedEvent
+		if err := event.GetJsonData(&eventData); err != nil {
+			return "", nil, errors.Wrap(err, "GetJsonData")
+		}
+		return ShopItemAdded, &ShopItemAddedV1{
+			ShopItem:   shopItemToV1(eventData.ShopItem),
+			TotalPrice: eventData.TotalPrice.Float64(),
+			Currency:   eventData.TotalPrice.Currency,
+		}, nil
+
+	case v2.ShopItemRemoved:
+		var eventData v2.ShopItemRemovedEvent
+		if err := event.GetJsonData(&eventData); err != nil {
+			return "", nil, errors.Wrap(err, "GetJsonData")
+		}
+		return ShopItemRemoved, &ShopItemRemovedV1{
+			ShopItemID: eventData.ShopItemID,
+			TotalPrice: eventData.TotalPrice.Float64(),
+			Currency:   eventData.TotalPrice.Currency,
+		}, nil
+
+	case v2.ShopItemQuantityChanged:
+		var eventData v2.ShopItemQuantityChangedEvent
+		if err := event.GetJsonData(&eventData); err != nil {
+			return "", nil, errors.Wrap(err, "GetJsonData")
+		}
+		return ShopItemQuantityChanged, &ShopItemQuantityChangedV1{
+			ShopItemID: eventData.ShopItemID,
+			Quantity:   eventData.Quantity,
+			TotalPrice: eventData.TotalPrice.Float64(),
+			Currency:   eventData.TotalPrice.Currency,
+		}, nil
+
+	case v2.OrderRefunded:
+		var eventData v2.OrderRefundedEvent
+		if err := event.GetJsonData(&eventData); err != nil {
+			return "", nil, errors.Wrap(err, "GetJsonData")
+		}
+		return OrderRefunded, &OrderRefundedV1{
+			RefundID:       eventData.Refund.RefundID,
+			Amount:         eventData.Refund.Amount.Float64(),
+			Reason:         eventData.Refund.Reason,
+			RefundedAt:     eventData.Refund.RefundedAt,
+			RefundedAmount: eventData.RefundedAmount.Float64(),
+			Currency:       eventData.RefundedAmount.Currency,
+		}, nil
+
+	case v1.DeliveryAddressChanged:
+		var eventData v1.OrderDeliveryAddressChangedEvent
+		if err := event.GetJsonData(&eventData); err != nil {
+			return "", nil, errors.Wrap(err, "GetJsonData")
+		}
+		return DeliveryAddressChanged, &DeliveryAddressChangedV1{DeliveryAddress: eventData.DeliveryAddress}, nil
+
+	case v1.OrderCanceled:
+		var eventData v1.OrderCanceledEvent
+		if err := event.GetJsonData(&eventData); err != nil {
+			return "", nil, errors.Wrap(err, "GetJsonData")
+		}
+		return OrderCanceled, &OrderCanceledV1{CancelReason: eventData.CancelReason}, nil
+
+	case v1.OrderCompleted:
+		var eventData v1.OrderCompletedEvent
+		if err := event.GetJsonData(&eventData); err != nil {
+			return "", nil, errors.Wrap(err, "GetJsonData")
+		}
+		return OrderCompleted, &OrderCompletedV1{DeliveredAt: eventData.DeliveryTimestamp}, nil
+
+	default:
+		return "", nil, nil
+	}
+}
+
+func shopItemsToV1(shopItems []*models.ShopItem) []*ShopItemV1 {
+	items := make([]*ShopItemV1, 0, len(shopItems))
+	for _, item := range shopItems {
+		items = append(items, shopItemToV1(item))
+	}
+	return items
+}
+
+func shopItemToV1(item *models.ShopItem) *ShopItemV1 {
+	return &ShopItemV1{
+		ID:          item.ID,
+		Title:       item.Title,
+		Description: item.Description,
+		Quantity:    item.Quantity,
+		Price:       item.Price.Float64(),
+		Currency:    item.Price.Currency,
+	}
+}
@@ -0,0 +1,152 @@
+package models
+
+import (
+	"fmt"
+	"math"
+
+	orderService "github.com/AleksK1NG/es-microservice/proto/order"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultMinorUnits = 2
+)
+
+var (
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	ErrInvalidCurrency  = errors.New("invalid currency")
+	ErrAmountOverflow   = errors.New("money amount overflow")
+)
+
+// currencyMinorUnits ISO 4217 currencies with the number of minor units other than default 2.
+var currencyMinorUnits = map[string]int{
+	"BHD": 3,
+	"CLP": 0,
+	"ISK": 0,
+	"JOD": 3,
+	"JPY": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"OMR": 3,
+	"TND": 3,
+	"UGX": 0,
+	"VND": 0,
+}
+
+// Money exact amount in the currency minor units, like cents, with ISO 4217 currency code.
+type Money struct {
+	Amount   int64  `json:"amount" bson:"amount" validate:"gte=0"`
+	Currency string `json:"currency" bson:"currency" validate:"required,len=3,alpha"`
+}
+
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// NewMoneyFromFloat converts amount in the major units to Money rounding it to the currency minor units.
+func NewMoneyFromFloat(amount float64, currency string) Money {
+	return Money{Amount: int64(math.Round(amount * math.Pow10(MinorUnits(currency)))), Currency: currency}
+}
+
+// MinorUnits returns the number of the currency minor units.
+func MinorUnits(currency string) int {
+	if units, ok := currencyMinorUnits[currency]; ok {
+		return units
+	}
+	return defaultMinorUnits
+}
+
+// ValidateCurrency check is currency three upper case letters code.
+func ValidateCurrency(currency string) error {
+	if len(currency) != 3 {
+		return errors.Wrapf(ErrInvalidCurrency, "currency: {%s}", currency)
+	}
+	for _, r := range currency {
+		if r < 'A' || r > 'Z' {
+			return errors.Wrapf(ErrInvalidCurrency, "currency: {%s}", currency)
+		}
+	}
+	return nil
+}
+
+// IsZero check is Money empty, zero amount with the currency is not empty.
+func (m Money) IsZero() bool {
+	return m.Amount == 0 && m.Currency == ""
+}
+
+// Add returns sum of the same currency amounts, empty Money is added to any currency.
+func (m Money) Add(other Money) (Money, error) {
+	if m.IsZero() {
+		return other, nil
+	}
+	if other.IsZero() {
+		return m, nil
+	}
+	if m.Currency != other.Currency {
+		return Money{}, errors.Wrapf(ErrCurrencyMismatch, "%s and %s", m.Currency, other.Currency)
+	}
+	if (other.Amount > 0 && m.Amount > math.MaxInt64-other.Amount) || (other.Amount < 0 && m.Amount < math.MinInt64-other.Amount) {
+		return Money{}, errors.Wrapf(ErrAmountOverflow, "%s + %s", m, other)
+	}
+	return Money{Amount: m.Amount + other.Amount, Currency: m.Currency}, nil
+}
+
+// Sub returns difference of the same currency amounts, empty Money is subtracted from any currency.
+func (m Money) Sub(other Money) (Money, error) {
+	if other.IsZero() {
+		return m, nil
+	}
+	if other.Amount == math.MinInt64 {
+		return Money{}, errors.Wrapf(ErrAmountOverflow, "%s - %s", m, other)
+	}
+	return m.Add(Money{Amount: -other.Amount, Currency: other.Currency})
+}
+
+// Multiply returns amount multiplied by the quantity, ErrAmountOverflow if it doesn't fit into int64.
+func (m Money) Multiply(quantity uint64) (Money, error) {
+	if m.Amount == 0 || quantity == 0 {
+		return Money{Amount: 0, Currency: m.Currency}, nil
+	}
+
+	amount := m.Amount
+	if amount < 0 {
+		amount = -amount
+	}
+	if amount < 0 || quantity > uint64(math.MaxInt64/amount) {
+		return Money{}, errors.Wrapf(ErrAmountOverflow, "%s * %d", m, quantity)
+	}
+	return Money{Amount: m.Amount * int64(quantity), Currency: m.Currency}, nil
+}
+
+// Float64 returns amount in the major units, it is used only for the presentation.
+func (m Money) Float64() float64 {
+	return float64(m.Amount) / math.Pow10(MinorUnits(m.Currency))
+}
+
+func (m Money) String() string {
+	units := MinorUnits(m.Currency)
+	if units == 0 {
+		return fmt.Sprintf("%d %s", m.Amount, m.Currency)
+	}
+
+	sign, amount := "", m.Amount
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	divisor := int64(math.Pow10(units))
+	return fmt.Sprintf("%s%d.%0*d %s", sign, amount/divisor, units, amount%divisor, m.Currency)
+}
+
+func MoneyToProto(money Money) *orderService.Money {
+	return &orderService.Money{
+		Amount:   money.Amount,
+		Currency: money.Currency,
+	}
+}
+
+func MoneyFromProto(money *orderService.Money) Money {
+	return Money{
+		Amount:   money.GetAmount(),
+		Currency: money.GetCurrency(),
+	}
+}
@@ -0,0 +1,177 @@
+package models
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestMoneyAdd(t *testing.T) {
+	tests := []struct {
+		name  string
+		m     Money
+		other Money
+		want  Money
+		err   error
+	}{
+		{name: "same currency", m: NewMoney(150, "USD"), other: NewMoney(250, "USD"), want: NewMoney(400, "USD")},
+		{name: "empty to currency", m: Money{}, other: NewMoney(250, "EUR"), want: NewMoney(250, "EUR")},
+		{name: "currency to empty", m: NewMoney(150, "EUR"), other: Money{}, want: NewMoney(150, "EUR")},
+		{name: "zero amount keeps currency", m: NewMoney(0, "USD"), other: NewMoney(250, "EUR"), err: ErrCurrencyMismatch},
+		{name: "mixed currencies", m: NewMoney(150, "USD"), other: NewMoney(250, "EUR"), err: ErrCurrencyMismatch},
+		{name: "overflow", m: NewMoney(math.MaxInt64, "USD"), other: NewMoney(1, "USD"), err: ErrAmountOverflow},
+		{name: "negative overflow", m: NewMoney(math.MinInt64, "USD"), other: NewMoney(-1, "USD"), err: ErrAmountOverflow},
+		{name: "max amount", m: NewMoney(math.MaxInt64-1, "USD"), other: NewMoney(1, "USD"), want: NewMoney(math.MaxInt64, "USD")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.m.Add(tt.other)
+			if !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+				t.Fatalf("Add() err = %v, want %v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("Add() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneySub(t *testing.T) {
+	tests := []struct {
+		name  string
+		m     Money
+		other Money
+		want  Money
+		err   error
+	}{
+		{name: "same currency", m: NewMoney(400, "USD"), other: NewMoney(150, "USD"), want: NewMoney(250, "USD")},
+		{name: "below zero", m: NewMoney(100, "USD"), other: NewMoney(150, "USD"), want: NewMoney(-50, "USD")},
+		{name: "from empty", m: Money{}, other: NewMoney(150, "USD"), want: NewMoney(-150, "USD")},
+		{name: "empty", m: NewMoney(150, "USD"), other: Money{}, want: NewMoney(150, "USD")},
+		{name: "mixed currencies", m: NewMoney(400, "USD"), other: NewMoney(150, "EUR"), err: ErrCurrencyMismatch},
+		{name: "overflow", m: NewMoney(math.MinInt64, "USD"), other: NewMoney(1, "USD"), err: ErrAmountOverflow},
+		{name: "min amount negated", m: NewMoney(0, "USD"), other: NewMoney(math.MinInt64, "USD"), err: ErrAmountOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.m.Sub(tt.other)
+			if !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+				t.Fatalf("Sub() err = %v, want %v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("Sub() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyMultiply(t *testing.T) {
+	tests := []struct {
+		name     string
+		m        Money
+		quantity uint64
+		want     Money
+		err      error
+	}{
+		{name: "quantity", m: NewMoney(1999, "USD"), quantity: 3, want: NewMoney(5997, "USD")},
+		{name: "zero quantity", m: NewMoney(1999, "USD"), quantity: 0, want: NewMoney(0, "USD")},
+		{name: "zero amount huge quantity", m: NewMoney(0, "USD"), quantity: math.MaxUint64, want: NewMoney(0, "USD")},
+		{name: "negative amount", m: NewMoney(-250, "USD"), quantity: 2, want: NewMoney(-500, "USD")},
+		{name: "max amount", m: NewMoney(math.MaxInt64, "USD"), quantity: 1, want: NewMoney(math.MaxInt64, "USD")},
+		{name: "overflow", m: NewMoney(math.MaxInt64/2+1, "USD"), quantity: 2, err: ErrAmountOverflow},
+		{name: "quantity over int64", m: NewMoney(1, "USD"), quantity: math.MaxInt64 + 1, err: ErrAmountOverflow},
+		{name: "negative overflow", m: NewMoney(math.MinInt64, "USD"), quantity: 1, err: ErrAmountOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.m.Multiply(tt.quantity)
+			if !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+				t.Fatalf("Multiply() err = %v, want %v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("Multiply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewMoneyFromFloat(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   float64
+		currency string
+		want     int64
+	}{
+		{name: "cents", amount: 19.99, currency: "USD", want: 1999},
+		{name: "binary float rounding", amount: 0.29, currency: "EUR", want: 29},
+		{name: "half up", amount: 10.005, currency: "USD", want: 1001},
+		{name: "no minor units", amount: 1500.4, currency: "JPY", want: 1500},
+		{name: "no minor units half up", amount: 1500.5, currency: "JPY", want: 1501},
+		{name: "three minor units", amount: 1.2345, currency: "KWD", want: 1235},
+		{name: "negative", amount: -2.5, currency: "USD", want: -250},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewMoneyFromFloat(tt.amount, tt.currency)
+			if got.Amount != tt.want || got.Currency != tt.currency {
+				t.Errorf("NewMoneyFromFloat(%v, %s) = %v, want %d", tt.amount, tt.currency, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyMinorUnits(t *testing.T) {
+	tests := []struct {
+		money  Money
+		units  int
+		float  float64
+		string string
+	}{
+		{money: NewMoney(1999, "USD"), units: 2, float: 19.99, string: "19.99 USD"},
+		{money: NewMoney(-5, "EUR"), units: 2, float: -0.05, string: "-0.05 EUR"},
+		{money: NewMoney(1500, "JPY"), units: 0, float: 1500, string: "1500 JPY"},
+		{money: NewMoney(1005, "KWD"), units: 3, float: 1.005, string: "1.005 KWD"},
+		{money: NewMoney(7, "XYZ"), units: 2, float: 0.07, string: "0.07 XYZ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.string, func(t *testing.T) {
+			if units := MinorUnits(tt.money.Currency); units != tt.units {
+				t.Errorf("MinorUnits(%s) = %d, want %d", tt.money.Currency, units, tt.units)
+			}
+			if f := tt.money.Float64(); f != tt.float {
+				t.Errorf("Float64() = %v, want %v", f, tt.float)
+			}
+			if s := tt.money.String(); s != tt.string {
+				t.Errorf("String() = %q, want %q", s, tt.string)
+			}
+		})
+	}
+}
+
+func TestValidateCurrency(t *testing.T) {
+	tests := []struct {
+		currency string
+		err      error
+	}{
+		{currency: "USD"},
+		{currency: "usd", err: ErrInvalidCurrency},
+		{currency: "US", err: ErrInvalidCurrency},
+		{currency: "USDT", err: ErrInvalidCurrency},
+		{currency: "U5D", err: ErrInvalidCurrency},
+		{currency: "", err: ErrInvalidCurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.currency, func(t *testing.T) {
+			if err := ValidateCurrency(tt.currency); !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+				t.Errorf("ValidateCurrency(%q) err = %v, want %v", tt.currency, err, tt.err)
+			}
+		})
+	}
+}
@@ -10,60 +10,89 @@ import (
 
 type Order struct {
 	ID              string      `json:"id" bson:"_id,omitempty"`
+	TenantID        string      `json:"tenantId,omitempty" bson:"tenantId,omitempty"`
 	ShopItems       []*ShopItem `json:"shopItems" bson:"shopItems,omitempty"`
 	AccountEmail    string      `json:"accountEmail" bson:"accountEmail,omitempty"`
 	DeliveryAddress string      `json:"deliveryAddress" bson:"deliveryAddress,omitempty"`
 	CancelReason    string      `json:"cancelReason" bson:"cancelReason,omitempty"`
-	TotalPrice      float64     `json:"totalPrice" bson:"totalPrice,omitempty"`
+	TotalPrice      Money       `json:"totalPrice" bson:"totalPrice,omitempty"`
 	DeliveredTime   time.Time   `json:"deliveredTime" bson:"deliveredTime,omitempty"`
-	Paid            bool        `json:"paid" bson:"paid,omitempty"`
-	Submitted       bool        `json:"submitted" bson:"submitted,omitempty"`
-	Completed       bool        `json:"completed" bson:"completed,omitempty"`
-	Canceled        bool        `json:"canceled" bson:"canceled,omitempty"`
+	CreatedAt       time.Time   `json:"createdAt" bson:"createdAt,omitempty"`
+	Status          OrderStatus `json:"status" bson:"status,omitempty"`
 	Payment         Payment     `json:"payment" bson:"payment,omitempty"`
+	PaidAmount      Money       `json:"paidAmount" bson:"paidAmount,omitempty"`
+	RefundedAmount  Money       `json:"refundedAmount" bson:"refundedAmount,omitempty"`
+	Refunds         []*Refund   `json:"refunds" bson:"refunds,omitempty"`
 }
 
 func (o *Order) String() string {
-	return fmt.Sprintf("ID: {%s}, ShopItems: {%+v}, Paid: {%v}, Submitted: {%v}, "+
-		"Completed: {%v}, Canceled: {%v}, CancelReason: {%s}, TotalPrice: {%v}, AccountEmail: {%s}, DeliveryAddress: {%s}, DeliveredTime: {%s}, Payment: {%s}",
+	return fmt.Sprintf("ID: {%s}, TenantID: {%s}, ShopItems: {%+v}, Status: {%s}, "+
+		"CancelReason: {%s}, TotalPrice: {%v}, AccountEmail: {%s}, DeliveryAddress: {%s}, DeliveredTime: {%s}, Payment: {%s}, "+
+		"PaidAmount: {%v}, RefundedAmount: {%v}, Refunds: {%+v}",
 		o.ID,
+		o.TenantID,
 		o.ShopItems,
-		o.Paid,
-		o.Submitted,
-		o.Completed,
-		o.Canceled,
+		o.Status,
 		o.CancelReason,
 		o.TotalPrice,
 		o.AccountEmail,
 		o.DeliveryAddress,
 		o.DeliveredTime.UTC().String(),
 		o.Payment.String(),
+		o.PaidAmount,
+		o.RefundedAmount,
+		o.Refunds,
 	)
 }
 
+// RemainingRefundAmount returns the paid amount which is not refunded yet.
+func (o *Order) RemainingRefundAmount() (Money, error) {
+	return o.PaidAmount.Sub(o.RefundedAmount)
+}
+
 func NewOrder() *Order {
 	return &Order{
 		ShopItems: make([]*ShopItem, 0),
-		Paid:      false,
-		Submitted: false,
-		Completed: false,
-		Canceled:  false,
+		Refunds:   make([]*Refund, 0),
+		Status:    OrderStatusNew,
 	}
 }
 
+// IsPaid check was the order paid, the order stays paid after cancel.
+func (o *Order) IsPaid() bool {
+	return o.Payment.PaymentID != ""
+}
+
+func (o *Order) IsSubmitted() bool {
+	return o.Status == OrderStatusSubmitted || o.Status == OrderStatusCompleted
+}
+
+func (o *Order) IsCompleted() bool {
+	return o.Status == OrderStatusCompleted
+}
+
+func (o *Order) IsCanceled() bool {
+	return o.Status == OrderStatusCanceled
+}
+
 func OrderToProto(order *Order, id string) *orderService.Order {
 	return &orderService.Order{
 		ID:                id,
+		TenantID:          order.TenantID,
 		ShopItems:         ShopItemsToProto(order.ShopItems),
-		Paid:              order.Paid,
-		Submitted:         order.Submitted,
-		Completed:         order.Completed,
-		Canceled:          order.Canceled,
+		Status:            order.Status.String(),
+		Paid:              order.IsPaid(),
+		Submitted:         order.IsSubmitted(),
+		Completed:         order.IsCompleted(),
+		Canceled:          order.IsCanceled(),
 		CancelReason:      order.CancelReason,
 		DeliveryTimestamp: timestamppb.New(order.DeliveredTime),
+		CreatedAt:         timestamppb.New(order.CreatedAt),
 		DeliveryAddress:   order.DeliveryAddress,
 		AccountEmail:      order.AccountEmail,
-		TotalPrice:        order.TotalPrice,
+		TotalPrice:        MoneyToProto(order.TotalPrice),
 		Payment:           PaymentToProto(order.Payment),
+		RefundedAmount:    MoneyToProto(order.RefundedAmount),
+		Refunds:           RefundsToProto(order.Refunds),
 	}
 }
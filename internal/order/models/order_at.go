@@ -0,0 +1,13 @@
+package models
+
+import (
+	"time"
+)
+
+// OrderAt order state reconstructed from the events up to the requested version or timestamp,
+// Version and Timestamp are of the last applied event.
+type OrderAt struct {
+	Order     *Order    `json:"order"`
+	Version   int64     `json:"version"`
+	Timestamp time.Time `json:"timestamp"`
+}
@@ -11,24 +11,41 @@ import (
 type OrderProjection struct {
 	ID              string      `json:"id" bson:"_id,omitempty"`
 	OrderID         string      `json:"orderId,omitempty" bson:"orderId,omitempty"`
+	TenantID        string      `json:"tenantId,omitempty" bson:"tenantId,omitempty"`
 	ShopItems       []*ShopItem `json:"shopItems,omitempty" bson:"shopItems,omitempty"`
 	AccountEmail    string      `json:"accountEmail,omitempty" bson:"accountEmail,omitempty" validate:"required,email"`
 	DeliveryAddress string      `json:"deliveryAddress,omitempty" bson:"deliveryAddress,omitempty"`
 	CancelReason    string      `json:"cancelReason,omitempty" bson:"cancelReason,omitempty"`
-	TotalPrice      float64     `json:"totalPrice,omitempty" bson:"totalPrice,omitempty"`
+	TotalPrice      Money       `json:"totalPrice,omitempty" bson:"totalPrice,omitempty"`
 	DeliveredTime   time.Time   `json:"deliveredTime,omitempty" bson:"deliveredTime,omitempty"`
+	CreatedAt       time.Time   `json:"createdAt,omitempty" bson:"createdAt,omitempty"`
 	Paid            bool        `json:"paid,omitempty" bson:"paid,omitempty"`
 	Submitted       bool        `json:"submitted,omitempty" bson:"submitted,omitempty"`
 	Completed       bool        `json:"completed,omitempty" bson:"completed,omitempty"`
 	Canceled        bool        `json:"canceled,omitempty" bson:"canceled,omitempty"`
+	Status          OrderStatus `json:"status,omitempty" bson:"status,omitempty"`
 	Payment         Payment     `json:"payment,omitempty" bson:"payment,omitempty"`
+	RefundedAmount  Money       `json:"refundedAmount,omitempty" bson:"refundedAmount,omitempty"`
+	Refunds         []*Refund   `json:"refunds,omitempty" bson:"refunds,omitempty"`
+	// Version of the last order event applied to the projection.
+	Version int64 `json:"version" bson:"version"`
+}
+
+// AccountOrdersPage page of the account orders with the cursor of the next page, it is empty on the last page.
+type AccountOrdersPage struct {
+	Orders     []*OrderProjection
+	TotalCount int64
+	NextCursor string
 }
 
 func (o *OrderProjection) String() string {
-	return fmt.Sprintf("ID: {%s}, ShopItems: {%+v}, Paid: {%v}, Submitted: {%v}, "+
-		"Completed: {%v}, Canceled: {%v}, CancelReason: {%s}, TotalPrice: {%v}, AccountEmail: {%s}, DeliveryAddress: {%s}, DeliveredTime: {%s}, Payment: {%s}",
+	return fmt.Sprintf("ID: {%s}, TenantID: {%s}, ShopItems: {%+v}, Status: {%s}, Paid: {%v}, Submitted: {%v}, "+
+		"Completed: {%v}, Canceled: {%v}, CancelReason: {%s}, TotalPrice: {%v}, AccountEmail: {%s}, DeliveryAddress: {%s}, DeliveredTime: {%s}, Payment: {%s}, "+
+		"RefundedAmount: {%v}, Refunds: {%+v}",
 		o.ID,
+		o.TenantID,
 		o.ShopItems,
+		o.Status,
 		o.Paid,
 		o.Submitted,
 		o.Completed,
@@ -39,23 +56,30 @@ func (o *OrderProjection) String() string {
 		o.DeliveryAddress,
 		o.DeliveredTime.UTC().String(),
 		o.Payment.String(),
+		o.RefundedAmount,
+		o.Refunds,
 	)
 }
 
 func OrderProjectionToProto(order *OrderProjection) *orderService.Order {
 	return &orderService.Order{
 		ID:                order.OrderID,
+		TenantID:          order.TenantID,
 		ShopItems:         ShopItemsToProto(order.ShopItems),
+		Status:            order.Status.String(),
 		Paid:              order.Paid,
 		Submitted:         order.Submitted,
 		Completed:         order.Completed,
 		Canceled:          order.Canceled,
-		TotalPrice:        order.TotalPrice,
+		TotalPrice:        MoneyToProto(order.TotalPrice),
 		AccountEmail:      order.AccountEmail,
 		CancelReason:      order.CancelReason,
 		DeliveryTimestamp: timestamppb.New(order.DeliveredTime),
+		CreatedAt:         timestamppb.New(order.CreatedAt),
 		DeliveryAddress:   order.DeliveryAddress,
 		Payment:           PaymentToProto(order.Payment),
+		RefundedAmount:    MoneyToProto(order.RefundedAmount),
+		Refunds:           RefundsToProto(order.Refunds),
 	}
 }
 
@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// Fields the orders search results can be sorted by, the order is ascending by default or set by :asc and :desc suffix.
+const (
+	OrderSortCreatedAt     = "createdAt"
+	OrderSortDeliveredTime = "deliveredTime"
+	OrderSortTotalPrice    = "totalPrice"
+	OrderSortStatus        = "status"
+	OrderSortAccountEmail  = "accountEmail"
+
+	OrderSortAsc  = "asc"
+	OrderSortDesc = "desc"
+)
+
+// OrderSearchFilter filters of the orders search, empty and nil filters match all orders,
+// the ranges bounds are inclusive and the total price is in the currency minor units.
+type OrderSearchFilter struct {
+	TenantID      string
+	AccountEmail  string
+	Text          string
+	Statuses      []OrderStatus
+	Currency      string
+	MinTotalPrice *int64
+	MaxTotalPrice *int64
+	CreatedFrom   *time.Time
+	CreatedTo     *time.Time
+	DeliveredFrom *time.Time
+	DeliveredTo   *time.Time
+}
+
+// OrderSearchFacets aggregations of all orders matching the search filters.
+type OrderSearchFacets struct {
+	// Statuses number of the orders per status, it isn't narrowed by the statuses filter.
+	Statuses []FacetBucket
+	// TotalPrice histogram of the orders total prices, the buckets without orders are omitted.
+	TotalPrice []HistogramBucket
+}
+
+type FacetBucket struct {
+	Key   string
+	Count int64
+}
+
+// HistogramBucket number of the values from From until From plus the histogram interval.
+type HistogramBucket struct {
+	From  int64
+	Count int64
+}
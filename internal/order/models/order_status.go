@@ -0,0 +1,54 @@
+package models
+
+import "github.com/pkg/errors"
+
+var (
+	ErrUnknownOrderStatus = errors.New("unknown order status")
+)
+
+// OrderStatus lifecycle status of the order, allowed transitions are declared by the order aggregate.
+type OrderStatus string
+
+const (
+	OrderStatusNew       OrderStatus = "new"
+	OrderStatusPending   OrderStatus = "pending"
+	OrderStatusPaid      OrderStatus = "paid"
+	OrderStatusSubmitted OrderStatus = "submitted"
+	OrderStatusCompleted OrderStatus = "completed"
+	OrderStatusCanceled  OrderStatus = "canceled"
+)
+
+// orderStatuses all known order statuses, the filters by the other statuses are rejected.
+var orderStatuses = map[OrderStatus]struct{}{
+	OrderStatusNew:       {},
+	OrderStatusPending:   {},
+	OrderStatusPaid:      {},
+	OrderStatusSubmitted: {},
+	OrderStatusCompleted: {},
+	OrderStatusCanceled:  {},
+}
+
+func (s OrderStatus) String() string {
+	return string(s)
+}
+
+// OrderStatusFromString returns ErrUnknownOrderStatus if the status isn't one of the order statuses.
+func OrderStatusFromString(status string) (OrderStatus, error) {
+	if _, ok := orderStatuses[OrderStatus(status)]; !ok {
+		return "", errors.Wrapf(ErrUnknownOrderStatus, "status: {%s}", status)
+	}
+	return OrderStatus(status), nil
+}
+
+// OrderStatusesFromStrings returns ErrUnknownOrderStatus if any of the statuses isn't one of the order statuses.
+func OrderStatusesFromStrings(statuses []string) ([]OrderStatus, error) {
+	result := make([]OrderStatus, 0, len(statuses))
+	for _, status := range statuses {
+		orderStatus, err := OrderStatusFromString(status)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, orderStatus)
+	}
+	return result, nil
+}
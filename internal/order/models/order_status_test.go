@@ -0,0 +1,37 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestOrderStatusesFromStrings(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []string
+		want     []OrderStatus
+		err      error
+	}{
+		{name: "no statuses", want: []OrderStatus{}},
+		{name: "single status", statuses: []string{"paid"}, want: []OrderStatus{OrderStatusPaid}},
+		{name: "all statuses", statuses: []string{"new", "pending", "paid", "submitted", "completed", "canceled"}, want: []OrderStatus{OrderStatusNew, OrderStatusPending, OrderStatusPaid, OrderStatusSubmitted, OrderStatusCompleted, OrderStatusCanceled}},
+		{name: "unknown status", statuses: []string{"shipped"}, err: ErrUnknownOrderStatus},
+		{name: "unknown among known", statuses: []string{"paid", "refunded"}, err: ErrUnknownOrderStatus},
+		{name: "case mismatch", statuses: []string{"PAID"}, err: ErrUnknownOrderStatus},
+		{name: "empty status", statuses: []string{""}, err: ErrUnknownOrderStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := OrderStatusesFromStrings(tt.statuses)
+			if !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+				t.Fatalf("OrderStatusesFromStrings() err = %v, want %v", err, tt.err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("OrderStatusesFromStrings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
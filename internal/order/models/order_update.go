@@ -0,0 +1,12 @@
+package models
+
+import (
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+)
+
+// OrderUpdate the committed order event and the order state after applying it,
+// Order is changed by the next events, so it is valid only until the update handler returns.
+type OrderUpdate struct {
+	Event es.Event `json:"event"`
+	Order *Order   `json:"order"`
+}
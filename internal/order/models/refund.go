@@ -0,0 +1,37 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	orderService "github.com/AleksK1NG/es-microservice/proto/order"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type Refund struct {
+	RefundID   string    `json:"refundId" bson:"refundId,omitempty"`
+	Amount     Money     `json:"amount" bson:"amount,omitempty"`
+	Reason     string    `json:"reason" bson:"reason,omitempty"`
+	RefundedAt time.Time `json:"refundedAt" bson:"refundedAt,omitempty"`
+}
+
+func (r *Refund) String() string {
+	return fmt.Sprintf("RefundID: {%s}, Amount: {%v}, Reason: {%s}, RefundedAt: {%s}", r.RefundID, r.Amount, r.Reason, r.RefundedAt.UTC().String())
+}
+
+func RefundToProto(refund *Refund) *orderService.Refund {
+	return &orderService.Refund{
+		RefundID:   refund.RefundID,
+		Amount:     MoneyToProto(refund.Amount),
+		Reason:     refund.Reason,
+		RefundedAt: timestamppb.New(refund.RefundedAt),
+	}
+}
+
+func RefundsToProto(refunds []*Refund) []*orderService.Refund {
+	result := make([]*orderService.Refund, 0, len(refunds))
+	for _, refund := range refunds {
+		result = append(result, RefundToProto(refund))
+	}
+	return result
+}
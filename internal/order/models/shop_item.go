@@ -6,11 +6,11 @@ import (
 )
 
 type ShopItem struct {
-	ID          string  `json:"id" bson:"id,omitempty"`
-	Title       string  `json:"title" bson:"title,omitempty"`
-	Description string  `json:"description" bson:"description,omitempty"`
-	Quantity    uint64  `json:"quantity" bson:"quantity,omitempty"`
-	Price       float64 `json:"price" bson:"price,omitempty"`
+	ID          string `json:"id" bson:"id,omitempty" validate:"required"`
+	Title       string `json:"title" bson:"title,omitempty"`
+	Description string `json:"description" bson:"description,omitempty"`
+	Quantity    uint64 `json:"quantity" bson:"quantity,omitempty" validate:"gt=0"`
+	Price       Money  `json:"price" bson:"price,omitempty"`
 }
 
 func (s *ShopItem) String() string {
@@ -29,7 +29,7 @@ func (s *ShopItem) ToProto() *orderService.ShopItem {
 		Title:       s.Title,
 		Description: s.Description,
 		Quantity:    s.Quantity,
-		Price:       s.Price,
+		Price:       MoneyToProto(s.Price),
 	}
 }
 
@@ -39,7 +39,7 @@ func ShopItemToProto(shopItem *ShopItem) *orderService.ShopItem {
 		Title:       shopItem.Title,
 		Description: shopItem.Description,
 		Quantity:    shopItem.Quantity,
-		Price:       shopItem.Price,
+		Price:       MoneyToProto(shopItem.Price),
 	}
 }
 
@@ -49,7 +49,7 @@ func ShopItemFromProto(shopItem *orderService.ShopItem) *ShopItem {
 		Title:       shopItem.Title,
 		Description: shopItem.Description,
 		Quantity:    shopItem.Quantity,
-		Price:       shopItem.Price,
+		Price:       MoneyFromProto(shopItem.GetPrice()),
 	}
 }
 
@@ -0,0 +1,123 @@
+package dead_letters
+
+import (
+	"context"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
+	"github.com/AleksK1NG/es-microservice/pkg/utils"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var (
+	ErrUnknownGroupName = errors.New("unknown projection group name")
+)
+
+// DeadLetterService manages events parked by the projections.
+type DeadLetterService interface {
+	// List returns page of the dead letters of the projection group, all groups if groupName is empty,
+	// the page is loaded by the offset or continues from the position of the pagination cursor.
+	List(ctx context.Context, groupName string, pq *utils.Pagination) (*DeadLettersPage, error)
+	// Get returns dead letter by id.
+	Get(ctx context.Context, id string) (*es.DeadLetter, error)
+	// Replay applies dead letter event to its projection and deletes it on success.
+	Replay(ctx context.Context, id string) error
+	// Discard deletes dead letter without processing.
+	Discard(ctx context.Context, id string) error
+}
+
+// DeadLettersPage dead letters with the cursor of the next page, it is empty on the last page.
+type DeadLettersPage struct {
+	DeadLetters []es.DeadLetter
+	TotalCount  int64
+	NextCursor  string
+}
+
+// deadLettersCursor position of the next dead letters page encoded into the pagination cursor.
+type deadLettersCursor struct {
+	After es.DeadLetterPosition `json:"after"`
+	Page  int                   `json:"page"`
+}
+
+type deadLetterService struct {
+	log         logger.Logger
+	cursors     *utils.CursorCodec
+	deadLetters es.DeadLetterStore
+	projections map[string]es.Projection
+}
+
+// NewDeadLetterService dead letters service, projections are the group name to projection map used to replay events.
+func NewDeadLetterService(log logger.Logger, cursors *utils.CursorCodec, deadLetters es.DeadLetterStore, projections map[string]es.Projection) *deadLetterService {
+	return &deadLetterService{log: log, cursors: cursors, deadLetters: deadLetters, projections: projections}
+}
+
+func (s *deadLetterService) List(ctx context.Context, groupName string, pq *utils.Pagination) (*DeadLettersPage, error) {
+	var after *es.DeadLetterPosition
+	if pq.GetCursor() != "" {
+		var cursor deadLettersCursor
+		if err := s.cursors.Decode(pq.GetCursor(), &cursor); err != nil {
+			return nil, err
+		}
+		after = &cursor.After
+		pq.Page = cursor.Page
+	}
+
+	deadLetters, totalCount, err := s.deadLetters.ListDeadLetters(ctx, groupName, after, int64(pq.GetOffset()), int64(pq.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+
+	page := &DeadLettersPage{DeadLetters: deadLetters, TotalCount: totalCount}
+	if len(deadLetters) > 0 && int64(pq.GetOffset()+len(deadLetters)) < totalCount {
+		last := deadLetters[len(deadLetters)-1]
+		page.NextCursor, err = s.cursors.Encode(deadLettersCursor{
+			After: es.DeadLetterPosition{ParkedAt: last.ParkedAt, ID: last.ID},
+			Page:  pq.GetPage() + 1,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+func (s *deadLetterService) Get(ctx context.Context, id string) (*es.DeadLetter, error) {
+	return s.deadLetters.GetDeadLetter(ctx, id)
+}
+
+func (s *deadLetterService) Replay(ctx context.Context, id string) error {
+	ctx, span := tracing.StartSpan(ctx, "deadLetterService.Replay")
+	defer span.End()
+	span.SetAttributes(attribute.String("ID", id))
+
+	deadLetter, err := s.deadLetters.GetDeadLetter(ctx, id)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	projection, ok := s.projections[deadLetter.GroupName]
+	if !ok {
+		return errors.Wrapf(ErrUnknownGroupName, "groupName: {%s}", deadLetter.GroupName)
+	}
+
+	if err := projection.When(ctx, deadLetter.Event); err != nil {
+		tracing.TraceErr(span, err)
+		deadLetter.Attempts++
+		deadLetter.Error = err.Error()
+		if err := s.deadLetters.SaveDeadLetter(ctx, *deadLetter); err != nil {
+			s.log.Errorf("(SaveDeadLetter) id: {%s}, err: {%v}", id, err)
+		}
+		return errors.Wrap(err, "projection.When")
+	}
+
+	s.log.Infof("(Replay) dead letter id: {%s}, EventType: {%s} replayed", id, deadLetter.Event.GetEventType())
+	return s.deadLetters.DeleteDeadLetter(ctx, id)
+}
+
+func (s *deadLetterService) Discard(ctx context.Context, id string) error {
+	s.log.Infof("(Discard) dead letter id: {%s}", id)
+	return s.deadLetters.DeleteDeadLetter(ctx, id)
+}
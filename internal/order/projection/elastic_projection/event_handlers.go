@@ -5,94 +5,101 @@ import (
 
 	"github.com/AleksK1NG/es-microservice/internal/order/aggregate"
 	"github.com/AleksK1NG/es-microservice/internal/order/events/v1"
+	"github.com/AleksK1NG/es-microservice/internal/order/events/v2"
 	"github.com/AleksK1NG/es-microservice/internal/order/models"
+	"github.com/AleksK1NG/es-microservice/internal/order/repository"
 	"github.com/AleksK1NG/es-microservice/pkg/es"
 	"github.com/AleksK1NG/es-microservice/pkg/tracing"
-	"github.com/opentracing/opentracing-go"
-	"github.com/opentracing/opentracing-go/log"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 func (o *elasticProjection) onOrderCreate(ctx context.Context, evt es.Event) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticProjection.onOrderCreate")
-	defer span.Finish()
-	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+	ctx, span := tracing.StartSpan(ctx, "elasticProjection.onOrderCreate")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", evt.GetAggregateID()))
 
-	var eventData v1.OrderCreatedEvent
+	var eventData v2.OrderCreatedEvent
 	if err := evt.GetJsonData(&eventData); err != nil {
 		tracing.TraceErr(span, err)
 		return errors.Wrap(err, "evt.GetJsonData")
 	}
 
+	totalPrice, err := aggregate.GetShopItemsTotalPrice(eventData.ShopItems)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
 	op := &models.OrderProjection{
 		OrderID:      aggregate.GetOrderAggregateID(evt.AggregateID),
+		TenantID:     aggregate.GetOrderTenantID(evt.AggregateID),
+		Version:      evt.GetVersion(),
 		ShopItems:    eventData.ShopItems,
 		AccountEmail: eventData.AccountEmail,
-		TotalPrice:   aggregate.GetShopItemsTotalPrice(eventData.ShopItems),
+		TotalPrice:   totalPrice,
+		Status:       models.OrderStatusPending,
+		CreatedAt:    evt.GetTimeStamp(),
 	}
 
 	return o.elasticRepository.IndexOrder(ctx, op)
 }
 
 func (o *elasticProjection) onOrderPaid(ctx context.Context, evt es.Event) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticProjection.onOrderPaid")
-	defer span.Finish()
-	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+	ctx, span := tracing.StartSpan(ctx, "elasticProjection.onOrderPaid")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", evt.GetAggregateID()))
 
 	var payment models.Payment
 	if err := evt.GetJsonData(&payment); err != nil {
 		return errors.Wrap(err, "GetJsonData")
 	}
 
-	projection, err := o.elasticRepository.GetByID(ctx, aggregate.GetOrderAggregateID(evt.AggregateID))
-	if err != nil {
-		return err
-	}
-	projection.Paid = true
-	projection.Payment = payment
-
-	return o.elasticRepository.UpdateOrder(ctx, projection)
+	return o.updateOrder(ctx, evt, func(projection *models.OrderProjection) {
+		projection.Paid = true
+		projection.Payment = payment
+		projection.Status = models.OrderStatusPaid
+	})
 }
 
 func (o *elasticProjection) onSubmit(ctx context.Context, evt es.Event) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticProjection.onSubmit")
-	defer span.Finish()
-	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
-
-	projection, err := o.elasticRepository.GetByID(ctx, aggregate.GetOrderAggregateID(evt.AggregateID))
-	if err != nil {
-		return err
-	}
-	projection.Submitted = true
-
-	return o.elasticRepository.UpdateOrder(ctx, projection)
+	ctx, span := tracing.StartSpan(ctx, "elasticProjection.onSubmit")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", evt.GetAggregateID()))
+
+	return o.updateOrder(ctx, evt, func(projection *models.OrderProjection) {
+		projection.Submitted = true
+		projection.Status = models.OrderStatusSubmitted
+	})
 }
 
 func (o *elasticProjection) onShoppingCartUpdate(ctx context.Context, evt es.Event) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticProjection.onShoppingCartUpdate")
-	defer span.Finish()
-	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+	ctx, span := tracing.StartSpan(ctx, "elasticProjection.onShoppingCartUpdate")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", evt.GetAggregateID()))
 
-	var eventData v1.ShoppingCartUpdatedEvent
+	var eventData v2.ShoppingCartUpdatedEvent
 	if err := evt.GetJsonData(&eventData); err != nil {
 		tracing.TraceErr(span, err)
 		return errors.Wrap(err, "evt.GetJsonData")
 	}
 
-	projection, err := o.elasticRepository.GetByID(ctx, aggregate.GetOrderAggregateID(evt.AggregateID))
+	totalPrice, err := aggregate.GetShopItemsTotalPrice(eventData.ShopItems)
 	if err != nil {
+		tracing.TraceErr(span, err)
 		return err
 	}
-	projection.ShopItems = eventData.ShopItems
-	projection.TotalPrice = aggregate.GetShopItemsTotalPrice(eventData.ShopItems)
 
-	return o.elasticRepository.UpdateOrder(ctx, projection)
+	return o.updateOrder(ctx, evt, func(projection *models.OrderProjection) {
+		projection.ShopItems = eventData.ShopItems
+		projection.TotalPrice = totalPrice
+	})
 }
 
 func (o *elasticProjection) onCancel(ctx context.Context, evt es.Event) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticProjection.onCancel")
-	defer span.Finish()
-	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+	ctx, span := tracing.StartSpan(ctx, "elasticProjection.onCancel")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", evt.GetAggregateID()))
 
 	var eventData v1.OrderCanceledEvent
 	if err := evt.GetJsonData(&eventData); err != nil {
@@ -100,21 +107,18 @@ func (o *elasticProjection) onCancel(ctx context.Context, evt es.Event) error {
 		return errors.Wrap(err, "evt.GetJsonData")
 	}
 
-	projection, err := o.elasticRepository.GetByID(ctx, aggregate.GetOrderAggregateID(evt.AggregateID))
-	if err != nil {
-		return err
-	}
-	projection.Canceled = true
-	projection.Completed = false
-	projection.CancelReason = eventData.CancelReason
-
-	return o.elasticRepository.UpdateOrder(ctx, projection)
+	return o.updateOrder(ctx, evt, func(projection *models.OrderProjection) {
+		projection.Canceled = true
+		projection.Completed = false
+		projection.CancelReason = eventData.CancelReason
+		projection.Status = models.OrderStatusCanceled
+	})
 }
 
 func (o *elasticProjection) onComplete(ctx context.Context, evt es.Event) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticProjection.onComplete")
-	defer span.Finish()
-	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+	ctx, span := tracing.StartSpan(ctx, "elasticProjection.onComplete")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", evt.GetAggregateID()))
 
 	var eventData v1.OrderCompletedEvent
 	if err := evt.GetJsonData(&eventData); err != nil {
@@ -122,20 +126,17 @@ func (o *elasticProjection) onComplete(ctx context.Context, evt es.Event) error
 		return errors.Wrap(err, "evt.GetJsonData")
 	}
 
-	projection, err := o.elasticRepository.GetByID(ctx, aggregate.GetOrderAggregateID(evt.AggregateID))
-	if err != nil {
-		return err
-	}
-	projection.Completed = true
-	projection.DeliveredTime = eventData.DeliveryTimestamp
-
-	return o.elasticRepository.UpdateOrder(ctx, projection)
+	return o.updateOrder(ctx, evt, func(projection *models.OrderProjection) {
+		projection.Completed = true
+		projection.DeliveredTime = eventData.DeliveryTimestamp
+		projection.Status = models.OrderStatusCompleted
+	})
 }
 
 func (o *elasticProjection) onDeliveryAddressChnaged(ctx context.Context, evt es.Event) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticProjection.onDeliveryAddressChnaged")
-	defer span.Finish()
-	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+	ctx, span := tracing.StartSpan(ctx, "elasticProjection.onDeliveryAddressChnaged")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", evt.GetAggregateID()))
 
 	var eventData v1.OrderDeliveryAddressChangedEvent
 	if err := evt.GetJsonData(&eventData); err != nil {
@@ -143,12 +144,107 @@ func (o *elasticProjection) onDeliveryAddressChnaged(ctx context.Context, evt es
 		return errors.Wrap(err, "evt.GetJsonData")
 	}
 
-	projection, err := o.elasticRepository.GetByID(ctx, aggregate.GetOrderAggregateID(evt.AggregateID))
+	return o.updateOrder(ctx, evt, func(projection *models.OrderProjection) {
+		projection.DeliveryAddress = eventData.DeliveryAddress
+	})
+
+}
+
+func (o *elasticProjection) onShopItemAdded(ctx context.Context, evt es.Event) error {
+	ctx, span := tracing.StartSpan(ctx, "elasticProjection.onShopItemAdded")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData v2.ShopItemAddedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	return o.updateOrder(ctx, evt, func(projection *models.OrderProjection) {
+		projection.ShopItems = append(projection.ShopItems, eventData.ShopItem)
+		projection.TotalPrice = eventData.TotalPrice
+	})
+}
+
+func (o *elasticProjection) onShopItemRemoved(ctx context.Context, evt es.Event) error {
+	ctx, span := tracing.StartSpan(ctx, "elasticProjection.onShopItemRemoved")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData v2.ShopItemRemovedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	return o.updateOrder(ctx, evt, func(projection *models.OrderProjection) {
+		shopItems := make([]*models.ShopItem, 0, len(projection.ShopItems))
+		for _, item := range projection.ShopItems {
+			if item.ID != eventData.ShopItemID {
+				shopItems = append(shopItems, item)
+			}
+		}
+		projection.ShopItems = shopItems
+		projection.TotalPrice = eventData.TotalPrice
+	})
+}
+
+func (o *elasticProjection) onShopItemQuantityChanged(ctx context.Context, evt es.Event) error {
+	ctx, span := tracing.StartSpan(ctx, "elasticProjection.onShopItemQuantityChanged")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData v2.ShopItemQuantityChangedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	return o.updateOrder(ctx, evt, func(projection *models.OrderProjection) {
+		for _, item := range projection.ShopItems {
+			if item.ID == eventData.ShopItemID {
+				item.Quantity = eventData.Quantity
+			}
+		}
+		projection.TotalPrice = eventData.TotalPrice
+	})
+}
+
+func (o *elasticProjection) onOrderRefunded(ctx context.Context, evt es.Event) error {
+	ctx, span := tracing.StartSpan(ctx, "elasticProjection.onOrderRefunded")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData v2.OrderRefundedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	return o.updateOrder(ctx, evt, func(projection *models.OrderProjection) {
+		projection.Refunds = append(projection.Refunds, eventData.Refund)
+		projection.RefundedAmount = eventData.RefundedAmount
+	})
+}
+
+// updateOrder applies the event to the projection of the order with the previous event applied and saves it with the event version,
+// the projection is saved only if no other worker has applied the event meanwhile, see ElasticOrderRepository.UpdateOrder.
+// It is nil if the event is already applied and repository.ErrOrderEventsPending if the previous events are not applied yet.
+func (o *elasticProjection) updateOrder(ctx context.Context, evt es.Event, apply func(projection *models.OrderProjection)) error {
+	projection, err := o.elasticRepository.GetByID(ctx, aggregate.GetOrderTenantID(evt.AggregateID), aggregate.GetOrderAggregateID(evt.AggregateID))
 	if err != nil {
 		return err
 	}
-	projection.DeliveryAddress = eventData.DeliveryAddress
+	if projection.Version >= evt.GetVersion() {
+		o.log.Debugf("(updateOrder) already applied OrderID: {%s}, version: {%d}, projected: {%d}", projection.OrderID, evt.GetVersion(), projection.Version)
+		return nil
+	}
+	if projection.Version < evt.GetVersion()-1 {
+		return errors.Wrapf(repository.ErrOrderEventsPending, "OrderID: {%s}, version: {%d}, projected: {%d}", projection.OrderID, evt.GetVersion(), projection.Version)
+	}
 
+	apply(projection)
+	projection.Version = evt.GetVersion()
 	return o.elasticRepository.UpdateOrder(ctx, projection)
-
 }
@@ -0,0 +1,310 @@
+package elastic_projection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AleksK1NG/es-microservice/internal/dto"
+	"github.com/AleksK1NG/es-microservice/internal/order/aggregate"
+	"github.com/AleksK1NG/es-microservice/internal/order/models"
+	"github.com/AleksK1NG/es-microservice/internal/order/repository"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/utils"
+	"github.com/pkg/errors"
+)
+
+const testOrderID = "8c4f2b1e"
+
+// ordersRepository in memory orders index, the order is updated only if its version is the previous one as by the update script.
+type ordersRepository struct {
+	mu     sync.Mutex
+	orders map[string][]byte
+}
+
+func newOrdersRepository() *ordersRepository {
+	return &ordersRepository{orders: make(map[string][]byte)}
+}
+
+func (r *ordersRepository) IndexOrder(ctx context.Context, order *models.OrderProjection) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.orders[order.OrderID]; ok {
+		return nil
+	}
+	return r.save(order)
+}
+
+func (r *ordersRepository) GetByID(ctx context.Context, tenantID string, orderID string) (*models.OrderProjection, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.get(tenantID, orderID)
+}
+
+func (r *ordersRepository) UpdateOrder(ctx context.Context, order *models.OrderProjection) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	projected, err := r.get(order.TenantID, order.OrderID)
+	if err != nil {
+		return err
+	}
+	if projected.Version+1 == order.Version {
+		return r.save(order)
+	}
+	if projected.Version >= order.Version {
+		return nil
+	}
+	return errors.Wrapf(repository.ErrOrderEventsPending, "version: {%d}, projected: {%d}", order.Version, projected.Version)
+}
+
+func (r *ordersRepository) Search(ctx context.Context, filter *models.OrderSearchFilter, pq *utils.Pagination) (*dto.OrderSearchResponseDto, error) {
+	return nil, nil
+}
+
+func (r *ordersRepository) get(tenantID string, orderID string) (*models.OrderProjection, error) {
+	data, ok := r.orders[orderID]
+	if !ok {
+		return nil, errors.Wrapf(aggregate.ErrOrderNotFound, "orderID: {%s}", orderID)
+	}
+	var order models.OrderProjection
+	if err := json.Unmarshal(data, &order); err != nil {
+		return nil, err
+	}
+	if order.TenantID != tenantID {
+		return nil, errors.Wrapf(aggregate.ErrOrderNotFound, "orderID: {%s}, tenantID: {%s}", orderID, tenantID)
+	}
+	return &order, nil
+}
+
+func (r *ordersRepository) save(order *models.OrderProjection) error {
+	data, err := json.Marshal(order)
+	if err != nil {
+		return err
+	}
+	r.orders[order.OrderID] = data
+	return nil
+}
+
+func newTestLogger() logger.Logger {
+	appLogger := logger.NewAppLogger(&logger.Config{LogLevel: "error", Encoder: "console"})
+	appLogger.InitLogger()
+	return appLogger
+}
+
+func newTestShopItem(id string, amount int64) *models.ShopItem {
+	return &models.ShopItem{ID: id, Title: id, Quantity: 1, Price: models.NewMoney(amount, "USD")}
+}
+
+// newOrderEvents returns events of the created order with the first shop item and the next changes of the shopping cart.
+func newOrderEvents(t *testing.T, changes ...func(order *aggregate.OrderAggregate) error) []es.Event {
+	ctx := context.Background()
+	order := aggregate.NewOrderAggregateWithID("", testOrderID)
+	if err := order.CreateOrder(ctx, []*models.ShopItem{newTestShopItem("item-0", 100)}, "customer@mail.com", "address"); err != nil {
+		t.Fatalf("CreateOrder() err: %v", err)
+	}
+	for _, change := range changes {
+		if err := change(order); err != nil {
+			t.Fatalf("change order err: %v", err)
+		}
+	}
+	return order.GetUncommittedEvents()
+}
+
+func addShopItem(id string, amount int64) func(order *aggregate.OrderAggregate) error {
+	return func(order *aggregate.OrderAggregate) error {
+		return order.AddShopItem(context.Background(), newTestShopItem(id, amount))
+	}
+}
+
+func TestElasticProjectionOrderEvents(t *testing.T) {
+	events := newOrderEvents(t,
+		addShopItem("item-1", 200),
+		addShopItem("item-2", 300),
+		func(order *aggregate.OrderAggregate) error {
+			return order.RemoveShopItem(context.Background(), "item-0")
+		},
+		func(order *aggregate.OrderAggregate) error {
+			return order.ChangeItemQuantity(context.Background(), "item-2", 3)
+		},
+	)
+
+	tests := []struct {
+		name       string
+		events     []int
+		pending    []int
+		version    int64
+		shopItems  []string
+		totalPrice int64
+	}{
+		{name: "in order", events: []int{0, 1, 2, 3, 4}, version: 4, shopItems: []string{"item-1", "item-2"}, totalPrice: 1100},
+		{name: "redelivered", events: []int{0, 1, 1, 2, 2}, version: 2, shopItems: []string{"item-0", "item-1", "item-2"}, totalPrice: 600},
+		{name: "redelivered created", events: []int{0, 1, 0}, version: 1, shopItems: []string{"item-0", "item-1"}, totalPrice: 300},
+		{name: "out of order", events: []int{0, 2, 4, 1, 2, 3, 4}, pending: []int{1, 2}, version: 4, shopItems: []string{"item-1", "item-2"}, totalPrice: 1100},
+		{name: "previous events pending", events: []int{0, 3}, pending: []int{1}, version: 0, shopItems: []string{"item-0"}, totalPrice: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := newOrdersRepository()
+			projection := NewElasticProjection(newTestLogger(), repo)
+
+			pending := make(map[int]bool, len(tt.pending))
+			for _, i := range tt.pending {
+				pending[i] = true
+			}
+			for i, event := range tt.events {
+				err := projection.When(ctx, events[event])
+				if pending[i] {
+					if !errors.Is(err, repository.ErrOrderEventsPending) {
+						t.Fatalf("When() event %d err = %v, want %v", event, err, repository.ErrOrderEventsPending)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("When() event %d err: %v", event, err)
+				}
+			}
+
+			order, err := repo.GetByID(ctx, "", testOrderID)
+			if err != nil {
+				t.Fatalf("GetByID() err: %v", err)
+			}
+			if order.Version != tt.version || order.TotalPrice != models.NewMoney(tt.totalPrice, "USD") {
+				t.Errorf("order version = %d, total price = %s, want %d, %d", order.Version, order.TotalPrice, tt.version, tt.totalPrice)
+			}
+			if shopItems := getShopItemIDs(order); fmt.Sprint(shopItems) != fmt.Sprint(tt.shopItems) {
+				t.Errorf("order shop items = %v, want %v", shopItems, tt.shopItems)
+			}
+		})
+	}
+}
+
+func TestElasticProjectionConcurrentEvents(t *testing.T) {
+	const shopItems = 20
+	changes := make([]func(order *aggregate.OrderAggregate) error, 0, shopItems)
+	for i := 1; i <= shopItems; i++ {
+		changes = append(changes, addShopItem(fmt.Sprintf("item-%d", i), 100))
+	}
+	events := newOrderEvents(t, changes...)
+
+	ctx := context.Background()
+	repo := newOrdersRepository()
+	projection := NewElasticProjection(newTestLogger(), repo)
+	if err := projection.When(ctx, events[0]); err != nil {
+		t.Fatalf("When() created err: %v", err)
+	}
+
+	// the workers retry the event until the previous events are applied as the subscription runner does
+	var wg sync.WaitGroup
+	errs := make(chan error, shopItems)
+	for _, event := range events[1:] {
+		wg.Add(1)
+		go func(event es.Event) {
+			defer wg.Done()
+			for {
+				err := projection.When(ctx, event)
+				if !errors.Is(err, repository.ErrOrderEventsPending) {
+					errs <- err
+					return
+				}
+			}
+		}(event)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("When() err: %v", err)
+		}
+	}
+
+	order, err := repo.GetByID(ctx, "", testOrderID)
+	if err != nil {
+		t.Fatalf("GetByID() err: %v", err)
+	}
+	if len(order.ShopItems) != shopItems+1 || order.Version != shopItems || order.TotalPrice != models.NewMoney((shopItems+1)*100, "USD") {
+		t.Errorf("order shop items = %v, version = %d, total price = %s", getShopItemIDs(order), order.Version, order.TotalPrice)
+	}
+}
+
+func getShopItemIDs(order *models.OrderProjection) []string {
+	ids := make([]string, 0, len(order.ShopItems))
+	for _, item := range order.ShopItems {
+		ids = append(ids, item.ID)
+	}
+	return ids
+}
+
+func TestElasticProjectionRefundEvents(t *testing.T) {
+	partial := models.NewMoney(40, "USD")
+	events := newOrderEvents(t,
+		func(order *aggregate.OrderAggregate) error {
+			return order.PayOrder(context.Background(), models.Payment{PaymentID: "payment-1", Timestamp: time.Now().UTC()})
+		},
+		func(order *aggregate.OrderAggregate) error {
+			return order.RefundOrder(context.Background(), "refund-1", &partial, "damaged")
+		},
+		func(order *aggregate.OrderAggregate) error {
+			return order.RefundOrder(context.Background(), "refund-2", nil, "returned")
+		},
+	)
+
+	tests := []struct {
+		name     string
+		events   []int
+		pending  []int
+		refunds  []string
+		refunded int64
+	}{
+		{name: "in order", events: []int{0, 1, 2, 3}, refunds: []string{"refund-1", "refund-2"}, refunded: 100},
+		{name: "redelivered", events: []int{0, 1, 2, 2}, refunds: []string{"refund-1"}, refunded: 40},
+		{name: "redelivered after next refund", events: []int{0, 1, 2, 3, 2}, refunds: []string{"refund-1", "refund-2"}, refunded: 100},
+		{name: "out of order", events: []int{0, 1, 3, 2, 3}, pending: []int{2}, refunds: []string{"refund-1", "refund-2"}, refunded: 100},
+		{name: "before payment", events: []int{0, 2, 1, 2}, pending: []int{1}, refunds: []string{"refund-1"}, refunded: 40},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := newOrdersRepository()
+			projection := NewElasticProjection(newTestLogger(), repo)
+
+			pending := make(map[int]bool, len(tt.pending))
+			for _, i := range tt.pending {
+				pending[i] = true
+			}
+			for i, event := range tt.events {
+				err := projection.When(ctx, events[event])
+				if pending[i] {
+					if !errors.Is(err, repository.ErrOrderEventsPending) {
+						t.Fatalf("When() event %d err = %v, want %v", event, err, repository.ErrOrderEventsPending)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("When() event %d err: %v", event, err)
+				}
+			}
+
+			order, err := repo.GetByID(ctx, "", testOrderID)
+			if err != nil {
+				t.Fatalf("GetByID() err: %v", err)
+			}
+			refunds := make([]string, 0, len(order.Refunds))
+			for _, refund := range order.Refunds {
+				refunds = append(refunds, refund.RefundID)
+			}
+			if fmt.Sprint(refunds) != fmt.Sprint(tt.refunds) || order.RefundedAmount != models.NewMoney(tt.refunded, "USD") {
+				t.Errorf("order refunds = %v, refunded amount = %s, want %v, %d", refunds, order.RefundedAmount, tt.refunds, tt.refunded)
+			}
+			if !order.Paid || order.Status != models.OrderStatusPaid {
+				t.Errorf("order paid = %v, status = %s", order.Paid, order.Status)
+			}
+		})
+	}
+}
@@ -5,35 +5,45 @@ import (
 
 	"github.com/AleksK1NG/es-microservice/internal/order/aggregate"
 	"github.com/AleksK1NG/es-microservice/internal/order/events/v1"
+	"github.com/AleksK1NG/es-microservice/internal/order/events/v2"
 	"github.com/AleksK1NG/es-microservice/internal/order/models"
 	"github.com/AleksK1NG/es-microservice/pkg/es"
 	"github.com/AleksK1NG/es-microservice/pkg/tracing"
-	"github.com/opentracing/opentracing-go"
-	"github.com/opentracing/opentracing-go/log"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 func (o *mongoProjection) onOrderCreate(ctx context.Context, evt es.Event) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProjection.onOrderCreate")
-	defer span.Finish()
-	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+	ctx, span := tracing.StartSpan(ctx, "mongoProjection.onOrderCreate")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", evt.GetAggregateID()))
 
-	var eventData v1.OrderCreatedEvent
+	var eventData v2.OrderCreatedEvent
 	if err := evt.GetJsonData(&eventData); err != nil {
 		tracing.TraceErr(span, err)
 		return errors.Wrap(err, "evt.GetJsonData")
 	}
-	span.LogFields(log.String("AccountEmail", eventData.AccountEmail))
+
+	totalPrice, err := aggregate.GetShopItemsTotalPrice(eventData.ShopItems)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+	span.SetAttributes(attribute.String("AccountEmail", eventData.AccountEmail))
 
 	op := &models.OrderProjection{
 		OrderID:         aggregate.GetOrderAggregateID(evt.AggregateID),
+		TenantID:        aggregate.GetOrderTenantID(evt.AggregateID),
+		Version:         evt.GetVersion(),
 		ShopItems:       eventData.ShopItems,
 		AccountEmail:    eventData.AccountEmail,
-		TotalPrice:      aggregate.GetShopItemsTotalPrice(eventData.ShopItems),
+		TotalPrice:      totalPrice,
 		DeliveryAddress: eventData.DeliveryAddress,
+		Status:          models.OrderStatusPending,
+		CreatedAt:       evt.GetTimeStamp(),
 	}
 
-	_, err := o.mongoRepo.Insert(ctx, op)
+	_, err = o.mongoRepo.Insert(ctx, op)
 	if err != nil {
 		return err
 	}
@@ -42,48 +52,54 @@ func (o *mongoProjection) onOrderCreate(ctx context.Context, evt es.Event) error
 }
 
 func (o *mongoProjection) onOrderPaid(ctx context.Context, evt es.Event) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProjection.onOrderPaid")
-	defer span.Finish()
-	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+	ctx, span := tracing.StartSpan(ctx, "mongoProjection.onOrderPaid")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", evt.GetAggregateID()))
 
 	var payment models.Payment
 	if err := evt.GetJsonData(&payment); err != nil {
 		return errors.Wrap(err, "GetJsonData")
 	}
 
-	op := &models.OrderProjection{OrderID: aggregate.GetOrderAggregateID(evt.AggregateID), Paid: true, Payment: payment}
+	op := &models.OrderProjection{OrderID: aggregate.GetOrderAggregateID(evt.AggregateID), TenantID: aggregate.GetOrderTenantID(evt.AggregateID), Version: evt.GetVersion(), Paid: true, Payment: payment, Status: models.OrderStatusPaid}
 	return o.mongoRepo.UpdatePayment(ctx, op)
 }
 
 func (o *mongoProjection) onSubmit(ctx context.Context, evt es.Event) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProjection.onSubmit")
-	defer span.Finish()
-	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+	ctx, span := tracing.StartSpan(ctx, "mongoProjection.onSubmit")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", evt.GetAggregateID()))
 
-	op := &models.OrderProjection{OrderID: aggregate.GetOrderAggregateID(evt.AggregateID), Submitted: true}
+	op := &models.OrderProjection{OrderID: aggregate.GetOrderAggregateID(evt.AggregateID), TenantID: aggregate.GetOrderTenantID(evt.AggregateID), Version: evt.GetVersion(), Submitted: true, Status: models.OrderStatusSubmitted}
 	return o.mongoRepo.UpdateSubmit(ctx, op)
 }
 
 func (o *mongoProjection) onShoppingCartUpdate(ctx context.Context, evt es.Event) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProjection.onShoppingCartUpdate")
-	defer span.Finish()
-	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+	ctx, span := tracing.StartSpan(ctx, "mongoProjection.onShoppingCartUpdate")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", evt.GetAggregateID()))
 
-	var eventData v1.ShoppingCartUpdatedEvent
+	var eventData v2.ShoppingCartUpdatedEvent
 	if err := evt.GetJsonData(&eventData); err != nil {
 		tracing.TraceErr(span, err)
 		return errors.Wrap(err, "evt.GetJsonData")
 	}
 
-	op := &models.OrderProjection{OrderID: aggregate.GetOrderAggregateID(evt.AggregateID), ShopItems: eventData.ShopItems}
-	op.TotalPrice = aggregate.GetShopItemsTotalPrice(eventData.ShopItems)
+	totalPrice, err := aggregate.GetShopItemsTotalPrice(eventData.ShopItems)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	op := &models.OrderProjection{OrderID: aggregate.GetOrderAggregateID(evt.AggregateID), TenantID: aggregate.GetOrderTenantID(evt.AggregateID), Version: evt.GetVersion(), ShopItems: eventData.ShopItems}
+	op.TotalPrice = totalPrice
 	return o.mongoRepo.UpdateOrder(ctx, op)
 }
 
 func (o *mongoProjection) onCancel(ctx context.Context, evt es.Event) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProjection.onCancel")
-	defer span.Finish()
-	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+	ctx, span := tracing.StartSpan(ctx, "mongoProjection.onCancel")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", evt.GetAggregateID()))
 
 	var eventData v1.OrderCanceledEvent
 	if err := evt.GetJsonData(&eventData); err != nil {
@@ -93,17 +109,20 @@ func (o *mongoProjection) onCancel(ctx context.Context, evt es.Event) error {
 
 	op := &models.OrderProjection{
 		OrderID:      aggregate.GetOrderAggregateID(evt.AggregateID),
+		TenantID:     aggregate.GetOrderTenantID(evt.AggregateID),
+		Version:      evt.GetVersion(),
 		Canceled:     true,
 		Completed:    false,
 		CancelReason: eventData.CancelReason,
+		Status:       models.OrderStatusCanceled,
 	}
 	return o.mongoRepo.UpdateCancel(ctx, op)
 }
 
 func (o *mongoProjection) onCompleted(ctx context.Context, evt es.Event) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProjection.onCompleted")
-	defer span.Finish()
-	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+	ctx, span := tracing.StartSpan(ctx, "mongoProjection.onCompleted")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", evt.GetAggregateID()))
 
 	var eventData v1.OrderCompletedEvent
 	if err := evt.GetJsonData(&eventData); err != nil {
@@ -113,17 +132,20 @@ func (o *mongoProjection) onCompleted(ctx context.Context, evt es.Event) error {
 
 	op := &models.OrderProjection{
 		OrderID:       aggregate.GetOrderAggregateID(evt.AggregateID),
+		TenantID:      aggregate.GetOrderTenantID(evt.AggregateID),
+		Version:       evt.GetVersion(),
 		Canceled:      false,
 		Completed:     true,
 		DeliveredTime: eventData.DeliveryTimestamp,
+		Status:        models.OrderStatusCompleted,
 	}
 	return o.mongoRepo.Complete(ctx, op)
 }
 
 func (o *mongoProjection) onDeliveryAddressChnaged(ctx context.Context, evt es.Event) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoProjection.onDeliveryAddressChnaged")
-	defer span.Finish()
-	span.LogFields(log.String("AggregateID", evt.GetAggregateID()))
+	ctx, span := tracing.StartSpan(ctx, "mongoProjection.onDeliveryAddressChnaged")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", evt.GetAggregateID()))
 
 	var eventData v1.OrderDeliveryAddressChangedEvent
 	if err := evt.GetJsonData(&eventData); err != nil {
@@ -133,7 +155,66 @@ func (o *mongoProjection) onDeliveryAddressChnaged(ctx context.Context, evt es.E
 
 	op := &models.OrderProjection{
 		OrderID:         aggregate.GetOrderAggregateID(evt.AggregateID),
+		TenantID:        aggregate.GetOrderTenantID(evt.AggregateID),
+		Version:         evt.GetVersion(),
 		DeliveryAddress: eventData.DeliveryAddress,
 	}
 	return o.mongoRepo.UpdateDeliveryAddress(ctx, op)
 }
+
+func (o *mongoProjection) onShopItemAdded(ctx context.Context, evt es.Event) error {
+	ctx, span := tracing.StartSpan(ctx, "mongoProjection.onShopItemAdded")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData v2.ShopItemAddedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	return o.mongoRepo.AddShopItem(ctx, aggregate.GetOrderTenantID(evt.AggregateID), aggregate.GetOrderAggregateID(evt.AggregateID), evt.GetVersion(), eventData.ShopItem, eventData.TotalPrice)
+}
+
+func (o *mongoProjection) onShopItemRemoved(ctx context.Context, evt es.Event) error {
+	ctx, span := tracing.StartSpan(ctx, "mongoProjection.onShopItemRemoved")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData v2.ShopItemRemovedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	return o.mongoRepo.RemoveShopItem(ctx, aggregate.GetOrderTenantID(evt.AggregateID), aggregate.GetOrderAggregateID(evt.AggregateID), evt.GetVersion(), eventData.ShopItemID, eventData.TotalPrice)
+}
+
+func (o *mongoProjection) onShopItemQuantityChanged(ctx context.Context, evt es.Event) error {
+	ctx, span := tracing.StartSpan(ctx, "mongoProjection.onShopItemQuantityChanged")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData v2.ShopItemQuantityChangedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	tenantID, orderID := aggregate.GetOrderTenantID(evt.AggregateID), aggregate.GetOrderAggregateID(evt.AggregateID)
+	return o.mongoRepo.ChangeShopItemQuantity(ctx, tenantID, orderID, evt.GetVersion(), eventData.ShopItemID, eventData.Quantity, eventData.TotalPrice)
+}
+
+func (o *mongoProjection) onOrderRefunded(ctx context.Context, evt es.Event) error {
+	ctx, span := tracing.StartSpan(ctx, "mongoProjection.onOrderRefunded")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", evt.GetAggregateID()))
+
+	var eventData v2.OrderRefundedEvent
+	if err := evt.GetJsonData(&eventData); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "evt.GetJsonData")
+	}
+
+	return o.mongoRepo.AddRefund(ctx, aggregate.GetOrderTenantID(evt.AggregateID), aggregate.GetOrderAggregateID(evt.AggregateID), evt.GetVersion(), eventData.Refund, eventData.RefundedAmount)
+}
@@ -0,0 +1,83 @@
+package mongo_projection
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AleksK1NG/es-microservice/internal/order/aggregate"
+	"github.com/AleksK1NG/es-microservice/internal/order/models"
+	"github.com/AleksK1NG/es-microservice/internal/order/repository"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/pkg/errors"
+)
+
+type addRefundCall struct {
+	tenantID       string
+	orderID        string
+	version        int64
+	refundID       string
+	refundedAmount models.Money
+}
+
+// refundsRepository records the refunds, the other methods of the embedded nil repository are not called.
+type refundsRepository struct {
+	repository.OrderMongoRepository
+	calls []addRefundCall
+	err   error
+}
+
+func (r *refundsRepository) AddRefund(ctx context.Context, tenantID string, orderID string, version int64, refund *models.Refund, refundedAmount models.Money) error {
+	r.calls = append(r.calls, addRefundCall{tenantID: tenantID, orderID: orderID, version: version, refundID: refund.RefundID, refundedAmount: refundedAmount})
+	return r.err
+}
+
+func newTestLogger() logger.Logger {
+	appLogger := logger.NewAppLogger(&logger.Config{LogLevel: "error", Encoder: "console"})
+	appLogger.InitLogger()
+	return appLogger
+}
+
+func TestMongoProjectionOrderRefunded(t *testing.T) {
+	tests := []struct {
+		name     string
+		tenantID string
+		amount   *models.Money
+		refunded int64
+		err      error
+	}{
+		{name: "full refund", refunded: 100},
+		{name: "partial refund", amount: &models.Money{Amount: 40, Currency: "USD"}, refunded: 40},
+		{name: "tenant order", tenantID: "tenantA", refunded: 100},
+		{name: "previous events pending", refunded: 100, err: repository.ErrOrderEventsPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			order := aggregate.NewOrderAggregateWithID(tt.tenantID, "8c4f2b1e")
+			shopItems := []*models.ShopItem{{ID: "item-1", Title: "book", Quantity: 1, Price: models.NewMoney(100, "USD")}}
+			if err := order.CreateOrder(ctx, shopItems, "customer@mail.com", "address"); err != nil {
+				t.Fatalf("CreateOrder() err: %v", err)
+			}
+			if err := order.PayOrder(ctx, models.Payment{PaymentID: "payment-1", Timestamp: time.Now().UTC()}); err != nil {
+				t.Fatalf("PayOrder() err: %v", err)
+			}
+			if err := order.RefundOrder(ctx, "refund-1", tt.amount, "damaged"); err != nil {
+				t.Fatalf("RefundOrder() err: %v", err)
+			}
+			events := order.GetUncommittedEvents()
+
+			repo := &refundsRepository{err: tt.err}
+			err := NewOrderProjection(newTestLogger(), repo).When(ctx, events[len(events)-1])
+			if !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+				t.Fatalf("When() err = %v, want %v", err, tt.err)
+			}
+
+			want := addRefundCall{tenantID: tt.tenantID, orderID: "8c4f2b1e", version: 2, refundID: "refund-1", refundedAmount: models.NewMoney(tt.refunded, "USD")}
+			if len(repo.calls) != 1 || repo.calls[0] != want {
+				t.Errorf("AddRefund() calls = %+v, want %+v", repo.calls, want)
+			}
+		})
+	}
+}
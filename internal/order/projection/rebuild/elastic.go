@@ -0,0 +1,75 @@
+package rebuild
+
+import (
+	"context"
+
+	"github.com/AleksK1NG/es-microservice/config"
+	"github.com/AleksK1NG/es-microservice/internal/order/projection/elastic_projection"
+	"github.com/AleksK1NG/es-microservice/internal/order/repository"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	v7 "github.com/olivere/elastic/v7"
+	"github.com/pkg/errors"
+)
+
+type elasticReadModel struct {
+	log           logger.Logger
+	cfg           *config.Config
+	elasticClient *v7.Client
+}
+
+func (e *elasticReadModel) name() string {
+	return e.cfg.ElasticIndexes.Orders
+}
+
+func (e *elasticReadModel) groupName() string {
+	return e.cfg.Subscriptions.ElasticProjectionGroupName
+}
+
+func (e *elasticReadModel) createShadow(ctx context.Context, shadow string) (es.Projection, error) {
+	if _, err := e.elasticClient.CreateIndex(shadow).Do(ctx); err != nil {
+		return nil, errors.Wrap(err, "elasticClient.CreateIndex")
+	}
+
+	elasticRepository := repository.NewElasticRepositoryWithIndex(e.log, e.cfg, e.elasticClient, shadow)
+	return elastic_projection.NewElasticProjection(e.log, elasticRepository), nil
+}
+
+// swap points orders alias to the shadow index and removes previous indexes in the single atomic aliases request,
+// orders index created before the first rebuild is replaced by the alias with the same name.
+func (e *elasticReadModel) swap(ctx context.Context, shadow string) error {
+	actions := []v7.AliasAction{v7.NewAliasAddAction(e.name()).Index(shadow)}
+
+	aliases, err := e.elasticClient.Aliases().Alias(e.name()).Do(ctx)
+	if err != nil && !v7.IsNotFound(err) {
+		return errors.Wrap(err, "elasticClient.Aliases")
+	}
+	indices := make([]string, 0)
+	if aliases != nil {
+		indices = aliases.IndicesByAlias(e.name())
+	}
+
+	if len(indices) == 0 {
+		exists, err := e.elasticClient.IndexExists(e.name()).Do(ctx)
+		if err != nil {
+			return errors.Wrap(err, "elasticClient.IndexExists")
+		}
+		if exists {
+			indices = append(indices, e.name())
+		}
+	}
+
+	for _, index := range indices {
+		actions = append(actions, v7.NewAliasRemoveIndexAction(index))
+	}
+
+	if _, err := e.elasticClient.Alias().Action(actions...).Do(ctx); err != nil {
+		return errors.Wrap(err, "elasticClient.Alias")
+	}
+	return nil
+}
+
+func (e *elasticReadModel) dropShadow(ctx context.Context, shadow string) error {
+	_, err := e.elasticClient.DeleteIndex(shadow).Do(ctx)
+	return err
+}
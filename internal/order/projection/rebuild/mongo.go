@@ -0,0 +1,64 @@
+package rebuild
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AleksK1NG/es-microservice/config"
+	"github.com/AleksK1NG/es-microservice/internal/order/projection/mongo_projection"
+	"github.com/AleksK1NG/es-microservice/internal/order/repository"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	adminDatabase = "admin"
+)
+
+type mongoReadModel struct {
+	log         logger.Logger
+	cfg         *config.Config
+	mongoClient *mongo.Client
+}
+
+func (m *mongoReadModel) name() string {
+	return m.cfg.MongoCollections.Orders
+}
+
+func (m *mongoReadModel) groupName() string {
+	return m.cfg.Subscriptions.MongoProjectionGroupName
+}
+
+func (m *mongoReadModel) createShadow(ctx context.Context, shadow string) (es.Projection, error) {
+	if err := m.mongoClient.Database(m.cfg.Mongo.Db).CreateCollection(ctx, shadow); err != nil {
+		return nil, errors.Wrap(err, "CreateCollection")
+	}
+
+	// the shadow replaces the live collection with its indexes, so it needs all of them
+	if _, err := m.mongoClient.Database(m.cfg.Mongo.Db).Collection(shadow).Indexes().CreateMany(ctx, repository.GetOrdersIndexes()); err != nil {
+		return nil, errors.Wrap(err, "Indexes.CreateMany")
+	}
+
+	mongoRepository := repository.NewMongoRepositoryWithCollection(m.log, m.cfg, m.mongoClient, shadow)
+	return mongo_projection.NewOrderProjection(m.log, mongoRepository), nil
+}
+
+// swap renames shadow collection to the live one, renameCollection with dropTarget replaces it atomically.
+func (m *mongoReadModel) swap(ctx context.Context, shadow string) error {
+	command := bson.D{
+		{Key: "renameCollection", Value: fmt.Sprintf("%s.%s", m.cfg.Mongo.Db, shadow)},
+		{Key: "to", Value: fmt.Sprintf("%s.%s", m.cfg.Mongo.Db, m.name())},
+		{Key: "dropTarget", Value: true},
+	}
+	if err := m.mongoClient.Database(adminDatabase).RunCommand(ctx, command).Err(); err != nil {
+		return errors.Wrap(err, "renameCollection")
+	}
+	return nil
+}
+
+func (m *mongoReadModel) dropShadow(ctx context.Context, shadow string) error {
+	return m.mongoClient.Database(m.cfg.Mongo.Db).Collection(shadow).Drop(ctx)
+}
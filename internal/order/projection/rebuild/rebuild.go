@@ -0,0 +1,296 @@
+package rebuild
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/AleksK1NG/es-microservice/config"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/es/store"
+	"github.com/AleksK1NG/es-microservice/pkg/es/subscription"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	v7 "github.com/olivere/elastic/v7"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+)
+
+const (
+	TargetMongo   = "mongo"
+	TargetElastic = "elastic"
+
+	StateRunning   = "running"
+	StateCompleted = "completed"
+	StateFailed    = "failed"
+)
+
+var (
+	ErrInvalidTarget     = errors.New("invalid projection rebuild target")
+	ErrRebuildInProgress = errors.New("projection rebuild is already in progress")
+	ErrRebuildNotFound   = errors.New("projection rebuild not found")
+)
+
+// Rebuilder rebuilds read models from the event store.
+type Rebuilder interface {
+	// Rebuild rebuilds target read model and blocks until it is finished.
+	Rebuild(ctx context.Context, target string) (Status, error)
+	// RebuildAsync starts target read model rebuild in background and returns running status.
+	RebuildAsync(target string) (Status, error)
+	// GetStatus returns status of the last target rebuild.
+	GetStatus(target string) (Status, error)
+}
+
+// Status of the read model rebuild.
+type Status struct {
+	Target         string    `json:"target"`
+	State          string    `json:"state"`
+	Shadow         string    `json:"shadow"`
+	Events         uint64    `json:"events"`
+	CommitPosition uint64    `json:"commitPosition"`
+	StartedAt      time.Time `json:"startedAt"`
+	FinishedAt     time.Time `json:"finishedAt,omitempty"`
+	Error          string    `json:"error,omitempty"`
+}
+
+// readModel is the rebuild target, shadow is the new collection or index which replaces the live one.
+type readModel interface {
+	name() string
+	groupName() string
+	createShadow(ctx context.Context, shadow string) (es.Projection, error)
+	swap(ctx context.Context, shadow string) error
+	dropShadow(ctx context.Context, shadow string) error
+}
+
+type rebuilder struct {
+	log      logger.Logger
+	cfg      *config.Config
+	db       *esdb.Client
+	upcaster es.Upcaster
+	targets  map[string]readModel
+	mu       sync.Mutex
+	statuses map[string]*Status
+}
+
+// NewRebuilder read models rebuilder: it replays all order streams into the shadow collection or index while live projection
+// keeps working, then stops live persistent subscription, catches up the shadow, swaps it with the live one
+// and recreates live persistent subscription from the last replayed position.
+func NewRebuilder(
+	log logger.Logger,
+	cfg *config.Config,
+	db *esdb.Client,
+	mongoClient *mongo.Client,
+	elasticClient *v7.Client,
+	upcaster es.Upcaster,
+) *rebuilder {
+	return &rebuilder{
+		log:      log,
+		cfg:      cfg,
+		db:       db,
+		upcaster: upcaster,
+		targets: map[string]readModel{
+			TargetMongo:   &mongoReadModel{log: log, cfg: cfg, mongoClient: mongoClient},
+			TargetElastic: &elasticReadModel{log: log, cfg: cfg, elasticClient: elasticClient},
+		},
+		statuses: make(map[string]*Status),
+	}
+}
+
+func (r *rebuilder) Rebuild(ctx context.Context, target string) (Status, error) {
+	status, err := r.start(target)
+	if err != nil {
+		return Status{}, err
+	}
+
+	err = r.rebuild(ctx, target, status)
+	return r.finish(status, err), err
+}
+
+func (r *rebuilder) RebuildAsync(target string) (Status, error) {
+	status, err := r.start(target)
+	if err != nil {
+		return Status{}, err
+	}
+
+	go func() {
+		if err := r.rebuild(context.Background(), target, status); err != nil {
+			r.log.Errorf("(rebuild) target: {%s}, err: {%v}", target, err)
+		}
+		r.finish(status, err)
+	}()
+
+	return r.getStatus(status), nil
+}
+
+func (r *rebuilder) GetStatus(target string) (Status, error) {
+	if _, ok := r.targets[target]; !ok {
+		return Status{}, errors.Wrapf(ErrInvalidTarget, "target: {%s}", target)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	status, ok := r.statuses[target]
+	if !ok {
+		return Status{}, errors.Wrapf(ErrRebuildNotFound, "target: {%s}", target)
+	}
+	return *status, nil
+}
+
+func (r *rebuilder) rebuild(ctx context.Context, target string, status *Status) error {
+	ctx, span := tracing.StartSpan(ctx, "rebuilder.rebuild")
+	defer span.End()
+	span.SetAttributes(attribute.String("Target", target))
+
+	model := r.targets[target]
+	shadow := fmt.Sprintf("%s_%d", model.name(), status.StartedAt.Unix())
+	r.setShadow(status, shadow)
+
+	shadowProjection, err := model.createShadow(ctx, shadow)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "createShadow")
+	}
+	projection := es.NewUpcastingProjection(shadowProjection, r.upcaster)
+
+	r.log.Infof("(rebuild) target: {%s}, shadow: {%s} replay started", target, shadow)
+	result, err := store.Replay(ctx, r.db, projection, esdb.Start{}, r.getPrefixes())
+	if err != nil {
+		tracing.TraceErr(span, err)
+		r.dropShadow(ctx, model, shadow)
+		return errors.Wrap(err, "store.Replay")
+	}
+	r.setProgress(status, result.Events, result.Position)
+
+	// from here live projection is stopped, events appended after the first replay are applied by the catch-up replay
+	if err := r.deleteSubscription(ctx, model.groupName()); err != nil {
+		tracing.TraceErr(span, err)
+		r.dropShadow(ctx, model, shadow)
+		return err
+	}
+
+	catchUpResult, err := store.Replay(ctx, r.db, projection, r.getFromPosition(result), r.getPrefixes())
+	if err == nil {
+		err = model.swap(ctx, shadow)
+	}
+	if err != nil {
+		tracing.TraceErr(span, err)
+		r.dropShadow(ctx, model, shadow)
+		// live read model keeps the state at least up to the first replay position, continue from it
+		if err := r.createSubscription(ctx, model.groupName(), r.getFromPosition(result)); err != nil {
+			r.log.Errorf("(createSubscription) groupName: {%s}, err: {%v}", model.groupName(), err)
+		}
+		return errors.Wrap(err, "catch up and swap")
+	}
+	r.setProgress(status, result.Events+catchUpResult.Events, catchUpResult.Position)
+
+	// projections are idempotent, so the last replayed event delivered again is safe
+	if err := r.createSubscription(ctx, model.groupName(), r.getFromPosition(catchUpResult)); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	r.log.Infof("(rebuild) target: {%s}, shadow: {%s}, events: {%d}, CommitPosition: {%d} rebuild completed",
+		target, shadow, status.Events, status.CommitPosition)
+	return nil
+}
+
+func (r *rebuilder) deleteSubscription(ctx context.Context, groupName string) error {
+	err := r.db.DeletePersistentSubscriptionAll(ctx, groupName, esdb.DeletePersistentSubscriptionOptions{})
+	if err != nil {
+		var subscriptionError *esdb.PersistentSubscriptionError
+		if errors.As(err, &subscriptionError) && grpcStatus.Code(subscriptionError.Err) == codes.NotFound {
+			r.log.Warnf("(DeletePersistentSubscriptionAll) groupName: {%s} subscription not found", groupName)
+			return nil
+		}
+		return errors.Wrap(err, "db.DeletePersistentSubscriptionAll")
+	}
+	return nil
+}
+
+func (r *rebuilder) createSubscription(ctx context.Context, groupName string, from esdb.AllPosition) error {
+	err := r.db.CreatePersistentSubscriptionAll(ctx, groupName, esdb.PersistentAllSubscriptionOptions{
+		From:   from,
+		Filter: &esdb.SubscriptionFilter{Type: esdb.StreamFilterType, Prefixes: r.getPrefixes()},
+	})
+	if err != nil {
+		return errors.Wrap(err, "db.CreatePersistentSubscriptionAll")
+	}
+
+	// the recreated group subscribes to the configured prefixes, so the runners accept it after the restart
+	if err := subscription.SaveGroupFilter(ctx, r.db, groupName, r.getPrefixes()); err != nil {
+		return errors.Wrap(err, "subscription.SaveGroupFilter")
+	}
+	return nil
+}
+
+func (r *rebuilder) dropShadow(ctx context.Context, model readModel, shadow string) {
+	if err := model.dropShadow(ctx, shadow); err != nil {
+		r.log.Warnf("(dropShadow) shadow: {%s}, err: {%v}", shadow, err)
+	}
+}
+
+func (r *rebuilder) getFromPosition(result *store.ReplayResult) esdb.AllPosition {
+	if result.Position == (esdb.Position{}) {
+		return esdb.Start{}
+	}
+	return result.Position
+}
+
+func (r *rebuilder) getPrefixes() []string {
+	return r.cfg.EventSourcing.Tenancy.GetStreamPrefixes(r.cfg.Subscriptions.OrderPrefix)
+}
+
+func (r *rebuilder) start(target string) (*Status, error) {
+	if _, ok := r.targets[target]; !ok {
+		return nil, errors.Wrapf(ErrInvalidTarget, "target: {%s}", target)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if status, ok := r.statuses[target]; ok && status.State == StateRunning {
+		return nil, errors.Wrapf(ErrRebuildInProgress, "target: {%s}, startedAt: {%s}", target, status.StartedAt)
+	}
+
+	status := &Status{Target: target, State: StateRunning, StartedAt: time.Now().UTC()}
+	r.statuses[target] = status
+	return status, nil
+}
+
+func (r *rebuilder) finish(status *Status, err error) Status {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	status.FinishedAt = time.Now().UTC()
+	status.State = StateCompleted
+	if err != nil {
+		status.State = StateFailed
+		status.Error = err.Error()
+	}
+	return *status
+}
+
+func (r *rebuilder) setShadow(status *Status, shadow string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	status.Shadow = shadow
+}
+
+func (r *rebuilder) setProgress(status *Status, events uint64, position esdb.Position) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	status.Events = events
+	status.CommitPosition = position.Commit
+}
+
+func (r *rebuilder) getStatus(status *Status) Status {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return *status
+}
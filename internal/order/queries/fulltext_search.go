@@ -4,11 +4,14 @@ import (
 	"context"
 	"github.com/AleksK1NG/es-microservice/config"
 	"github.com/AleksK1NG/es-microservice/internal/dto"
+	"github.com/AleksK1NG/es-microservice/internal/order/models"
 	"github.com/AleksK1NG/es-microservice/internal/order/repository"
+	"github.com/AleksK1NG/es-microservice/pkg/auth"
 	"github.com/AleksK1NG/es-microservice/pkg/es"
 	"github.com/AleksK1NG/es-microservice/pkg/logger"
-	"github.com/opentracing/opentracing-go"
-	"github.com/opentracing/opentracing-go/log"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type SearchOrdersQueryHandler interface {
@@ -27,9 +30,42 @@ func NewSearchOrdersHandler(log logger.Logger, cfg *config.Config, es es.Aggrega
 }
 
 func (s *searchOrdersHandler) Handle(ctx context.Context, command *SearchOrdersQuery) (*dto.OrderSearchResponseDto, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "searchOrdersHandler.Handle")
-	defer span.Finish()
-	span.LogFields(log.String("SearchText", command.SearchText))
+	ctx, span := tracing.StartSpan(ctx, "searchOrdersHandler.Handle")
+	defer span.End()
+	span.SetAttributes(attribute.String("TenantID", command.TenantID), attribute.String("SearchText", command.SearchText), tracing.Object("Filter", command.Filter), attribute.String("OrderBy", command.OrderBy))
 
-	return s.elasticRepository.Search(ctx, command.SearchText, command.Pq)
+	statuses, err := models.OrderStatusesFromStrings(command.Filter.Statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &models.OrderSearchFilter{
+		TenantID:      command.TenantID,
+		AccountEmail:  command.Filter.AccountEmail,
+		Text:          command.SearchText,
+		Statuses:      statuses,
+		Currency:      command.Filter.Currency,
+		MinTotalPrice: command.Filter.MinTotalPrice,
+		MaxTotalPrice: command.Filter.MaxTotalPrice,
+		CreatedFrom:   command.Filter.CreatedFrom,
+		CreatedTo:     command.Filter.CreatedTo,
+		DeliveredFrom: command.Filter.DeliveredFrom,
+		DeliveredTo:   command.Filter.DeliveredTo,
+	}
+
+	// customers find only the orders of their account
+	accountEmail, isCustomer := auth.GetCustomerEmail(ctx)
+	if isCustomer {
+		if accountEmail == "" {
+			return nil, errors.Wrap(auth.ErrForbidden, "customer without account email")
+		}
+		if filter.AccountEmail != "" {
+			if err := auth.CheckOrderAccess(ctx, filter.AccountEmail); err != nil {
+				return nil, err
+			}
+		}
+		filter.AccountEmail = accountEmail
+	}
+
+	return s.elasticRepository.Search(ctx, filter, command.Pq)
 }
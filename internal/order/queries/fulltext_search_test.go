@@ -0,0 +1,67 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/AleksK1NG/es-microservice/config"
+	"github.com/AleksK1NG/es-microservice/internal/dto"
+	"github.com/AleksK1NG/es-microservice/internal/order/models"
+	"github.com/AleksK1NG/es-microservice/internal/order/repository"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/utils"
+	"github.com/pkg/errors"
+)
+
+func newTestLogger() logger.Logger {
+	appLogger := logger.NewAppLogger(&logger.Config{LogLevel: "error", Encoder: "console"})
+	appLogger.InitLogger()
+	return appLogger
+}
+
+// searchRepository records the filter of the search.
+type searchRepository struct {
+	repository.ElasticOrderRepository
+	filter *models.OrderSearchFilter
+}
+
+func (r *searchRepository) Search(ctx context.Context, filter *models.OrderSearchFilter, pq *utils.Pagination) (*dto.OrderSearchResponseDto, error) {
+	r.filter = filter
+	return &dto.OrderSearchResponseDto{}, nil
+}
+
+func TestSearchOrdersHandlerStatuses(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []string
+		want     []models.OrderStatus
+		err      error
+	}{
+		{name: "without statuses", want: []models.OrderStatus{}},
+		{name: "known statuses", statuses: []string{"paid", "completed"}, want: []models.OrderStatus{models.OrderStatusPaid, models.OrderStatusCompleted}},
+		{name: "unknown status", statuses: []string{"paid", "shipped"}, err: models.ErrUnknownOrderStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &searchRepository{}
+			handler := NewSearchOrdersHandler(newTestLogger(), &config.Config{}, nil, repo)
+
+			query := NewSearchOrdersQuery("", "", SearchOrdersFilter{Statuses: tt.statuses}, utils.NewPaginationQuery(10, 1))
+			_, err := handler.Handle(context.Background(), query)
+			if !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+				t.Fatalf("Handle() err = %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				if repo.filter != nil {
+					t.Errorf("searched with unknown status, filter: %+v", repo.filter)
+				}
+				return
+			}
+			if fmt.Sprint(repo.filter.Statuses) != fmt.Sprint(tt.want) {
+				t.Errorf("filter statuses = %v, want %v", repo.filter.Statuses, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/AleksK1NG/es-microservice/config"
+	"github.com/AleksK1NG/es-microservice/internal/order/aggregate"
+	"github.com/AleksK1NG/es-microservice/internal/order/models"
+	"github.com/AleksK1NG/es-microservice/pkg/auth"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type GetOrderAtQueryHandler interface {
+	Handle(ctx context.Context, query *GetOrderAtQuery) (*models.OrderAt, error)
+}
+
+type getOrderAtHandler struct {
+	log        logger.Logger
+	cfg        *config.Config
+	eventStore es.EventStore
+	upcaster   es.Upcaster
+}
+
+func NewGetOrderAtHandler(log logger.Logger, cfg *config.Config, eventStore es.EventStore, upcaster es.Upcaster) *getOrderAtHandler {
+	return &getOrderAtHandler{log: log, cfg: cfg, eventStore: eventStore, upcaster: upcaster}
+}
+
+// Handle replays order stream events into the new OrderAggregate while they are not after the requested version and timestamp,
+// snapshots are not used because they keep only the latest state.
+func (q *getOrderAtHandler) Handle(ctx context.Context, query *GetOrderAtQuery) (*models.OrderAt, error) {
+	ctx, span := tracing.StartSpan(ctx, "getOrderAtHandler.Handle")
+	defer span.End()
+	span.SetAttributes(attribute.String("TenantID", query.TenantID), attribute.String("AggregateID", query.ID), tracing.Object("Version", query.Version), tracing.Object("Timestamp", query.Timestamp))
+
+	order := aggregate.NewOrderAggregateWithID(query.TenantID, query.ID)
+	events, err := q.eventStore.LoadEvents(ctx, order.GetID())
+	if err != nil {
+		if errors.Is(err, esdb.ErrStreamNotFound) {
+			return nil, errors.Wrapf(es.ErrAggregateNotFound, "AggregateID: {%s}", order.GetID())
+		}
+		return nil, err
+	}
+
+	orderAt := &models.OrderAt{Order: order.Order}
+	applied := 0
+	for _, event := range events {
+		if query.IsAfter(event) {
+			break
+		}
+
+		upcasted, err := es.UpcastEvent(q.upcaster, event)
+		if err != nil {
+			return nil, errors.Wrap(err, "UpcastEvent")
+		}
+		if err := order.RaiseEvent(upcasted); err != nil {
+			return nil, errors.Wrap(err, "RaiseEvent")
+		}
+
+		applied++
+		orderAt.Version = event.GetVersion()
+		orderAt.Timestamp = event.GetTimeStamp()
+	}
+
+	if applied == 0 {
+		return nil, errors.Wrapf(es.ErrAggregateNotFound, "AggregateID: {%s} has no events at: {%+v}", order.GetID(), query)
+	}
+	if err := auth.CheckOrderAccess(ctx, order.Order.AccountEmail); err != nil {
+		return nil, err
+	}
+
+	q.log.Debugf("(GetOrderAt) order: {%s}, version: {%d}", order.String(), orderAt.Version)
+	return orderAt, nil
+}
@@ -7,12 +7,13 @@ import (
 	"github.com/AleksK1NG/es-microservice/internal/order/aggregate"
 	"github.com/AleksK1NG/es-microservice/internal/order/models"
 	"github.com/AleksK1NG/es-microservice/internal/order/repository"
+	"github.com/AleksK1NG/es-microservice/pkg/auth"
 	"github.com/AleksK1NG/es-microservice/pkg/es"
 	"github.com/AleksK1NG/es-microservice/pkg/logger"
-	"github.com/opentracing/opentracing-go"
-	"github.com/opentracing/opentracing-go/log"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type GetOrderByIDQueryHandler interface {
@@ -31,19 +32,22 @@ func NewGetOrderByIDHandler(log logger.Logger, cfg *config.Config, es es.Aggrega
 }
 
 func (q *getOrderByIDHandler) Handle(ctx context.Context, query *GetOrderByIDQuery) (*models.OrderProjection, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "getOrderByIDHandler.Handle")
-	defer span.Finish()
-	span.LogFields(log.String("AggregateID", query.ID))
+	ctx, span := tracing.StartSpan(ctx, "getOrderByIDHandler.Handle")
+	defer span.End()
+	span.SetAttributes(attribute.String("TenantID", query.TenantID), attribute.String("AggregateID", query.ID))
 
-	orderProjection, err := q.mongoRepo.GetByID(ctx, query.ID)
+	orderProjection, err := q.mongoRepo.GetByID(ctx, query.TenantID, query.ID)
 	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
 		return nil, err
 	}
 	if orderProjection != nil {
+		if err := auth.CheckOrderAccess(ctx, orderProjection.AccountEmail); err != nil {
+			return nil, err
+		}
 		return orderProjection, nil
 	}
 
-	order := aggregate.NewOrderAggregateWithID(query.ID)
+	order := aggregate.NewOrderAggregateWithID(query.TenantID, query.ID)
 	if err := q.es.Load(ctx, order); err != nil {
 		return nil, err
 	}
@@ -51,6 +55,9 @@ func (q *getOrderByIDHandler) Handle(ctx context.Context, query *GetOrderByIDQue
 	if aggregate.IsAggregateNotFound(order) {
 		return nil, aggregate.ErrOrderNotFound
 	}
+	if err := auth.CheckOrderAccess(ctx, order.Order.AccountEmail); err != nil {
+		return nil, err
+	}
 
 	orderProjection = mappers.OrderProjectionFromAggregate(order)
 
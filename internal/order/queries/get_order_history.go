@@ -0,0 +1,109 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/AleksK1NG/es-microservice/config"
+	"github.com/AleksK1NG/es-microservice/internal/dto"
+	"github.com/AleksK1NG/es-microservice/internal/mappers"
+	"github.com/AleksK1NG/es-microservice/internal/order/aggregate"
+	"github.com/AleksK1NG/es-microservice/pkg/auth"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type GetOrderHistoryQueryHandler interface {
+	Handle(ctx context.Context, query *GetOrderHistoryQuery) (*dto.OrderHistoryResponseDto, error)
+}
+
+type getOrderHistoryHandler struct {
+	log        logger.Logger
+	cfg        *config.Config
+	eventStore es.EventStore
+	upcaster   es.Upcaster
+}
+
+func NewGetOrderHistoryHandler(log logger.Logger, cfg *config.Config, eventStore es.EventStore, upcaster es.Upcaster) *getOrderHistoryHandler {
+	return &getOrderHistoryHandler{log: log, cfg: cfg, eventStore: eventStore, upcaster: upcaster}
+}
+
+// Handle loads all order stream events upcasted to the current schema, so the clients get the same payloads
+// of the events stored with the previous schema versions, filters them by the current event types and returns the requested page.
+func (q *getOrderHistoryHandler) Handle(ctx context.Context, query *GetOrderHistoryQuery) (*dto.OrderHistoryResponseDto, error) {
+	ctx, span := tracing.StartSpan(ctx, "getOrderHistoryHandler.Handle")
+	defer span.End()
+	span.SetAttributes(attribute.String("TenantID", query.TenantID), attribute.String("AggregateID", query.ID), tracing.Object("EventTypes", query.EventTypes))
+
+	order := aggregate.NewOrderAggregateWithID(query.TenantID, query.ID)
+	events, err := q.eventStore.LoadEvents(ctx, order.GetID())
+	if err != nil {
+		if errors.Is(err, esdb.ErrStreamNotFound) {
+			return nil, errors.Wrapf(es.ErrAggregateNotFound, "AggregateID: {%s}", order.GetID())
+		}
+		return nil, err
+	}
+	for i, event := range events {
+		upcasted, err := es.UpcastEvent(q.upcaster, event)
+		if err != nil {
+			return nil, errors.Wrap(err, "UpcastEvent")
+		}
+		events[i] = upcasted
+	}
+	if err := checkOrderEventsAccess(ctx, events); err != nil {
+		return nil, err
+	}
+
+	filtered := filterEventsByTypes(events, query.EventTypes)
+	totalCount := int64(len(filtered))
+
+	from := query.Pq.GetOffset()
+	if from > len(filtered) {
+		from = len(filtered)
+	}
+	to := from + query.Pq.GetLimit()
+	if to > len(filtered) {
+		to = len(filtered)
+	}
+
+	return mappers.OrderHistoryResponseFromModel(filtered[from:to], totalCount, query.Pq), nil
+}
+
+// checkOrderEventsAccess checks the customer caller access to the order by the account email of its created event.
+func checkOrderEventsAccess(ctx context.Context, events []es.Event) error {
+	if _, ok := auth.GetCustomerEmail(ctx); !ok {
+		return nil
+	}
+
+	var created struct {
+		AccountEmail string `json:"accountEmail"`
+	}
+	if len(events) > 0 {
+		if err := events[0].GetJsonData(&created); err != nil {
+			return errors.Wrap(err, "GetJsonData")
+		}
+	}
+	return auth.CheckOrderAccess(ctx, created.AccountEmail)
+}
+
+func filterEventsByTypes(events []es.Event, eventTypes []string) []es.Event {
+	if len(eventTypes) == 0 {
+		return events
+	}
+
+	types := make(map[string]struct{}, len(eventTypes))
+	for _, eventType := range eventTypes {
+		types[eventType] = struct{}{}
+	}
+
+	filtered := make([]es.Event, 0, len(events))
+	for _, event := range events {
+		if _, ok := types[event.GetEventType()]; ok {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
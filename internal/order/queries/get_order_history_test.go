@@ -0,0 +1,110 @@
+package queries
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/AleksK1NG/es-microservice/config"
+	"github.com/AleksK1NG/es-microservice/internal/order/aggregate"
+	"github.com/AleksK1NG/es-microservice/internal/order/events"
+	eventsV1 "github.com/AleksK1NG/es-microservice/internal/order/events/v1"
+	eventsV2 "github.com/AleksK1NG/es-microservice/internal/order/events/v2"
+	"github.com/AleksK1NG/es-microservice/internal/order/models"
+	"github.com/AleksK1NG/es-microservice/pkg/auth"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/es/memory"
+	"github.com/AleksK1NG/es-microservice/pkg/utils"
+	"github.com/pkg/errors"
+)
+
+// saveV1OrderEvents saves the order stream created with the V1 events schema.
+func saveV1OrderEvents(t *testing.T, eventStore es.EventStore, orderID string) {
+	order := aggregate.NewOrderAggregateWithID("", orderID)
+
+	created := es.NewBaseEvent(order, eventsV1.OrderCreated)
+	if err := created.SetJsonData(eventsV1.OrderCreatedEvent{
+		ShopItems:       []*eventsV1.ShopItem{{ID: "item-1", Title: "pen", Quantity: 2, Price: 10.5}},
+		AccountEmail:    "customer@mail.com",
+		DeliveryAddress: "address",
+	}); err != nil {
+		t.Fatalf("SetJsonData() err: %v", err)
+	}
+	paid, err := eventsV1.NewOrderPaidEvent(order, &models.Payment{PaymentID: "payment-1", Timestamp: time.Now().UTC()})
+	if err != nil {
+		t.Fatalf("NewOrderPaidEvent() err: %v", err)
+	}
+	updated := es.NewBaseEvent(order, eventsV1.ShoppingCartUpdated)
+	if err := updated.SetJsonData(eventsV1.ShoppingCartUpdatedEvent{ShopItems: []*eventsV1.ShopItem{{ID: "item-2", Title: "pencil", Quantity: 1, Price: 0.99}}}); err != nil {
+		t.Fatalf("SetJsonData() err: %v", err)
+	}
+
+	if err := eventStore.SaveEvents(context.Background(), order.GetID(), []es.Event{created, paid, updated}); err != nil {
+		t.Fatalf("SaveEvents() err: %v", err)
+	}
+}
+
+func TestGetOrderHistoryHandlerUpcastsEvents(t *testing.T) {
+	tests := []struct {
+		name       string
+		eventTypes []string
+		identity   *auth.Identity
+		types      []string
+		err        error
+	}{
+		{name: "all events", types: []string{eventsV2.OrderCreated, eventsV1.OrderPaid, eventsV2.ShoppingCartUpdated}},
+		{name: "current event type", eventTypes: []string{eventsV2.OrderCreated}, types: []string{eventsV2.OrderCreated}},
+		{name: "stored event type", eventTypes: []string{eventsV1.OrderCreated}, types: []string{}},
+		{name: "owner", identity: &auth.Identity{Subject: "customer-1", Email: "customer@mail.com"}, types: []string{eventsV2.OrderCreated, eventsV1.OrderPaid, eventsV2.ShoppingCartUpdated}},
+		{name: "other customer", identity: &auth.Identity{Subject: "customer-2", Email: "other@mail.com"}, err: auth.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventStore := memory.NewEventStore(newTestLogger(), memory.NewDB())
+			saveV1OrderEvents(t, eventStore, "order-1")
+			handler := NewGetOrderHistoryHandler(newTestLogger(), &config.Config{}, eventStore, events.NewOrderUpcaster("USD"))
+
+			ctx := context.Background()
+			if tt.identity != nil {
+				ctx = auth.WithIdentity(ctx, tt.identity)
+			}
+			history, err := handler.Handle(ctx, &GetOrderHistoryQuery{ID: "order-1", EventTypes: tt.eventTypes, Pq: utils.NewPaginationQuery(10, 1)})
+			if !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+				t.Fatalf("Handle() err = %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				return
+			}
+
+			types := make([]string, 0, len(history.Events))
+			for _, event := range history.Events {
+				types = append(types, event.EventType)
+			}
+			if fmt.Sprint(types) != fmt.Sprint(tt.types) {
+				t.Fatalf("event types = %v, want %v", types, tt.types)
+			}
+			if len(tt.eventTypes) > 0 {
+				return
+			}
+
+			// the V1 float prices are returned as the V2 money in the legacy currency minor units
+			var created eventsV2.OrderCreatedEvent
+			if err := json.Unmarshal(history.Events[0].Data, &created); err != nil {
+				t.Fatalf("json.Unmarshal() created err: %v", err)
+			}
+			if len(created.ShopItems) != 1 || created.ShopItems[0].Price != models.NewMoney(1050, "USD") || created.AccountEmail != "customer@mail.com" {
+				t.Errorf("created = %+v, want price 1050 USD", created)
+			}
+			var updated eventsV2.ShoppingCartUpdatedEvent
+			if err := json.Unmarshal(history.Events[2].Data, &updated); err != nil {
+				t.Fatalf("json.Unmarshal() updated err: %v", err)
+			}
+			if len(updated.ShopItems) != 1 || updated.ShopItems[0].Price != models.NewMoney(99, "USD") {
+				t.Errorf("updated = %+v, want price 99 USD", updated)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/AleksK1NG/es-microservice/config"
+	"github.com/AleksK1NG/es-microservice/internal/dto"
+	"github.com/AleksK1NG/es-microservice/internal/mappers"
+	"github.com/AleksK1NG/es-microservice/internal/order/models"
+	"github.com/AleksK1NG/es-microservice/internal/order/repository"
+	"github.com/AleksK1NG/es-microservice/pkg/auth"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type ListOrdersByAccountQueryHandler interface {
+	Handle(ctx context.Context, query *ListOrdersByAccountQuery) (*dto.AccountOrdersResponseDto, error)
+}
+
+type listOrdersByAccountHandler struct {
+	log       logger.Logger
+	cfg       *config.Config
+	mongoRepo repository.OrderMongoRepository
+}
+
+func NewListOrdersByAccountHandler(log logger.Logger, cfg *config.Config, mongoRepo repository.OrderMongoRepository) *listOrdersByAccountHandler {
+	return &listOrdersByAccountHandler{log: log, cfg: cfg, mongoRepo: mongoRepo}
+}
+
+func (q *listOrdersByAccountHandler) Handle(ctx context.Context, query *ListOrdersByAccountQuery) (*dto.AccountOrdersResponseDto, error) {
+	ctx, span := tracing.StartSpan(ctx, "listOrdersByAccountHandler.Handle")
+	defer span.End()
+	span.SetAttributes(attribute.String("TenantID", query.TenantID), attribute.String("AccountEmail", query.AccountEmail), tracing.Object("Statuses", query.Statuses), attribute.String("OrderBy", query.OrderBy))
+
+	// customers list only the orders of their account
+	if err := auth.CheckOrderAccess(ctx, query.AccountEmail); err != nil {
+		return nil, err
+	}
+
+	statuses, err := models.OrderStatusesFromStrings(query.Statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := q.mongoRepo.ListByAccount(ctx, query.TenantID, query.AccountEmail, statuses, query.Pq)
+	if err != nil {
+		return nil, err
+	}
+
+	return mappers.AccountOrdersResponseFromModel(page, query.Pq), nil
+}
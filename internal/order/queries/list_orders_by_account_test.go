@@ -0,0 +1,61 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/AleksK1NG/es-microservice/config"
+	"github.com/AleksK1NG/es-microservice/internal/order/models"
+	"github.com/AleksK1NG/es-microservice/internal/order/repository"
+	"github.com/AleksK1NG/es-microservice/pkg/utils"
+	"github.com/pkg/errors"
+)
+
+// listRepository records the statuses of the listed orders.
+type listRepository struct {
+	repository.OrderMongoRepository
+	listed   bool
+	statuses []models.OrderStatus
+}
+
+func (r *listRepository) ListByAccount(ctx context.Context, tenantID string, accountEmail string, statuses []models.OrderStatus, pq *utils.Pagination) (*models.AccountOrdersPage, error) {
+	r.listed = true
+	r.statuses = statuses
+	return &models.AccountOrdersPage{}, nil
+}
+
+func TestListOrdersByAccountHandlerStatuses(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []string
+		want     []models.OrderStatus
+		err      error
+	}{
+		{name: "without statuses", want: []models.OrderStatus{}},
+		{name: "known statuses", statuses: []string{"pending"}, want: []models.OrderStatus{models.OrderStatusPending}},
+		{name: "unknown status", statuses: []string{"Pending"}, err: models.ErrUnknownOrderStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &listRepository{}
+			handler := NewListOrdersByAccountHandler(newTestLogger(), &config.Config{}, repo)
+
+			query := NewListOrdersByAccountQuery("", "customer@mail.com", tt.statuses, utils.NewPaginationQuery(10, 1))
+			_, err := handler.Handle(context.Background(), query)
+			if !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+				t.Fatalf("Handle() err = %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				if repo.listed {
+					t.Errorf("listed with unknown status, statuses: %v", repo.statuses)
+				}
+				return
+			}
+			if fmt.Sprint(repo.statuses) != fmt.Sprint(tt.want) {
+				t.Errorf("listed statuses = %v, want %v", repo.statuses, tt.want)
+			}
+		})
+	}
+}
@@ -1,29 +1,128 @@
 package queries
 
-import "github.com/AleksK1NG/es-microservice/pkg/utils"
+import (
+	"time"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/utils"
+)
 
 type OrderQueries struct {
-	GetOrderByID GetOrderByIDQueryHandler
-	SearchOrders SearchOrdersQueryHandler
+	GetOrderByID        GetOrderByIDQueryHandler
+	SearchOrders        SearchOrdersQueryHandler
+	ListOrdersByAccount ListOrdersByAccountQueryHandler
+	GetOrderHistory     GetOrderHistoryQueryHandler
+	GetOrderAt          GetOrderAtQueryHandler
+	WatchOrder          WatchOrderQueryHandler
 }
 
-func NewOrderQueries(getOrderByID GetOrderByIDQueryHandler, searchOrders SearchOrdersQueryHandler) *OrderQueries {
-	return &OrderQueries{GetOrderByID: getOrderByID, SearchOrders: searchOrders}
+func NewOrderQueries(
+	getOrderByID GetOrderByIDQueryHandler,
+	searchOrders SearchOrdersQueryHandler,
+	listOrdersByAccount ListOrdersByAccountQueryHandler,
+	getOrderHistory GetOrderHistoryQueryHandler,
+	getOrderAt GetOrderAtQueryHandler,
+	watchOrder WatchOrderQueryHandler,
+) *OrderQueries {
+	return &OrderQueries{
+		GetOrderByID:        getOrderByID,
+		SearchOrders:        searchOrders,
+		ListOrdersByAccount: listOrdersByAccount,
+		GetOrderHistory:     getOrderHistory,
+		GetOrderAt:          getOrderAt,
+		WatchOrder:          watchOrder,
+	}
 }
 
 type GetOrderByIDQuery struct {
-	ID string
+	TenantID string
+	ID       string
 }
 
-func NewGetOrderByIDQuery(ID string) *GetOrderByIDQuery {
-	return &GetOrderByIDQuery{ID: ID}
+func NewGetOrderByIDQuery(tenantID string, ID string) *GetOrderByIDQuery {
+	return &GetOrderByIDQuery{TenantID: tenantID, ID: ID}
 }
 
 type SearchOrdersQuery struct {
-	SearchText string `json:"searchText"`
+	TenantID   string             `json:"tenantId"`
+	SearchText string             `json:"searchText"`
+	Filter     SearchOrdersFilter `json:"filter"`
+	OrderBy    string             `json:"orderBy" validate:"omitempty,oneof=createdAt createdAt:asc createdAt:desc deliveredTime deliveredTime:asc deliveredTime:desc totalPrice totalPrice:asc totalPrice:desc status status:asc status:desc accountEmail accountEmail:asc accountEmail:desc"`
+	Pq         *utils.Pagination
+}
+
+// SearchOrdersFilter filters of the orders search, the ranges bounds are inclusive and the total price is in the currency minor units.
+// Statuses are checked against the order statuses by the query handler.
+type SearchOrdersFilter struct {
+	Statuses      []string   `json:"statuses"`
+	AccountEmail  string     `json:"accountEmail" validate:"omitempty,email"`
+	Currency      string     `json:"currency" validate:"omitempty,len=3,alpha"`
+	MinTotalPrice *int64     `json:"minTotalPrice" validate:"omitempty,gte=0"`
+	MaxTotalPrice *int64     `json:"maxTotalPrice" validate:"omitempty,gte=0"`
+	CreatedFrom   *time.Time `json:"createdFrom"`
+	CreatedTo     *time.Time `json:"createdTo"`
+	DeliveredFrom *time.Time `json:"deliveredFrom"`
+	DeliveredTo   *time.Time `json:"deliveredTo"`
+}
+
+// NewSearchOrdersQuery the results are sorted by the pagination order by.
+func NewSearchOrdersQuery(tenantID string, searchText string, filter SearchOrdersFilter, pq *utils.Pagination) *SearchOrdersQuery {
+	return &SearchOrdersQuery{TenantID: tenantID, SearchText: searchText, Filter: filter, OrderBy: pq.GetOrderBy(), Pq: pq}
+}
+
+// ListOrdersByAccountQuery orders of the account read from the MongoDB projection, sorted by the pagination order by.
+type ListOrdersByAccountQuery struct {
+	TenantID     string   `json:"tenantId"`
+	AccountEmail string   `json:"accountEmail" validate:"required,email"`
+	Statuses     []string `json:"statuses"`
+	OrderBy      string   `json:"orderBy" validate:"omitempty,oneof=createdAt createdAt:asc createdAt:desc deliveredTime deliveredTime:asc deliveredTime:desc totalPrice totalPrice:asc totalPrice:desc status status:asc status:desc"`
+	Pq           *utils.Pagination
+}
+
+func NewListOrdersByAccountQuery(tenantID string, accountEmail string, statuses []string, pq *utils.Pagination) *ListOrdersByAccountQuery {
+	return &ListOrdersByAccountQuery{TenantID: tenantID, AccountEmail: accountEmail, Statuses: statuses, OrderBy: pq.GetOrderBy(), Pq: pq}
+}
+
+type GetOrderHistoryQuery struct {
+	TenantID   string   `json:"tenantId"`
+	ID         string   `json:"id" validate:"required"`
+	EventTypes []string `json:"eventTypes"`
 	Pq         *utils.Pagination
 }
 
-func NewSearchOrdersQuery(searchText string, pq *utils.Pagination) *SearchOrdersQuery {
-	return &SearchOrdersQuery{SearchText: searchText, Pq: pq}
+func NewGetOrderHistoryQuery(tenantID string, ID string, eventTypes []string, pq *utils.Pagination) *GetOrderHistoryQuery {
+	return &GetOrderHistoryQuery{TenantID: tenantID, ID: ID, EventTypes: eventTypes, Pq: pq}
+}
+
+// GetOrderAtQuery point in time order state query, Version is the stream revision of the last event to apply,
+// Timestamp is the time of the last event to apply, when both are set the order stops at the first of them.
+type GetOrderAtQuery struct {
+	TenantID  string     `json:"tenantId"`
+	ID        string     `json:"id" validate:"required"`
+	Version   *int64     `json:"version" validate:"required_without=Timestamp,omitempty,gte=0"`
+	Timestamp *time.Time `json:"timestamp" validate:"required_without=Version"`
+}
+
+func NewGetOrderAtQuery(tenantID string, ID string, version *int64, timestamp *time.Time) *GetOrderAtQuery {
+	return &GetOrderAtQuery{TenantID: tenantID, ID: ID, Version: version, Timestamp: timestamp}
+}
+
+// IsAfter check is the event after the requested point in time.
+func (q *GetOrderAtQuery) IsAfter(event es.Event) bool {
+	if q.Version != nil && event.GetVersion() > *q.Version {
+		return true
+	}
+	return q.Timestamp != nil && event.GetTimeStamp().After(*q.Timestamp)
+}
+
+// WatchOrderQuery live order updates query, AfterVersion is the stream revision of the last update received by the client
+// before reconnect, without it the updates start from the current order state.
+type WatchOrderQuery struct {
+	TenantID     string `json:"tenantId"`
+	ID           string `json:"id" validate:"required"`
+	AfterVersion *int64 `json:"afterVersion" validate:"omitempty,gte=0"`
+}
+
+func NewWatchOrderQuery(tenantID string, ID string, afterVersion *int64) *WatchOrderQuery {
+	return &WatchOrderQuery{TenantID: tenantID, ID: ID, AfterVersion: afterVersion}
 }
@@ -0,0 +1,97 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/AleksK1NG/es-microservice/config"
+	"github.com/AleksK1NG/es-microservice/internal/order/aggregate"
+	"github.com/AleksK1NG/es-microservice/internal/order/models"
+	"github.com/AleksK1NG/es-microservice/pkg/auth"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// OrderUpdateHandler called for each order update, returned error stops watching.
+type OrderUpdateHandler func(ctx context.Context, update *models.OrderUpdate) error
+
+type WatchOrderQueryHandler interface {
+	Handle(ctx context.Context, query *WatchOrderQuery, handler OrderUpdateHandler) error
+}
+
+type watchOrderHandler struct {
+	log        logger.Logger
+	cfg        *config.Config
+	eventStore es.EventStore
+	subscriber es.StreamSubscriber
+	upcaster   es.Upcaster
+}
+
+func NewWatchOrderHandler(
+	log logger.Logger,
+	cfg *config.Config,
+	eventStore es.EventStore,
+	subscriber es.StreamSubscriber,
+	upcaster es.Upcaster,
+) *watchOrderHandler {
+	return &watchOrderHandler{log: log, cfg: cfg, eventStore: eventStore, subscriber: subscriber, upcaster: upcaster}
+}
+
+// Handle replays the order stream into the new OrderAggregate and calls handler for the events after AfterVersion,
+// or for the last event without it, then applies and hands over the new events from the catch-up subscription until ctx is done.
+func (q *watchOrderHandler) Handle(ctx context.Context, query *WatchOrderQuery, handler OrderUpdateHandler) error {
+	ctx, span := tracing.StartSpan(ctx, "watchOrderHandler.Handle")
+	defer span.End()
+	span.SetAttributes(attribute.String("TenantID", query.TenantID), attribute.String("AggregateID", query.ID), tracing.Object("AfterVersion", query.AfterVersion))
+
+	order := aggregate.NewOrderAggregateWithID(query.TenantID, query.ID)
+	events, err := q.eventStore.LoadEvents(ctx, order.GetID())
+	if err != nil {
+		if errors.Is(err, esdb.ErrStreamNotFound) {
+			return errors.Wrapf(es.ErrAggregateNotFound, "AggregateID: {%s}", order.GetID())
+		}
+		return err
+	}
+	if len(events) == 0 {
+		return errors.Wrapf(es.ErrAggregateNotFound, "AggregateID: {%s}", order.GetID())
+	}
+
+	lastVersion := events[len(events)-1].GetVersion()
+	afterVersion := lastVersion - 1
+	if query.AfterVersion != nil {
+		afterVersion = *query.AfterVersion
+	}
+
+	apply := func(ctx context.Context, event es.Event) error {
+		upcasted, err := es.UpcastEvent(q.upcaster, event)
+		if err != nil {
+			return errors.Wrap(err, "UpcastEvent")
+		}
+		if err := order.RaiseEvent(upcasted); err != nil {
+			return errors.Wrap(err, "RaiseEvent")
+		}
+		if event.GetVersion() <= afterVersion {
+			return nil
+		}
+		if err := auth.CheckOrderAccess(ctx, order.Order.AccountEmail); err != nil {
+			return err
+		}
+
+		q.log.Debugf("(WatchOrder) order: {%s}, eventType: {%s}, version: {%d}", order.GetID(), upcasted.GetEventType(), event.GetVersion())
+		return handler(ctx, &models.OrderUpdate{Event: upcasted, Order: order.Order})
+	}
+
+	for _, event := range events {
+		if err := apply(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	if err := q.subscriber.SubscribeToStream(ctx, order.GetID(), lastVersion, apply); err != nil {
+		return errors.Wrap(err, "subscriber.SubscribeToStream")
+	}
+	return nil
+}
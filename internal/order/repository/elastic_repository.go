@@ -3,43 +3,116 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/AleksK1NG/es-microservice/config"
 	"github.com/AleksK1NG/es-microservice/internal/dto"
 	"github.com/AleksK1NG/es-microservice/internal/mappers"
+	"github.com/AleksK1NG/es-microservice/internal/order/aggregate"
 	"github.com/AleksK1NG/es-microservice/internal/order/models"
 	"github.com/AleksK1NG/es-microservice/pkg/logger"
 	"github.com/AleksK1NG/es-microservice/pkg/tracing"
 	"github.com/AleksK1NG/es-microservice/pkg/utils"
 	v7 "github.com/olivere/elastic/v7"
-	"github.com/opentracing/opentracing-go"
-	"github.com/opentracing/opentracing-go/log"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const (
-	shopItemTitle            = "shopItems.title"
-	shopItemDescription      = "shopItems.description"
-	minimumNumberShouldMatch = 1
+	shopItemTitle                  = "shopItems.title"
+	shopItemDescription            = "shopItems.description"
+	orderStatusKeyword             = "status.keyword"
+	orderTenantID                  = "tenantId"
+	orderTenantIDKeyword           = "tenantId.keyword"
+	orderAccountEmailKeyword       = "accountEmail.keyword"
+	orderTotalPriceAmount          = "totalPrice.amount"
+	orderTotalPriceCurrencyKeyword = "totalPrice.currency.keyword"
+	orderCreatedAt                 = "createdAt"
+	orderDeliveredTime             = "deliveredTime"
+	orderIDKeyword                 = "orderId.keyword"
+	minimumNumberShouldMatch       = 1
+
+	statusesAggregation     = "statuses"
+	statusesAggregationSize = 10
+	totalPriceAggregation   = "totalPrice"
+
+	// totalHitsRelationGte the total hits is the lower bound, elastic stops counting the hits at 10000 by default
+	totalHitsRelationGte = "gte"
 )
 
+const (
+	opTypeCreate     = "create"
+	updateResultNoop = "noop"
+
+	// updateOrderScript replaces the order if its version is the previous one, the orders indexed before the versions
+	// were tracked have no version, they are updated only by the projection rebuild.
+	updateOrderScript = `if (ctx._source.version != null && ((Number) ctx._source.version).longValue() + 1 == ((Number) params.version).longValue()) {
+	ctx._source.clear();
+	ctx._source.putAll(params.order);
+} else {
+	ctx.op = 'noop';
+}`
+)
+
+const (
+	dateType    = "date"
+	longType    = "long"
+	keywordType = "keyword"
+)
+
+// searchCursor position of the next search page encoded into the pagination cursor.
+type searchCursor struct {
+	PitID       string        `json:"pit,omitempty"`
+	OrderBy     string        `json:"orderBy,omitempty"`
+	Page        int           `json:"page"`
+	SearchAfter []interface{} `json:"after,omitempty"`
+}
+
+type searchSortField struct {
+	field        string
+	unmappedType string
+}
+
+// searchSortFields index fields of the search order by fields.
+var searchSortFields = map[string]searchSortField{
+	models.OrderSortCreatedAt:     {field: orderCreatedAt, unmappedType: dateType},
+	models.OrderSortDeliveredTime: {field: orderDeliveredTime, unmappedType: dateType},
+	models.OrderSortTotalPrice:    {field: orderTotalPriceAmount, unmappedType: longType},
+	models.OrderSortStatus:        {field: orderStatusKeyword, unmappedType: keywordType},
+	models.OrderSortAccountEmail:  {field: orderAccountEmailKeyword, unmappedType: keywordType},
+}
+
 type elasticRepository struct {
 	log           logger.Logger
 	cfg           *config.Config
 	elasticClient *v7.Client
+	index         string
+	cursors       *utils.CursorCodec
 }
 
 func NewElasticRepository(log logger.Logger, cfg *config.Config, elasticClient *v7.Client) *elasticRepository {
-	return &elasticRepository{log: log, cfg: cfg, elasticClient: elasticClient}
+	return NewElasticRepositoryWithIndex(log, cfg, elasticClient, cfg.ElasticIndexes.Orders)
+}
+
+// NewElasticRepositoryWithIndex elastic repository for the given orders index or alias, used to rebuild projection into shadow index.
+func NewElasticRepositoryWithIndex(log logger.Logger, cfg *config.Config, elasticClient *v7.Client, index string) *elasticRepository {
+	return &elasticRepository{log: log, cfg: cfg, elasticClient: elasticClient, index: index, cursors: utils.NewCursorCodec(cfg.Cursors)}
 }
 
+// IndexOrder indexes the created order, the redelivered created event doesn't overwrite the order updated after it.
 func (e *elasticRepository) IndexOrder(ctx context.Context, order *models.OrderProjection) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticRepository.IndexOrder")
-	defer span.Finish()
-	span.LogFields(log.String("OrderID", order.OrderID))
+	ctx, span := tracing.StartSpan(ctx, "elasticRepository.IndexOrder")
+	defer span.End()
+	span.SetAttributes(attribute.String("OrderID", order.OrderID))
 
-	res, err := e.elasticClient.Index().Index(e.cfg.ElasticIndexes.Orders).BodyJson(order).Id(order.OrderID).Do(ctx)
+	res, err := e.elasticClient.Index().Index(e.index).OpType(opTypeCreate).BodyJson(order).Id(order.OrderID).Do(ctx)
 	if err != nil {
+		if v7.IsConflict(err) {
+			e.log.Debugf("(IndexOrder) already projected OrderID: {%s}", order.OrderID)
+			return nil
+		}
 		tracing.TraceErr(span, err)
 		return errors.Wrap(err, "elasticClient.Index")
 	}
@@ -48,12 +121,13 @@ func (e *elasticRepository) IndexOrder(ctx context.Context, order *models.OrderP
 	return nil
 }
 
-func (e *elasticRepository) GetByID(ctx context.Context, orderID string) (*models.OrderProjection, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticRepository.GetByID")
-	defer span.Finish()
-	span.LogFields(log.String("OrderID", orderID))
+// GetByID returns order of the tenant, orders of the other tenants are not found.
+func (e *elasticRepository) GetByID(ctx context.Context, tenantID string, orderID string) (*models.OrderProjection, error) {
+	ctx, span := tracing.StartSpan(ctx, "elasticRepository.GetByID")
+	defer span.End()
+	span.SetAttributes(attribute.String("TenantID", tenantID), attribute.String("OrderID", orderID))
 
-	result, err := e.elasticClient.Get().Index(e.cfg.ElasticIndexes.Orders).Id(orderID).FetchSource(true).Do(ctx)
+	result, err := e.elasticClient.Get().Index(e.index).Id(orderID).FetchSource(true).Do(ctx)
 	if err != nil {
 		tracing.TraceErr(span, err)
 		return nil, errors.Wrap(err, "elasticClient.Get")
@@ -71,49 +145,125 @@ func (e *elasticRepository) GetByID(ctx context.Context, orderID string) (*model
 		return nil, errors.Wrap(err, "json.Unmarshal")
 	}
 
+	if order.TenantID != tenantID {
+		return nil, errors.Wrapf(aggregate.ErrOrderNotFound, "orderID: {%s}, tenantID: {%s}", orderID, tenantID)
+	}
+
 	return &order, nil
 }
 
+// UpdateOrder replaces the order with the projection of the order event with the version only if the order version
+// is still the previous one, so the events processed concurrently by the subscription workers are applied in the stream order
+// and the projection loaded before the other worker has updated it is not saved over.
+// It is nil if the event is already applied and ErrOrderEventsPending if the previous events are not applied yet.
 func (e *elasticRepository) UpdateOrder(ctx context.Context, order *models.OrderProjection) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticRepository.UpdateShoppingCart")
-	defer span.Finish()
-	span.LogFields(log.String("OrderID", order.OrderID))
+	ctx, span := tracing.StartSpan(ctx, "elasticRepository.UpdateOrder")
+	defer span.End()
+	span.SetAttributes(attribute.String("OrderID", order.OrderID), attribute.Int64("Version", order.Version))
 
-	res, err := e.elasticClient.Update().Index(e.cfg.ElasticIndexes.Orders).Id(order.OrderID).Doc(order).FetchSource(true).Do(ctx)
+	script := v7.NewScript(updateOrderScript).Params(map[string]interface{}{"version": order.Version, "order": order})
+	res, err := e.elasticClient.Update().Index(e.index).Id(order.OrderID).Script(script).Do(ctx)
 	if err != nil {
 		tracing.TraceErr(span, err)
 		return errors.Wrap(err, "elasticClient.Update")
 	}
+	if res.Result != updateResultNoop {
+		e.log.Debugf("(UpdateOrder) OrderID: {%s}, version: {%d}, result: {%s}", order.OrderID, order.Version, res.Result)
+		return nil
+	}
 
-	e.log.Debugf("(UpdateShoppingCart) result: {%s}", res.Result)
-	return nil
+	projected, err := e.GetByID(ctx, order.TenantID, order.OrderID)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+	if projected.Version >= order.Version {
+		e.log.Debugf("(UpdateOrder) already applied OrderID: {%s}, version: {%d}, projected: {%d}", order.OrderID, order.Version, projected.Version)
+		return nil
+	}
+	return errors.Wrapf(ErrOrderEventsPending, "OrderID: {%s}, version: {%d}, projected: {%d}", order.OrderID, order.Version, projected.Version)
 }
 
-func (e *elasticRepository) Search(ctx context.Context, text string, pq *utils.Pagination) (*dto.OrderSearchResponseDto, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "elasticRepository.Search")
-	defer span.Finish()
-	span.LogFields(log.String("Search", text))
+// Search full text search by the shop items of the orders matching the filter, empty text matches all orders,
+// the results are sorted by the pagination order by and aggregated by the statuses and the total price.
+// The pages after the first one are loaded by the offset or by the search_after position of the pagination cursor,
+// the cursor pages are searched in the point in time opened by the first page of the cursor pagination if the cursor keep alive is configured,
+// it is closed on the last page.
+func (e *elasticRepository) Search(ctx context.Context, filter *models.OrderSearchFilter, pq *utils.Pagination) (*dto.OrderSearchResponseDto, error) {
+	ctx, span := tracing.StartSpan(ctx, "elasticRepository.Search")
+	defer span.End()
+	span.SetAttributes(attribute.String("TenantID", filter.TenantID), attribute.String("AccountEmail", filter.AccountEmail), attribute.String("Search", filter.Text), tracing.Object("Statuses", filter.Statuses))
 
-	shouldMatch := v7.NewBoolQuery().
-		Should(v7.NewMatchPhrasePrefixQuery(shopItemTitle, text), v7.NewMatchPhrasePrefixQuery(shopItemDescription, text)).
-		MinimumNumberShouldMatch(minimumNumberShouldMatch)
+	// statuses filter is applied after the aggregations, the statuses facet counts all statuses
+	statusQuery := v7.Query(v7.NewMatchAllQuery())
+	if len(filter.Statuses) > 0 {
+		values := make([]interface{}, 0, len(filter.Statuses))
+		for _, status := range filter.Statuses {
+			values = append(values, status.String())
+		}
+		statusQuery = v7.NewTermsQuery(orderStatusKeyword, values...)
+	}
 
-	searchResult, err := e.elasticClient.Search(e.cfg.ElasticIndexes.Orders).
-		Query(shouldMatch).
-		From(pq.GetOffset()).
+	cursor, err := e.getSearchCursor(ctx, pq)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return nil, err
+	}
+
+	searchService := e.elasticClient.Search(e.index)
+	if cursor.PitID != "" {
+		// the point in time search has no index, it is set by the point in time
+		searchService = e.elasticClient.Search().PointInTime(v7.NewPointInTimeWithKeepAlive(cursor.PitID, e.getCursorKeepAlive()))
+	}
+	if len(cursor.SearchAfter) > 0 {
+		searchService = searchService.SearchAfter(cursor.SearchAfter...)
+	} else {
+		searchService = searchService.From(pq.GetOffset())
+	}
+
+	searchService = searchService.
+		Query(getSearchQuery(filter)).
+		PostFilter(statusQuery).
+		Aggregation(statusesAggregation, v7.NewTermsAggregation().Field(orderStatusKeyword).Size(statusesAggregationSize)).
+		Aggregation(totalPriceAggregation, v7.NewFilterAggregation().Filter(statusQuery).SubAggregation(
+			totalPriceAggregation,
+			v7.NewHistogramAggregation().Field(orderTotalPriceAmount).Interval(float64(e.cfg.Orders.SearchPriceInterval)).MinDocCount(1),
+		)).
+		SortBy(getSearchSorters(cursor.OrderBy)...).
 		Explain(e.cfg.Elastic.Explain).
 		FetchSource(e.cfg.Elastic.FetchSource).
 		Version(e.cfg.Elastic.Version).
-		Size(pq.GetSize()).
-		Pretty(e.cfg.Elastic.Pretty).
-		Do(ctx)
+		// the extra hit tells if there is the next page, the total hits are not counted past the track total hits limit
+		Size(pq.GetSize() + 1).
+		Pretty(e.cfg.Elastic.Pretty)
+
+	searchResult, err := searchService.Do(ctx)
 	if err != nil {
 		tracing.TraceErr(span, err)
+		if pq.GetCursor() != "" && v7.IsNotFound(err) {
+			return nil, errors.Wrapf(utils.ErrInvalidCursor, "point in time expired, err: {%v}", err)
+		}
+		// the client gets no cursor of the point in time opened for the first page
+		if pq.GetCursor() == "" {
+			e.closePointInTime(ctx, cursor)
+		}
 		return nil, errors.Wrap(err, "elasticClient.Search")
 	}
 
-	orders := make([]*models.OrderProjection, 0, len(searchResult.Hits.Hits))
-	for _, hit := range searchResult.Hits.Hits {
+	hits := searchResult.Hits.Hits
+	hasMore := len(hits) > pq.GetSize()
+	if hasMore {
+		hits = hits[:pq.GetSize()]
+	}
+
+	nextCursor, err := e.getNextSearchCursor(ctx, cursor, searchResult, hits, hasMore, pq)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return nil, err
+	}
+
+	orders := make([]*models.OrderProjection, 0, len(hits))
+	for _, hit := range hits {
 		jsonBytes, err := hit.Source.MarshalJSON()
 		if err != nil {
 			tracing.TraceErr(span, err)
@@ -128,13 +278,179 @@ func (e *elasticRepository) Search(ctx context.Context, text string, pq *utils.P
 	}
 
 	return &dto.OrderSearchResponseDto{
-		Pagination: dto.Pagination{
-			TotalCount: searchResult.TotalHits(),
-			TotalPages: int64(pq.GetTotalPages(int(searchResult.TotalHits()))),
-			Page:       int64(pq.GetPage()),
-			Size:       int64(pq.GetSize()),
-			HasMore:    pq.GetHasMore(int(searchResult.TotalHits())),
-		},
-		Orders: mappers.OrdersFromProjections(orders),
+		Pagination: getSearchPagination(searchResult, hasMore, nextCursor, pq),
+		Orders:     mappers.OrdersFromProjections(orders),
+		Facets:     mappers.SearchFacetsResponseFromModel(getSearchFacets(searchResult.Aggregations)),
 	}, nil
 }
+
+// getSearchPagination returns pagination of the search page, if the total hits is the lower bound
+// the total pages are counted up to the next page.
+func getSearchPagination(searchResult *v7.SearchResult, hasMore bool, nextCursor string, pq *utils.Pagination) dto.Pagination {
+	totalCount := searchResult.TotalHits()
+	totalPages := int64(pq.GetTotalPages(int(totalCount)))
+	lowerBound := searchResult.Hits.TotalHits != nil && searchResult.Hits.TotalHits.Relation == totalHitsRelationGte
+	if lowerBound && hasMore && totalPages <= int64(pq.GetPage()) {
+		totalPages = int64(pq.GetPage()) + 1
+	}
+
+	return dto.Pagination{
+		TotalCount: totalCount,
+		TotalPages: totalPages,
+		Page:       int64(pq.GetPage()),
+		Size:       int64(pq.GetSize()),
+		HasMore:    hasMore,
+		NextCursor: nextCursor,
+	}
+}
+
+// getSearchQuery returns query of the tenant orders matching the filter except the statuses.
+func getSearchQuery(filter *models.OrderSearchFilter) *v7.BoolQuery {
+	// the default tenant orders have no tenant id
+	query := v7.NewBoolQuery().MustNot(v7.NewExistsQuery(orderTenantID))
+	if filter.TenantID != "" {
+		query = v7.NewBoolQuery().Filter(v7.NewTermQuery(orderTenantIDKeyword, filter.TenantID))
+	}
+	if filter.AccountEmail != "" {
+		query = query.Filter(v7.NewTermQuery(orderAccountEmailKeyword, filter.AccountEmail))
+	}
+	if filter.Text != "" {
+		shouldMatch := v7.NewBoolQuery().
+			Should(v7.NewMatchPhrasePrefixQuery(shopItemTitle, filter.Text), v7.NewMatchPhrasePrefixQuery(shopItemDescription, filter.Text)).
+			MinimumNumberShouldMatch(minimumNumberShouldMatch)
+		query = query.Must(shouldMatch)
+	}
+	if filter.Currency != "" {
+		query = query.Filter(v7.NewTermQuery(orderTotalPriceCurrencyKeyword, filter.Currency))
+	}
+	if filter.MinTotalPrice != nil || filter.MaxTotalPrice != nil {
+		priceRange := v7.NewRangeQuery(orderTotalPriceAmount)
+		if filter.MinTotalPrice != nil {
+			priceRange = priceRange.Gte(*filter.MinTotalPrice)
+		}
+		if filter.MaxTotalPrice != nil {
+			priceRange = priceRange.Lte(*filter.MaxTotalPrice)
+		}
+		query = query.Filter(priceRange)
+	}
+	if dateRange := getDateRangeQuery(orderCreatedAt, filter.CreatedFrom, filter.CreatedTo); dateRange != nil {
+		query = query.Filter(dateRange)
+	}
+	if dateRange := getDateRangeQuery(orderDeliveredTime, filter.DeliveredFrom, filter.DeliveredTo); dateRange != nil {
+		query = query.Filter(dateRange)
+	}
+	return query
+}
+
+func getDateRangeQuery(field string, from *time.Time, to *time.Time) *v7.RangeQuery {
+	if from == nil && to == nil {
+		return nil
+	}
+	dateRange := v7.NewRangeQuery(field)
+	if from != nil {
+		dateRange = dateRange.Gte(from.UTC().Format(time.RFC3339Nano))
+	}
+	if to != nil {
+		dateRange = dateRange.Lte(to.UTC().Format(time.RFC3339Nano))
+	}
+	return dateRange
+}
+
+// getSearchCursor returns position of the page, it is decoded from the pagination cursor,
+// the first page of the cursor pagination opens point in time if the cursor keep alive is configured.
+func (e *elasticRepository) getSearchCursor(ctx context.Context, pq *utils.Pagination) (*searchCursor, error) {
+	if pq.GetCursor() != "" {
+		var cursor searchCursor
+		if err := e.cursors.Decode(pq.GetCursor(), &cursor); err != nil {
+			return nil, err
+		}
+		pq.SetOrderBy(cursor.OrderBy)
+		pq.Page = cursor.Page
+		return &cursor, nil
+	}
+
+	cursor := &searchCursor{OrderBy: pq.GetOrderBy(), Page: pq.GetPage()}
+	if !pq.GetUseCursor() || e.cfg.Orders.SearchCursorKeepAlive <= 0 {
+		return cursor, nil
+	}
+
+	pit, err := e.elasticClient.OpenPointInTime(e.index).KeepAlive(e.getCursorKeepAlive()).Do(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "elasticClient.OpenPointInTime")
+	}
+	cursor.PitID = pit.Id
+	return cursor, nil
+}
+
+// getNextSearchCursor returns cursor of the next page after the page hits, empty string and closed point in time on the last page.
+func (e *elasticRepository) getNextSearchCursor(ctx context.Context, cursor *searchCursor, searchResult *v7.SearchResult, hits []*v7.SearchHit, hasMore bool, pq *utils.Pagination) (string, error) {
+	if !hasMore || len(hits) == 0 {
+		e.closePointInTime(ctx, cursor)
+		return "", nil
+	}
+
+	next := &searchCursor{OrderBy: cursor.OrderBy, Page: pq.GetPage() + 1, SearchAfter: hits[len(hits)-1].Sort}
+	if cursor.PitID != "" {
+		// the point in time id can change between the searches
+		next.PitID = cursor.PitID
+		if searchResult.PitId != "" {
+			next.PitID = searchResult.PitId
+		}
+	}
+	return e.cursors.Encode(next)
+}
+
+// closePointInTime closes point in time of the cursor, if it is not closed it expires after the keep alive.
+func (e *elasticRepository) closePointInTime(ctx context.Context, cursor *searchCursor) {
+	if cursor.PitID == "" {
+		return
+	}
+	if _, err := e.elasticClient.ClosePointInTime(cursor.PitID).Do(ctx); err != nil {
+		e.log.Warnf("(ClosePointInTime) err: {%v}", err)
+	}
+}
+
+// getCursorKeepAlive returns the point in time keep alive in the elastic time units.
+func (e *elasticRepository) getCursorKeepAlive() string {
+	return fmt.Sprintf("%dms", e.cfg.Orders.SearchCursorKeepAlive.Milliseconds())
+}
+
+// getSearchSorters returns sorters of the field:direction order by, by the relevance if it is empty,
+// the order id tiebreaker makes the order of the search_after pages stable.
+func getSearchSorters(orderBy string) []v7.Sorter {
+	tiebreaker := v7.NewFieldSort(orderIDKeyword).UnmappedType(keywordType).Asc()
+
+	parts := strings.SplitN(orderBy, ":", 2)
+	sortField, ok := searchSortFields[parts[0]]
+	if !ok {
+		return []v7.Sorter{v7.NewScoreSort().Desc(), tiebreaker}
+	}
+
+	// the field can be not mapped yet if the index has no orders with it
+	sorter := v7.NewFieldSort(sortField.field).UnmappedType(sortField.unmappedType).Asc()
+	if len(parts) == 2 && parts[1] == models.OrderSortDesc {
+		sorter = sorter.Desc()
+	}
+	return []v7.Sorter{sorter, tiebreaker}
+}
+
+func getSearchFacets(aggregations v7.Aggregations) *models.OrderSearchFacets {
+	facets := &models.OrderSearchFacets{}
+
+	if statuses, ok := aggregations.Terms(statusesAggregation); ok {
+		for _, bucket := range statuses.Buckets {
+			key, _ := bucket.Key.(string)
+			facets.Statuses = append(facets.Statuses, models.FacetBucket{Key: key, Count: bucket.DocCount})
+		}
+	}
+
+	if filtered, ok := aggregations.Filter(totalPriceAggregation); ok {
+		if totalPrice, ok := filtered.Histogram(totalPriceAggregation); ok {
+			for _, bucket := range totalPrice.Buckets {
+				facets.TotalPrice = append(facets.TotalPrice, models.HistogramBucket{From: int64(bucket.Key), Count: bucket.DocCount})
+			}
+		}
+	}
+
+	return facets
+}
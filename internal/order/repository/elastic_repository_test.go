@@ -0,0 +1,328 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AleksK1NG/es-microservice/config"
+	"github.com/AleksK1NG/es-microservice/internal/order/models"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/utils"
+	v7 "github.com/olivere/elastic/v7"
+	"github.com/pkg/errors"
+)
+
+const testPitID = "pit-1"
+
+// elasticServer fake elasticsearch recording the requests and the body of the last search, the search returns hits orders
+// with the aggregations and fails with failStatus if it is set.
+type elasticServer struct {
+	mu           sync.Mutex
+	requests     []string
+	searchBody   map[string]interface{}
+	hits         int
+	aggregations map[string]interface{}
+	failStatus   int
+}
+
+func (s *elasticServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+	if strings.HasSuffix(r.URL.Path, "/_search") {
+		s.searchBody = nil
+		_ = json.NewDecoder(r.Body).Decode(&s.searchBody)
+	}
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/orders/_pit":
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": testPitID})
+	case r.Method == http.MethodDelete && r.URL.Path == "/_pit":
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"succeeded": true, "num_freed": 1})
+	case s.failStatus != 0:
+		w.WriteHeader(s.failStatus)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"error": map[string]interface{}{"type": "search_phase_execution_exception"}, "status": s.failStatus})
+	default:
+		hits := make([]map[string]interface{}, 0, s.hits)
+		for i := 0; i < s.hits; i++ {
+			orderID := fmt.Sprintf("order-%d", i)
+			hits = append(hits, map[string]interface{}{"_id": orderID, "_source": map[string]interface{}{"orderId": orderID}, "sort": []interface{}{i, orderID}})
+		}
+		response := map[string]interface{}{"hits": map[string]interface{}{"total": map[string]interface{}{"value": s.hits, "relation": "eq"}, "hits": hits}}
+		if s.aggregations != nil {
+			response["aggregations"] = s.aggregations
+		}
+		if r.URL.Path == "/_search" {
+			response["pit_id"] = testPitID
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}
+}
+
+func (s *elasticServer) getRequests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.requests...)
+}
+
+// getSearchBody returns the json of the key of the last search request body.
+func (s *elasticServer) getSearchBody(t *testing.T, key string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := json.Marshal(s.searchBody[key])
+	if err != nil {
+		t.Fatalf("json.Marshal() err: %v", err)
+	}
+	return string(data)
+}
+
+func newTestElasticRepository(t *testing.T, server *elasticServer, keepAlive time.Duration) *elasticRepository {
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	client, err := v7.NewClient(v7.SetURL(httpServer.URL), v7.SetSniff(false), v7.SetHealthcheck(false))
+	if err != nil {
+		t.Fatalf("NewClient() err: %v", err)
+	}
+
+	appLogger := logger.NewAppLogger(&logger.Config{LogLevel: "error", Encoder: "console"})
+	appLogger.InitLogger()
+	cfg := &config.Config{
+		Orders:  config.Orders{SearchPriceInterval: 1000, SearchCursorKeepAlive: keepAlive},
+		Cursors: utils.CursorConfig{Secret: "secret", TTL: time.Hour},
+	}
+	return NewElasticRepositoryWithIndex(appLogger, cfg, client, "orders")
+}
+
+func TestElasticRepositorySearchPointInTime(t *testing.T) {
+	const size = 2
+
+	tests := []struct {
+		name       string
+		keepAlive  time.Duration
+		useCursor  bool
+		cursor     *searchCursor
+		hits       int
+		failStatus int
+		err        error
+		requests   []string
+		nextPit    string
+		lastPage   bool
+	}{
+		{name: "offset page", keepAlive: time.Minute, hits: size + 1, requests: []string{"POST /orders/_search"}},
+		{name: "offset last page", keepAlive: time.Minute, hits: size, requests: []string{"POST /orders/_search"}, lastPage: true},
+		{name: "cursor first page", keepAlive: time.Minute, useCursor: true, hits: size + 1, requests: []string{"POST /orders/_pit", "POST /_search"}, nextPit: testPitID},
+		{name: "cursor single page", keepAlive: time.Minute, useCursor: true, hits: size, requests: []string{"POST /orders/_pit", "POST /_search", "DELETE /_pit"}, lastPage: true},
+		{name: "cursor first page failed", keepAlive: time.Minute, useCursor: true, failStatus: http.StatusBadRequest, requests: []string{"POST /orders/_pit", "POST /_search", "DELETE /_pit"}},
+		{name: "cursor without keep alive", useCursor: true, hits: size + 1, requests: []string{"POST /orders/_search"}},
+		{name: "cursor next page", keepAlive: time.Minute, cursor: &searchCursor{PitID: testPitID, Page: 2, SearchAfter: []interface{}{1, "order-1"}}, hits: size + 1, requests: []string{"POST /_search"}, nextPit: testPitID},
+		{name: "cursor last page", keepAlive: time.Minute, cursor: &searchCursor{PitID: testPitID, Page: 3, SearchAfter: []interface{}{1, "order-1"}}, hits: 1, requests: []string{"POST /_search", "DELETE /_pit"}, lastPage: true},
+		{name: "cursor next page failed", keepAlive: time.Minute, cursor: &searchCursor{PitID: testPitID, Page: 2, SearchAfter: []interface{}{1, "order-1"}}, failStatus: http.StatusBadRequest, requests: []string{"POST /_search"}},
+		{name: "cursor point in time expired", keepAlive: time.Minute, cursor: &searchCursor{PitID: testPitID, Page: 2, SearchAfter: []interface{}{1, "order-1"}}, failStatus: http.StatusNotFound, requests: []string{"POST /_search"}, err: utils.ErrInvalidCursor},
+		{name: "cursor without point in time", keepAlive: time.Minute, cursor: &searchCursor{Page: 2, SearchAfter: []interface{}{1, "order-1"}}, hits: size, requests: []string{"POST /orders/_search"}, lastPage: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &elasticServer{hits: tt.hits, failStatus: tt.failStatus}
+			repo := newTestElasticRepository(t, server, tt.keepAlive)
+
+			pq := utils.NewPaginationQuery(size, 1)
+			pq.SetUseCursor(tt.useCursor)
+			if tt.cursor != nil {
+				cursor, err := repo.cursors.Encode(tt.cursor)
+				if err != nil {
+					t.Fatalf("Encode() err: %v", err)
+				}
+				pq.SetCursor(cursor)
+			}
+
+			res, err := repo.Search(context.Background(), &models.OrderSearchFilter{}, pq)
+			if fmt.Sprint(server.getRequests()) != fmt.Sprint(tt.requests) {
+				t.Errorf("requests = %v, want %v", server.getRequests(), tt.requests)
+			}
+			if tt.failStatus != 0 {
+				if err == nil || (tt.err != nil && !errors.Is(err, tt.err)) {
+					t.Fatalf("Search() err = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Search() err: %v", err)
+			}
+
+			if tt.lastPage {
+				if res.Pagination.HasMore || res.Pagination.NextCursor != "" {
+					t.Errorf("last page has more = %v, next cursor = %q", res.Pagination.HasMore, res.Pagination.NextCursor)
+				}
+				return
+			}
+			var next searchCursor
+			if err := repo.cursors.Decode(res.Pagination.NextCursor, &next); err != nil {
+				t.Fatalf("Decode() next cursor err: %v", err)
+			}
+			if next.PitID != tt.nextPit || next.Page != pq.GetPage()+1 {
+				t.Errorf("next cursor pit = %q, page = %d, want %q, %d", next.PitID, next.Page, tt.nextPit, pq.GetPage()+1)
+			}
+		})
+	}
+}
+
+func TestGetSearchQuery(t *testing.T) {
+	minPrice, maxPrice := int64(1000), int64(5000)
+	from := time.Date(2026, 1, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600))
+	to := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter *models.OrderSearchFilter
+		query  string
+	}{
+		{
+			name:   "default tenant",
+			filter: &models.OrderSearchFilter{},
+			query:  `{"bool":{"must_not":{"exists":{"field":"tenantId"}}}}`,
+		},
+		{
+			name:   "tenant",
+			filter: &models.OrderSearchFilter{TenantID: "tenantA"},
+			query:  `{"bool":{"filter":{"term":{"tenantId.keyword":"tenantA"}}}}`,
+		},
+		{
+			name:   "account email",
+			filter: &models.OrderSearchFilter{AccountEmail: "customer@mail.com"},
+			query:  `{"bool":{"filter":{"term":{"accountEmail.keyword":"customer@mail.com"}},"must_not":{"exists":{"field":"tenantId"}}}}`,
+		},
+		{
+			name:   "text",
+			filter: &models.OrderSearchFilter{TenantID: "tenantA", Text: "pen"},
+			query: `{"bool":{"filter":{"term":{"tenantId.keyword":"tenantA"}},"must":{"bool":{"minimum_should_match":"1","should":[` +
+				`{"match_phrase_prefix":{"shopItems.title":{"query":"pen"}}},{"match_phrase_prefix":{"shopItems.description":{"query":"pen"}}}]}}}}`,
+		},
+		{
+			name:   "currency",
+			filter: &models.OrderSearchFilter{TenantID: "tenantA", Currency: "USD"},
+			query:  `{"bool":{"filter":[{"term":{"tenantId.keyword":"tenantA"}},{"term":{"totalPrice.currency.keyword":"USD"}}]}}`,
+		},
+		{
+			name:   "total price range",
+			filter: &models.OrderSearchFilter{TenantID: "tenantA", MinTotalPrice: &minPrice, MaxTotalPrice: &maxPrice},
+			query: `{"bool":{"filter":[{"term":{"tenantId.keyword":"tenantA"}},` +
+				`{"range":{"totalPrice.amount":{"from":1000,"include_lower":true,"include_upper":true,"to":5000}}}]}}`,
+		},
+		{
+			name:   "min total price",
+			filter: &models.OrderSearchFilter{TenantID: "tenantA", MinTotalPrice: &minPrice},
+			query: `{"bool":{"filter":[{"term":{"tenantId.keyword":"tenantA"}},` +
+				`{"range":{"totalPrice.amount":{"from":1000,"include_lower":true,"include_upper":true,"to":null}}}]}}`,
+		},
+		{
+			name:   "created and delivered dates in UTC",
+			filter: &models.OrderSearchFilter{TenantID: "tenantA", CreatedFrom: &from, CreatedTo: &to, DeliveredTo: &to},
+			query: `{"bool":{"filter":[{"term":{"tenantId.keyword":"tenantA"}},` +
+				`{"range":{"createdAt":{"from":"2026-01-02T02:04:05Z","include_lower":true,"include_upper":true,"to":"2026-02-01T00:00:00Z"}}},` +
+				`{"range":{"deliveredTime":{"from":null,"include_lower":true,"include_upper":true,"to":"2026-02-01T00:00:00Z"}}}]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := getSearchQuery(tt.filter).Source()
+			if err != nil {
+				t.Fatalf("Source() err: %v", err)
+			}
+			query, err := json.Marshal(source)
+			if err != nil {
+				t.Fatalf("json.Marshal() err: %v", err)
+			}
+			if string(query) != tt.query {
+				t.Errorf("getSearchQuery() = %s, want %s", query, tt.query)
+			}
+		})
+	}
+}
+
+func TestGetSearchSorters(t *testing.T) {
+	const tiebreaker = `{"orderId.keyword":{"order":"asc","unmapped_type":"keyword"}}`
+
+	tests := []struct {
+		name    string
+		orderBy string
+		sorters string
+	}{
+		{name: "relevance", orderBy: "", sorters: `[{"_score":{"order":"desc"}},` + tiebreaker + `]`},
+		{name: "total price descending", orderBy: "totalPrice:desc", sorters: `[{"totalPrice.amount":{"order":"desc","unmapped_type":"long"}},` + tiebreaker + `]`},
+		{name: "created at ascending", orderBy: "createdAt", sorters: `[{"createdAt":{"order":"asc","unmapped_type":"date"}},` + tiebreaker + `]`},
+		{name: "status", orderBy: "status:asc", sorters: `[{"status.keyword":{"order":"asc","unmapped_type":"keyword"}},` + tiebreaker + `]`},
+		{name: "unknown field", orderBy: "unknown:desc", sorters: `[{"_score":{"order":"desc"}},` + tiebreaker + `]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sources := make([]interface{}, 0, 2)
+			for _, sorter := range getSearchSorters(tt.orderBy) {
+				source, err := sorter.Source()
+				if err != nil {
+					t.Fatalf("Source() err: %v", err)
+				}
+				sources = append(sources, source)
+			}
+			sorters, err := json.Marshal(sources)
+			if err != nil {
+				t.Fatalf("json.Marshal() err: %v", err)
+			}
+			if string(sorters) != tt.sorters {
+				t.Errorf("getSearchSorters() = %s, want %s", sorters, tt.sorters)
+			}
+		})
+	}
+}
+
+func TestElasticRepositorySearchFacets(t *testing.T) {
+	server := &elasticServer{hits: 1, aggregations: map[string]interface{}{
+		"statuses": map[string]interface{}{"buckets": []interface{}{
+			map[string]interface{}{"key": "paid", "doc_count": 3},
+			map[string]interface{}{"key": "new", "doc_count": 1},
+		}},
+		"totalPrice": map[string]interface{}{"doc_count": 3, "totalPrice": map[string]interface{}{"buckets": []interface{}{
+			map[string]interface{}{"key": 0, "doc_count": 1},
+			map[string]interface{}{"key": 2000, "doc_count": 2},
+		}}},
+	}}
+	repo := newTestElasticRepository(t, server, 0)
+
+	filter := &models.OrderSearchFilter{TenantID: "tenantA", Statuses: []models.OrderStatus{models.OrderStatusPaid, models.OrderStatusSubmitted}}
+	res, err := repo.Search(context.Background(), filter, utils.NewPaginationQuery(10, 1))
+	if err != nil {
+		t.Fatalf("Search() err: %v", err)
+	}
+
+	// the statuses narrow the hits and the total price facet, the statuses facet counts the orders of all statuses
+	const statusQuery = `{"terms":{"status.keyword":["paid","submitted"]}}`
+	if postFilter := server.getSearchBody(t, "post_filter"); postFilter != statusQuery {
+		t.Errorf("post_filter = %s, want %s", postFilter, statusQuery)
+	}
+	if query := server.getSearchBody(t, "query"); query != `{"bool":{"filter":{"term":{"tenantId.keyword":"tenantA"}}}}` {
+		t.Errorf("query = %s, want the tenant filter without the statuses", query)
+	}
+	wantAggregations := `{"statuses":{"terms":{"field":"status.keyword","size":10}},` +
+		`"totalPrice":{"aggregations":{"totalPrice":{"histogram":{"field":"totalPrice.amount","interval":1000,"min_doc_count":1}}},"filter":` + statusQuery + `}}`
+	if aggregations := server.getSearchBody(t, "aggregations"); aggregations != wantAggregations {
+		t.Errorf("aggregations = %s, want %s", aggregations, wantAggregations)
+	}
+
+	if statuses := fmt.Sprint(res.Facets.Statuses); statuses != "[{paid 3} {new 1}]" {
+		t.Errorf("statuses facet = %s, want [{paid 3} {new 1}]", statuses)
+	}
+	if totalPrice := fmt.Sprint(res.Facets.TotalPrice); totalPrice != "[{0 1} {2000 2}]" {
+		t.Errorf("total price facet = %s, want [{0 1} {2000 2}]", totalPrice)
+	}
+}
@@ -2,37 +2,100 @@ package repository
 
 import (
 	"context"
+	"strings"
 
 	"github.com/AleksK1NG/es-microservice/config"
 	"github.com/AleksK1NG/es-microservice/internal/order/models"
 	"github.com/AleksK1NG/es-microservice/pkg/constants"
 	"github.com/AleksK1NG/es-microservice/pkg/logger"
 	"github.com/AleksK1NG/es-microservice/pkg/tracing"
-	"github.com/opentracing/opentracing-go"
-	"github.com/opentracing/opentracing-go/log"
+	"github.com/AleksK1NG/es-microservice/pkg/utils"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
 
+var (
+	// ErrOrderEventsPending the previous events of the order are not projected yet, the event is retried after them.
+	ErrOrderEventsPending = errors.New("previous order events are not projected yet")
+)
+
+// accountOrdersSortFields collection fields of the account orders order by fields.
+var accountOrdersSortFields = map[string]string{
+	models.OrderSortCreatedAt:     constants.CreatedAt,
+	models.OrderSortDeliveredTime: constants.DeliveredTime,
+	models.OrderSortTotalPrice:    constants.TotalPriceAmount,
+	models.OrderSortStatus:        constants.Status,
+}
+
+// accountOrdersCursor position of the next account orders page encoded into the pagination cursor,
+// it is the sort field bson value and the order id of the last order of the previous page.
+type accountOrdersCursor struct {
+	OrderBy    string        `json:"orderBy,omitempty"`
+	Page       int           `json:"page"`
+	AfterType  bsontype.Type `json:"afterType"`
+	AfterValue []byte        `json:"afterValue,omitempty"`
+	AfterID    string        `json:"afterId"`
+}
+
+// GetOrdersIndexes returns indexes of the orders collection, they are created on the start
+// and on the shadow collection before it replaces the live one by the projection rebuild.
+func GetOrdersIndexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: constants.OrderIdIndex, Value: 1}},
+			Options: options.Index().SetSparse(true).SetUnique(true),
+		},
+		// account orders are filtered by the tenant and account email and sorted by the creation time by default
+		{
+			Keys: bson.D{
+				{Key: constants.TenantID, Value: 1},
+				{Key: constants.AccountEmail, Value: 1},
+				{Key: constants.CreatedAt, Value: -1},
+				{Key: constants.OrderId, Value: 1},
+			},
+		},
+	}
+}
+
 type mongoRepository struct {
-	log logger.Logger
-	cfg *config.Config
-	db  *mongo.Client
+	log        logger.Logger
+	cfg        *config.Config
+	db         *mongo.Client
+	collection string
+	cursors    *utils.CursorCodec
 }
 
 func NewMongoRepository(log logger.Logger, cfg *config.Config, db *mongo.Client) *mongoRepository {
-	return &mongoRepository{log: log, cfg: cfg, db: db}
+	return NewMongoRepositoryWithCollection(log, cfg, db, cfg.MongoCollections.Orders)
 }
 
-func (m *mongoRepository) Insert(ctx context.Context, order *models.OrderProjection) (string, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoRepository.Insert")
-	defer span.Finish()
-	span.LogFields(log.String("OrderID", order.OrderID))
+// NewMongoRepositoryWithCollection mongo repository for the given orders collection, used to rebuild projection into shadow collection.
+func NewMongoRepositoryWithCollection(log logger.Logger, cfg *config.Config, db *mongo.Client, collection string) *mongoRepository {
+	return &mongoRepository{log: log, cfg: cfg, db: db, collection: collection, cursors: utils.NewCursorCodec(cfg.Cursors)}
+}
 
-	_, err := m.getOrdersCollection().InsertOne(ctx, order, &options.InsertOneOptions{})
+func (m *mongoRepository) Insert(ctx context.Context, order *models.OrderProjection) (string, error) {
+	ctx, span := tracing.StartSpan(ctx, "mongoRepository.Insert")
+	defer span.End()
+	span.SetAttributes(attribute.String("OrderID", order.OrderID))
+
+	// upsert instead of insert, so redelivered or replayed created event is idempotent,
+	// the order updated by the later events is not replaced, the upsert fails with the duplicate key instead
+	filter := getOrderFilter(order.TenantID, order.OrderID)
+	filter[constants.Version] = bson.M{"$not": bson.M{"$gt": order.Version}}
+	ops := options.Replace().SetUpsert(true)
+	_, err := m.getOrdersCollection().ReplaceOne(ctx, filter, order, ops)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			m.log.Debugf("(Insert) already projected OrderID: {%s}, version: {%d}", order.OrderID, order.Version)
+			return order.OrderID, nil
+		}
 		tracing.TraceErr(span, err)
 		return "", err
 	}
@@ -40,13 +103,13 @@ func (m *mongoRepository) Insert(ctx context.Context, order *models.OrderProject
 	return order.OrderID, nil
 }
 
-func (m *mongoRepository) GetByID(ctx context.Context, orderID string) (*models.OrderProjection, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoRepository.GetByID")
-	defer span.Finish()
-	span.LogFields(log.String("OrderID", orderID))
+func (m *mongoRepository) GetByID(ctx context.Context, tenantID string, orderID string) (*models.OrderProjection, error) {
+	ctx, span := tracing.StartSpan(ctx, "mongoRepository.GetByID")
+	defer span.End()
+	span.SetAttributes(attribute.String("TenantID", tenantID), attribute.String("OrderID", orderID))
 
 	var orderProjection models.OrderProjection
-	if err := m.getOrdersCollection().FindOne(ctx, bson.M{constants.OrderId: orderID}).Decode(&orderProjection); err != nil {
+	if err := m.getOrdersCollection().FindOne(ctx, getOrderFilter(tenantID, orderID)).Decode(&orderProjection); err != nil {
 		tracing.TraceErr(span, err)
 		return nil, err
 	}
@@ -55,124 +118,345 @@ func (m *mongoRepository) GetByID(ctx context.Context, orderID string) (*models.
 }
 
 func (m *mongoRepository) UpdateOrder(ctx context.Context, order *models.OrderProjection) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoRepository.UpdateShoppingCart")
-	defer span.Finish()
-	span.LogFields(log.String("OrderID", order.OrderID))
+	ctx, span := tracing.StartSpan(ctx, "mongoRepository.UpdateShoppingCart")
+	defer span.End()
+	span.SetAttributes(attribute.String("OrderID", order.OrderID))
 
-	ops := options.FindOneAndUpdate()
-	ops.SetReturnDocument(options.After)
-	ops.SetUpsert(false)
-
-	var res models.OrderProjection
-	if err := m.getOrdersCollection().FindOneAndUpdate(ctx, bson.M{constants.OrderId: order.OrderID}, bson.M{"$set": order}, ops).Decode(&res); err != nil {
+	update := bson.M{"$set": order}
+	if err := m.updateOrder(ctx, order.TenantID, order.OrderID, order.Version, update); err != nil {
 		tracing.TraceErr(span, err)
 		return err
 	}
 
-	m.log.Debugf("(UpdateShoppingCart) result OrderID: {%s}", res.OrderID)
+	m.log.Debugf("(UpdateShoppingCart) OrderID: {%s}, version: {%d}", order.OrderID, order.Version)
 	return nil
 }
 
 func (m *mongoRepository) UpdateCancel(ctx context.Context, order *models.OrderProjection) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoRepository.UpdateCancel")
-	defer span.Finish()
-	span.LogFields(log.String("OrderID", order.OrderID))
-
-	ops := options.FindOneAndUpdate()
-	ops.SetReturnDocument(options.After)
-	ops.SetUpsert(false)
+	ctx, span := tracing.StartSpan(ctx, "mongoRepository.UpdateCancel")
+	defer span.End()
+	span.SetAttributes(attribute.String("OrderID", order.OrderID))
 
-	update := bson.M{"$set": bson.M{constants.Canceled: order.Canceled, constants.CancelReason: order.CancelReason}}
-	var res models.OrderProjection
-	if err := m.getOrdersCollection().FindOneAndUpdate(ctx, bson.M{constants.OrderId: order.OrderID}, update, ops).Decode(&res); err != nil {
+	update := bson.M{"$set": bson.M{constants.Canceled: order.Canceled, constants.CancelReason: order.CancelReason, constants.Status: order.Status, constants.Version: order.Version}}
+	if err := m.updateOrder(ctx, order.TenantID, order.OrderID, order.Version, update); err != nil {
 		tracing.TraceErr(span, err)
 		return err
 	}
 
-	m.log.Debugf("(UpdateCancel) result OrderID: {%s}", res.OrderID)
+	m.log.Debugf("(UpdateCancel) OrderID: {%s}, version: {%d}", order.OrderID, order.Version)
 	return nil
 }
 
 func (m *mongoRepository) UpdatePayment(ctx context.Context, order *models.OrderProjection) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoRepository.UpdatePayment")
-	defer span.Finish()
-	span.LogFields(log.String("OrderID", order.OrderID))
-
-	ops := options.FindOneAndUpdate()
-	ops.SetReturnDocument(options.After)
-	ops.SetUpsert(false)
+	ctx, span := tracing.StartSpan(ctx, "mongoRepository.UpdatePayment")
+	defer span.End()
+	span.SetAttributes(attribute.String("OrderID", order.OrderID))
 
-	update := bson.M{"$set": bson.M{constants.Payment: order.Payment, constants.Paid: order.Paid}}
-	var res models.OrderProjection
-	if err := m.getOrdersCollection().FindOneAndUpdate(ctx, bson.M{constants.OrderId: order.OrderID}, update, ops).Decode(&res); err != nil {
+	update := bson.M{"$set": bson.M{constants.Payment: order.Payment, constants.Paid: order.Paid, constants.Status: order.Status, constants.Version: order.Version}}
+	if err := m.updateOrder(ctx, order.TenantID, order.OrderID, order.Version, update); err != nil {
 		tracing.TraceErr(span, err)
 		return err
 	}
 
-	m.log.Debugf("(UpdatePayment) result OrderID: {%s}", res.OrderID)
+	m.log.Debugf("(UpdatePayment) OrderID: {%s}, version: {%d}", order.OrderID, order.Version)
 	return nil
 }
 
 func (m *mongoRepository) Complete(ctx context.Context, order *models.OrderProjection) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoRepository.Complete")
-	defer span.Finish()
-	span.LogFields(log.String("OrderID", order.OrderID))
+	ctx, span := tracing.StartSpan(ctx, "mongoRepository.Complete")
+	defer span.End()
+	span.SetAttributes(attribute.String("OrderID", order.OrderID))
 
-	ops := options.FindOneAndUpdate()
-	ops.SetReturnDocument(options.After)
-	ops.SetUpsert(false)
-
-	update := bson.M{"$set": bson.M{constants.Completed: order.Completed, constants.DeliveredTime: order.DeliveredTime}}
-	var res models.OrderProjection
-	if err := m.getOrdersCollection().FindOneAndUpdate(ctx, bson.M{constants.OrderId: order.OrderID}, update, ops).Decode(&res); err != nil {
+	update := bson.M{"$set": bson.M{constants.Completed: order.Completed, constants.DeliveredTime: order.DeliveredTime, constants.Status: order.Status, constants.Version: order.Version}}
+	if err := m.updateOrder(ctx, order.TenantID, order.OrderID, order.Version, update); err != nil {
 		tracing.TraceErr(span, err)
 		return err
 	}
 
-	m.log.Debugf("(Complete) result OrderID: {%s}", res.OrderID)
+	m.log.Debugf("(Complete) OrderID: {%s}, version: {%d}", order.OrderID, order.Version)
 	return nil
 }
 
 func (m *mongoRepository) UpdateDeliveryAddress(ctx context.Context, order *models.OrderProjection) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoRepository.UpdateDeliveryAddress")
-	defer span.Finish()
-	span.LogFields(log.String("OrderID", order.OrderID))
-
-	ops := options.FindOneAndUpdate()
-	ops.SetReturnDocument(options.After)
-	ops.SetUpsert(false)
+	ctx, span := tracing.StartSpan(ctx, "mongoRepository.UpdateDeliveryAddress")
+	defer span.End()
+	span.SetAttributes(attribute.String("OrderID", order.OrderID))
 
-	update := bson.M{"$set": bson.M{constants.DeliveryAddress: order.DeliveryAddress}}
-	var res models.OrderProjection
-	if err := m.getOrdersCollection().FindOneAndUpdate(ctx, bson.M{constants.OrderId: order.OrderID}, update, ops).Decode(&res); err != nil {
+	update := bson.M{"$set": bson.M{constants.DeliveryAddress: order.DeliveryAddress, constants.Version: order.Version}}
+	if err := m.updateOrder(ctx, order.TenantID, order.OrderID, order.Version, update); err != nil {
 		tracing.TraceErr(span, err)
 		return err
 	}
 
-	m.log.Debugf("(UpdateDeliveryAddress) result OrderID: {%s}", res.OrderID)
+	m.log.Debugf("(UpdateDeliveryAddress) OrderID: {%s}, version: {%d}", order.OrderID, order.Version)
 	return nil
 }
 
 func (m *mongoRepository) UpdateSubmit(ctx context.Context, order *models.OrderProjection) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "mongoRepository.UpdateSubmit")
-	defer span.Finish()
-	span.LogFields(log.String("OrderID", order.OrderID))
+	ctx, span := tracing.StartSpan(ctx, "mongoRepository.UpdateSubmit")
+	defer span.End()
+	span.SetAttributes(attribute.String("OrderID", order.OrderID))
+
+	update := bson.M{"$set": bson.M{constants.Submitted: order.Submitted, constants.Status: order.Status, constants.Version: order.Version}}
+	if err := m.updateOrder(ctx, order.TenantID, order.OrderID, order.Version, update); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
 
-	ops := options.FindOneAndUpdate()
-	ops.SetReturnDocument(options.After)
-	ops.SetUpsert(false)
+	m.log.Debugf("(UpdateSubmit) OrderID: {%s}, version: {%d}", order.OrderID, order.Version)
+	return nil
+}
 
-	update := bson.M{"$set": bson.M{constants.Submitted: order.Submitted}}
-	var res models.OrderProjection
-	if err := m.getOrdersCollection().FindOneAndUpdate(ctx, bson.M{constants.OrderId: order.OrderID}, update, ops).Decode(&res); err != nil {
+// AddShopItem push the shop item to the order, the version guard makes redelivered event idempotent.
+func (m *mongoRepository) AddShopItem(ctx context.Context, tenantID string, orderID string, version int64, shopItem *models.ShopItem, totalPrice models.Money) error {
+	ctx, span := tracing.StartSpan(ctx, "mongoRepository.AddShopItem")
+	defer span.End()
+	span.SetAttributes(attribute.String("TenantID", tenantID), attribute.String("OrderID", orderID), attribute.String("ShopItemID", shopItem.ID))
+
+	update := bson.M{"$push": bson.M{constants.ShopItems: shopItem}, "$set": bson.M{constants.TotalPrice: totalPrice, constants.Version: version}}
+	if err := m.updateOrder(ctx, tenantID, orderID, version, update); err != nil {
 		tracing.TraceErr(span, err)
 		return err
 	}
 
-	m.log.Debugf("(UpdateSubmit) result OrderID: {%s}", res.OrderID)
+	m.log.Debugf("(AddShopItem) OrderID: {%s}, ShopItemID: {%s}, version: {%d}", orderID, shopItem.ID, version)
 	return nil
 }
 
+func (m *mongoRepository) RemoveShopItem(ctx context.Context, tenantID string, orderID string, version int64, shopItemID string, totalPrice models.Money) error {
+	ctx, span := tracing.StartSpan(ctx, "mongoRepository.RemoveShopItem")
+	defer span.End()
+	span.SetAttributes(attribute.String("TenantID", tenantID), attribute.String("OrderID", orderID), attribute.String("ShopItemID", shopItemID))
+
+	update := bson.M{"$pull": bson.M{constants.ShopItems: bson.M{constants.ID: shopItemID}}, "$set": bson.M{constants.TotalPrice: totalPrice, constants.Version: version}}
+	if err := m.updateOrder(ctx, tenantID, orderID, version, update); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	m.log.Debugf("(RemoveShopItem) OrderID: {%s}, ShopItemID: {%s}, version: {%d}", orderID, shopItemID, version)
+	return nil
+}
+
+func (m *mongoRepository) ChangeShopItemQuantity(ctx context.Context, tenantID string, orderID string, version int64, shopItemID string, quantity uint64, totalPrice models.Money) error {
+	ctx, span := tracing.StartSpan(ctx, "mongoRepository.ChangeShopItemQuantity")
+	defer span.End()
+	span.SetAttributes(attribute.String("TenantID", tenantID), attribute.String("OrderID", orderID), attribute.String("ShopItemID", shopItemID), attribute.Int64("Quantity", int64(quantity)))
+
+	update := bson.M{"$set": bson.M{constants.ShopItemQty: quantity, constants.TotalPrice: totalPrice, constants.Version: version}}
+	arrayFilters := options.ArrayFilters{Filters: []interface{}{bson.M{constants.ShopItemFilterID: shopItemID}}}
+	if err := m.updateOrder(ctx, tenantID, orderID, version, update, options.Update().SetArrayFilters(arrayFilters)); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	m.log.Debugf("(ChangeShopItemQuantity) OrderID: {%s}, ShopItemID: {%s}, version: {%d}", orderID, shopItemID, version)
+	return nil
+}
+
+// AddRefund push the refund to the order, the version guard makes redelivered event idempotent.
+func (m *mongoRepository) AddRefund(ctx context.Context, tenantID string, orderID string, version int64, refund *models.Refund, refundedAmount models.Money) error {
+	ctx, span := tracing.StartSpan(ctx, "mongoRepository.AddRefund")
+	defer span.End()
+	span.SetAttributes(attribute.String("TenantID", tenantID), attribute.String("OrderID", orderID), attribute.String("RefundID", refund.RefundID))
+
+	update := bson.M{"$push": bson.M{constants.Refunds: refund}, "$set": bson.M{constants.RefundedAmount: refundedAmount, constants.Version: version}}
+	if err := m.updateOrder(ctx, tenantID, orderID, version, update); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+
+	m.log.Debugf("(AddRefund) OrderID: {%s}, RefundID: {%s}, version: {%d}", orderID, refund.RefundID, version)
+	return nil
+}
+
+// ListByAccount returns page of the tenant orders of the account email with one of the statuses, any status if statuses are empty,
+// sorted by the pagination order by, the newest orders first if it is empty.
+// The pages after the first one are loaded by the offset or continue from the position of the pagination cursor.
+func (m *mongoRepository) ListByAccount(ctx context.Context, tenantID string, accountEmail string, statuses []models.OrderStatus, pq *utils.Pagination) (*models.AccountOrdersPage, error) {
+	ctx, span := tracing.StartSpan(ctx, "mongoRepository.ListByAccount")
+	defer span.End()
+	span.SetAttributes(attribute.String("TenantID", tenantID), attribute.String("AccountEmail", accountEmail), tracing.Object("Statuses", statuses), attribute.String("OrderBy", pq.GetOrderBy()))
+
+	filter := getAccountOrdersFilter(tenantID, accountEmail, statuses)
+
+	totalCount, err := m.getOrdersCollection().CountDocuments(ctx, filter)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return nil, err
+	}
+
+	after, err := m.getAccountOrdersCursor(pq)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return nil, err
+	}
+
+	// the extra order tells if there is the next page
+	field, direction := getAccountOrdersSortField(pq.GetOrderBy())
+	ops := options.Find().
+		SetSort(bson.D{{Key: field, Value: direction}, {Key: constants.OrderId, Value: 1}}).
+		SetLimit(int64(pq.GetLimit() + 1))
+	if after != nil {
+		filter["$and"] = bson.A{getAccountOrdersAfterFilter(field, direction, after)}
+	} else {
+		ops.SetSkip(int64(pq.GetOffset()))
+	}
+
+	cursor, err := m.getOrdersCollection().Find(ctx, filter, ops)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return nil, err
+	}
+
+	orders := make([]*models.OrderProjection, 0, pq.GetLimit()+1)
+	if err := cursor.All(ctx, &orders); err != nil {
+		tracing.TraceErr(span, err)
+		return nil, err
+	}
+
+	page := &models.AccountOrdersPage{Orders: orders, TotalCount: totalCount}
+	if len(orders) > pq.GetLimit() {
+		page.Orders = orders[:pq.GetLimit()]
+		page.NextCursor, err = m.getNextAccountOrdersCursor(page.Orders[len(page.Orders)-1], field, pq)
+		if err != nil {
+			tracing.TraceErr(span, err)
+			return nil, err
+		}
+	}
+
+	m.log.Debugf("(ListByAccount) AccountEmail: {%s}, found: {%d}, totalCount: {%d}", accountEmail, len(page.Orders), totalCount)
+	return page, nil
+}
+
+// updateOrder applies the update of the order event with the version only if the previous event of the order is applied,
+// so the events processed concurrently by the subscription workers are applied in the stream order, the update must set the version.
+// It is nil if the event is already applied, mongo.ErrNoDocuments if the order is not projected yet
+// and ErrOrderEventsPending if the previous events are not applied yet, the runner retries both and parks the event after that.
+func (m *mongoRepository) updateOrder(ctx context.Context, tenantID string, orderID string, version int64, update bson.M, opts ...*options.UpdateOptions) error {
+	filter := getOrderFilter(tenantID, orderID)
+	// orders projected before the versions were tracked accept the next event and track the version after it
+	filter["$or"] = bson.A{bson.M{constants.Version: version - 1}, bson.M{constants.Version: bson.M{"$exists": false}}}
+
+	res, err := m.getOrdersCollection().UpdateOne(ctx, filter, update, opts...)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount > 0 {
+		return nil
+	}
+
+	var projected struct {
+		Version int64 `bson:"version"`
+	}
+	ops := options.FindOne().SetProjection(bson.M{constants.Version: 1})
+	if err := m.getOrdersCollection().FindOne(ctx, getOrderFilter(tenantID, orderID), ops).Decode(&projected); err != nil {
+		return err
+	}
+	if projected.Version >= version {
+		m.log.Debugf("(updateOrder) already applied OrderID: {%s}, version: {%d}, projected: {%d}", orderID, version, projected.Version)
+		return nil
+	}
+	return errors.Wrapf(ErrOrderEventsPending, "OrderID: {%s}, version: {%d}, projected: {%d}", orderID, version, projected.Version)
+}
+
+// getOrderFilter filters the order of the tenant, projections of the default tenant orders have no tenant id.
+func getOrderFilter(tenantID string, orderID string) bson.M {
+	if tenantID == "" {
+		return bson.M{constants.OrderId: orderID, constants.TenantID: bson.M{"$exists": false}}
+	}
+	return bson.M{constants.OrderId: orderID, constants.TenantID: tenantID}
+}
+
+// getAccountOrdersFilter filters the tenant orders of the account, the equality fields are the prefix of the account orders index.
+func getAccountOrdersFilter(tenantID string, accountEmail string, statuses []models.OrderStatus) bson.M {
+	filter := bson.M{constants.TenantID: tenantID, constants.AccountEmail: accountEmail}
+	if tenantID == "" {
+		filter[constants.TenantID] = bson.M{"$exists": false}
+	}
+	if len(statuses) > 0 {
+		filter[constants.Status] = bson.M{"$in": statuses}
+	}
+	return filter
+}
+
+// getAccountOrdersSortField returns sort field and direction of the field:direction order by,
+// the order id tiebreaker sorted after it makes the pages order stable.
+func getAccountOrdersSortField(orderBy string) (string, int) {
+	parts := strings.SplitN(orderBy, ":", 2)
+	field, ok := accountOrdersSortFields[parts[0]]
+	if !ok {
+		return constants.CreatedAt, -1
+	}
+
+	if len(parts) == 2 && parts[1] == models.OrderSortDesc {
+		return field, -1
+	}
+	return field, 1
+}
+
+// getAccountOrdersCursor returns position of the pagination cursor, nil if the page is loaded by the offset.
+func (m *mongoRepository) getAccountOrdersCursor(pq *utils.Pagination) (*accountOrdersCursor, error) {
+	if pq.GetCursor() == "" {
+		return nil, nil
+	}
+
+	var cursor accountOrdersCursor
+	if err := m.cursors.Decode(pq.GetCursor(), &cursor); err != nil {
+		return nil, err
+	}
+	if err := (bson.RawValue{Type: cursor.AfterType, Value: cursor.AfterValue}).Validate(); err != nil {
+		return nil, errors.Wrapf(utils.ErrInvalidCursor, "err: {%v}", err)
+	}
+	pq.SetOrderBy(cursor.OrderBy)
+	pq.Page = cursor.Page
+	return &cursor, nil
+}
+
+// getNextAccountOrdersCursor returns cursor of the page after the last order, the orders without the sort field have null position.
+func (m *mongoRepository) getNextAccountOrdersCursor(last *models.OrderProjection, field string, pq *utils.Pagination) (string, error) {
+	lastBytes, err := bson.Marshal(last)
+	if err != nil {
+		return "", errors.Wrap(err, "bson.Marshal")
+	}
+
+	value, err := bson.Raw(lastBytes).LookupErr(strings.Split(field, ".")...)
+	if errors.Is(err, bsoncore.ErrElementNotFound) {
+		value = bson.RawValue{Type: bsontype.Null}
+	} else if err != nil {
+		return "", errors.Wrap(err, "Raw.LookupErr")
+	}
+
+	return m.cursors.Encode(accountOrdersCursor{
+		OrderBy:    pq.GetOrderBy(),
+		Page:       pq.GetPage() + 1,
+		AfterType:  value.Type,
+		AfterValue: value.Value,
+		AfterID:    last.OrderID,
+	})
+}
+
+// getAccountOrdersAfterFilter returns filter of the orders after the cursor position in the sort order,
+// the orders without the sort field, for example not delivered yet, are sorted as null before all others.
+func getAccountOrdersAfterFilter(field string, direction int, after *accountOrdersCursor) bson.M {
+	afterID := bson.M{"$gt": after.AfterID}
+
+	if after.AfterType == bsontype.Null {
+		sameValue := bson.M{field: nil, constants.OrderId: afterID}
+		if direction > 0 {
+			return bson.M{"$or": bson.A{sameValue, bson.M{field: bson.M{"$ne": nil}}}}
+		}
+		return sameValue
+	}
+
+	value := bson.RawValue{Type: after.AfterType, Value: after.AfterValue}
+	sameValue := bson.M{field: value, constants.OrderId: afterID}
+	if direction > 0 {
+		return bson.M{"$or": bson.A{bson.M{field: bson.M{"$gt": value}}, sameValue}}
+	}
+	return bson.M{"$or": bson.A{bson.M{field: bson.M{"$lt": value}}, sameValue, bson.M{field: nil}}}
+}
+
 func (m *mongoRepository) getOrdersCollection() *mongo.Collection {
-	return m.db.Database(m.cfg.Mongo.Db).Collection(m.cfg.MongoCollections.Orders)
+	return m.db.Database(m.cfg.Mongo.Db).Collection(m.collection)
 }
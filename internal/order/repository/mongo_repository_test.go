@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AleksK1NG/es-microservice/config"
+	"github.com/AleksK1NG/es-microservice/internal/order/models"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/mongodb"
+	"github.com/AleksK1NG/es-microservice/pkg/utils"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+const testOrdersNamespace = "orders.orders"
+
+func newTestMongoRepository(mt *mtest.T) *mongoRepository {
+	appLogger := logger.NewAppLogger(&logger.Config{LogLevel: "error", Encoder: "console"})
+	appLogger.InitLogger()
+	cfg := &config.Config{
+		Mongo:   &mongodb.Config{Db: "orders"},
+		Cursors: utils.CursorConfig{Secret: "secret", TTL: time.Hour},
+	}
+	return NewMongoRepositoryWithCollection(appLogger, cfg, mt.Client, "orders")
+}
+
+// addListResponses mocks the count of the account orders and the found orders.
+func addListResponses(mt *mtest.T, totalCount int64, orders ...*models.OrderProjection) {
+	docs := make([]bson.D, 0, len(orders))
+	for _, order := range orders {
+		data, err := bson.Marshal(order)
+		if err != nil {
+			mt.Fatalf("bson.Marshal() err: %v", err)
+		}
+		var doc bson.D
+		if err := bson.Unmarshal(data, &doc); err != nil {
+			mt.Fatalf("bson.Unmarshal() err: %v", err)
+		}
+		docs = append(docs, doc)
+	}
+
+	mt.AddMockResponses(
+		mtest.CreateCursorResponse(0, testOrdersNamespace, mtest.FirstBatch, bson.D{{Key: "_id", Value: 1}, {Key: "n", Value: totalCount}}),
+		mtest.CreateCursorResponse(0, testOrdersNamespace, mtest.FirstBatch, docs...),
+	)
+}
+
+// getFindCommand returns the find command of the listed orders, it is sent after the count aggregate.
+func getFindCommand(mt *mtest.T) bson.Raw {
+	for event := mt.GetStartedEvent(); event != nil; event = mt.GetStartedEvent() {
+		if event.CommandName == "find" {
+			return event.Command
+		}
+	}
+	mt.Fatalf("find command is not sent")
+	return nil
+}
+
+// getSort returns the field:direction sort of the find command.
+func getSort(mt *mtest.T, find bson.Raw) string {
+	elements, err := find.Lookup("sort").Document().Elements()
+	if err != nil {
+		mt.Fatalf("sort Elements() err: %v", err)
+	}
+	sort := make([]string, 0, len(elements))
+	for _, element := range elements {
+		sort = append(sort, fmt.Sprintf("%s:%d", element.Key(), element.Value().AsInt64()))
+	}
+	return strings.Join(sort, " ")
+}
+
+// getFilter returns the filter key of the find command, the maps are printed sorted by the keys.
+func getFilter(mt *mtest.T, find bson.Raw, key string) string {
+	var filter bson.M
+	if err := bson.Unmarshal(find.Lookup("filter").Document(), &filter); err != nil {
+		mt.Fatalf("bson.Unmarshal() err: %v", err)
+	}
+	value, ok := filter[key]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+func TestMongoRepositoryListByAccountFilter(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	tests := []struct {
+		name     string
+		tenantID string
+		statuses []models.OrderStatus
+		tenant   string
+		status   string
+	}{
+		{name: "default tenant", tenant: "map[$exists:false]"},
+		{name: "tenant", tenantID: "tenantA", tenant: "tenantA"},
+		{name: "statuses", tenantID: "tenantA", statuses: []models.OrderStatus{models.OrderStatusPaid, models.OrderStatusSubmitted}, tenant: "tenantA", status: "map[$in:[paid submitted]]"},
+	}
+
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			repo := newTestMongoRepository(mt)
+			addListResponses(mt, 1, &models.OrderProjection{OrderID: "order-1", AccountEmail: "customer@mail.com"})
+
+			page, err := repo.ListByAccount(context.Background(), tt.tenantID, "customer@mail.com", tt.statuses, utils.NewPaginationQuery(10, 1))
+			if err != nil {
+				mt.Fatalf("ListByAccount() err: %v", err)
+			}
+			if len(page.Orders) != 1 || page.TotalCount != 1 || page.NextCursor != "" {
+				mt.Errorf("ListByAccount() = %d orders, total count %d, next cursor %q, want the single page of 1 order", len(page.Orders), page.TotalCount, page.NextCursor)
+			}
+
+			find := getFindCommand(mt)
+			if accountEmail := getFilter(mt, find, "accountEmail"); accountEmail != "customer@mail.com" {
+				mt.Errorf("accountEmail filter = %s, want customer@mail.com", accountEmail)
+			}
+			if tenant := getFilter(mt, find, "tenantId"); tenant != tt.tenant {
+				mt.Errorf("tenantId filter = %s, want %s", tenant, tt.tenant)
+			}
+			if status := getFilter(mt, find, "status"); status != tt.status {
+				mt.Errorf("status filter = %s, want %s", status, tt.status)
+			}
+		})
+	}
+}
+
+func TestMongoRepositoryListByAccountCursor(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	delivered := primitive.NewDateTimeFromTime(createdAt)
+	orders := []*models.OrderProjection{
+		{OrderID: "order-1", CreatedAt: createdAt.Add(2 * time.Hour), TotalPrice: models.NewMoney(1000, "USD")},
+		{OrderID: "order-2", CreatedAt: createdAt, TotalPrice: models.NewMoney(2000, "USD"), DeliveredTime: createdAt},
+		{OrderID: "order-3", CreatedAt: createdAt, TotalPrice: models.NewMoney(2000, "USD")},
+	}
+
+	tests := []struct {
+		name    string
+		orderBy string
+		// first page orders, the next page continues after the last of them
+		first []*models.OrderProjection
+		sort  string
+		// after filter of the next page
+		after string
+	}{
+		{
+			name:  "newest first",
+			first: orders[:2],
+			sort:  "createdAt:-1 orderId:1",
+			after: fmt.Sprintf("[map[$or:[map[createdAt:map[$lt:%[1]d]] map[createdAt:%[1]d orderId:map[$gt:order-2]] map[createdAt:<nil>]]]]", delivered),
+		},
+		{
+			name:    "total price ascending",
+			orderBy: "totalPrice:asc",
+			first:   orders[:2],
+			sort:    "totalPrice.amount:1 orderId:1",
+			after:   "[map[$or:[map[totalPrice.amount:map[$gt:2000]] map[orderId:map[$gt:order-2] totalPrice.amount:2000]]]]",
+		},
+		{
+			name:    "delivered time descending",
+			orderBy: "deliveredTime:desc",
+			first:   orders[1:3],
+			sort:    "deliveredTime:-1 orderId:1",
+			after:   "[map[deliveredTime:<nil> orderId:map[$gt:order-3]]]",
+		},
+		{
+			name:    "not delivered ascending",
+			orderBy: "deliveredTime:asc",
+			first:   []*models.OrderProjection{orders[0], orders[2]},
+			sort:    "deliveredTime:1 orderId:1",
+			after:   "[map[$or:[map[deliveredTime:<nil> orderId:map[$gt:order-3]] map[deliveredTime:map[$ne:<nil>]]]]]",
+		},
+	}
+
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			repo := newTestMongoRepository(mt)
+
+			pq := utils.NewPaginationQuery(2, 1)
+			pq.SetOrderBy(tt.orderBy)
+			addListResponses(mt, 3, append(append([]*models.OrderProjection{}, tt.first...), orders[1])...)
+			page, err := repo.ListByAccount(context.Background(), "", "customer@mail.com", nil, pq)
+			if err != nil {
+				mt.Fatalf("ListByAccount() err: %v", err)
+			}
+			if len(page.Orders) != 2 || page.NextCursor == "" {
+				mt.Fatalf("ListByAccount() = %d orders, next cursor %q, want 2 orders with the next cursor", len(page.Orders), page.NextCursor)
+			}
+			find := getFindCommand(mt)
+			if sort := getSort(mt, find); sort != tt.sort {
+				mt.Errorf("first page sort = %s, want %s", sort, tt.sort)
+			}
+			if find.Lookup("limit").AsInt64() != 3 || find.Lookup("skip").AsInt64() != 0 {
+				mt.Errorf("first page limit = %v, skip = %v, want 3, 0", find.Lookup("limit"), find.Lookup("skip"))
+			}
+
+			// the next page continues after the cursor position with the order by of the cursor
+			next := utils.NewPaginationQuery(2, 1)
+			next.SetCursor(page.NextCursor)
+			addListResponses(mt, 3, orders[2])
+			lastPage, err := repo.ListByAccount(context.Background(), "", "customer@mail.com", nil, next)
+			if err != nil {
+				mt.Fatalf("ListByAccount() next page err: %v", err)
+			}
+			if len(lastPage.Orders) != 1 || lastPage.NextCursor != "" || next.GetPage() != 2 || next.GetOrderBy() != tt.orderBy {
+				mt.Errorf("next page = %d orders, next cursor %q, page %d, order by %q, want the last page 2 of 1 order by %q",
+					len(lastPage.Orders), lastPage.NextCursor, next.GetPage(), next.GetOrderBy(), tt.orderBy)
+			}
+			find = getFindCommand(mt)
+			if sort := getSort(mt, find); sort != tt.sort {
+				mt.Errorf("next page sort = %s, want %s", sort, tt.sort)
+			}
+			if _, err := find.LookupErr("skip"); err == nil {
+				mt.Errorf("next page skip = %v, want the cursor position without skip", find.Lookup("skip"))
+			}
+			if after := getFilter(mt, find, "$and"); after != tt.after {
+				mt.Errorf("next page after filter = %s, want %s", after, tt.after)
+			}
+		})
+	}
+
+	mt.Run("invalid cursor", func(mt *mtest.T) {
+		repo := newTestMongoRepository(mt)
+		addListResponses(mt, 3)
+
+		pq := utils.NewPaginationQuery(2, 1)
+		pq.SetCursor("invalid")
+		if _, err := repo.ListByAccount(context.Background(), "", "customer@mail.com", nil, pq); !errors.Is(err, utils.ErrInvalidCursor) {
+			mt.Errorf("ListByAccount() err = %v, want %v", err, utils.ErrInvalidCursor)
+		}
+	})
+}
@@ -10,19 +10,25 @@ import (
 
 type OrderMongoRepository interface {
 	Insert(ctx context.Context, order *models.OrderProjection) (string, error)
-	GetByID(ctx context.Context, orderID string) (*models.OrderProjection, error)
+	GetByID(ctx context.Context, tenantID string, orderID string) (*models.OrderProjection, error)
 	UpdateOrder(ctx context.Context, order *models.OrderProjection) error
+	ListByAccount(ctx context.Context, tenantID string, accountEmail string, statuses []models.OrderStatus, pq *utils.Pagination) (*models.AccountOrdersPage, error)
 
 	UpdateCancel(ctx context.Context, order *models.OrderProjection) error
 	UpdatePayment(ctx context.Context, order *models.OrderProjection) error
 	Complete(ctx context.Context, order *models.OrderProjection) error
 	UpdateDeliveryAddress(ctx context.Context, order *models.OrderProjection) error
 	UpdateSubmit(ctx context.Context, order *models.OrderProjection) error
+
+	AddShopItem(ctx context.Context, tenantID string, orderID string, version int64, shopItem *models.ShopItem, totalPrice models.Money) error
+	RemoveShopItem(ctx context.Context, tenantID string, orderID string, version int64, shopItemID string, totalPrice models.Money) error
+	ChangeShopItemQuantity(ctx context.Context, tenantID string, orderID string, version int64, shopItemID string, quantity uint64, totalPrice models.Money) error
+	AddRefund(ctx context.Context, tenantID string, orderID string, version int64, refund *models.Refund, refundedAmount models.Money) error
 }
 
 type ElasticOrderRepository interface {
 	IndexOrder(ctx context.Context, order *models.OrderProjection) error
-	GetByID(ctx context.Context, orderID string) (*models.OrderProjection, error)
+	GetByID(ctx context.Context, tenantID string, orderID string) (*models.OrderProjection, error)
 	UpdateOrder(ctx context.Context, order *models.OrderProjection) error
-	Search(ctx context.Context, text string, pq *utils.Pagination) (*dto.OrderSearchResponseDto, error)
+	Search(ctx context.Context, filter *models.OrderSearchFilter, pq *utils.Pagination) (*dto.OrderSearchResponseDto, error)
 }
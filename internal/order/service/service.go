@@ -10,16 +10,21 @@ import (
 )
 
 type OrderService struct {
-	Commands *v1.OrderCommands
-	Queries  *queries.OrderQueries
+	Commands    *v1.OrderCommands
+	Queries     *queries.OrderQueries
+	Idempotency es.Idempotency
 }
 
 func NewOrderService(
 	log logger.Logger,
 	cfg *config.Config,
 	es es.AggregateStore,
+	eventStore es.EventStore,
+	subscriber es.StreamSubscriber,
 	mongoRepo repository.OrderMongoRepository,
 	elasticRepository repository.ElasticOrderRepository,
+	idempotency es.Idempotency,
+	upcaster es.Upcaster,
 ) *OrderService {
 
 	createOrderHandler := v1.NewCreateOrderHandler(log, cfg, es)
@@ -29,9 +34,17 @@ func NewOrderService(
 	cancelOrderCommandHandler := v1.NewCancelOrderCommandHandler(log, cfg, es)
 	deliveryOrderCommandHandler := v1.NewCompleteOrderCommandHandler(log, cfg, es)
 	changeOrderDeliveryAddressCmdHandler := v1.NewChangeDeliveryAddressCmdHandler(log, cfg, es)
+	addShopItemCmdHandler := v1.NewAddShopItemCmdHandler(log, cfg, es)
+	removeShopItemCmdHandler := v1.NewRemoveShopItemCmdHandler(log, cfg, es)
+	changeItemQuantityCmdHandler := v1.NewChangeItemQuantityCmdHandler(log, cfg, es)
+	refundOrderCmdHandler := v1.NewRefundOrderCmdHandler(log, cfg, es)
 
 	getOrderByIDHandler := queries.NewGetOrderByIDHandler(log, cfg, es, mongoRepo)
 	searchOrdersHandler := queries.NewSearchOrdersHandler(log, cfg, es, elasticRepository)
+	listOrdersByAccountHandler := queries.NewListOrdersByAccountHandler(log, cfg, mongoRepo)
+	getOrderHistoryHandler := queries.NewGetOrderHistoryHandler(log, cfg, eventStore, upcaster)
+	getOrderAtHandler := queries.NewGetOrderAtHandler(log, cfg, eventStore, upcaster)
+	watchOrderHandler := queries.NewWatchOrderHandler(log, cfg, eventStore, subscriber, upcaster)
 
 	orderCommands := v1.NewOrderCommands(
 		createOrderHandler,
@@ -41,8 +54,19 @@ func NewOrderService(
 		cancelOrderCommandHandler,
 		deliveryOrderCommandHandler,
 		changeOrderDeliveryAddressCmdHandler,
+		addShopItemCmdHandler,
+		removeShopItemCmdHandler,
+		changeItemQuantityCmdHandler,
+		refundOrderCmdHandler,
+	)
+	orderQueries := queries.NewOrderQueries(
+		getOrderByIDHandler,
+		searchOrdersHandler,
+		listOrdersByAccountHandler,
+		getOrderHistoryHandler,
+		getOrderAtHandler,
+		watchOrderHandler,
 	)
-	orderQueries := queries.NewOrderQueries(getOrderByIDHandler, searchOrdersHandler)
 
-	return &OrderService{Commands: orderCommands, Queries: orderQueries}
+	return &OrderService{Commands: orderCommands, Queries: orderQueries, Idempotency: idempotency}
 }
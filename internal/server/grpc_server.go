@@ -42,6 +42,18 @@ func (s *server) newOrderGrpcServer() (func() error, *grpc.Server, error) {
 			grpc_prometheus.UnaryServerInterceptor,
 			grpc_recovery.UnaryServerInterceptor(),
 			s.im.Logger,
+			s.im.Auth,
+			s.im.RateLimit,
+			s.im.Tenant,
+		),
+		),
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
+			grpc_ctxtags.StreamServerInterceptor(),
+			grpc_prometheus.StreamServerInterceptor,
+			grpc_recovery.StreamServerInterceptor(),
+			s.im.StreamAuth,
+			s.im.StreamRateLimit,
+			s.im.StreamTenant,
 		),
 		),
 	)
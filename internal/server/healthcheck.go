@@ -2,15 +2,81 @@ package server
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"github.com/AleksK1NG/es-microservice/pkg/constants"
+	"github.com/AleksK1NG/es-microservice/pkg/es/subscription"
+	"github.com/AleksK1NG/es-microservice/pkg/eventstroredb"
 	"github.com/heptiolabs/healthcheck"
 	"github.com/pkg/errors"
 	"net/http"
+	"sort"
+	"sync"
 	"time"
 )
 
+const (
+	healthStatusOK   = "OK"
+	healthStatusFail = "FAIL"
+)
+
+// healthReport detailed health of the dependencies and the projections subscriptions.
+type healthReport struct {
+	Status        string                `json:"status"`
+	Checks        map[string]string     `json:"checks"`
+	Subscriptions []subscription.Health `json:"subscriptions"`
+	Lag           []subscription.Lag    `json:"lag"`
+	CheckedAt     time.Time             `json:"checkedAt"`
+}
+
+// healthChecks registers the checks in the health handler and keeps them for the detailed health report.
+type healthChecks struct {
+	health healthcheck.Handler
+	mu     sync.RWMutex
+	checks map[string]healthcheck.Check
+}
+
+func newHealthChecks(health healthcheck.Handler) *healthChecks {
+	return &healthChecks{health: health, checks: make(map[string]healthcheck.Check)}
+}
+
+func (h *healthChecks) AddReadinessCheck(name string, check healthcheck.Check) {
+	h.health.AddReadinessCheck(name, check)
+	h.add(name, check)
+}
+
+func (h *healthChecks) AddLivenessCheck(name string, check healthcheck.Check) {
+	h.health.AddLivenessCheck(name, check)
+	h.add(name, check)
+}
+
+func (h *healthChecks) add(name string, check healthcheck.Check) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checks[name] = check
+}
+
+// run returns results of all checks by the name, false if any of them failed.
+func (h *healthChecks) run() (map[string]string, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	results := make(map[string]string, len(h.checks))
+	healthy := true
+	for name, check := range h.checks {
+		if err := check(); err != nil {
+			results[name] = err.Error()
+			healthy = false
+			continue
+		}
+		results[name] = healthStatusOK
+	}
+	return results, healthy
+}
+
 func (s *server) runHealthCheck(ctx context.Context) {
 	health := healthcheck.NewHandler()
+	checks := newHealthChecks(health)
 
 	mux := http.NewServeMux()
 	s.ps = &http.Server{
@@ -21,8 +87,11 @@ func (s *server) runHealthCheck(ctx context.Context) {
 	}
 	mux.HandleFunc(s.cfg.Probes.LivenessPath, health.LiveEndpoint)
 	mux.HandleFunc(s.cfg.Probes.ReadinessPath, health.ReadyEndpoint)
+	if s.cfg.Probes.HealthPath != "" {
+		mux.HandleFunc(s.cfg.Probes.HealthPath, s.healthReportEndpoint(checks))
+	}
 
-	s.configureHealthCheckEndpoints(ctx, health)
+	s.configureHealthCheckEndpoints(ctx, checks)
 
 	go func() {
 		s.log.Infof("(%s) Kubernetes probes listening on port: {%s}", s.cfg.ServiceName, s.cfg.Probes.Port)
@@ -32,7 +101,7 @@ func (s *server) runHealthCheck(ctx context.Context) {
 	}()
 }
 
-func (s *server) configureHealthCheckEndpoints(ctx context.Context, health healthcheck.Handler) {
+func (s *server) configureHealthCheckEndpoints(ctx context.Context, health *healthChecks) {
 
 	health.AddReadinessCheck(constants.MongoDB, healthcheck.AsyncWithContext(ctx, func() error {
 		if err := s.mongoClient.Ping(ctx, nil); err != nil {
@@ -67,6 +136,109 @@ func (s *server) configureHealthCheckEndpoints(ctx context.Context, health healt
 		}
 		return nil
 	}, time.Duration(s.cfg.Probes.CheckIntervalSeconds)*time.Second))
+
+	health.AddReadinessCheck(constants.EventStoreDB, healthcheck.AsyncWithContext(ctx, func() error {
+		if err := s.pingEventStoreDB(ctx); err != nil {
+			s.log.Warnf("(EventStoreDB Readiness Check) err: {%v}", err)
+			return err
+		}
+		return nil
+	}, time.Duration(s.cfg.Probes.CheckIntervalSeconds)*time.Second))
+
+	health.AddLivenessCheck(constants.EventStoreDB, healthcheck.AsyncWithContext(ctx, func() error {
+		if err := s.pingEventStoreDB(ctx); err != nil {
+			s.log.Warnf("(EventStoreDB Liveness Check) err: {%v}", err)
+			return err
+		}
+		return nil
+	}, time.Duration(s.cfg.Probes.CheckIntervalSeconds)*time.Second))
+
+	// the runners keep their subscriptions state, the checks read it
+	for groupName, runner := range s.projectionRunners {
+		runner := runner
+		health.AddReadinessCheck(getSubscriptionCheckName(groupName), func() error {
+			if err := runner.GetHealth().Check(s.cfg.Subscriptions.Health.ReadinessTimeout); err != nil {
+				s.log.Warnf("(Subscription Readiness Check) err: {%v}", err)
+				return err
+			}
+			return nil
+		})
+		if s.cfg.Subscriptions.Health.LivenessTimeout > 0 {
+			health.AddLivenessCheck(getSubscriptionLivenessCheckName(groupName), func() error {
+				if err := runner.GetHealth().Check(s.cfg.Subscriptions.Health.LivenessTimeout); err != nil {
+					s.log.Warnf("(Subscription Liveness Check) err: {%v}", err)
+					return err
+				}
+				return nil
+			})
+		}
+	}
+
+	// the lag is checked by the lag monitor in background, readiness reads the last checked lag
+	if s.cfg.Subscriptions.Lag.Interval > 0 {
+		health.AddReadinessCheck(constants.ProjectionLag, func() error {
+			if err := s.lagMonitor.CheckLag(); err != nil {
+				s.log.Warnf("(Projection Lag Readiness Check) err: {%v}", err)
+				return err
+			}
+			return nil
+		})
+	}
+}
+
+// healthReportEndpoint responds with the results of all checks and the projections subscriptions state,
+// the status code is 503 if any check failed.
+func (s *server) healthReportEndpoint(health *healthChecks) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		checks, healthy := health.run()
+		report := healthReport{
+			Status:        healthStatusOK,
+			Checks:        checks,
+			Subscriptions: s.getSubscriptionsHealth(),
+			Lag:           s.lagMonitor.GetLags(),
+			CheckedAt:     time.Now().UTC(),
+		}
+		status := http.StatusOK
+		if !healthy {
+			report.Status = healthStatusFail
+			status = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(status)
+		if err := json.NewEncoder(w).Encode(report); err != nil {
+			s.log.Warnf("(healthReportEndpoint) err: {%v}", err)
+		}
+	}
+}
+
+func (s *server) getSubscriptionsHealth() []subscription.Health {
+	subscriptions := make([]subscription.Health, 0, len(s.projectionRunners))
+	for _, runner := range s.projectionRunners {
+		subscriptions = append(subscriptions, runner.GetHealth())
+	}
+	sort.Slice(subscriptions, func(i, j int) bool { return subscriptions[i].GroupName < subscriptions[j].GroupName })
+	return subscriptions
+}
+
+func (s *server) pingEventStoreDB(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(s.cfg.Probes.CheckIntervalSeconds)*time.Second)
+	defer cancel()
+	return eventstroredb.Ping(ctx, s.esdbClient)
+}
+
+func getSubscriptionCheckName(groupName string) string {
+	return fmt.Sprintf("%s:%s", constants.Subscription, groupName)
+}
+
+// getSubscriptionLivenessCheckName liveness checks are also readiness checks, so they need own names.
+func getSubscriptionLivenessCheckName(groupName string) string {
+	return fmt.Sprintf("%s:%s:live", constants.Subscription, groupName)
 }
 
 func (s *server) shutDownHealthCheckServer(ctx context.Context) error {
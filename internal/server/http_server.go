@@ -48,7 +48,8 @@ func (s *server) mapRoutes() {
 	s.echo.Use(middleware.GzipWithConfig(middleware.GzipConfig{
 		Level: gzipLevel,
 		Skipper: func(c echo.Context) bool {
-			return strings.Contains(c.Request().URL.Path, "swagger")
+			// Server-Sent Events streams are flushed per event, so they are not compressed
+			return strings.Contains(c.Request().URL.Path, "swagger") || strings.HasSuffix(c.Request().URL.Path, "/watch")
 		},
 	}))
 	s.echo.Use(middleware.BodyLimit(bodyLimit))
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/AleksK1NG/es-microservice/internal/order/events"
+	"github.com/AleksK1NG/es-microservice/internal/order/projection/rebuild"
+	"github.com/AleksK1NG/es-microservice/pkg/eventstroredb"
+	"github.com/AleksK1NG/es-microservice/pkg/mongodb"
+	"github.com/pkg/errors"
+)
+
+// RunRebuild rebuilds given read models and exits, all of them if targets are empty.
+func (s *server) RunRebuild(targets []string) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
+	defer cancel()
+
+	if err := s.v.StructCtx(ctx, s.cfg); err != nil {
+		return errors.Wrap(err, "cfg validate")
+	}
+
+	if len(targets) == 0 {
+		targets = []string{rebuild.TargetMongo, rebuild.TargetElastic}
+	}
+
+	mongoDBConn, err := mongodb.NewMongoDBConn(ctx, s.cfg.Mongo)
+	if err != nil {
+		return errors.Wrap(err, "NewMongoDBConn")
+	}
+	s.mongoClient = mongoDBConn
+	defer mongoDBConn.Disconnect(ctx) // nolint: errcheck
+
+	if err := s.initElasticClient(ctx); err != nil {
+		return err
+	}
+
+	db, err := eventstroredb.NewEventStoreDB(s.cfg.EventStoreConfig)
+	if err != nil {
+		return err
+	}
+	defer db.Close() // nolint: errcheck
+
+	rebuilder := rebuild.NewRebuilder(s.log, s.cfg, db, s.mongoClient, s.elasticClient, events.NewOrderUpcaster(s.cfg.Orders.LegacyCurrency))
+	for _, target := range targets {
+		status, err := rebuilder.Rebuild(ctx, target)
+		if err != nil {
+			return errors.Wrapf(err, "Rebuild target: {%s}", target)
+		}
+		s.log.Infof("(rebuild) status: {%+v}", status)
+	}
+
+	return nil
+}
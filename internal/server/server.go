@@ -5,21 +5,31 @@ import (
 	"github.com/AleksK1NG/es-microservice/config"
 	"github.com/AleksK1NG/es-microservice/internal/metrics"
 	orderHttp "github.com/AleksK1NG/es-microservice/internal/order/delivery/http/v1"
+	"github.com/AleksK1NG/es-microservice/internal/order/events"
+	"github.com/AleksK1NG/es-microservice/internal/order/integration"
+	"github.com/AleksK1NG/es-microservice/internal/order/projection/dead_letters"
 	"github.com/AleksK1NG/es-microservice/internal/order/projection/elastic_projection"
 	"github.com/AleksK1NG/es-microservice/internal/order/projection/mongo_projection"
+	"github.com/AleksK1NG/es-microservice/internal/order/projection/rebuild"
 	"github.com/AleksK1NG/es-microservice/internal/order/repository"
 	"github.com/AleksK1NG/es-microservice/internal/order/service"
+	"github.com/AleksK1NG/es-microservice/pkg/constants"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
 	"github.com/AleksK1NG/es-microservice/pkg/es/store"
+	"github.com/AleksK1NG/es-microservice/pkg/es/subscription"
 	"github.com/AleksK1NG/es-microservice/pkg/eventstroredb"
 	"github.com/AleksK1NG/es-microservice/pkg/interceptors"
 	"github.com/AleksK1NG/es-microservice/pkg/logger"
 	"github.com/AleksK1NG/es-microservice/pkg/middlewares"
 	"github.com/AleksK1NG/es-microservice/pkg/mongodb"
+	"github.com/AleksK1NG/es-microservice/pkg/outbox"
+	"github.com/AleksK1NG/es-microservice/pkg/ratelimit"
 	"github.com/AleksK1NG/es-microservice/pkg/tracing"
+	"github.com/AleksK1NG/es-microservice/pkg/utils"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
 	"github.com/go-playground/validator"
 	"github.com/labstack/echo/v4"
 	v7 "github.com/olivere/elastic/v7"
-	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/mongo"
 	"net/http"
@@ -29,18 +39,21 @@ import (
 )
 
 type server struct {
-	cfg           *config.Config
-	log           logger.Logger
-	im            interceptors.InterceptorManager
-	mw            middlewares.MiddlewareManager
-	os            *service.OrderService
-	v             *validator.Validate
-	mongoClient   *mongo.Client
-	elasticClient *v7.Client
-	echo          *echo.Echo
-	metrics       *metrics.ESMicroserviceMetrics
-	ps            *http.Server
-	doneCh        chan struct{}
+	cfg               *config.Config
+	log               logger.Logger
+	im                interceptors.InterceptorManager
+	mw                middlewares.MiddlewareManager
+	os                *service.OrderService
+	v                 *validator.Validate
+	mongoClient       *mongo.Client
+	elasticClient     *v7.Client
+	esdbClient        *esdb.Client
+	echo              *echo.Echo
+	metrics           *metrics.ESMicroserviceMetrics
+	ps                *http.Server
+	projectionRunners map[string]subscription.Runner
+	lagMonitor        subscription.LagMonitor
+	doneCh            chan struct{}
 }
 
 func NewServer(cfg *config.Config, log logger.Logger) *server {
@@ -55,18 +68,24 @@ func (s *server) Run() error {
 		return errors.Wrap(err, "cfg validate")
 	}
 
-	if s.cfg.Jaeger.Enable {
-		tracer, closer, err := tracing.NewJaegerTracer(s.cfg.Jaeger)
+	if s.cfg.Tracing.Enable {
+		provider, err := tracing.NewTracerProvider(ctx, s.cfg.Tracing)
 		if err != nil {
-			return err
+			return errors.Wrap(err, "NewTracerProvider")
 		}
-		defer closer.Close() // nolint: errcheck
-		opentracing.SetGlobalTracer(tracer)
+		// ctx is already done on shutdown, the pending spans are flushed anyway
+		defer provider.Shutdown(context.Background()) // nolint: errcheck
 	}
 
 	s.metrics = metrics.NewESMicroserviceMetrics(s.cfg)
-	s.im = interceptors.NewInterceptorManager(s.log, s.getGrpcMetricsCb())
-	s.mw = middlewares.NewMiddlewareManager(s.log, s.cfg, s.getHttpMetricsCb())
+	verifier, err := s.newAuthVerifier()
+	if err != nil {
+		return errors.Wrap(err, "newAuthVerifier")
+	}
+	grpcLimiter := ratelimit.NewLimiter(s.cfg.RateLimit, s.getGrpcRateLimitMetricsCb())
+	httpLimiter := ratelimit.NewLimiter(s.cfg.RateLimit, s.getHttpRateLimitMetricsCb())
+	s.im = interceptors.NewInterceptorManager(s.log, s.cfg, verifier, grpcLimiter, s.getGrpcMetricsCb())
+	s.mw = middlewares.NewMiddlewareManager(s.log, s.cfg, verifier, httpLimiter, s.getHttpMetricsCb())
 
 	mongoDBConn, err := mongodb.NewMongoDBConn(ctx, s.cfg.Mongo)
 	if err != nil {
@@ -89,32 +108,90 @@ func (s *server) Run() error {
 		return err
 	}
 	defer db.Close() // nolint: errcheck
+	s.esdbClient = db
+
+	upcaster := events.NewOrderUpcaster(s.cfg.Orders.LegacyCurrency)
+	aggregateStore := store.NewAggregateStore(s.log, s.cfg.EventSourcing, db, s.newSnapshotStore(db), upcaster)
+	idempotencyStore := store.NewMongoIdempotencyStore(s.log, s.mongoClient.Database(s.cfg.Mongo.Db).Collection(s.cfg.MongoCollections.Idempotency))
+	idempotency := es.NewIdempotency(s.log, idempotencyStore, s.cfg.EventSourcing.IdempotencyTTL, s.cfg.EventSourcing.IdempotencyLease)
+	eventStore := store.NewEventStore(s.log, db)
+	streamSubscriber := store.NewStreamSubscriber(s.log, db)
+	s.os = service.NewOrderService(s.log, s.cfg, aggregateStore, eventStore, streamSubscriber, mongoRepository, elasticRepository, idempotency, upcaster)
+
+	deadLetterStore := s.newDeadLetterStore()
+	mongoProjection := es.NewUpcastingProjection(mongo_projection.NewOrderProjection(s.log, mongoRepository), upcaster)
+	elasticProjection := es.NewUpcastingProjection(elastic_projection.NewElasticProjection(s.log, elasticRepository), upcaster)
+
+	projectionRunners := map[string]subscription.Runner{
+		s.cfg.Subscriptions.MongoProjectionGroupName: subscription.NewRunner(
+			s.log,
+			s.newSubscriptionConfig(constants.MongoProjection, s.cfg.Subscriptions.MongoProjectionGroupName),
+			db,
+			mongoProjection,
+			deadLetterStore,
+			s.getSubscriptionMetricsCb(),
+		),
+		s.cfg.Subscriptions.ElasticProjectionGroupName: subscription.NewRunner(
+			s.log,
+			s.newSubscriptionConfig(constants.ElasticProjection, s.cfg.Subscriptions.ElasticProjectionGroupName),
+			db,
+			elasticProjection,
+			deadLetterStore,
+			s.getSubscriptionMetricsCb(),
+		),
+	}
+	s.projectionRunners = projectionRunners
+	for groupName, runner := range projectionRunners {
+		go func(groupName string, runner subscription.Runner) {
+			if err := runner.Run(ctx); err != nil {
+				s.log.Errorf("(subscription.Run) groupName: {%s}, err: {%v}", groupName, err)
+				cancel()
+			}
+		}(groupName, runner)
+	}
 
-	aggregateStore := store.NewAggregateStore(s.log, db)
-	s.os = service.NewOrderService(s.log, s.cfg, aggregateStore, mongoRepository, elasticRepository)
-
-	mongoProjection := mongo_projection.NewOrderProjection(s.log, db, mongoRepository, s.cfg)
-	elasticProjection := elastic_projection.NewElasticProjection(s.log, db, elasticRepository, s.cfg)
-
+	s.lagMonitor = subscription.NewLagMonitor(
+		s.log,
+		s.cfg.Subscriptions.Lag,
+		store.NewHeadReader(s.log, db),
+		store.NewCheckpointStore(s.log, db),
+		projectionRunners,
+		s.cfg.EventSourcing.Tenancy.GetStreamPrefixes(s.cfg.Subscriptions.OrderPrefix),
+		s.getLagMetricsCb(),
+	)
 	go func() {
-		err := mongoProjection.Subscribe(ctx, []string{s.cfg.Subscriptions.OrderPrefix}, s.cfg.Subscriptions.PoolSize, mongoProjection.ProcessEvents)
-		if err != nil {
-			s.log.Errorf("(orderProjection.Subscribe) err: {%v}", err)
-			cancel()
+		if err := s.lagMonitor.Run(ctx); err != nil {
+			s.log.Errorf("(lagMonitor.Run) err: {%v}", err)
 		}
 	}()
 
-	go func() {
-		err := elasticProjection.Subscribe(ctx, []string{s.cfg.Subscriptions.OrderPrefix}, s.cfg.Subscriptions.PoolSize, elasticProjection.ProcessEvents)
+	if s.cfg.Outbox.Enable {
+		sink, err := s.newOutboxSink()
 		if err != nil {
-			s.log.Errorf("(elasticProjection.Subscribe) err: {%v}", err)
-			cancel()
+			return errors.Wrap(err, "newOutboxSink")
 		}
-	}()
+		defer sink.Close() // nolint: errcheck
+
+		publisher := outbox.NewPublisher(s.log, s.cfg.Outbox, db, sink, store.NewCheckpointStore(s.log, db), outbox.NewUpcastingMapper(integration.MapOrderEvent, upcaster), s.getPublisherMetricsCb())
+		go func() {
+			if err := publisher.Run(ctx, s.cfg.EventSourcing.Tenancy.GetStreamPrefixes(s.cfg.Subscriptions.OrderPrefix)); err != nil {
+				s.log.Errorf("(publisher.Run) err: {%v}", err)
+				cancel()
+			}
+		}()
+	}
 
-	orderHandlers := orderHttp.NewOrderHandlers(s.echo.Group(s.cfg.Http.OrdersPath), s.log, s.mw, s.cfg, s.v, s.os, s.metrics)
+	orderHandlers := orderHttp.NewOrderHandlers(s.echo.Group(s.cfg.Http.OrdersPath, s.mw.AuthMiddleware, s.mw.RateLimitMiddleware, s.mw.TenantMiddleware), s.log, s.mw, s.cfg, s.v, s.os, s.metrics)
 	orderHandlers.MapRoutes()
 
+	rebuilder := rebuild.NewRebuilder(s.log, s.cfg, db, s.mongoClient, s.elasticClient, upcaster)
+	deadLetterService := dead_letters.NewDeadLetterService(s.log, utils.NewCursorCodec(s.cfg.Cursors), deadLetterStore, map[string]es.Projection{
+		s.cfg.Subscriptions.MongoProjectionGroupName:   mongoProjection,
+		s.cfg.Subscriptions.ElasticProjectionGroupName: elasticProjection,
+	})
+	adminHandlers := orderHttp.NewAdminHandlers(s.echo.Group(s.cfg.Http.AdminPath, s.mw.AuthMiddleware, s.mw.RateLimitMiddleware, s.mw.StaffMiddleware), s.log, s.mw, s.cfg, rebuilder, deadLetterService, s.lagMonitor)
+	adminHandlers.MapRoutes()
+
 	s.initMongoDBCollections(ctx)
 	s.runMetrics(cancel)
 	s.runHealthCheck(ctx)
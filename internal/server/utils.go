@@ -4,10 +4,18 @@ import (
 	"context"
 	"fmt"
 	"github.com/AleksK1NG/es-microservice/config"
+	"github.com/AleksK1NG/es-microservice/internal/order/repository"
+	"github.com/AleksK1NG/es-microservice/pkg/auth"
 	"github.com/AleksK1NG/es-microservice/pkg/constants"
 	"github.com/AleksK1NG/es-microservice/pkg/elasticsearch"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/es/store"
+	"github.com/AleksK1NG/es-microservice/pkg/es/subscription"
+	"github.com/AleksK1NG/es-microservice/pkg/outbox"
+	"github.com/AleksK1NG/es-microservice/pkg/ratelimit"
 	serviceErrors "github.com/AleksK1NG/es-microservice/pkg/service_errors"
 	"github.com/AleksK1NG/es-microservice/pkg/utils"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/pkg/errors"
@@ -31,15 +39,21 @@ func (s *server) initMongoDBCollections(ctx context.Context) {
 		}
 	}
 
-	indexOptions := options.Index().SetSparse(true).SetUnique(true)
-	index, err := s.mongoClient.Database(s.cfg.Mongo.Db).Collection(s.cfg.MongoCollections.Orders).Indexes().CreateOne(ctx, mongo.IndexModel{
-		Keys:    bson.D{{Key: constants.OrderIdIndex, Value: 1}},
-		Options: indexOptions,
+	indexes, err := s.mongoClient.Database(s.cfg.Mongo.Db).Collection(s.cfg.MongoCollections.Orders).Indexes().CreateMany(ctx, repository.GetOrdersIndexes())
+	if err != nil && !utils.CheckErrMessages(err, serviceErrors.ErrMsgAlreadyExists) {
+		s.log.Warnf("(CreateMany) err: {%v}", err)
+	}
+	s.log.Infof("(CreatedIndexes) indexes: {%v}", indexes)
+
+	// expired idempotency keys are removed by MongoDB TTL monitor
+	ttlIndex, err := s.mongoClient.Database(s.cfg.Mongo.Db).Collection(s.cfg.MongoCollections.Idempotency).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: constants.ExpiresAtIndex, Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
 	})
 	if err != nil && !utils.CheckErrMessages(err, serviceErrors.ErrMsgAlreadyExists) {
 		s.log.Warnf("(CreateOne) err: {%v}", err)
 	}
-	s.log.Infof("(CreatedIndex) index: {%s}", index)
+	s.log.Infof("(CreatedIndex) index: {%s}", ttlIndex)
 
 	list, err := s.mongoClient.Database(s.cfg.Mongo.Db).Collection(s.cfg.MongoCollections.Orders).Indexes().List(ctx)
 	if err != nil {
@@ -61,6 +75,31 @@ func (s *server) initMongoDBCollections(ctx context.Context) {
 	s.log.Infof("(Collections) created collections: {%v}", collections)
 }
 
+// newAuthVerifier returns nil if the authentication is disabled.
+func (s *server) newAuthVerifier() (auth.Verifier, error) {
+	if !s.cfg.Auth.Enable {
+		return nil, nil
+	}
+
+	verifier, err := auth.NewJWTVerifier(s.cfg.Auth)
+	if err != nil {
+		return nil, err
+	}
+	s.log.Infof("(auth enabled) jwks: {%s}, issuer: {%s}, audience: {%s}", s.cfg.Auth.JWKSPath, s.cfg.Auth.Issuer, s.cfg.Auth.Audience)
+	return verifier, nil
+}
+
+func (s *server) newSnapshotStore(db *esdb.Client) es.SnapshotStore {
+	if s.cfg.EventSourcing.SnapshotStore == es.SnapshotStoreMongoDB {
+		return store.NewMongoSnapshotStore(s.log, s.mongoClient.Database(s.cfg.Mongo.Db).Collection(s.cfg.MongoCollections.Snapshots))
+	}
+	return store.NewSnapshotStore(s.log, db)
+}
+
+func (s *server) newDeadLetterStore() es.DeadLetterStore {
+	return store.NewMongoDeadLetterStore(s.log, s.mongoClient.Database(s.cfg.Mongo.Db).Collection(s.cfg.MongoCollections.DeadLetters))
+}
+
 func (s *server) initElasticClient(ctx context.Context) error {
 	elasticClient, err := elasticsearch.NewElasticClient(s.cfg.Elastic)
 	if err != nil {
@@ -120,6 +159,67 @@ func (s *server) getGrpcMetricsCb() func(err error) {
 	}
 }
 
+func (s *server) getPublisherMetricsCb() outbox.MetricsCb {
+	return func(err error) {
+		if err != nil {
+			s.metrics.ErrorPublishedMessages.Inc()
+		} else {
+			s.metrics.SuccessPublishedMessages.Inc()
+		}
+	}
+}
+
+func (s *server) getSubscriptionMetricsCb() subscription.MetricsCb {
+	return func(groupName string, err error) {
+		if err != nil {
+			s.metrics.ErrorProjectedEvents.WithLabelValues(groupName).Inc()
+		} else {
+			s.metrics.SuccessProjectedEvents.WithLabelValues(groupName).Inc()
+		}
+	}
+}
+
+func (s *server) getLagMetricsCb() subscription.LagMetricsCb {
+	return func(groupName string, events uint64, seconds float64) {
+		s.metrics.ProjectionLagEvents.WithLabelValues(groupName).Set(float64(events))
+		s.metrics.ProjectionLagSeconds.WithLabelValues(groupName).Set(seconds)
+	}
+}
+
+func (s *server) getHttpRateLimitMetricsCb() ratelimit.MetricsCb {
+	return func(route string) {
+		s.metrics.RateLimitedHttpRequests.WithLabelValues(route).Inc()
+	}
+}
+
+func (s *server) getGrpcRateLimitMetricsCb() ratelimit.MetricsCb {
+	return func(method string) {
+		s.metrics.RateLimitedGrpcRequests.WithLabelValues(method).Inc()
+	}
+}
+
+// newSubscriptionConfig returns config of the projection subscription to the orders streams of all tenants,
+// the runner fails on the start if the tenants are changed since the group was created until the projection is rebuilt.
+func (s *server) newSubscriptionConfig(name string, groupName string) subscription.Config {
+	return subscription.Config{
+		Name:            name,
+		GroupName:       groupName,
+		Prefixes:        s.cfg.EventSourcing.Tenancy.GetStreamPrefixes(s.cfg.Subscriptions.OrderPrefix),
+		PoolSize:        s.cfg.Subscriptions.PoolSize,
+		ReconnectDelay:  s.cfg.Subscriptions.ReconnectDelay,
+		ProcessingRetry: s.cfg.Subscriptions.ProcessingRetry,
+	}
+}
+
+func (s *server) newOutboxSink() (outbox.Sink, error) {
+	switch s.cfg.Outbox.Sink {
+	case outbox.SinkFile:
+		return outbox.NewFileSink(s.cfg.Outbox.FilePath)
+	default:
+		return outbox.NewStdoutSink(), nil
+	}
+}
+
 func (s *server) waitShootDown(duration time.Duration) {
 	go func() {
 		time.Sleep(duration)
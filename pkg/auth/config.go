@@ -0,0 +1,56 @@
+package auth
+
+import "time"
+
+const (
+	defaultRolesClaim = "roles"
+	defaultEmailClaim = "email"
+)
+
+// Config of the callers authentication with JWT bearer tokens.
+type Config struct {
+	// Enable requires valid bearer token in the http Authorization header and gRPC authorization metadata.
+	Enable bool `mapstructure:"enable"`
+	// JWKSPath path of the local JSON Web Key Set with the keys verifying the tokens signatures.
+	JWKSPath string `mapstructure:"jwksPath" validate:"required_with=Enable"`
+	// Issuer expected iss claim, not checked if empty.
+	Issuer string `mapstructure:"issuer"`
+	// Audience expected aud claim, not checked if empty.
+	Audience string `mapstructure:"audience"`
+	// Leeway allowed clock skew checking exp and nbf claims.
+	Leeway time.Duration `mapstructure:"leeway"`
+	// EmailClaim claim with the caller email, email by default.
+	EmailClaim string `mapstructure:"emailClaim"`
+	// RolesClaim claim with the caller roles, roles by default.
+	RolesClaim string `mapstructure:"rolesClaim"`
+	// TenantClaim claim with the caller tenant id, the callers with it can't use the other tenants, not checked if empty.
+	TenantClaim string `mapstructure:"tenantClaim"`
+	// StaffRoles roles allowed to read any order and use the admin api,
+	// the other callers are customers reading only the orders of their account email.
+	StaffRoles []string `mapstructure:"staffRoles"`
+}
+
+func (c Config) getEmailClaim() string {
+	if c.EmailClaim == "" {
+		return defaultEmailClaim
+	}
+	return c.EmailClaim
+}
+
+func (c Config) getRolesClaim() string {
+	if c.RolesClaim == "" {
+		return defaultRolesClaim
+	}
+	return c.RolesClaim
+}
+
+func (c Config) isStaff(roles []string) bool {
+	for _, role := range roles {
+		for _, staffRole := range c.StaffRoles {
+			if role == staffRole {
+				return true
+			}
+		}
+	}
+	return false
+}
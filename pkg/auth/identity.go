@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrMissingToken = errors.New("missing bearer token")
+	ErrInvalidToken = errors.New("invalid token")
+	ErrForbidden    = errors.New("forbidden")
+)
+
+// Identity authenticated caller.
+type Identity struct {
+	Subject  string   `json:"subject"`
+	Email    string   `json:"email"`
+	Roles    []string `json:"roles"`
+	TenantID string   `json:"tenantId"`
+	Staff    bool     `json:"staff"`
+}
+
+func (i *Identity) String() string {
+	return fmt.Sprintf("Subject: {%s}, Email: {%s}, Roles: {%v}, TenantID: {%s}, Staff: {%v}", i.Subject, i.Email, i.Roles, i.TenantID, i.Staff)
+}
+
+type identityCtx struct{}
+
+// WithIdentity returns ctx carrying the authenticated caller, its subject is written to the saved events metadata.
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	ctx = es.WithSubject(ctx, identity.Subject)
+	return context.WithValue(ctx, identityCtx{}, identity)
+}
+
+// GetIdentity returns authenticated caller carried by ctx, nil if the authentication is disabled.
+func GetIdentity(ctx context.Context) *Identity {
+	identity, _ := ctx.Value(identityCtx{}).(*Identity)
+	return identity
+}
+
+// GetCustomerEmail returns account email of the customer caller, the orders it can read are limited to this account,
+// false for the staff callers and if the authentication is disabled.
+func GetCustomerEmail(ctx context.Context) (string, bool) {
+	identity := GetIdentity(ctx)
+	if identity == nil || identity.Staff {
+		return "", false
+	}
+	return identity.Email, true
+}
+
+// CheckOrderAccess returns ErrForbidden if the customer caller reads or changes the order of another account.
+func CheckOrderAccess(ctx context.Context, accountEmail string) error {
+	identity := GetIdentity(ctx)
+	if identity == nil || identity.Staff {
+		return nil
+	}
+	if identity.Email == "" || !strings.EqualFold(identity.Email, accountEmail) {
+		return errors.Wrapf(ErrForbidden, "subject: {%s} can't access orders of the other accounts", identity.Subject)
+	}
+	return nil
+}
+
+// CheckStaffAccess returns ErrForbidden if the caller is not staff.
+func CheckStaffAccess(ctx context.Context) error {
+	identity := GetIdentity(ctx)
+	if identity == nil || identity.Staff {
+		return nil
+	}
+	return errors.Wrapf(ErrForbidden, "subject: {%s} is not staff", identity.Subject)
+}
+
+// CheckTenantAccess returns ErrForbidden if the caller token is issued for another tenant.
+func CheckTenantAccess(ctx context.Context, tenantID string) error {
+	identity := GetIdentity(ctx)
+	if identity == nil || identity.TenantID == "" || identity.TenantID == tenantID {
+		return nil
+	}
+	return errors.Wrapf(ErrForbidden, "subject: {%s} can't use tenant: {%s}", identity.Subject, tenantID)
+}
@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/pkg/errors"
+)
+
+var (
+	testCustomer = &Identity{Subject: "customer-1", Email: "Customer@mail.com", Roles: []string{"customer"}, TenantID: "tenantA"}
+	testStaff    = &Identity{Subject: "staff-1", Email: "support@mail.com", Roles: []string{"support"}, TenantID: "tenantA", Staff: true}
+	testNoTenant = &Identity{Subject: "customer-2", Email: "other@mail.com", Roles: []string{"customer"}}
+	testNoEmail  = &Identity{Subject: "customer-3", Roles: []string{"customer"}, TenantID: "tenantA"}
+)
+
+func newIdentityCtx(identity *Identity) context.Context {
+	if identity == nil {
+		return context.Background()
+	}
+	return WithIdentity(context.Background(), identity)
+}
+
+func TestCheckOrderAccess(t *testing.T) {
+	tests := []struct {
+		name         string
+		identity     *Identity
+		accountEmail string
+		err          error
+	}{
+		{name: "own order", identity: testCustomer, accountEmail: "customer@mail.com"},
+		{name: "own order email case", identity: testCustomer, accountEmail: "CUSTOMER@MAIL.COM"},
+		{name: "other account order", identity: testCustomer, accountEmail: "other@mail.com", err: ErrForbidden},
+		{name: "customer without email", identity: testNoEmail, accountEmail: "", err: ErrForbidden},
+		{name: "staff", identity: testStaff, accountEmail: "other@mail.com"},
+		{name: "authentication disabled", accountEmail: "other@mail.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckOrderAccess(newIdentityCtx(tt.identity), tt.accountEmail)
+			if !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+				t.Errorf("CheckOrderAccess() err = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestCheckTenantAccess(t *testing.T) {
+	tests := []struct {
+		name     string
+		identity *Identity
+		tenantID string
+		err      error
+	}{
+		{name: "customer own tenant", identity: testCustomer, tenantID: "tenantA"},
+		{name: "customer other tenant", identity: testCustomer, tenantID: "tenantB", err: ErrForbidden},
+		{name: "customer default tenant", identity: testCustomer, tenantID: "", err: ErrForbidden},
+		{name: "staff own tenant", identity: testStaff, tenantID: "tenantA"},
+		{name: "staff other tenant", identity: testStaff, tenantID: "tenantB", err: ErrForbidden},
+		{name: "token without tenant", identity: testNoTenant, tenantID: "tenantB"},
+		{name: "authentication disabled", tenantID: "tenantB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckTenantAccess(newIdentityCtx(tt.identity), tt.tenantID)
+			if !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+				t.Errorf("CheckTenantAccess() err = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestCheckStaffAccess(t *testing.T) {
+	tests := []struct {
+		name     string
+		identity *Identity
+		err      error
+	}{
+		{name: "customer", identity: testCustomer, err: ErrForbidden},
+		{name: "staff", identity: testStaff},
+		{name: "authentication disabled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckStaffAccess(newIdentityCtx(tt.identity))
+			if !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+				t.Errorf("CheckStaffAccess() err = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestGetCustomerEmail(t *testing.T) {
+	tests := []struct {
+		name     string
+		identity *Identity
+		email    string
+		customer bool
+	}{
+		{name: "customer", identity: testCustomer, email: "Customer@mail.com", customer: true},
+		{name: "staff", identity: testStaff},
+		{name: "authentication disabled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := newIdentityCtx(tt.identity)
+			email, customer := GetCustomerEmail(ctx)
+			if email != tt.email || customer != tt.customer {
+				t.Errorf("GetCustomerEmail() = %s, %v, want %s, %v", email, customer, tt.email, tt.customer)
+			}
+			if tt.identity != nil && es.GetSubject(ctx) != tt.identity.Subject {
+				t.Errorf("GetSubject() = %s, want %s", es.GetSubject(ctx), tt.identity.Subject)
+			}
+		})
+	}
+}
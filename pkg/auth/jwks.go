@@ -0,0 +1,140 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	keyTypeRSA = "RSA"
+	keyTypeEC  = "EC"
+	keyTypeOct = "oct"
+)
+
+// jsonWebKey public RSA, EC or symmetric oct key of the JSON Web Key Set.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	K   string `json:"k"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// verificationKey parsed key of the JSON Web Key Set, alg is empty if the key doesn't restrict the signing algorithm.
+type verificationKey struct {
+	kid string
+	alg string
+	key interface{}
+}
+
+// loadJWKS reads the signature keys of the local JSON Web Key Set file.
+func loadJWKS(path string) ([]*verificationKey, error) {
+	jwksBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "ioutil.ReadFile")
+	}
+
+	var jwks jsonWebKeySet
+	if err := json.Unmarshal(jwksBytes, &jwks); err != nil {
+		return nil, errors.Wrap(err, "json.Unmarshal")
+	}
+	if len(jwks.Keys) == 0 {
+		return nil, errors.Errorf("JWKS: {%s} has no keys", path)
+	}
+
+	keys := make([]*verificationKey, 0, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.parse()
+		if err != nil {
+			return nil, errors.Wrapf(err, "kid: {%s}", jwk.Kid)
+		}
+		keys = append(keys, &verificationKey{kid: jwk.Kid, alg: jwk.Alg, key: key})
+	}
+	return keys, nil
+}
+
+func (k jsonWebKey) parse() (interface{}, error) {
+	switch k.Kty {
+	case keyTypeRSA:
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, errors.Wrap(err, "n")
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, errors.Wrap(err, "e")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case keyTypeEC:
+		curve, err := getCurve(k.Crv)
+		if err != nil {
+			return nil, err
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, errors.Wrap(err, "x")
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, errors.Wrap(err, "y")
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("point is not on the curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+
+	case keyTypeOct:
+		secret, err := base64.RawURLEncoding.DecodeString(k.K)
+		if err != nil {
+			return nil, errors.Wrap(err, "k")
+		}
+		return secret, nil
+
+	default:
+		return nil, errors.Errorf("unsupported key type: {%s}", k.Kty)
+	}
+}
+
+func getCurve(crv string) (elliptic.Curve, error) {
+	switch crv {
+	case "P-256":
+		return elliptic.P256(), nil
+	case "P-384":
+		return elliptic.P384(), nil
+	case "P-521":
+		return elliptic.P521(), nil
+	default:
+		return nil, errors.Errorf("unsupported curve: {%s}", crv)
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	valueBytes, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(valueBytes) == 0 {
+		return nil, errors.New("empty value")
+	}
+	return new(big.Int).SetBytes(valueBytes), nil
+}
@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/pkg/errors"
+)
+
+const (
+	bearerScheme = "bearer "
+)
+
+// validMethods asymmetric and HMAC signing algorithms, none is never accepted.
+var validMethods = []string{
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+	"HS256", "HS384", "HS512",
+}
+
+// Verifier verifies the callers bearer tokens.
+type Verifier interface {
+	// Verify returns caller identity of the valid token, ErrInvalidToken otherwise.
+	Verify(token string) (*Identity, error)
+}
+
+type jwtVerifier struct {
+	cfg    Config
+	keys   []*verificationKey
+	parser *jwt.Parser
+}
+
+// NewJWTVerifier creates Verifier of the JWT signed with the keys of the local JSON Web Key Set.
+func NewJWTVerifier(cfg Config) (*jwtVerifier, error) {
+	keys, err := loadJWKS(cfg.JWKSPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "loadJWKS")
+	}
+
+	// time based claims are checked with the configured leeway
+	parser := &jwt.Parser{ValidMethods: validMethods, SkipClaimsValidation: true}
+	return &jwtVerifier{cfg: cfg, keys: keys, parser: parser}, nil
+}
+
+func (v *jwtVerifier) Verify(token string) (*Identity, error) {
+	claims := jwt.MapClaims{}
+	if _, err := v.parser.ParseWithClaims(token, claims, v.getKey); err != nil {
+		return nil, errors.Wrapf(ErrInvalidToken, "err: {%v}", err)
+	}
+
+	if err := v.validateClaims(claims); err != nil {
+		return nil, errors.Wrapf(ErrInvalidToken, "err: {%v}", err)
+	}
+
+	subject, _ := claims["sub"].(string)
+	email, _ := claims[v.cfg.getEmailClaim()].(string)
+	roles := getStringsClaim(claims, v.cfg.getRolesClaim())
+
+	identity := &Identity{Subject: subject, Email: email, Roles: roles, Staff: v.cfg.isStaff(roles)}
+	if v.cfg.TenantClaim != "" {
+		identity.TenantID, _ = claims[v.cfg.TenantClaim].(string)
+	}
+	return identity, nil
+}
+
+// getKey returns the key with the token kid, or the only key of the set if the token has no kid.
+func (v *jwtVerifier) getKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	for _, key := range v.keys {
+		if kid != "" && key.kid != kid {
+			continue
+		}
+		if kid == "" && len(v.keys) > 1 {
+			return nil, errors.New("token without kid")
+		}
+		if key.alg != "" && key.alg != token.Method.Alg() {
+			return nil, errors.Errorf("key: {%s} doesn't allow alg: {%s}", key.kid, token.Method.Alg())
+		}
+		return key.key, nil
+	}
+	return nil, errors.Errorf("unknown kid: {%s}", kid)
+}
+
+func (v *jwtVerifier) validateClaims(claims jwt.MapClaims) error {
+	now := time.Now()
+	if !claims.VerifyExpiresAt(now.Add(-v.cfg.Leeway).Unix(), true) {
+		return errors.New("token is expired")
+	}
+	if !claims.VerifyNotBefore(now.Add(v.cfg.Leeway).Unix(), false) {
+		return errors.New("token is not valid yet")
+	}
+	if v.cfg.Issuer != "" && !claims.VerifyIssuer(v.cfg.Issuer, true) {
+		return errors.New("invalid issuer")
+	}
+	if v.cfg.Audience != "" && !claims.VerifyAudience(v.cfg.Audience, true) {
+		return errors.New("invalid audience")
+	}
+	if sub, _ := claims["sub"].(string); sub == "" {
+		return errors.New("token without subject")
+	}
+	return nil
+}
+
+// GetBearerToken returns token of the Bearer authorization header value, ErrMissingToken if there is no token.
+func GetBearerToken(authorization string) (string, error) {
+	if len(authorization) <= len(bearerScheme) || !strings.EqualFold(authorization[:len(bearerScheme)], bearerScheme) {
+		return "", ErrMissingToken
+	}
+	return strings.TrimSpace(authorization[len(bearerScheme):]), nil
+}
+
+// getStringsClaim returns the claim with array of strings or space separated string value.
+func getStringsClaim(claims jwt.MapClaims, name string) []string {
+	switch value := claims[name].(type) {
+	case string:
+		return strings.Fields(value)
+	case []interface{}:
+		values := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
@@ -0,0 +1,366 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/pkg/errors"
+)
+
+const (
+	testIssuer   = "https://auth.example.com"
+	testAudience = "orders"
+)
+
+type testKeys struct {
+	rsa    *rsa.PrivateKey
+	other  *rsa.PrivateKey
+	ec     *ecdsa.PrivateKey
+	secret []byte
+}
+
+func newTestKeys(t *testing.T) *testKeys {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() err: %v", err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() err: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey() err: %v", err)
+	}
+	return &testKeys{rsa: rsaKey, other: otherKey, ec: ecKey, secret: []byte("0123456789abcdef0123456789abcdef")}
+}
+
+func encodeBigInt(value *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(value.Bytes())
+}
+
+// writeJWKS writes JSON Web Key Set with the public keys and the symmetric key restricted to HS256.
+func (k *testKeys) writeJWKS(t *testing.T) string {
+	jwks := jsonWebKeySet{Keys: []jsonWebKey{
+		{Kty: keyTypeRSA, Kid: "rsa-1", Use: "sig", N: encodeBigInt(k.rsa.N), E: encodeBigInt(big.NewInt(int64(k.rsa.E)))},
+		{Kty: keyTypeRSA, Kid: "rsa-256", Alg: "RS256", N: encodeBigInt(k.rsa.N), E: encodeBigInt(big.NewInt(int64(k.rsa.E)))},
+		{Kty: keyTypeEC, Kid: "ec-1", Crv: "P-256", X: encodeBigInt(k.ec.X), Y: encodeBigInt(k.ec.Y)},
+		{Kty: keyTypeOct, Kid: "hmac-1", Alg: "HS256", K: base64.RawURLEncoding.EncodeToString(k.secret)},
+		{Kty: keyTypeRSA, Kid: "enc-1", Use: "enc", N: encodeBigInt(k.other.N), E: encodeBigInt(big.NewInt(int64(k.other.E)))},
+	}}
+	return writeTestFile(t, jwks)
+}
+
+func writeTestFile(t *testing.T, content interface{}) string {
+	data, err := json.Marshal(content)
+	if err != nil {
+		t.Fatalf("json.Marshal() err: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("WriteFile() err: %v", err)
+	}
+	return path
+}
+
+func newTestClaims(changes ...func(claims jwt.MapClaims)) jwt.MapClaims {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"sub":    "subject-1",
+		"email":  "customer@mail.com",
+		"roles":  []string{"customer"},
+		"tenant": "tenantA",
+		"iss":    testIssuer,
+		"aud":    testAudience,
+		"iat":    now.Unix(),
+		"nbf":    now.Add(-time.Minute).Unix(),
+		"exp":    now.Add(time.Hour).Unix(),
+	}
+	for _, change := range changes {
+		change(claims)
+	}
+	return claims
+}
+
+func setClaim(name string, value interface{}) func(claims jwt.MapClaims) {
+	return func(claims jwt.MapClaims) {
+		if value == nil {
+			delete(claims, name)
+			return
+		}
+		claims[name] = value
+	}
+}
+
+func signTestToken(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("SignedString() err: %v", err)
+	}
+	return signed
+}
+
+func TestJWTVerifierVerify(t *testing.T) {
+	keys := newTestKeys(t)
+	verifier, err := NewJWTVerifier(Config{
+		JWKSPath:    keys.writeJWKS(t),
+		Issuer:      testIssuer,
+		Audience:    testAudience,
+		Leeway:      30 * time.Second,
+		TenantClaim: "tenant",
+		StaffRoles:  []string{"support", "admin"},
+	})
+	if err != nil {
+		t.Fatalf("NewJWTVerifier() err: %v", err)
+	}
+
+	publicKeyDER, err := x509.MarshalPKIXPublicKey(&keys.rsa.PublicKey)
+	if err != nil {
+		t.Fatalf("MarshalPKIXPublicKey() err: %v", err)
+	}
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		token    string
+		identity *Identity
+	}{
+		{
+			name:     "rsa key",
+			token:    signTestToken(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa, newTestClaims()),
+			identity: &Identity{Subject: "subject-1", Email: "customer@mail.com", Roles: []string{"customer"}, TenantID: "tenantA"},
+		},
+		{
+			name:     "rsa pss key",
+			token:    signTestToken(t, jwt.SigningMethodPS256, "rsa-1", keys.rsa, newTestClaims()),
+			identity: &Identity{Subject: "subject-1", Email: "customer@mail.com", Roles: []string{"customer"}, TenantID: "tenantA"},
+		},
+		{
+			name:     "ec key",
+			token:    signTestToken(t, jwt.SigningMethodES256, "ec-1", keys.ec, newTestClaims()),
+			identity: &Identity{Subject: "subject-1", Email: "customer@mail.com", Roles: []string{"customer"}, TenantID: "tenantA"},
+		},
+		{
+			name:     "hmac key",
+			token:    signTestToken(t, jwt.SigningMethodHS256, "hmac-1", keys.secret, newTestClaims()),
+			identity: &Identity{Subject: "subject-1", Email: "customer@mail.com", Roles: []string{"customer"}, TenantID: "tenantA"},
+		},
+		{
+			name:     "staff roles",
+			token:    signTestToken(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa, newTestClaims(setClaim("roles", []string{"viewer", "support"}))),
+			identity: &Identity{Subject: "subject-1", Email: "customer@mail.com", Roles: []string{"viewer", "support"}, TenantID: "tenantA", Staff: true},
+		},
+		{
+			name:     "space separated roles",
+			token:    signTestToken(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa, newTestClaims(setClaim("roles", "viewer admin"))),
+			identity: &Identity{Subject: "subject-1", Email: "customer@mail.com", Roles: []string{"viewer", "admin"}, TenantID: "tenantA", Staff: true},
+		},
+		{
+			name:     "audiences list",
+			token:    signTestToken(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa, newTestClaims(setClaim("aud", []string{"billing", testAudience}))),
+			identity: &Identity{Subject: "subject-1", Email: "customer@mail.com", Roles: []string{"customer"}, TenantID: "tenantA"},
+		},
+		{
+			name:     "expired within leeway",
+			token:    signTestToken(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa, newTestClaims(setClaim("exp", now.Add(-10*time.Second).Unix()))),
+			identity: &Identity{Subject: "subject-1", Email: "customer@mail.com", Roles: []string{"customer"}, TenantID: "tenantA"},
+		},
+		{
+			name:     "not before within leeway",
+			token:    signTestToken(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa, newTestClaims(setClaim("nbf", now.Add(10*time.Second).Unix()))),
+			identity: &Identity{Subject: "subject-1", Email: "customer@mail.com", Roles: []string{"customer"}, TenantID: "tenantA"},
+		},
+		{name: "expired", token: signTestToken(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa, newTestClaims(setClaim("exp", now.Add(-time.Minute).Unix())))},
+		{name: "no expiration", token: signTestToken(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa, newTestClaims(setClaim("exp", nil)))},
+		{name: "not valid yet", token: signTestToken(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa, newTestClaims(setClaim("nbf", now.Add(time.Minute).Unix())))},
+		{name: "wrong issuer", token: signTestToken(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa, newTestClaims(setClaim("iss", "https://other.example.com")))},
+		{name: "no issuer", token: signTestToken(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa, newTestClaims(setClaim("iss", nil)))},
+		{name: "wrong audience", token: signTestToken(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa, newTestClaims(setClaim("aud", "billing")))},
+		{name: "no audience", token: signTestToken(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa, newTestClaims(setClaim("aud", nil)))},
+		{name: "no subject", token: signTestToken(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa, newTestClaims(setClaim("sub", nil)))},
+		{name: "unknown kid", token: signTestToken(t, jwt.SigningMethodRS256, "rsa-2", keys.rsa, newTestClaims())},
+		{name: "no kid with many keys", token: signTestToken(t, jwt.SigningMethodRS256, "", keys.rsa, newTestClaims())},
+		{name: "encryption key", token: signTestToken(t, jwt.SigningMethodRS256, "enc-1", keys.other, newTestClaims())},
+		{name: "signed by other key", token: signTestToken(t, jwt.SigningMethodRS256, "rsa-1", keys.other, newTestClaims())},
+		{name: "key alg mismatch", token: signTestToken(t, jwt.SigningMethodRS512, "rsa-256", keys.rsa, newTestClaims())},
+		{name: "hmac signed with rsa public key", token: signTestToken(t, jwt.SigningMethodHS256, "rsa-1", publicKeyDER, newTestClaims())},
+		{name: "hmac signed with rsa public key of the key with alg", token: signTestToken(t, jwt.SigningMethodHS256, "rsa-256", publicKeyDER, newTestClaims())},
+		{name: "rsa signed with hmac kid", token: signTestToken(t, jwt.SigningMethodRS256, "hmac-1", keys.rsa, newTestClaims())},
+		{name: "none alg", token: signTestToken(t, jwt.SigningMethodNone, "rsa-1", jwt.UnsafeAllowNoneSignatureType, newTestClaims())},
+		{name: "none alg without kid", token: signTestToken(t, jwt.SigningMethodNone, "", jwt.UnsafeAllowNoneSignatureType, newTestClaims())},
+		{name: "malformed", token: "not.a.token"},
+		{name: "empty", token: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := verifier.Verify(tt.token)
+			if tt.identity == nil {
+				if !errors.Is(err, ErrInvalidToken) {
+					t.Fatalf("Verify() = %v, err = %v, want %v", identity, err, ErrInvalidToken)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify() err: %v", err)
+			}
+			if identity.String() != tt.identity.String() {
+				t.Errorf("Verify() = %s, want %s", identity, tt.identity)
+			}
+		})
+	}
+}
+
+func TestJWTVerifierTamperedToken(t *testing.T) {
+	keys := newTestKeys(t)
+	verifier, err := NewJWTVerifier(Config{JWKSPath: keys.writeJWKS(t)})
+	if err != nil {
+		t.Fatalf("NewJWTVerifier() err: %v", err)
+	}
+
+	token := signTestToken(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa, newTestClaims())
+	staffClaims, err := json.Marshal(newTestClaims(setClaim("roles", []string{"admin"})))
+	if err != nil {
+		t.Fatalf("json.Marshal() err: %v", err)
+	}
+	parts := splitToken(token)
+	tampered := parts[0] + "." + base64.RawURLEncoding.EncodeToString(staffClaims) + "." + parts[2]
+
+	if _, err := verifier.Verify(token); err != nil {
+		t.Fatalf("Verify() err: %v", err)
+	}
+	if _, err := verifier.Verify(tampered); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Verify() tampered err = %v, want %v", err, ErrInvalidToken)
+	}
+}
+
+func splitToken(token string) [3]string {
+	var parts [3]string
+	part := 0
+	for _, c := range token {
+		if c == '.' {
+			part++
+			continue
+		}
+		parts[part] += string(c)
+	}
+	return parts
+}
+
+func TestJWTVerifierSingleKey(t *testing.T) {
+	keys := newTestKeys(t)
+	path := writeTestFile(t, jsonWebKeySet{Keys: []jsonWebKey{
+		{Kty: keyTypeRSA, N: encodeBigInt(keys.rsa.N), E: encodeBigInt(big.NewInt(int64(keys.rsa.E)))},
+	}})
+	verifier, err := NewJWTVerifier(Config{JWKSPath: path})
+	if err != nil {
+		t.Fatalf("NewJWTVerifier() err: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		err   error
+	}{
+		{name: "no kid", token: signTestToken(t, jwt.SigningMethodRS256, "", keys.rsa, newTestClaims())},
+		{name: "kid of the other key", token: signTestToken(t, jwt.SigningMethodRS256, "rsa-1", keys.rsa, newTestClaims()), err: ErrInvalidToken},
+		{name: "signed by other key", token: signTestToken(t, jwt.SigningMethodRS256, "", keys.other, newTestClaims()), err: ErrInvalidToken},
+		{name: "none alg", token: signTestToken(t, jwt.SigningMethodNone, "", jwt.UnsafeAllowNoneSignatureType, newTestClaims()), err: ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := verifier.Verify(tt.token); !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+				t.Errorf("Verify() err = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestLoadJWKS(t *testing.T) {
+	keys := newTestKeys(t)
+	n, e := encodeBigInt(keys.rsa.N), encodeBigInt(big.NewInt(int64(keys.rsa.E)))
+
+	tests := []struct {
+		name    string
+		content interface{}
+		keys    int
+		invalid bool
+	}{
+		{name: "signature keys", content: jsonWebKeySet{Keys: []jsonWebKey{{Kty: keyTypeRSA, Kid: "rsa-1", N: n, E: e}, {Kty: keyTypeRSA, Kid: "enc-1", Use: "enc", N: n, E: e}}}, keys: 1},
+		{name: "no keys", content: jsonWebKeySet{}, invalid: true},
+		{name: "malformed", content: "keys", invalid: true},
+		{name: "unsupported key type", content: jsonWebKeySet{Keys: []jsonWebKey{{Kty: "OKP", Kid: "okp-1"}}}, invalid: true},
+		{name: "empty modulus", content: jsonWebKeySet{Keys: []jsonWebKey{{Kty: keyTypeRSA, Kid: "rsa-1", E: e}}}, invalid: true},
+		{name: "unsupported curve", content: jsonWebKeySet{Keys: []jsonWebKey{{Kty: keyTypeEC, Kid: "ec-1", Crv: "P-224", X: encodeBigInt(keys.ec.X), Y: encodeBigInt(keys.ec.Y)}}}, invalid: true},
+		{name: "point not on curve", content: jsonWebKeySet{Keys: []jsonWebKey{{Kty: keyTypeEC, Kid: "ec-1", Crv: "P-256", X: encodeBigInt(keys.ec.X), Y: encodeBigInt(keys.ec.X)}}}, invalid: true},
+		{name: "invalid base64", content: jsonWebKeySet{Keys: []jsonWebKey{{Kty: keyTypeOct, Kid: "hmac-1", K: "not base64!"}}}, invalid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loaded, err := loadJWKS(writeTestFile(t, tt.content))
+			if tt.invalid {
+				if err == nil {
+					t.Fatalf("loadJWKS() = %d keys, want error", len(loaded))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadJWKS() err: %v", err)
+			}
+			if len(loaded) != tt.keys {
+				t.Errorf("loadJWKS() = %d keys, want %d", len(loaded), tt.keys)
+			}
+		})
+	}
+
+	if _, err := loadJWKS(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Errorf("loadJWKS() of the missing file err = nil")
+	}
+}
+
+func TestGetBearerToken(t *testing.T) {
+	tests := []struct {
+		authorization string
+		token         string
+		err           error
+	}{
+		{authorization: "Bearer token", token: "token"},
+		{authorization: "bearer token", token: "token"},
+		{authorization: "BEARER  token ", token: "token"},
+		{authorization: "", err: ErrMissingToken},
+		{authorization: "Bearer", err: ErrMissingToken},
+		{authorization: "Bearer ", err: ErrMissingToken},
+		{authorization: "Basic dXNlcjpwYXNz", err: ErrMissingToken},
+		{authorization: "token", err: ErrMissingToken},
+		{authorization: "Bearertoken", err: ErrMissingToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.authorization, func(t *testing.T) {
+			token, err := GetBearerToken(tt.authorization)
+			if !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+				t.Fatalf("GetBearerToken() err = %v, want %v", err, tt.err)
+			}
+			if token != tt.token {
+				t.Errorf("GetBearerToken() = %q, want %q", token, tt.token)
+			}
+		})
+	}
+}
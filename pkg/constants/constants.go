@@ -5,11 +5,12 @@ const (
 	HttpPort                   = "HTTP_PORT"
 	ConfigPath                 = "CONFIG_PATH"
 	KafkaBrokers               = "KAFKA_BROKERS"
-	JaegerHostPort             = "JAEGER_HOST"
+	OtlpEndpoint               = "OTLP_ENDPOINT"
 	RedisAddr                  = "REDIS_ADDR"
 	MongoDbURI                 = "MONGO_URI"
 	EventStoreConnectionString = "EVENT_STORE_CONNECTION_STRING"
 	ElasticUrl                 = "ELASTIC_URL"
+	CursorSecret               = "CURSOR_SECRET"
 
 	ReaderServicePort = "READER_SERVICE"
 
@@ -20,6 +21,9 @@ const (
 	Postgres      = "postgres"
 	MongoDB       = "mongo"
 	ElasticSearch = "elasticSearch"
+	ProjectionLag = "projectionLag"
+	EventStoreDB  = "eventStoreDB"
+	Subscription  = "subscription"
 
 	GRPC     = "GRPC"
 	SIZE     = "SIZE"
@@ -51,6 +55,38 @@ const (
 	Size   = "size"
 	Search = "search"
 	ID     = "id"
+	ItemID = "itemId"
+
+	EventTypeQuery = "eventType"
+	StatusQuery    = "status"
+	VersionQuery   = "version"
+	TimestampQuery = "timestamp"
+
+	OrderByQuery       = "orderBy"
+	CursorQuery        = "cursor"
+	UseCursorQuery     = "useCursor"
+	AccountEmailQuery  = "accountEmail"
+	CurrencyQuery      = "currency"
+	MinTotalPriceQuery = "minTotalPrice"
+	MaxTotalPriceQuery = "maxTotalPrice"
+	CreatedFromQuery   = "createdFrom"
+	CreatedToQuery     = "createdTo"
+	DeliveredFromQuery = "deliveredFrom"
+	DeliveredToQuery   = "deliveredTo"
+
+	AfterVersionQuery = "afterVersion"
+	LastEventIDHeader = "Last-Event-ID"
+
+	IdempotencyKeyHeader   = "Idempotency-Key"
+	IdempotencyKeyMetadata = "idempotency-key"
+
+	TenantIDHeader   = "X-Tenant-ID"
+	TenantIDMetadata = "x-tenant-id"
+
+	AuthorizationMetadata = "authorization"
+
+	RetryAfterHeader   = "Retry-After"
+	RetryAfterMetadata = "retry-after"
 
 	EsAll = "$all"
 
@@ -69,7 +105,9 @@ const (
 	ElasticProjection = "(Elastic Projection)"
 
 	OrderIdIndex    = "orderId"
+	ExpiresAtIndex  = "expiresAt"
 	OrderId         = "orderId"
+	TenantID        = "tenantId"
 	DeliveryAddress = "deliveryAddress"
 	Submitted       = "submitted"
 	Completed       = "completed"
@@ -78,4 +116,17 @@ const (
 	Paid            = "paid"
 	Canceled        = "canceled"
 	CancelReason    = "cancelReason"
+	Status          = "status"
+	ShopItems       = "shopItems"
+	ShopItemQty     = "shopItems.$[item].quantity"
+	TotalPrice      = "totalPrice"
+	Refunds         = "refunds"
+	RefundedAmount  = "refundedAmount"
+
+	AccountEmail     = "accountEmail"
+	CreatedAt        = "createdAt"
+	TotalPriceAmount = "totalPrice.amount"
+
+	Version          = "version"
+	ShopItemFilterID = "item.id"
 )
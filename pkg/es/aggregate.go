@@ -77,6 +77,7 @@ type AggregateRoot interface {
 	GetID() string
 	SetID(id string) *AggregateBase
 	GetVersion() int64
+	GetLoadedVersion() int64
 	ClearUncommittedEvents()
 	ToSnapshot()
 	SetType(aggregateType AggregateType)
@@ -156,6 +157,12 @@ func (a *AggregateBase) GetVersion() int64 {
 	return a.Version
 }
 
+// GetLoadedVersion get AggregateBase version before uncommitted Event's were applied,
+// used as expected stream revision for optimistic concurrency, -1 means new aggregate.
+func (a *AggregateBase) GetLoadedVersion() int64 {
+	return a.Version - int64(len(a.UncommittedEvents))
+}
+
 // ClearUncommittedEvents clear AggregateBase uncommitted Event's
 func (a *AggregateBase) ClearUncommittedEvents() {
 	a.UncommittedEvents = make([]Event, 0, aggregateUncommittedEventsInitialCap)
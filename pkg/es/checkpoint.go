@@ -0,0 +1,24 @@
+package es
+
+import (
+	"time"
+)
+
+// Checkpoint is the last processed $all position of the named catch-up subscription,
+// subscription restarts from it, so events after the checkpoint are delivered at least once.
+type Checkpoint struct {
+	Name            string    `json:"name" bson:"_id"`
+	CommitPosition  uint64    `json:"commitPosition" bson:"commitPosition"`
+	PreparePosition uint64    `json:"preparePosition" bson:"preparePosition"`
+	Timestamp       time.Time `json:"timestamp" bson:"timestamp"`
+}
+
+// NewCheckpoint checkpoint constructor.
+func NewCheckpoint(name string, commitPosition, preparePosition uint64) Checkpoint {
+	return Checkpoint{
+		Name:            name,
+		CommitPosition:  commitPosition,
+		PreparePosition: preparePosition,
+		Timestamp:       time.Now().UTC(),
+	}
+}
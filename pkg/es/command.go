@@ -3,16 +3,23 @@ package es
 // Command commands interface for event sourcing.
 type Command interface {
 	GetAggregateID() string
+	GetTenantID() string
 }
 
 type BaseCommand struct {
 	AggregateID string `json:"aggregateID" validate:"required,gte=0"`
+	TenantID    string `json:"tenantID,omitempty" validate:"omitempty,alphanum,max=32"`
 }
 
-func NewBaseCommand(aggregateID string) BaseCommand {
-	return BaseCommand{AggregateID: aggregateID}
+func NewBaseCommand(tenantID string, aggregateID string) BaseCommand {
+	return BaseCommand{AggregateID: aggregateID, TenantID: tenantID}
 }
 
 func (c *BaseCommand) GetAggregateID() string {
 	return c.AggregateID
 }
+
+// GetTenantID returns id of the tenant of the command, empty for the default tenant.
+func (c *BaseCommand) GetTenantID() string {
+	return c.TenantID
+}
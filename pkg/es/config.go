@@ -1,6 +1,37 @@
 package es
 
+import "time"
+
+const (
+	SnapshotStoreEventStoreDB = "eventstoredb"
+	SnapshotStoreMongoDB      = "mongodb"
+)
+
 // Config of es package.
 type Config struct {
-	SnapshotFrequency int64 `json:"snapshotFrequency" validate:"required,gte=0"`
+	// SnapshotFrequency save aggregate snapshot every N events, 0 disables snapshotting.
+	SnapshotFrequency int64 `mapstructure:"snapshotFrequency" json:"snapshotFrequency" validate:"gte=0"`
+	// SnapshotStore storage of the snapshots, eventstoredb (default) or mongodb.
+	SnapshotStore string `mapstructure:"snapshotStore" json:"snapshotStore"`
+	// ConcurrencyRetry reload and retry commands on ErrConcurrencyConflict.
+	ConcurrencyRetry RetryPolicy `mapstructure:"concurrencyRetry" json:"concurrencyRetry"`
+	// IdempotencyTTL how long the results of the commands executed with idempotency keys are kept.
+	IdempotencyTTL time.Duration `mapstructure:"idempotencyTTL" json:"idempotencyTTL"`
+	// IdempotencyLease how long the key of the command in progress is reserved, the key of the command crashed
+	// before completion can be used again after the lease, it must be longer than the commands take and not longer than IdempotencyTTL.
+	IdempotencyLease time.Duration `mapstructure:"idempotencyLease" json:"idempotencyLease" validate:"ltefield=IdempotencyTTL"`
+	// Tenancy tenants sharing the event store, their streams are prefixed with the tenant id.
+	Tenancy TenancyConfig `mapstructure:"tenancy" json:"tenancy"`
+}
+
+// IsSnapshotRequired check is aggregate snapshot must be saved after appending uncommittedEvents count of events,
+// true when aggregate events count crossed the next SnapshotFrequency boundary.
+func (c Config) IsSnapshotRequired(aggregateVersion int64, uncommittedEvents int) bool {
+	if c.SnapshotFrequency <= 0 || uncommittedEvents == 0 {
+		return false
+	}
+
+	eventsCount := aggregateVersion + 1
+	previousEventsCount := eventsCount - int64(uncommittedEvents)
+	return eventsCount/c.SnapshotFrequency > previousEventsCount/c.SnapshotFrequency
 }
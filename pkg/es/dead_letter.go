@@ -0,0 +1,45 @@
+package es
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+)
+
+// DeadLetter is the event which projection failed to process after all the retries,
+// it is parked in the persistent subscription and its copy is kept in the DeadLetterStore for inspection and replay.
+type DeadLetter struct {
+	ID             string    `json:"id" bson:"_id"`
+	GroupName      string    `json:"groupName" bson:"groupName"`
+	Event          Event     `json:"event" bson:"event"`
+	EventNumber    uint64    `json:"eventNumber" bson:"eventNumber"`
+	CommitPosition uint64    `json:"commitPosition" bson:"commitPosition"`
+	Error          string    `json:"error" bson:"error"`
+	Attempts       int       `json:"attempts" bson:"attempts"`
+	ParkedAt       time.Time `json:"parkedAt" bson:"parkedAt"`
+}
+
+// NewDeadLetter dead letter constructor, id is unique per subscription group and event.
+func NewDeadLetter(groupName string, event *esdb.RecordedEvent, err error, attempts int) DeadLetter {
+	return DeadLetter{
+		ID:             GetDeadLetterID(groupName, event.EventID.String()),
+		GroupName:      groupName,
+		Event:          NewEventFromRecorded(event),
+		EventNumber:    event.EventNumber,
+		CommitPosition: event.Position.Commit,
+		Error:          err.Error(),
+		Attempts:       attempts,
+		ParkedAt:       time.Now().UTC(),
+	}
+}
+
+// DeadLetterPosition keyset position of the dead letter in the dead letters list.
+type DeadLetterPosition struct {
+	ParkedAt time.Time `json:"parkedAt"`
+	ID       string    `json:"id"`
+}
+
+func GetDeadLetterID(groupName string, eventID string) string {
+	return fmt.Sprintf("%s-%s", groupName, eventID)
+}
@@ -10,4 +10,16 @@ var (
 	ErrInvalidAggregate    = errors.New("invalid aggregate")
 	ErrInvalidAggregateID  = errors.New("invalid aggregate id")
 	ErrInvalidEventVersion = errors.New("invalid event version")
+	ErrInvalidUpcaster     = errors.New("invalid upcaster")
+	ErrSnapshotNotFound    = errors.New("snapshot not found")
+	ErrSnapshotOutdated    = errors.New("snapshot state schema is outdated")
+	ErrConcurrencyConflict = errors.New("concurrency conflict")
+	ErrCheckpointNotFound  = errors.New("checkpoint not found")
+	ErrDeadLetterNotFound  = errors.New("dead letter not found")
+
+	ErrIdempotencyKeyInProgress = errors.New("idempotency key request is in progress")
+	ErrIdempotencyKeyReused     = errors.New("idempotency key is reused with different request")
+
+	ErrTenantRequired = errors.New("tenant id is required")
+	ErrUnknownTenant  = errors.New("unknown tenant")
 )
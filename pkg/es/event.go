@@ -163,6 +163,19 @@ func (e *Event) SetMetadata(metaData interface{}) error {
 	return nil
 }
 
+// SetMetadataValue add the value to app-specific json object metadata of the Event keeping the existing ones.
+func (e *Event) SetMetadataValue(key string, value string) error {
+	metaData := make(map[string]interface{})
+	if len(e.Metadata) > 0 {
+		if err := json.Unmarshal(e.Metadata, &metaData); err != nil {
+			return err
+		}
+	}
+
+	metaData[key] = value
+	return e.SetMetadata(metaData)
+}
+
 // GetJsonMetadata unmarshal app-specific metadata serialized as json for the Event.
 func (e *Event) GetJsonMetadata(metaData interface{}) error {
 	return json.Unmarshal(e.GetMetadata(), metaData)
@@ -0,0 +1,17 @@
+package es
+
+import (
+	"time"
+)
+
+// StreamsHead newest event of the subscribed streams and the events after the projection position.
+type StreamsHead struct {
+	// CommitPosition $all commit position of the newest event, zero if there are no events after the projection position.
+	CommitPosition uint64
+	// PendingEvents number of the events after the projection position, it is the lower bound if Truncated.
+	PendingEvents uint64
+	// OldestPending creation time of the oldest read event after the projection position.
+	OldestPending time.Time
+	// Truncated read stopped at the scan limit before the projection position.
+	Truncated bool
+}
@@ -0,0 +1,162 @@
+package es
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	// IdempotencyKeyMetadata key of the client supplied idempotency key in the events metadata.
+	IdempotencyKeyMetadata = "idempotency-key"
+)
+
+type idempotencyKeyCtx struct{}
+
+// WithIdempotencyKey returns ctx carrying client supplied idempotency key,
+// AggregateStore Save writes it to the metadata of the saved events.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtx{}, key)
+}
+
+// GetIdempotencyKey returns idempotency key carried by ctx or empty string.
+func GetIdempotencyKey(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyCtx{}).(string)
+	return key
+}
+
+// SetIdempotencyKeyMetadata adds idempotency key carried by ctx to the Event metadata.
+func SetIdempotencyKeyMetadata(ctx context.Context, event *Event) error {
+	key := GetIdempotencyKey(ctx)
+	if key == "" {
+		return nil
+	}
+	return event.SetMetadataValue(IdempotencyKeyMetadata, key)
+}
+
+// IdempotencyRecord result of the command executed with the idempotency key,
+// Result is empty while the command is in progress.
+// The in progress record is reserved by LeaseID until LeaseExpiresAt, after it the key of the crashed command can be reserved again.
+type IdempotencyRecord struct {
+	Key            string    `json:"key" bson:"_id"`
+	Operation      string    `json:"operation" bson:"operation"`
+	RequestHash    string    `json:"requestHash" bson:"requestHash"`
+	Result         string    `json:"result" bson:"result"`
+	Completed      bool      `json:"completed" bson:"completed"`
+	LeaseID        string    `json:"leaseId" bson:"leaseId"`
+	LeaseExpiresAt time.Time `json:"leaseExpiresAt" bson:"leaseExpiresAt"`
+	CreatedAt      time.Time `json:"createdAt" bson:"createdAt"`
+	ExpiresAt      time.Time `json:"expiresAt" bson:"expiresAt"`
+}
+
+// IsReservable check is the key of the record can be reserved again, true when the record is expired
+// or the command is not completed within the lease.
+func (r IdempotencyRecord) IsReservable(now time.Time) bool {
+	return !now.Before(r.ExpiresAt) || (!r.Completed && !now.Before(r.LeaseExpiresAt))
+}
+
+// Idempotency executes the command once per client supplied idempotency key.
+type Idempotency interface {
+	// Execute runs handler if the key is empty or seen for the first time and stores its result,
+	// for the already completed key returns the stored result without running handler.
+	Execute(ctx context.Context, key string, operation string, request interface{}, handler func(ctx context.Context) (string, error)) (string, error)
+}
+
+type idempotency struct {
+	log   logger.Logger
+	store IdempotencyStore
+	ttl   time.Duration
+	lease time.Duration
+}
+
+// NewIdempotency creates Idempotency which keeps results in the store for ttl and reserves the keys
+// of the commands in progress for lease, lease is limited by ttl.
+func NewIdempotency(log logger.Logger, store IdempotencyStore, ttl time.Duration, lease time.Duration) *idempotency {
+	if lease <= 0 || lease > ttl {
+		lease = ttl
+	}
+	return &idempotency{log: log, store: store, ttl: ttl, lease: lease}
+}
+
+func (i *idempotency) Execute(ctx context.Context, key string, operation string, request interface{}, handler func(ctx context.Context) (string, error)) (string, error) {
+	if key == "" {
+		return handler(ctx)
+	}
+
+	requestHash, err := getRequestHash(request)
+	if err != nil {
+		return "", errors.Wrap(err, "getRequestHash")
+	}
+
+	now := time.Now().UTC()
+	record := IdempotencyRecord{
+		Key:            getIdempotencyRecordKey(ctx, key),
+		Operation:      operation,
+		RequestHash:    requestHash,
+		LeaseID:        uuid.NewV4().String(),
+		LeaseExpiresAt: now.Add(i.lease),
+		CreatedAt:      now,
+		ExpiresAt:      now.Add(i.ttl),
+	}
+
+	existing, err := i.store.ReserveIdempotencyKey(ctx, record)
+	if err != nil {
+		return "", errors.Wrap(err, "store.ReserveIdempotencyKey")
+	}
+	if existing != nil {
+		return i.replay(existing, record)
+	}
+
+	result, err := handler(WithIdempotencyKey(ctx, key))
+	if err != nil {
+		// the command is not applied, so release the key and let the client retry it
+		if err := i.store.ReleaseIdempotencyKey(ctx, record); err != nil {
+			i.log.Errorf("(ReleaseIdempotencyKey) key: {%s}, err: {%v}", record.Key, err)
+		}
+		return "", err
+	}
+
+	if err := i.store.CompleteIdempotencyKey(ctx, record, result); err != nil {
+		i.log.Errorf("(CompleteIdempotencyKey) key: {%s}, err: {%v}", record.Key, err)
+	}
+	return result, nil
+}
+
+func (i *idempotency) replay(existing *IdempotencyRecord, record IdempotencyRecord) (string, error) {
+	if existing.Operation != record.Operation || existing.RequestHash != record.RequestHash {
+		return "", errors.Wrapf(ErrIdempotencyKeyReused, "key: {%s}, operation: {%s}", record.Key, existing.Operation)
+	}
+	if !existing.Completed {
+		return "", errors.Wrapf(ErrIdempotencyKeyInProgress, "key: {%s}", record.Key)
+	}
+
+	i.log.Infof("(idempotent replay) key: {%s}, operation: {%s}, result: {%s}", record.Key, record.Operation, existing.Result)
+	return existing.Result, nil
+}
+
+// getIdempotencyRecordKey returns key of the idempotency record, the same keys of different tenants
+// or different authenticated callers are different records.
+func getIdempotencyRecordKey(ctx context.Context, key string) string {
+	tenantID := GetTenantID(ctx)
+	subject := GetSubject(ctx)
+	if tenantID == "" && subject == "" {
+		return key
+	}
+	return fmt.Sprintf("%s:%s:%s", tenantID, subject, key)
+}
+
+func getRequestHash(request interface{}) (string, error) {
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(requestBytes)
+	return hex.EncodeToString(hash[:]), nil
+}
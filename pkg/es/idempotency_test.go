@@ -0,0 +1,234 @@
+package es_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/es/memory"
+	"github.com/pkg/errors"
+)
+
+const (
+	createOperation = "create"
+	payOperation    = "pay"
+)
+
+type idempotencyCall struct {
+	ctx       context.Context
+	key       string
+	operation string
+	request   interface{}
+	// handlerErr returned by the handler instead of the result
+	handlerErr error
+}
+
+func TestIdempotencyExecute(t *testing.T) {
+	errHandler := errors.New("handler failed")
+	request := map[string]interface{}{"accountEmail": "customer@mail.com", "items": 2}
+	otherRequest := map[string]interface{}{"accountEmail": "customer@mail.com", "items": 3}
+	tenantA := es.WithTenantID(context.Background(), "tenantA")
+	tenantB := es.WithTenantID(context.Background(), "tenantB")
+	customer := es.WithSubject(tenantA, "customer-1")
+	otherCustomer := es.WithSubject(tenantA, "customer-2")
+
+	tests := []struct {
+		name   string
+		first  idempotencyCall
+		second idempotencyCall
+		// calls of the handlers of both executions
+		calls  int
+		result string
+		err    error
+	}{
+		{
+			name:   "replays stored result",
+			first:  idempotencyCall{key: "key-1", operation: createOperation, request: request},
+			second: idempotencyCall{key: "key-1", operation: createOperation, request: request},
+			calls:  1,
+			result: "result-1",
+		},
+		{
+			name:   "without key",
+			first:  idempotencyCall{operation: createOperation, request: request},
+			second: idempotencyCall{operation: createOperation, request: request},
+			calls:  2,
+			result: "result-2",
+		},
+		{
+			name:   "different keys",
+			first:  idempotencyCall{key: "key-1", operation: createOperation, request: request},
+			second: idempotencyCall{key: "key-2", operation: createOperation, request: request},
+			calls:  2,
+			result: "result-2",
+		},
+		{
+			name:   "conflicting request body",
+			first:  idempotencyCall{key: "key-1", operation: createOperation, request: request},
+			second: idempotencyCall{key: "key-1", operation: createOperation, request: otherRequest},
+			calls:  1,
+			err:    es.ErrIdempotencyKeyReused,
+		},
+		{
+			name:   "conflicting operation",
+			first:  idempotencyCall{key: "key-1", operation: createOperation, request: request},
+			second: idempotencyCall{key: "key-1", operation: payOperation, request: request},
+			calls:  1,
+			err:    es.ErrIdempotencyKeyReused,
+		},
+		{
+			name:   "failed command released",
+			first:  idempotencyCall{key: "key-1", operation: createOperation, request: request, handlerErr: errHandler},
+			second: idempotencyCall{key: "key-1", operation: createOperation, request: request},
+			calls:  2,
+			result: "result-2",
+		},
+		{
+			name:   "failed again",
+			first:  idempotencyCall{key: "key-1", operation: createOperation, request: request, handlerErr: errHandler},
+			second: idempotencyCall{key: "key-1", operation: createOperation, request: request, handlerErr: errHandler},
+			calls:  2,
+			err:    errHandler,
+		},
+		{
+			name:   "same subject replays stored result",
+			first:  idempotencyCall{ctx: customer, key: "key-1", operation: createOperation, request: request},
+			second: idempotencyCall{ctx: customer, key: "key-1", operation: createOperation, request: request},
+			calls:  1,
+			result: "result-1",
+		},
+		{
+			name:   "different tenants",
+			first:  idempotencyCall{ctx: tenantA, key: "key-1", operation: createOperation, request: request},
+			second: idempotencyCall{ctx: tenantB, key: "key-1", operation: createOperation, request: otherRequest},
+			calls:  2,
+			result: "result-2",
+		},
+		{
+			name:   "different subjects",
+			first:  idempotencyCall{ctx: customer, key: "key-1", operation: createOperation, request: request},
+			second: idempotencyCall{ctx: otherCustomer, key: "key-1", operation: createOperation, request: otherRequest},
+			calls:  2,
+			result: "result-2",
+		},
+		{
+			name:   "authenticated and anonymous",
+			first:  idempotencyCall{ctx: es.WithSubject(context.Background(), "customer-1"), key: "key-1", operation: createOperation, request: request},
+			second: idempotencyCall{key: "key-1", operation: createOperation, request: request},
+			calls:  2,
+			result: "result-2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idempotency := es.NewIdempotency(newTestLogger(), memory.NewIdempotencyStore(newTestLogger(), memory.NewDB()), time.Hour, time.Minute)
+
+			calls := 0
+			execute := func(call idempotencyCall) (string, error) {
+				ctx := call.ctx
+				if ctx == nil {
+					ctx = context.Background()
+				}
+				return idempotency.Execute(ctx, call.key, call.operation, call.request, func(ctx context.Context) (string, error) {
+					calls++
+					if es.GetIdempotencyKey(ctx) != call.key {
+						t.Errorf("handler idempotency key = %q, want %q", es.GetIdempotencyKey(ctx), call.key)
+					}
+					if call.handlerErr != nil {
+						return "", call.handlerErr
+					}
+					return fmt.Sprintf("result-%d", calls), nil
+				})
+			}
+
+			if _, err := execute(tt.first); !errors.Is(err, tt.first.handlerErr) || (tt.first.handlerErr == nil && err != nil) {
+				t.Fatalf("first Execute() err = %v, want %v", err, tt.first.handlerErr)
+			}
+			result, err := execute(tt.second)
+			if !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+				t.Fatalf("second Execute() err = %v, want %v", err, tt.err)
+			}
+			if result != tt.result {
+				t.Errorf("second Execute() = %q, want %q", result, tt.result)
+			}
+			if calls != tt.calls {
+				t.Errorf("handler calls = %d, want %d", calls, tt.calls)
+			}
+		})
+	}
+}
+
+func TestIdempotencyExecuteLease(t *testing.T) {
+	request := map[string]interface{}{"accountEmail": "customer@mail.com"}
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("json.Marshal() err: %v", err)
+	}
+	hash := sha256.Sum256(requestBytes)
+	requestHash := hex.EncodeToString(hash[:])
+
+	tests := []struct {
+		name string
+		// completed and the expiration times of the record reserved before relative to now
+		completed      bool
+		leaseExpiresIn time.Duration
+		expiresIn      time.Duration
+		calls          int
+		result         string
+		err            error
+	}{
+		{name: "in progress", leaseExpiresIn: time.Minute, expiresIn: time.Hour, err: es.ErrIdempotencyKeyInProgress},
+		{name: "crashed before completion", leaseExpiresIn: -time.Second, expiresIn: time.Hour, calls: 1, result: "result-1"},
+		{name: "completed after lease", completed: true, leaseExpiresIn: -time.Minute, expiresIn: time.Hour, result: "stored"},
+		{name: "completed and expired", completed: true, leaseExpiresIn: -time.Hour, expiresIn: -time.Second, calls: 1, result: "result-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := memory.NewIdempotencyStore(newTestLogger(), memory.NewDB())
+			idempotency := es.NewIdempotency(newTestLogger(), store, time.Hour, time.Minute)
+
+			// the key reserved by the previous execution of the same request
+			now := time.Now().UTC()
+			reserved := es.IdempotencyRecord{
+				Key:            "key-1",
+				Operation:      createOperation,
+				RequestHash:    requestHash,
+				LeaseID:        "previous-lease",
+				LeaseExpiresAt: now.Add(tt.leaseExpiresIn),
+				CreatedAt:      now.Add(-2 * time.Hour),
+				ExpiresAt:      now.Add(tt.expiresIn),
+			}
+			if existing, err := store.ReserveIdempotencyKey(ctx, reserved); err != nil || existing != nil {
+				t.Fatalf("ReserveIdempotencyKey() = %v, err: %v", existing, err)
+			}
+			if tt.completed {
+				if err := store.CompleteIdempotencyKey(ctx, reserved, "stored"); err != nil {
+					t.Fatalf("CompleteIdempotencyKey() err: %v", err)
+				}
+			}
+
+			calls := 0
+			result, err := idempotency.Execute(ctx, "key-1", createOperation, request, func(ctx context.Context) (string, error) {
+				calls++
+				return fmt.Sprintf("result-%d", calls), nil
+			})
+			if !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+				t.Fatalf("Execute() err = %v, want %v", err, tt.err)
+			}
+			if result != tt.result {
+				t.Errorf("Execute() = %q, want %q", result, tt.result)
+			}
+			if calls != tt.calls {
+				t.Errorf("handler calls = %d, want %d", calls, tt.calls)
+			}
+		})
+	}
+}
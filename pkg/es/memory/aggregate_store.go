@@ -0,0 +1,150 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type aggregateStore struct {
+	log logger.Logger
+	cfg es.Config
+	db  *DB
+}
+
+// NewAggregateStore in memory aggregate store, aggregates are restored from the DB snapshots and saved to them
+// every cfg.SnapshotFrequency events like the EventStoreDB aggregate store.
+func NewAggregateStore(log logger.Logger, cfg es.Config, db *DB) *aggregateStore {
+	return &aggregateStore{log: log, cfg: cfg, db: db}
+}
+
+func (a *aggregateStore) Load(ctx context.Context, aggregate es.Aggregate) error {
+	_, span := tracing.StartSpan(ctx, "memory.aggregateStore.Load")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", aggregate.GetID()))
+
+	var fromRevision uint64
+	snapshot, err := a.loadSnapshot(aggregate)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+	if snapshot != nil {
+		fromRevision = snapshot.Version + 1
+		span.SetAttributes(attribute.Int64("SnapshotVersion", int64(snapshot.Version)))
+	}
+
+	events, err := a.db.ReadStream(aggregate.GetID(), fromRevision)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "db.ReadStream")
+	}
+
+	for _, event := range events {
+		if err := aggregate.RaiseEvent(event); err != nil {
+			tracing.TraceErr(span, err)
+			return errors.Wrap(err, "RaiseEvent")
+		}
+		a.log.Debugf("(Load) esEvent: {%s}", event.String())
+	}
+
+	a.log.Debugf("(Load) aggregate: {%s}", aggregate.String())
+	return nil
+}
+
+func (a *aggregateStore) Save(ctx context.Context, aggregate es.Aggregate) error {
+	ctx, span := tracing.StartSpan(ctx, "memory.aggregateStore.Save")
+	defer span.End()
+	span.SetAttributes(attribute.String("aggregate", aggregate.String()))
+
+	if len(aggregate.GetUncommittedEvents()) == 0 {
+		a.log.Debugf("(Save) [no uncommittedEvents] len: {%d}", len(aggregate.GetUncommittedEvents()))
+		return nil
+	}
+
+	expectedRevision := aggregate.GetLoadedVersion()
+	if expectedRevision < noStreamRevision {
+		expectedRevision = noStreamRevision
+	}
+
+	events := make([]es.Event, 0, len(aggregate.GetUncommittedEvents()))
+	for _, event := range aggregate.GetUncommittedEvents() {
+		if err := es.SetIdempotencyKeyMetadata(ctx, &event); err != nil {
+			tracing.TraceErr(span, err)
+			return errors.Wrap(err, "SetIdempotencyKeyMetadata")
+		}
+		if err := es.SetTenantIDMetadata(ctx, &event); err != nil {
+			tracing.TraceErr(span, err)
+			return errors.Wrap(err, "SetTenantIDMetadata")
+		}
+		if err := es.SetSubjectMetadata(ctx, &event); err != nil {
+			tracing.TraceErr(span, err)
+			return errors.Wrap(err, "SetSubjectMetadata")
+		}
+		events = append(events, event)
+	}
+
+	revision, err := a.db.Append(aggregate.GetID(), &expectedRevision, events...)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "db.Append")
+	}
+
+	a.log.Debugf("(Save) expectedRevision: {%d}, revision: {%d}", expectedRevision, revision)
+	a.saveSnapshotIfRequired(aggregate)
+	return nil
+}
+
+func (a *aggregateStore) Exists(ctx context.Context, streamID string) error {
+	_, span := tracing.StartSpan(ctx, "memory.aggregateStore.Exists")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", streamID))
+
+	if !a.db.StreamExists(streamID) {
+		return errors.Wrap(esdb.ErrStreamNotFound, "db.StreamExists")
+	}
+	return nil
+}
+
+// loadSnapshot restore aggregate from the snapshot, returns nil snapshot if snapshotting is disabled or there is no snapshot yet.
+func (a *aggregateStore) loadSnapshot(aggregate es.Aggregate) (*es.Snapshot, error) {
+	if a.cfg.SnapshotFrequency <= 0 {
+		return nil, nil
+	}
+
+	snapshot, ok := a.db.getSnapshot(aggregate.GetID())
+	if !ok {
+		return nil, nil
+	}
+
+	if err := es.RestoreAggregateFromSnapshot(aggregate, &snapshot); err != nil {
+		if errors.Is(err, es.ErrSnapshotOutdated) {
+			a.log.Warnf("(loadSnapshot) AggregateID: {%s}, schemaVersion: {%d}, err: {%v}", aggregate.GetID(), snapshot.SchemaVersion, err)
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "RestoreAggregateFromSnapshot")
+	}
+	return &snapshot, nil
+}
+
+// saveSnapshotIfRequired clear saved uncommitted events and save aggregate snapshot every SnapshotFrequency events.
+func (a *aggregateStore) saveSnapshotIfRequired(aggregate es.Aggregate) {
+	isSnapshotRequired := a.cfg.IsSnapshotRequired(aggregate.GetVersion(), len(aggregate.GetUncommittedEvents()))
+	aggregate.ToSnapshot()
+
+	if !isSnapshotRequired {
+		return
+	}
+
+	snapshot, err := es.NewSnapshotFromAggregate(aggregate)
+	if err != nil {
+		a.log.Warnf("(NewSnapshotFromAggregate) AggregateID: {%s}, err: {%v}", aggregate.GetID(), err)
+		return
+	}
+	a.db.saveSnapshot(*snapshot)
+}
@@ -0,0 +1,190 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/pkg/errors"
+)
+
+const (
+	counterAggregateType es.AggregateType = "counter"
+	counterIncremented                    = "COUNTER_INCREMENTED"
+)
+
+// counterAggregate counts its events, Events is restored from the snapshot and Loaded counts only the loaded events.
+type counterAggregate struct {
+	*es.AggregateBase
+	Events int `json:"events"`
+	Loaded int `json:"-"`
+}
+
+func newCounterAggregate(id string) *counterAggregate {
+	counter := &counterAggregate{}
+	base := es.NewAggregateBase(counter.When)
+	base.SetType(counterAggregateType)
+	base.SetID(id)
+	counter.AggregateBase = base
+	return counter
+}
+
+func (c *counterAggregate) When(event es.Event) error {
+	c.Events++
+	c.Loaded++
+	return nil
+}
+
+func (c *counterAggregate) increment(times int) error {
+	for i := 0; i < times; i++ {
+		if err := c.Apply(es.NewBaseEvent(c, counterIncremented)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newTestLogger() logger.Logger {
+	appLogger := logger.NewAppLogger(&logger.Config{LogLevel: "error", Encoder: "console"})
+	appLogger.InitLogger()
+	return appLogger
+}
+
+func TestAggregateStoreSave(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing int
+		// loaded events of the stale aggregate, -1 if the aggregate is loaded before the save
+		loaded     int
+		increments int
+		version    int64
+		err        error
+	}{
+		{name: "new aggregate", loaded: -1, increments: 2, version: 1},
+		{name: "loaded aggregate", existing: 2, loaded: -1, increments: 1, version: 2},
+		{name: "new aggregate exists", existing: 1, loaded: 0, increments: 1, err: es.ErrConcurrencyConflict},
+		{name: "stale aggregate", existing: 3, loaded: 2, increments: 1, err: es.ErrConcurrencyConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := NewDB()
+			store := NewAggregateStore(newTestLogger(), es.Config{}, db)
+
+			existing := newCounterAggregate("1")
+			if err := existing.increment(tt.existing); err != nil {
+				t.Fatalf("increment() err: %v", err)
+			}
+			if err := store.Save(ctx, existing); err != nil {
+				t.Fatalf("Save() existing err: %v", err)
+			}
+
+			counter := newCounterAggregate("1")
+			if tt.loaded < 0 {
+				if tt.existing > 0 {
+					if err := store.Load(ctx, counter); err != nil {
+						t.Fatalf("Load() err: %v", err)
+					}
+				}
+			} else {
+				stream, err := db.ReadStream("counter-1", 0)
+				if err != nil {
+					t.Fatalf("ReadStream() err: %v", err)
+				}
+				if err := counter.Load(stream[:tt.loaded]); err != nil {
+					t.Fatalf("aggregate Load() err: %v", err)
+				}
+				counter.ClearUncommittedEvents()
+			}
+			if err := counter.increment(tt.increments); err != nil {
+				t.Fatalf("increment() err: %v", err)
+			}
+
+			err := store.Save(ctx, counter)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("Save() err = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Save() err: %v", err)
+			}
+			if len(counter.GetUncommittedEvents()) != 0 {
+				t.Errorf("Save() left %d uncommitted events", len(counter.GetUncommittedEvents()))
+			}
+
+			loaded := newCounterAggregate("1")
+			if err := store.Load(ctx, loaded); err != nil {
+				t.Fatalf("Load() err: %v", err)
+			}
+			if loaded.GetVersion() != tt.version || loaded.Events != int(tt.version)+1 {
+				t.Errorf("Load() version = %d, events = %d, want version %d", loaded.GetVersion(), loaded.Events, tt.version)
+			}
+		})
+	}
+}
+
+func TestAggregateStoreLoadAfterSnapshot(t *testing.T) {
+	tests := []struct {
+		name              string
+		snapshotFrequency int64
+		increments        []int
+		version           int64
+		snapshotVersion   int64
+		loaded            int
+	}{
+		{name: "snapshots disabled", increments: []int{3, 2}, version: 4, snapshotVersion: -1, loaded: 5},
+		{name: "events after snapshot", snapshotFrequency: 3, increments: []int{3, 2}, version: 4, snapshotVersion: 2, loaded: 2},
+		{name: "latest snapshot", snapshotFrequency: 2, increments: []int{3, 2}, version: 4, snapshotVersion: 4, loaded: 0},
+		{name: "snapshot crossed by batch", snapshotFrequency: 2, increments: []int{1, 4}, version: 4, snapshotVersion: 4, loaded: 0},
+		{name: "no snapshot yet", snapshotFrequency: 10, increments: []int{3, 2}, version: 4, snapshotVersion: -1, loaded: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := NewDB()
+			store := NewAggregateStore(newTestLogger(), es.Config{SnapshotFrequency: tt.snapshotFrequency}, db)
+
+			for _, increments := range tt.increments {
+				counter := newCounterAggregate("1")
+				if db.StreamExists("counter-1") {
+					if err := store.Load(ctx, counter); err != nil {
+						t.Fatalf("Load() err: %v", err)
+					}
+				}
+				if err := counter.increment(increments); err != nil {
+					t.Fatalf("increment() err: %v", err)
+				}
+				if err := store.Save(ctx, counter); err != nil {
+					t.Fatalf("Save() err: %v", err)
+				}
+			}
+
+			snapshot, ok := db.getSnapshot("counter-1")
+			if tt.snapshotVersion < 0 && ok {
+				t.Errorf("snapshot version %d is saved, want none", snapshot.Version)
+			}
+			if tt.snapshotVersion >= 0 && (!ok || int64(snapshot.Version) != tt.snapshotVersion) {
+				t.Errorf("snapshot version = %d, saved: %v, want %d", snapshot.Version, ok, tt.snapshotVersion)
+			}
+
+			loaded := newCounterAggregate("1")
+			if err := store.Load(ctx, loaded); err != nil {
+				t.Fatalf("Load() err: %v", err)
+			}
+			if loaded.GetVersion() != tt.version || loaded.Events != int(tt.version)+1 {
+				t.Errorf("Load() version = %d, events = %d, want version %d", loaded.GetVersion(), loaded.Events, tt.version)
+			}
+			if loaded.Loaded != tt.loaded {
+				t.Errorf("Load() applied %d events, want %d", loaded.Loaded, tt.loaded)
+			}
+			if loaded.GetLoadedVersion() != tt.version {
+				t.Errorf("GetLoadedVersion() = %d, want %d", loaded.GetLoadedVersion(), tt.version)
+			}
+		})
+	}
+}
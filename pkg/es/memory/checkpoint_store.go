@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type checkpointStore struct {
+	log logger.Logger
+	db  *DB
+}
+
+func NewCheckpointStore(log logger.Logger, db *DB) *checkpointStore {
+	return &checkpointStore{log: log, db: db}
+}
+
+func (c *checkpointStore) SaveCheckpoint(ctx context.Context, checkpoint es.Checkpoint) error {
+	_, span := tracing.StartSpan(ctx, "memory.checkpointStore.SaveCheckpoint")
+	defer span.End()
+	span.SetAttributes(attribute.String("Name", checkpoint.Name), attribute.Int64("CommitPosition", int64(checkpoint.CommitPosition)))
+
+	c.db.saveCheckpoint(checkpoint)
+	c.log.Debugf("(SaveCheckpoint) Name: {%s}, CommitPosition: {%d}", checkpoint.Name, checkpoint.CommitPosition)
+	return nil
+}
+
+func (c *checkpointStore) GetCheckpoint(ctx context.Context, name string) (*es.Checkpoint, error) {
+	_, span := tracing.StartSpan(ctx, "memory.checkpointStore.GetCheckpoint")
+	defer span.End()
+	span.SetAttributes(attribute.String("Name", name))
+
+	checkpoint, ok := c.db.getCheckpoint(name)
+	if !ok {
+		return nil, errors.Wrapf(es.ErrCheckpointNotFound, "name: {%s}", name)
+	}
+
+	return &checkpoint, nil
+}
@@ -0,0 +1,200 @@
+package memory
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/pkg/errors"
+)
+
+const (
+	// noStreamRevision expected revision of the stream which is not exists yet, same as esdb.NoStream.
+	noStreamRevision int64 = -1
+)
+
+type record struct {
+	event    es.Event
+	position uint64
+}
+
+// DB in memory event log, keeps per stream revisions and global $all commit positions,
+// shared between memory AggregateStore, EventStore, SnapshotStore and CheckpointStore.
+type DB struct {
+	mu          sync.RWMutex
+	streams     map[string][]record
+	all         []record
+	snapshots   map[string]es.Snapshot
+	checkpoints map[string]es.Checkpoint
+	idempotency map[string]es.IdempotencyRecord
+}
+
+// NewDB in memory DB constructor.
+func NewDB() *DB {
+	return &DB{
+		streams:     make(map[string][]record),
+		all:         make([]record, 0),
+		snapshots:   make(map[string]es.Snapshot),
+		checkpoints: make(map[string]es.Checkpoint),
+		idempotency: make(map[string]es.IdempotencyRecord),
+	}
+}
+
+// Append appends events to the stream, if expectedRevision is not nil it must be equal to the last stream revision,
+// use -1 for the new stream.
+func (d *DB) Append(streamID string, expectedRevision *int64, events ...es.Event) (uint64, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	stream := d.streams[streamID]
+	currentRevision := int64(len(stream)) - 1
+	if expectedRevision != nil && *expectedRevision != currentRevision {
+		return 0, errors.Wrapf(es.ErrConcurrencyConflict, "streamID: {%s}, expected: {%d}, current: {%d}", streamID, *expectedRevision, currentRevision)
+	}
+
+	for _, event := range events {
+		currentRevision++
+		event.AggregateID = streamID
+		event.Version = currentRevision
+		if event.Timestamp.IsZero() {
+			event.Timestamp = time.Now().UTC()
+		}
+
+		r := record{event: event, position: uint64(len(d.all))}
+		stream = append(stream, r)
+		d.all = append(d.all, r)
+	}
+	d.streams[streamID] = stream
+
+	return uint64(currentRevision), nil
+}
+
+// ReadStream reads all stream events starting from the given revision.
+func (d *DB) ReadStream(streamID string, fromRevision uint64) ([]es.Event, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	stream, ok := d.streams[streamID]
+	if !ok {
+		return nil, errors.Wrapf(esdb.ErrStreamNotFound, "streamID: {%s}", streamID)
+	}
+
+	events := make([]es.Event, 0, len(stream))
+	for _, r := range stream {
+		if uint64(r.event.GetVersion()) < fromRevision {
+			continue
+		}
+		events = append(events, r.event)
+	}
+	return events, nil
+}
+
+// StreamExists check is stream with given id exists.
+func (d *DB) StreamExists(streamID string) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	_, ok := d.streams[streamID]
+	return ok
+}
+
+// ReadAll reads events in global commit order starting from the given position,
+// filtered by stream prefixes like $all subscription with esdb.StreamFilterType filter,
+// returns read events and the next position to read from.
+func (d *DB) ReadAll(fromPosition uint64, prefixes ...string) ([]es.Event, uint64) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	events := make([]es.Event, 0, len(d.all))
+	for _, r := range d.all {
+		if r.position < fromPosition || !hasPrefix(r.event.GetAggregateID(), prefixes) {
+			continue
+		}
+		events = append(events, r.event)
+	}
+	return events, uint64(len(d.all))
+}
+
+// Position returns the next global commit position, the same as count of all stored events.
+func (d *DB) Position() uint64 {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return uint64(len(d.all))
+}
+
+func (d *DB) saveSnapshot(snapshot es.Snapshot) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.snapshots[snapshot.ID] = snapshot
+}
+
+func (d *DB) getSnapshot(id string) (es.Snapshot, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	snapshot, ok := d.snapshots[id]
+	return snapshot, ok
+}
+
+func (d *DB) saveCheckpoint(checkpoint es.Checkpoint) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.checkpoints[checkpoint.Name] = checkpoint
+}
+
+func (d *DB) getCheckpoint(name string) (es.Checkpoint, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	checkpoint, ok := d.checkpoints[name]
+	return checkpoint, ok
+}
+
+// reserveIdempotencyRecord saves the record if its key is not used or reservable at the record CreatedAt,
+// otherwise returns the existing record.
+func (d *DB) reserveIdempotencyRecord(record es.IdempotencyRecord) (es.IdempotencyRecord, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	existing, ok := d.idempotency[record.Key]
+	if ok && !existing.IsReservable(record.CreatedAt) {
+		return existing, false
+	}
+	d.idempotency[record.Key] = record
+	return record, true
+}
+
+// completeIdempotencyRecord saves the result to the record of the key if it is still reserved by leaseID.
+func (d *DB) completeIdempotencyRecord(key string, leaseID string, result string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	existing, ok := d.idempotency[key]
+	if !ok || existing.LeaseID != leaseID {
+		return false
+	}
+	existing.Result = result
+	existing.Completed = true
+	d.idempotency[key] = existing
+	return true
+}
+
+// releaseIdempotencyRecord deletes the not completed record of the key if it is still reserved by leaseID.
+func (d *DB) releaseIdempotencyRecord(key string, leaseID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	existing, ok := d.idempotency[key]
+	if ok && existing.LeaseID == leaseID && !existing.Completed {
+		delete(d.idempotency, key)
+	}
+}
+
+func hasPrefix(streamID string, prefixes []string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(streamID, prefix) {
+			return true
+		}
+	}
+	return false
+}
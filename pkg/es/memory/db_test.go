@@ -0,0 +1,165 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/pkg/errors"
+)
+
+func newTestEvent(eventType string) es.Event {
+	return es.Event{EventType: eventType}
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func TestDBAppend(t *testing.T) {
+	tests := []struct {
+		name             string
+		existing         int
+		expectedRevision *int64
+		events           int
+		revision         uint64
+		err              error
+	}{
+		{name: "new stream", expectedRevision: int64Ptr(noStreamRevision), events: 2, revision: 1},
+		{name: "existing stream", existing: 2, expectedRevision: int64Ptr(1), events: 1, revision: 2},
+		{name: "any revision", existing: 2, events: 1, revision: 2},
+		{name: "new stream exists", existing: 1, expectedRevision: int64Ptr(noStreamRevision), events: 1, err: es.ErrConcurrencyConflict},
+		{name: "stale revision", existing: 3, expectedRevision: int64Ptr(1), events: 1, err: es.ErrConcurrencyConflict},
+		{name: "revision ahead", existing: 1, expectedRevision: int64Ptr(2), events: 1, err: es.ErrConcurrencyConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := NewDB()
+			for i := 0; i < tt.existing; i++ {
+				if _, err := db.Append("order-1", nil, newTestEvent("existing")); err != nil {
+					t.Fatalf("Append() existing err: %v", err)
+				}
+			}
+
+			events := make([]es.Event, 0, tt.events)
+			for i := 0; i < tt.events; i++ {
+				events = append(events, newTestEvent("appended"))
+			}
+
+			revision, err := db.Append("order-1", tt.expectedRevision, events...)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("Append() err = %v, want %v", err, tt.err)
+				}
+				if stream, _ := db.ReadStream("order-1", 0); len(stream) != tt.existing {
+					t.Errorf("stream has %d events after conflict, want %d", len(stream), tt.existing)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Append() err: %v", err)
+			}
+			if revision != tt.revision {
+				t.Errorf("Append() revision = %d, want %d", revision, tt.revision)
+			}
+
+			stream, err := db.ReadStream("order-1", 0)
+			if err != nil {
+				t.Fatalf("ReadStream() err: %v", err)
+			}
+			for i, event := range stream {
+				if event.GetVersion() != int64(i) || event.GetAggregateID() != "order-1" {
+					t.Errorf("event %d has version %d and stream %q", i, event.GetVersion(), event.GetAggregateID())
+				}
+			}
+		})
+	}
+}
+
+func TestDBReadAll(t *testing.T) {
+	db := NewDB()
+	appends := []struct {
+		streamID  string
+		eventType string
+	}{
+		{streamID: "order-1", eventType: "created-1"},
+		{streamID: "tenantA-order-2", eventType: "created-2"},
+		{streamID: "payment-1", eventType: "paid-1"},
+		{streamID: "order-1", eventType: "submitted-1"},
+		{streamID: "tenantA-order-2", eventType: "submitted-2"},
+	}
+	for _, a := range appends {
+		if _, err := db.Append(a.streamID, nil, newTestEvent(a.eventType)); err != nil {
+			t.Fatalf("Append() err: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name         string
+		fromPosition uint64
+		prefixes     []string
+		eventTypes   []string
+	}{
+		{name: "all streams", eventTypes: []string{"created-1", "created-2", "paid-1", "submitted-1", "submitted-2"}},
+		{name: "prefix", prefixes: []string{"order-"}, eventTypes: []string{"created-1", "submitted-1"}},
+		{name: "prefixes keep commit order", prefixes: []string{"tenantA-order-", "order-"}, eventTypes: []string{"created-1", "created-2", "submitted-1", "submitted-2"}},
+		{name: "from position", fromPosition: 3, eventTypes: []string{"submitted-1", "submitted-2"}},
+		{name: "from the end", fromPosition: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, position := db.ReadAll(tt.fromPosition, tt.prefixes...)
+			if position != uint64(len(appends)) {
+				t.Errorf("ReadAll() position = %d, want %d", position, len(appends))
+			}
+			if len(events) != len(tt.eventTypes) {
+				t.Fatalf("ReadAll() returned %d events, want %d", len(events), len(tt.eventTypes))
+			}
+			for i, event := range events {
+				if event.GetEventType() != tt.eventTypes[i] {
+					t.Errorf("event %d type = %q, want %q", i, event.GetEventType(), tt.eventTypes[i])
+				}
+			}
+		})
+	}
+}
+
+type recordingProjection struct {
+	eventTypes []string
+}
+
+func (p *recordingProjection) When(ctx context.Context, event es.Event) error {
+	p.eventTypes = append(p.eventTypes, event.GetEventType())
+	return nil
+}
+
+func TestProject(t *testing.T) {
+	db := NewDB()
+	for _, streamID := range []string{"order-1", "payment-1", "order-2"} {
+		if _, err := db.Append(streamID, nil, newTestEvent(streamID)); err != nil {
+			t.Fatalf("Append() err: %v", err)
+		}
+	}
+
+	projection := &recordingProjection{}
+	position, err := Project(context.Background(), db, projection, 0, "order-")
+	if err != nil {
+		t.Fatalf("Project() err: %v", err)
+	}
+	if position != 3 || len(projection.eventTypes) != 2 || projection.eventTypes[0] != "order-1" || projection.eventTypes[1] != "order-2" {
+		t.Fatalf("Project() position = %d, events = %v", position, projection.eventTypes)
+	}
+
+	if _, err := db.Append("order-1", nil, newTestEvent("order-1-next")); err != nil {
+		t.Fatalf("Append() err: %v", err)
+	}
+	position, err = Project(context.Background(), db, projection, position, "order-")
+	if err != nil {
+		t.Fatalf("Project() err: %v", err)
+	}
+	if position != 4 || len(projection.eventTypes) != 3 || projection.eventTypes[2] != "order-1-next" {
+		t.Errorf("Project() from position = %d, events = %v", position, projection.eventTypes)
+	}
+}
@@ -0,0 +1,49 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type eventStore struct {
+	log logger.Logger
+	db  *DB
+}
+
+func NewEventStore(log logger.Logger, db *DB) *eventStore {
+	return &eventStore{log: log, db: db}
+}
+
+func (e *eventStore) SaveEvents(ctx context.Context, streamID string, events []es.Event) error {
+	_, span := tracing.StartSpan(ctx, "memory.eventStore.SaveEvents")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", streamID))
+
+	revision, err := e.db.Append(streamID, nil, events...)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "db.Append")
+	}
+
+	e.log.Debugf("SaveEvents streamID: {%s}, revision: {%d}", streamID, revision)
+	return nil
+}
+
+func (e *eventStore) LoadEvents(ctx context.Context, streamID string) ([]es.Event, error) {
+	_, span := tracing.StartSpan(ctx, "memory.eventStore.LoadEvents")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", streamID))
+
+	events, err := e.db.ReadStream(streamID, 0)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return nil, errors.Wrap(err, "db.ReadStream")
+	}
+
+	return events, nil
+}
@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type idempotencyStore struct {
+	log logger.Logger
+	db  *DB
+}
+
+func NewIdempotencyStore(log logger.Logger, db *DB) *idempotencyStore {
+	return &idempotencyStore{log: log, db: db}
+}
+
+func (i *idempotencyStore) ReserveIdempotencyKey(ctx context.Context, record es.IdempotencyRecord) (*es.IdempotencyRecord, error) {
+	_, span := tracing.StartSpan(ctx, "memory.idempotencyStore.ReserveIdempotencyKey")
+	defer span.End()
+	span.SetAttributes(attribute.String("Key", record.Key), attribute.String("Operation", record.Operation))
+
+	existing, reserved := i.db.reserveIdempotencyRecord(record)
+	if reserved {
+		return nil, nil
+	}
+
+	i.log.Debugf("(ReserveIdempotencyKey) key: {%s} exists, completed: {%v}", existing.Key, existing.Completed)
+	return &existing, nil
+}
+
+func (i *idempotencyStore) CompleteIdempotencyKey(ctx context.Context, record es.IdempotencyRecord, result string) error {
+	_, span := tracing.StartSpan(ctx, "memory.idempotencyStore.CompleteIdempotencyKey")
+	defer span.End()
+	span.SetAttributes(attribute.String("Key", record.Key))
+
+	if !i.db.completeIdempotencyRecord(record.Key, record.LeaseID, result) {
+		i.log.Warnf("(CompleteIdempotencyKey) key: {%s} is reserved by the other lease, leaseExpiresAt: {%s}", record.Key, record.LeaseExpiresAt)
+	}
+	return nil
+}
+
+func (i *idempotencyStore) ReleaseIdempotencyKey(ctx context.Context, record es.IdempotencyRecord) error {
+	_, span := tracing.StartSpan(ctx, "memory.idempotencyStore.ReleaseIdempotencyKey")
+	defer span.End()
+	span.SetAttributes(attribute.String("Key", record.Key))
+
+	i.db.releaseIdempotencyRecord(record.Key, record.LeaseID)
+	return nil
+}
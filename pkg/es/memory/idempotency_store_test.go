@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+)
+
+func TestIdempotencyStoreLease(t *testing.T) {
+	now := time.Now().UTC()
+	newRecord := func(leaseID string, createdAt time.Time) es.IdempotencyRecord {
+		return es.IdempotencyRecord{
+			Key:            "key-1",
+			Operation:      "create",
+			RequestHash:    "hash",
+			LeaseID:        leaseID,
+			LeaseExpiresAt: createdAt.Add(time.Minute),
+			CreatedAt:      createdAt,
+			ExpiresAt:      createdAt.Add(time.Hour),
+		}
+	}
+	crashed := newRecord("crashed-lease", now.Add(-2*time.Minute))
+	current := newRecord("current-lease", now)
+
+	tests := []struct {
+		name string
+		// finish of the crashed lease after the key is reserved by the current lease
+		finish    func(ctx context.Context, store *idempotencyStore) error
+		completed bool
+		result    string
+	}{
+		{name: "crashed lease completes", finish: func(ctx context.Context, store *idempotencyStore) error {
+			return store.CompleteIdempotencyKey(ctx, crashed, "crashed result")
+		}},
+		{name: "crashed lease releases", finish: func(ctx context.Context, store *idempotencyStore) error {
+			return store.ReleaseIdempotencyKey(ctx, crashed)
+		}},
+		{name: "current lease completes", finish: func(ctx context.Context, store *idempotencyStore) error {
+			return store.CompleteIdempotencyKey(ctx, current, "current result")
+		}, completed: true, result: "current result"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := NewIdempotencyStore(newTestLogger(), NewDB())
+
+			if existing, err := store.ReserveIdempotencyKey(ctx, crashed); err != nil || existing != nil {
+				t.Fatalf("crashed ReserveIdempotencyKey() = %v, err: %v", existing, err)
+			}
+			if existing, err := store.ReserveIdempotencyKey(ctx, current); err != nil || existing != nil {
+				t.Fatalf("current ReserveIdempotencyKey() = %v, err: %v, want reserved after the crashed lease", existing, err)
+			}
+			if err := tt.finish(ctx, store); err != nil {
+				t.Fatalf("finish err: %v", err)
+			}
+
+			existing, err := store.ReserveIdempotencyKey(ctx, newRecord("next-lease", now.Add(time.Second)))
+			if err != nil {
+				t.Fatalf("next ReserveIdempotencyKey() err: %v", err)
+			}
+			if existing == nil || existing.LeaseID != current.LeaseID {
+				t.Fatalf("next ReserveIdempotencyKey() = %+v, want record of %s", existing, current.LeaseID)
+			}
+			if existing.Completed != tt.completed || existing.Result != tt.result {
+				t.Errorf("record completed = %v, result = %q, want %v, %q", existing.Completed, existing.Result, tt.completed, tt.result)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/pkg/errors"
+)
+
+// Project replays events from the global log through the projection in commit order, like $all persistent subscription,
+// returns the next position to continue from.
+func Project(ctx context.Context, db *DB, projection es.Projection, fromPosition uint64, prefixes ...string) (uint64, error) {
+	events, position := db.ReadAll(fromPosition, prefixes...)
+
+	for _, event := range events {
+		if err := ctx.Err(); err != nil {
+			return fromPosition, err
+		}
+		if err := projection.When(ctx, event); err != nil {
+			return fromPosition, errors.Wrapf(err, "projection.When event: {%s}", event.String())
+		}
+	}
+
+	return position, nil
+}
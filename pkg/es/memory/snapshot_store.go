@@ -0,0 +1,49 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type snapshotStore struct {
+	log logger.Logger
+	db  *DB
+}
+
+func NewSnapshotStore(log logger.Logger, db *DB) *snapshotStore {
+	return &snapshotStore{log: log, db: db}
+}
+
+func (s *snapshotStore) SaveSnapshot(ctx context.Context, aggregate es.Aggregate) error {
+	_, span := tracing.StartSpan(ctx, "memory.snapshotStore.SaveSnapshot")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", aggregate.GetID()))
+
+	snapshot, err := es.NewSnapshotFromAggregate(aggregate)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewSnapshotFromAggregate")
+	}
+
+	s.db.saveSnapshot(*snapshot)
+	s.log.Debugf("(SaveSnapshot) id: {%s}, version: {%d}", snapshot.ID, snapshot.Version)
+	return nil
+}
+
+func (s *snapshotStore) GetSnapshot(ctx context.Context, id string) (*es.Snapshot, error) {
+	_, span := tracing.StartSpan(ctx, "memory.snapshotStore.GetSnapshot")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", id))
+
+	snapshot, ok := s.db.getSnapshot(id)
+	if !ok {
+		return nil, errors.Wrapf(es.ErrSnapshotNotFound, "id: {%s}", id)
+	}
+
+	return &snapshot, nil
+}
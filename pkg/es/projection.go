@@ -2,9 +2,29 @@ package es
 
 import (
 	"context"
+
+	"github.com/pkg/errors"
 )
 
 // Projection When method works and process Event's like Aggregate's for interacting with read database.
 type Projection interface {
 	When(ctx context.Context, evt Event) error
 }
+
+type upcastingProjection struct {
+	projection Projection
+	upcaster   Upcaster
+}
+
+// NewUpcastingProjection wraps Projection to upcast events to the current schema before processing.
+func NewUpcastingProjection(projection Projection, upcaster Upcaster) *upcastingProjection {
+	return &upcastingProjection{projection: projection, upcaster: upcaster}
+}
+
+func (p *upcastingProjection) When(ctx context.Context, evt Event) error {
+	upcasted, err := UpcastEvent(p.upcaster, evt)
+	if err != nil {
+		return errors.Wrap(err, "Upcast")
+	}
+	return p.projection.When(ctx, upcasted)
+}
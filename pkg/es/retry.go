@@ -0,0 +1,58 @@
+package es
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RetryPolicy bounded retry policy with linear backoff, used for the commands failed with ErrConcurrencyConflict
+// and for the events failed in projections, zero MaxRetries disables retries.
+type RetryPolicy struct {
+	MaxRetries int           `mapstructure:"maxRetries" json:"maxRetries" validate:"gte=0"`
+	Backoff    time.Duration `mapstructure:"backoff" json:"backoff"`
+}
+
+// RetryOnConcurrencyConflict run handler which loads aggregate, handle command and saves it,
+// and run it again with freshly loaded aggregate while it fails with ErrConcurrencyConflict.
+// Example:
+//
+//	return es.RetryOnConcurrencyConflict(ctx, c.cfg.EventSourcing.ConcurrencyRetry, func(ctx context.Context) error {
+//		order, err := aggregate.LoadOrderAggregate(ctx, c.es, command.GetAggregateID())
+//		if err != nil {
+//			return err
+//		}
+//		if err := order.PayOrder(ctx, command.Payment); err != nil {
+//			return err
+//		}
+//		return c.es.Save(ctx, order)
+//	})
+func RetryOnConcurrencyConflict(ctx context.Context, policy RetryPolicy, handler func(ctx context.Context) error) error {
+	err := handler(ctx)
+	for attempt := 1; attempt <= policy.MaxRetries && errors.Is(err, ErrConcurrencyConflict); attempt++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(policy.Backoff * time.Duration(attempt)):
+		}
+		err = handler(ctx)
+	}
+	return err
+}
+
+// RetryWithBackoff run handler and run it again while it fails, up to policy MaxRetries times,
+// returns the last error and count of the attempts.
+func RetryWithBackoff(ctx context.Context, policy RetryPolicy, handler func(ctx context.Context) error) (int, error) {
+	attempts := 1
+	err := handler(ctx)
+	for ; attempts <= policy.MaxRetries && err != nil; attempts++ {
+		select {
+		case <-ctx.Done():
+			return attempts, ctx.Err()
+		case <-time.After(policy.Backoff * time.Duration(attempts)):
+		}
+		err = handler(ctx)
+	}
+	return attempts, err
+}
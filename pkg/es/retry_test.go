@@ -0,0 +1,187 @@
+package es_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/es/memory"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/pkg/errors"
+)
+
+const counterIncremented = "COUNTER_INCREMENTED"
+
+// counterAggregate counts its events.
+type counterAggregate struct {
+	*es.AggregateBase
+	Events int `json:"events"`
+}
+
+func newCounterAggregate(id string) *counterAggregate {
+	counter := &counterAggregate{}
+	base := es.NewAggregateBase(counter.When)
+	base.SetType("counter")
+	base.SetID(id)
+	counter.AggregateBase = base
+	return counter
+}
+
+func (c *counterAggregate) When(event es.Event) error {
+	c.Events++
+	return nil
+}
+
+func (c *counterAggregate) increment() error {
+	return c.Apply(es.NewBaseEvent(c, counterIncremented))
+}
+
+func newTestLogger() logger.Logger {
+	appLogger := logger.NewAppLogger(&logger.Config{LogLevel: "error", Encoder: "console"})
+	appLogger.InitLogger()
+	return appLogger
+}
+
+func TestRetryOnConcurrencyConflict(t *testing.T) {
+	errNotFound := errors.New("not found")
+
+	tests := []struct {
+		name   string
+		policy es.RetryPolicy
+		// conflicts count of the concurrent increments saved between the load and the save of the handler
+		conflicts int
+		// handlerErr returned by the handler instead of the save
+		handlerErr error
+		calls      int
+		// events saved in the stream including the created one
+		events int
+		err    error
+	}{
+		{name: "no conflict", policy: es.RetryPolicy{MaxRetries: 3}, calls: 1, events: 2},
+		{name: "conflict retried", policy: es.RetryPolicy{MaxRetries: 3}, conflicts: 1, calls: 2, events: 3},
+		{name: "conflicts up to max retries", policy: es.RetryPolicy{MaxRetries: 3, Backoff: time.Millisecond}, conflicts: 3, calls: 4, events: 5},
+		{name: "conflicts exceed max retries", policy: es.RetryPolicy{MaxRetries: 3}, conflicts: 4, calls: 4, events: 5, err: es.ErrConcurrencyConflict},
+		{name: "retries disabled", conflicts: 1, calls: 1, events: 2, err: es.ErrConcurrencyConflict},
+		{name: "other error not retried", policy: es.RetryPolicy{MaxRetries: 3}, handlerErr: errNotFound, calls: 1, events: 1, err: errNotFound},
+		{name: "wrapped conflict retried", policy: es.RetryPolicy{MaxRetries: 3}, handlerErr: errors.Wrap(es.ErrConcurrencyConflict, "Save"), calls: 4, events: 1, err: es.ErrConcurrencyConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := memory.NewAggregateStore(newTestLogger(), es.Config{}, memory.NewDB())
+
+			created := newCounterAggregate("counter-1")
+			if err := created.increment(); err != nil {
+				t.Fatalf("increment() err: %v", err)
+			}
+			if err := store.Save(ctx, created); err != nil {
+				t.Fatalf("Save() err: %v", err)
+			}
+
+			calls := 0
+			err := es.RetryOnConcurrencyConflict(ctx, tt.policy, func(ctx context.Context) error {
+				calls++
+				if tt.handlerErr != nil {
+					return tt.handlerErr
+				}
+
+				counter := newCounterAggregate("counter-1")
+				if err := store.Load(ctx, counter); err != nil {
+					return err
+				}
+				if calls <= tt.conflicts {
+					concurrent := newCounterAggregate("counter-1")
+					if err := store.Load(ctx, concurrent); err != nil {
+						return err
+					}
+					if err := concurrent.increment(); err != nil {
+						return err
+					}
+					if err := store.Save(ctx, concurrent); err != nil {
+						return err
+					}
+				}
+				if err := counter.increment(); err != nil {
+					return err
+				}
+				return store.Save(ctx, counter)
+			})
+
+			if !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+				t.Fatalf("RetryOnConcurrencyConflict() err = %v, want %v", err, tt.err)
+			}
+			if calls != tt.calls {
+				t.Errorf("handler calls = %d, want %d", calls, tt.calls)
+			}
+
+			counter := newCounterAggregate("counter-1")
+			if err := store.Load(ctx, counter); err != nil {
+				t.Fatalf("Load() err: %v", err)
+			}
+			if counter.Events != tt.events {
+				t.Errorf("saved events = %d, want %d", counter.Events, tt.events)
+			}
+		})
+	}
+}
+
+func TestRetryOnConcurrencyConflictCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	calls := 0
+	err := es.RetryOnConcurrencyConflict(ctx, es.RetryPolicy{MaxRetries: 3, Backoff: time.Hour}, func(ctx context.Context) error {
+		calls++
+		cancel()
+		return es.ErrConcurrencyConflict
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("RetryOnConcurrencyConflict() err = %v, want %v", err, context.Canceled)
+	}
+	if calls != 1 {
+		t.Errorf("handler calls = %d, want 1", calls)
+	}
+}
+
+func TestRetryWithBackoff(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	tests := []struct {
+		name     string
+		policy   es.RetryPolicy
+		failures int
+		attempts int
+		err      error
+	}{
+		{name: "succeeded", policy: es.RetryPolicy{MaxRetries: 2}, attempts: 1},
+		{name: "succeeded after retry", policy: es.RetryPolicy{MaxRetries: 2, Backoff: time.Millisecond}, failures: 2, attempts: 3},
+		{name: "failures exceed max retries", policy: es.RetryPolicy{MaxRetries: 2}, failures: 3, attempts: 3, err: errFailed},
+		{name: "retries disabled", failures: 1, attempts: 1, err: errFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			attempts, err := es.RetryWithBackoff(context.Background(), tt.policy, func(ctx context.Context) error {
+				calls++
+				if calls <= tt.failures {
+					return errFailed
+				}
+				return nil
+			})
+
+			if !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+				t.Fatalf("RetryWithBackoff() err = %v, want %v", err, tt.err)
+			}
+			if calls != tt.attempts {
+				t.Errorf("handler calls = %d, want %d", calls, tt.attempts)
+			}
+			if attempts != tt.attempts {
+				t.Errorf("RetryWithBackoff() attempts = %d, want %d", attempts, tt.attempts)
+			}
+		})
+	}
+}
@@ -4,10 +4,26 @@ import "encoding/json"
 
 // Snapshot Event Sourcing Snapshotting is an optimisation that reduces time spent on reading event from an event store.
 type Snapshot struct {
-	ID      string        `json:"id"`
-	Type    AggregateType `json:"type"`
-	State   []byte        `json:"state"`
-	Version uint64        `json:"version"`
+	ID      string        `json:"id" bson:"_id"`
+	Type    AggregateType `json:"type" bson:"type"`
+	State   []byte        `json:"state" bson:"state"`
+	Version uint64        `json:"version" bson:"version"`
+	// SchemaVersion version of the aggregate state schema, see SnapshotSchema.
+	SchemaVersion int `json:"schemaVersion,omitempty" bson:"schemaVersion,omitempty"`
+}
+
+// SnapshotSchema is implemented by the aggregates which state schema was changed incompatibly,
+// snapshots of the other schema version are not restored and the aggregate is loaded from the events.
+type SnapshotSchema interface {
+	SnapshotSchemaVersion() int
+}
+
+// GetSnapshotSchemaVersion returns the aggregate state schema version, 0 if the aggregate does not implement SnapshotSchema.
+func GetSnapshotSchemaVersion(aggregate Aggregate) int {
+	if schema, ok := aggregate.(SnapshotSchema); ok {
+		return schema.SnapshotSchemaVersion()
+	}
+	return 0
 }
 
 // NewSnapshotFromAggregate create new snapshot from the Aggregate state.
@@ -19,9 +35,32 @@ func NewSnapshotFromAggregate(aggregate Aggregate) (*Snapshot, error) {
 	}
 
 	return &Snapshot{
-		ID:      aggregate.GetID(),
-		Type:    aggregate.GetType(),
-		State:   aggregateBytes,
-		Version: uint64(aggregate.GetVersion()),
+		ID:            aggregate.GetID(),
+		Type:          aggregate.GetType(),
+		State:         aggregateBytes,
+		Version:       uint64(aggregate.GetVersion()),
+		SchemaVersion: GetSnapshotSchemaVersion(aggregate),
 	}, nil
 }
+
+// RestoreAggregateFromSnapshot restore the Aggregate state and version from the Snapshot,
+// after restoring only events newer than Snapshot.Version must be applied.
+func RestoreAggregateFromSnapshot(aggregate Aggregate, snapshot *Snapshot) error {
+	if snapshot.ID != aggregate.GetID() {
+		return ErrInvalidAggregateID
+	}
+	if snapshot.SchemaVersion != GetSnapshotSchemaVersion(aggregate) {
+		return ErrSnapshotOutdated
+	}
+
+	if err := json.Unmarshal(snapshot.State, aggregate); err != nil {
+		return err
+	}
+
+	if aggregate.GetVersion() != int64(snapshot.Version) {
+		return ErrInvalidEventVersion
+	}
+
+	aggregate.ClearUncommittedEvents()
+	return nil
+}
@@ -28,6 +28,13 @@ type EventStore interface {
 	LoadEvents(ctx context.Context, streamID string) ([]Event, error)
 }
 
+// StreamSubscriber is an interface for the catch-up subscriptions to the single event stream.
+type StreamSubscriber interface {
+	// SubscribeToStream calls handler for each event of the stream after afterVersion, -1 subscribes from the start,
+	// it blocks until ctx is done or handler returns an error.
+	SubscribeToStream(ctx context.Context, streamID string, afterVersion int64, handler func(ctx context.Context, event Event) error) error
+}
+
 // SnapshotStore is an interface for an event sourcing snapshot store.
 type SnapshotStore interface {
 	// SaveSnapshot save aggregate snapshot.
@@ -36,3 +43,50 @@ type SnapshotStore interface {
 	// GetSnapshot load aggregate snapshot.
 	GetSnapshot(ctx context.Context, id string) (*Snapshot, error)
 }
+
+// CheckpointStore is an interface for the store of the subscriptions processed positions.
+type CheckpointStore interface {
+	// SaveCheckpoint save subscription checkpoint.
+	SaveCheckpoint(ctx context.Context, checkpoint Checkpoint) error
+
+	// GetCheckpoint load subscription checkpoint by subscription name.
+	GetCheckpoint(ctx context.Context, name string) (*Checkpoint, error)
+}
+
+// HeadReader is an interface for reading the head of $all.
+type HeadReader interface {
+	// GetStreamsHead reads $all backwards from the end until commitPosition and counts the events of the streams with
+	// given prefixes, at most scanLimit events of all streams are read.
+	GetStreamsHead(ctx context.Context, prefixes []string, commitPosition uint64, scanLimit uint64) (*StreamsHead, error)
+}
+
+// DeadLetterStore is an interface for the store of the events parked by projections.
+type DeadLetterStore interface {
+	// SaveDeadLetter save or replace dead letter.
+	SaveDeadLetter(ctx context.Context, deadLetter DeadLetter) error
+
+	// GetDeadLetter load dead letter by id.
+	GetDeadLetter(ctx context.Context, id string) (*DeadLetter, error)
+
+	// ListDeadLetters load dead letters of the subscription group ordered by parked time and id, all groups if groupName is empty,
+	// not nil after skips the dead letters up to the position instead of the offset.
+	ListDeadLetters(ctx context.Context, groupName string, after *DeadLetterPosition, offset int64, limit int64) ([]DeadLetter, int64, error)
+
+	// DeleteDeadLetter delete dead letter by id.
+	DeleteDeadLetter(ctx context.Context, id string) error
+}
+
+// IdempotencyStore is an interface for the store of the commands results by client supplied idempotency keys.
+type IdempotencyStore interface {
+	// ReserveIdempotencyKey save the record if its key is not used yet, expired or its lease expired at the record CreatedAt
+	// and returns nil, otherwise returns the existing record.
+	ReserveIdempotencyKey(ctx context.Context, record IdempotencyRecord) (*IdempotencyRecord, error)
+
+	// CompleteIdempotencyKey save the result of the command executed with the reserved record,
+	// does nothing if the key is reserved again by the other lease.
+	CompleteIdempotencyKey(ctx context.Context, record IdempotencyRecord, result string) error
+
+	// ReleaseIdempotencyKey delete the reserved record of the failed command,
+	// does nothing if the key is reserved again by the other lease.
+	ReleaseIdempotencyKey(ctx context.Context, record IdempotencyRecord) error
+}
@@ -6,9 +6,8 @@ import (
 	"github.com/AleksK1NG/es-microservice/pkg/logger"
 	"github.com/AleksK1NG/es-microservice/pkg/tracing"
 	"github.com/EventStore/EventStore-Client-Go/esdb"
-	"github.com/opentracing/opentracing-go"
-	"github.com/opentracing/opentracing-go/log"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 	"io"
 	"math"
 )
@@ -18,20 +17,40 @@ const (
 )
 
 type aggregateStore struct {
-	log logger.Logger
-	db  *esdb.Client
+	log           logger.Logger
+	cfg           es.Config
+	db            *esdb.Client
+	snapshotStore es.SnapshotStore
+	upcaster      es.Upcaster
 }
 
-func NewAggregateStore(log logger.Logger, db *esdb.Client) *aggregateStore {
-	return &aggregateStore{log: log, db: db}
+// NewAggregateStore EventStoreDB aggregate store, loaded events are upcasted to the current schema by upcaster if it is not nil.
+func NewAggregateStore(log logger.Logger, cfg es.Config, db *esdb.Client, snapshotStore es.SnapshotStore, upcaster es.Upcaster) *aggregateStore {
+	return &aggregateStore{log: log, cfg: cfg, db: db, snapshotStore: snapshotStore, upcaster: upcaster}
 }
 
 func (a *aggregateStore) Load(ctx context.Context, aggregate es.Aggregate) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "aggregateStore.Load")
-	defer span.Finish()
-	span.LogFields(log.String("AggregateID", aggregate.GetID()))
+	ctx, span := tracing.StartSpan(ctx, "aggregateStore.Load")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", aggregate.GetID()))
 
-	stream, err := a.db.ReadStream(ctx, aggregate.GetID(), esdb.ReadStreamOptions{}, count)
+	readOps := esdb.ReadStreamOptions{Direction: esdb.Forwards, From: esdb.Start{}}
+	snapshot, err := a.loadSnapshot(ctx, aggregate)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+	if snapshot != nil {
+		readOps.From = esdb.Revision(snapshot.Version + 1)
+		span.SetAttributes(attribute.Int64("SnapshotVersion", int64(snapshot.Version)))
+	}
+
+	stream, err := a.db.ReadStream(ctx, aggregate.GetID(), readOps, count)
+	// the client fails the read without any event with EOF, so the snapshot is at the latest version
+	if snapshot != nil && errors.Is(err, io.EOF) {
+		a.log.Debugf("(Load) aggregate: {%s}", aggregate.String())
+		return nil
+	}
 	if err != nil {
 		tracing.TraceErr(span, err)
 		return errors.Wrap(err, "db.ReadStream")
@@ -52,7 +71,11 @@ func (a *aggregateStore) Load(ctx context.Context, aggregate es.Aggregate) error
 			return errors.Wrap(err, "stream.Recv")
 		}
 
-		esEvent := es.NewEventFromRecorded(event.Event)
+		esEvent, err := es.UpcastEvent(a.upcaster, es.NewEventFromRecorded(event.Event))
+		if err != nil {
+			tracing.TraceErr(span, err)
+			return errors.Wrap(err, "UpcastEvent")
+		}
 		if err := aggregate.RaiseEvent(esEvent); err != nil {
 			tracing.TraceErr(span, err)
 			return errors.Wrap(err, "RaiseEvent")
@@ -65,9 +88,9 @@ func (a *aggregateStore) Load(ctx context.Context, aggregate es.Aggregate) error
 }
 
 func (a *aggregateStore) Save(ctx context.Context, aggregate es.Aggregate) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "aggregateStore.Save")
-	defer span.Finish()
-	span.LogFields(log.String("aggregate", aggregate.String()))
+	ctx, span := tracing.StartSpan(ctx, "aggregateStore.Save")
+	defer span.End()
+	span.SetAttributes(attribute.String("aggregate", aggregate.String()))
 
 	if len(aggregate.GetUncommittedEvents()) == 0 {
 		a.log.Debugf("(Save) [no uncommittedEvents] len: {%d}", len(aggregate.GetUncommittedEvents()))
@@ -76,46 +99,27 @@ func (a *aggregateStore) Save(ctx context.Context, aggregate es.Aggregate) error
 
 	eventsData := make([]esdb.EventData, 0, len(aggregate.GetUncommittedEvents()))
 	for _, event := range aggregate.GetUncommittedEvents() {
-		eventsData = append(eventsData, event.ToEventData())
-	}
-
-	// check for aggregate.GetVersion() == 0 or len(aggregate.GetAppliedEvents()) == 0 means new aggregate
-	var expectedRevision esdb.ExpectedRevision
-	if aggregate.GetVersion() == 0 {
-		expectedRevision = esdb.NoStream{}
-		a.log.Debugf("(Save) expectedRevision: {%T}", expectedRevision)
-
-		appendStream, err := a.db.AppendToStream(
-			ctx,
-			aggregate.GetID(),
-			esdb.AppendToStreamOptions{ExpectedRevision: expectedRevision},
-			eventsData...,
-		)
-		if err != nil {
+		if err := es.SetIdempotencyKeyMetadata(ctx, &event); err != nil {
 			tracing.TraceErr(span, err)
-			return errors.Wrap(err, "db.AppendToStream")
+			return errors.Wrap(err, "SetIdempotencyKeyMetadata")
 		}
-
-		a.log.Debugf("(Save) stream: {%+v}", appendStream)
-		return nil
-	}
-
-	readOps := esdb.ReadStreamOptions{Direction: esdb.Backwards, From: esdb.End{}}
-	stream, err := a.db.ReadStream(context.Background(), aggregate.GetID(), readOps, 1)
-	if err != nil {
-		tracing.TraceErr(span, err)
-		return errors.Wrap(err, "db.ReadStream")
+		if err := es.SetTenantIDMetadata(ctx, &event); err != nil {
+			tracing.TraceErr(span, err)
+			return errors.Wrap(err, "SetTenantIDMetadata")
+		}
+		if err := es.SetSubjectMetadata(ctx, &event); err != nil {
+			tracing.TraceErr(span, err)
+			return errors.Wrap(err, "SetSubjectMetadata")
+		}
+		eventsData = append(eventsData, event.ToEventData())
 	}
-	defer stream.Close()
 
-	lastEvent, err := stream.Recv()
-	if err != nil {
-		tracing.TraceErr(span, err)
-		return errors.Wrap(err, "stream.Recv")
+	// the stream must still be at the version the aggregate was loaded at, otherwise someone else has appended events
+	var expectedRevision esdb.ExpectedRevision = esdb.NoStream{}
+	if aggregate.GetLoadedVersion() >= 0 {
+		expectedRevision = esdb.Revision(uint64(aggregate.GetLoadedVersion()))
 	}
-
-	expectedRevision = esdb.Revision(lastEvent.OriginalEvent().EventNumber)
-	a.log.Debugf("(Save) expectedRevision: {%T}", expectedRevision)
+	a.log.Debugf("(Save) expectedRevision: {%T}, loadedVersion: {%d}", expectedRevision, aggregate.GetLoadedVersion())
 
 	appendStream, err := a.db.AppendToStream(
 		ctx,
@@ -125,18 +129,21 @@ func (a *aggregateStore) Save(ctx context.Context, aggregate es.Aggregate) error
 	)
 	if err != nil {
 		tracing.TraceErr(span, err)
+		if errors.Is(err, esdb.ErrWrongExpectedStreamRevision) {
+			return errors.Wrapf(es.ErrConcurrencyConflict, "AggregateID: {%s}, loadedVersion: {%d}, err: {%v}", aggregate.GetID(), aggregate.GetLoadedVersion(), err)
+		}
 		return errors.Wrap(err, "db.AppendToStream")
 	}
 
 	a.log.Debugf("(Save) stream: {%+v}", appendStream)
-	aggregate.ClearUncommittedEvents()
+	a.saveSnapshotIfRequired(ctx, aggregate)
 	return nil
 }
 
 func (a *aggregateStore) Exists(ctx context.Context, streamID string) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "aggregateStore.Exists")
-	defer span.Finish()
-	span.LogFields(log.String("AggregateID", streamID))
+	ctx, span := tracing.StartSpan(ctx, "aggregateStore.Exists")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", streamID))
 
 	readStreamOptions := esdb.ReadStreamOptions{Direction: esdb.Backwards, From: esdb.Revision(1)}
 
@@ -163,3 +170,45 @@ func (a *aggregateStore) Exists(ctx context.Context, streamID string) error {
 
 	return nil
 }
+
+// loadSnapshot restore aggregate from the latest snapshot, returns nil snapshot if snapshotting is disabled or there is no snapshot yet.
+func (a *aggregateStore) loadSnapshot(ctx context.Context, aggregate es.Aggregate) (*es.Snapshot, error) {
+	if a.snapshotStore == nil || a.cfg.SnapshotFrequency <= 0 {
+		return nil, nil
+	}
+
+	snapshot, err := a.snapshotStore.GetSnapshot(ctx, aggregate.GetID())
+	if err != nil {
+		if !errors.Is(err, es.ErrSnapshotNotFound) {
+			a.log.Warnf("(GetSnapshot) AggregateID: {%s}, err: {%v}", aggregate.GetID(), err)
+		}
+		return nil, nil
+	}
+
+	if err := es.RestoreAggregateFromSnapshot(aggregate, snapshot); err != nil {
+		// outdated snapshot is checked before restoring, so the aggregate is still empty and loaded from the events
+		if errors.Is(err, es.ErrSnapshotOutdated) {
+			a.log.Warnf("(loadSnapshot) AggregateID: {%s}, schemaVersion: {%d}, err: {%v}", aggregate.GetID(), snapshot.SchemaVersion, err)
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "RestoreAggregateFromSnapshot")
+	}
+
+	a.log.Debugf("(loadSnapshot) AggregateID: {%s}, version: {%d}", aggregate.GetID(), snapshot.Version)
+	return snapshot, nil
+}
+
+// saveSnapshotIfRequired clear saved uncommitted events and save aggregate snapshot every SnapshotFrequency events.
+func (a *aggregateStore) saveSnapshotIfRequired(ctx context.Context, aggregate es.Aggregate) {
+	isSnapshotRequired := a.snapshotStore != nil && a.cfg.IsSnapshotRequired(aggregate.GetVersion(), len(aggregate.GetUncommittedEvents()))
+	aggregate.ToSnapshot()
+
+	if !isSnapshotRequired {
+		return
+	}
+
+	// events are already committed, failed snapshot only means longer loading next time
+	if err := a.snapshotStore.SaveSnapshot(ctx, aggregate); err != nil {
+		a.log.Warnf("(SaveSnapshot) AggregateID: {%s}, err: {%v}", aggregate.GetID(), err)
+	}
+}
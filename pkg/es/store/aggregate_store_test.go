@@ -0,0 +1,301 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	api "github.com/EventStore/EventStore-Client-Go/protos/streams"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// streamsServer fake eventstoredb streams service, the append records the expected revision and returns the result
+// or fails with the code if it is set.
+type streamsServer struct {
+	api.UnimplementedStreamsServer
+	result *api.AppendResp
+	code   codes.Code
+
+	mu               sync.Mutex
+	expectedRevision string
+	events           int
+}
+
+func (s *streamsServer) Append(stream api.Streams_AppendServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		s.mu.Lock()
+		if options := req.GetOptions(); options != nil {
+			switch revision := options.GetExpectedStreamRevision().(type) {
+			case *api.AppendReq_Options_NoStream:
+				s.expectedRevision = "no stream"
+			case *api.AppendReq_Options_Revision:
+				s.expectedRevision = fmt.Sprint(revision.Revision)
+			default:
+				s.expectedRevision = fmt.Sprintf("%T", revision)
+			}
+		} else {
+			s.events++
+		}
+		s.mu.Unlock()
+	}
+
+	if s.code != codes.OK {
+		return status.Error(s.code, "append failed")
+	}
+	return stream.SendAndClose(s.result)
+}
+
+func newTestLogger() logger.Logger {
+	appLogger := logger.NewAppLogger(&logger.Config{LogLevel: "error", Encoder: "console"})
+	appLogger.InitLogger()
+	return appLogger
+}
+
+// newTestClient eventstoredb client connected to the fake streams service.
+func newTestClient(t *testing.T, server api.StreamsServer) *esdb.Client {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() err: %v", err)
+	}
+	grpcServer := grpc.NewServer()
+	api.RegisterStreamsServer(grpcServer, server)
+	go grpcServer.Serve(listener) // nolint: errcheck
+	t.Cleanup(grpcServer.Stop)
+
+	settings, err := esdb.ParseConnectionString(fmt.Sprintf("esdb://%s?tls=false", listener.Addr()))
+	if err != nil {
+		t.Fatalf("ParseConnectionString() err: %v", err)
+	}
+	db, err := esdb.NewClient(settings)
+	if err != nil {
+		t.Fatalf("NewClient() err: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func newTestAggregateStore(t *testing.T, server *streamsServer) *aggregateStore {
+	return NewAggregateStore(newTestLogger(), es.Config{}, newTestClient(t, server), nil, nil)
+}
+
+// counterAggregate counts its events, Events is restored from the snapshot and Loaded keeps only the loaded events.
+type counterAggregate struct {
+	*es.AggregateBase
+	Events int        `json:"events"`
+	Loaded []es.Event `json:"-"`
+}
+
+func newCounterAggregate(id string) *counterAggregate {
+	counter := &counterAggregate{}
+	base := es.NewAggregateBase(counter.When)
+	base.SetType("counter")
+	base.SetID(id)
+	counter.AggregateBase = base
+	return counter
+}
+
+func (c *counterAggregate) When(event es.Event) error {
+	c.Events++
+	c.Loaded = append(c.Loaded, event)
+	return nil
+}
+
+func (c *counterAggregate) increment(times int) error {
+	for i := 0; i < times; i++ {
+		if err := c.Apply(es.NewBaseEvent(c, "COUNTER_INCREMENTED")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestAggregateStoreSave(t *testing.T) {
+	success := &api.AppendResp{Result: &api.AppendResp_Success_{Success: &api.AppendResp_Success{
+		CurrentRevisionOption: &api.AppendResp_Success_CurrentRevision{CurrentRevision: 3},
+		PositionOption:        &api.AppendResp_Success_Position{Position: &api.AppendResp_Position{CommitPosition: 10, PreparePosition: 10}},
+	}}}
+	wrongExpectedVersion := &api.AppendResp{Result: &api.AppendResp_WrongExpectedVersion_{WrongExpectedVersion: &api.AppendResp_WrongExpectedVersion{
+		CurrentRevisionOption:  &api.AppendResp_WrongExpectedVersion_CurrentRevision{CurrentRevision: 5},
+		ExpectedRevisionOption: &api.AppendResp_WrongExpectedVersion_ExpectedRevision{ExpectedRevision: 2},
+	}}}
+
+	tests := []struct {
+		name string
+		// loadedVersion of the aggregate, -1 if the aggregate is new
+		loadedVersion    int64
+		result           *api.AppendResp
+		code             codes.Code
+		expectedRevision string
+		err              error
+	}{
+		{name: "new aggregate", loadedVersion: -1, result: success, expectedRevision: "no stream"},
+		{name: "loaded aggregate", loadedVersion: 2, result: success, expectedRevision: "2"},
+		{name: "wrong expected version", loadedVersion: 2, result: wrongExpectedVersion, err: es.ErrConcurrencyConflict},
+		{name: "new aggregate stream exists", loadedVersion: -1, result: wrongExpectedVersion, err: es.ErrConcurrencyConflict},
+		{name: "failed precondition", loadedVersion: 2, code: codes.FailedPrecondition, err: es.ErrConcurrencyConflict},
+		{name: "permission denied", loadedVersion: 2, code: codes.PermissionDenied, err: esdb.ErrPermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &streamsServer{result: tt.result, code: tt.code}
+			store := newTestAggregateStore(t, server)
+
+			counter := newCounterAggregate("counter-1")
+			counter.Version = tt.loadedVersion
+			if err := counter.increment(1); err != nil {
+				t.Fatalf("increment() err: %v", err)
+			}
+
+			err := store.Save(context.Background(), counter)
+			if !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+				t.Fatalf("Save() err = %v, want %v", err, tt.err)
+			}
+			if tt.err != nil && tt.err != es.ErrConcurrencyConflict && errors.Is(err, es.ErrConcurrencyConflict) {
+				t.Errorf("Save() err = %v is concurrency conflict", err)
+			}
+
+			server.mu.Lock()
+			defer server.mu.Unlock()
+			if server.events != 1 {
+				t.Errorf("appended events = %d, want 1", server.events)
+			}
+			if tt.expectedRevision != "" && server.expectedRevision != tt.expectedRevision {
+				t.Errorf("expected revision = %v, want %v", server.expectedRevision, tt.expectedRevision)
+			}
+		})
+	}
+}
+
+func TestAggregateStoreLoadUpcastsEvents(t *testing.T) {
+	errUpcast := errors.New("upcast failed")
+	// upcaster of the V1 counter events to V2 with the doubled data
+	upcaster := es.NewUpcasterRegistry().Register("V1_COUNTER_INCREMENTED", func(event es.Event) (es.Event, error) {
+		event.EventType = "V2_COUNTER_INCREMENTED"
+		event.Data = append(event.Data, event.Data...)
+		return event, nil
+	})
+
+	tests := []struct {
+		name     string
+		upcaster es.Upcaster
+		// loaded events types and data
+		loaded []string
+		err    error
+	}{
+		{name: "upcasts stored events", upcaster: upcaster, loaded: []string{"V2_COUNTER_INCREMENTED [1][1]", "COUNTER_INCREMENTED [2]", "V2_COUNTER_INCREMENTED [3][3]"}},
+		{name: "without upcaster", loaded: []string{"V1_COUNTER_INCREMENTED [1]", "COUNTER_INCREMENTED [2]", "V1_COUNTER_INCREMENTED [3]"}},
+		{
+			name: "upcast failed",
+			upcaster: es.NewUpcasterRegistry().Register("V1_COUNTER_INCREMENTED", func(event es.Event) (es.Event, error) {
+				return es.Event{}, errUpcast
+			}),
+			err: errUpcast,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := newTestClient(t, newEventStoreServer())
+			store := NewAggregateStore(newTestLogger(), es.Config{}, db, nil, tt.upcaster)
+
+			stored := []esdb.EventData{
+				{EventType: "V1_COUNTER_INCREMENTED", ContentType: esdb.JsonContentType, Data: []byte(`[1]`)},
+				{EventType: "COUNTER_INCREMENTED", ContentType: esdb.JsonContentType, Data: []byte(`[2]`)},
+				{EventType: "V1_COUNTER_INCREMENTED", ContentType: esdb.JsonContentType, Data: []byte(`[3]`)},
+			}
+			if _, err := db.AppendToStream(ctx, "counter-counter-1", esdb.AppendToStreamOptions{}, stored...); err != nil {
+				t.Fatalf("AppendToStream() err: %v", err)
+			}
+
+			counter := newCounterAggregate("counter-1")
+			err := store.Load(ctx, counter)
+			if !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+				t.Fatalf("Load() err = %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				return
+			}
+
+			loaded := make([]string, 0, len(counter.Loaded))
+			for _, event := range counter.Loaded {
+				loaded = append(loaded, fmt.Sprintf("%s %s", event.GetEventType(), event.GetData()))
+			}
+			if fmt.Sprint(loaded) != fmt.Sprint(tt.loaded) {
+				t.Errorf("loaded events = %v, want %v", loaded, tt.loaded)
+			}
+			if counter.GetVersion() != 2 {
+				t.Errorf("loaded version = %d, want 2", counter.GetVersion())
+			}
+		})
+	}
+}
+
+func TestAggregateStoreSaveStampsMetadata(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		// metadata of the appended events
+		metadata string
+	}{
+		{name: "default tenant", ctx: context.Background(), metadata: ""},
+		{name: "tenant", ctx: es.WithTenantID(context.Background(), "tenantA"), metadata: `{"tenant-id":"tenantA"}`},
+		{
+			name:     "tenant subject and idempotency key",
+			ctx:      es.WithIdempotencyKey(es.WithSubject(es.WithTenantID(context.Background(), "tenantA"), "customer-1"), "key-1"),
+			metadata: `{"idempotency-key":"key-1","subject":"customer-1","tenant-id":"tenantA"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newEventStoreServer()
+			store := NewAggregateStore(newTestLogger(), es.Config{}, newTestClient(t, server), nil, nil)
+
+			counter := newCounterAggregate("counter-1")
+			if err := counter.increment(2); err != nil {
+				t.Fatalf("increment() err: %v", err)
+			}
+			if err := store.Save(tt.ctx, counter); err != nil {
+				t.Fatalf("Save() err: %v", err)
+			}
+
+			events := server.getStream(counter.GetID())
+			if len(events) != 2 {
+				t.Fatalf("appended events = %d, want 2", len(events))
+			}
+			for _, event := range events {
+				if string(event.GetCustomMetadata()) != tt.metadata {
+					t.Errorf("appended event metadata = %s, want %s", event.GetCustomMetadata(), tt.metadata)
+				}
+			}
+
+			loaded := newCounterAggregate("counter-1")
+			if err := store.Load(context.Background(), loaded); err != nil {
+				t.Fatalf("Load() err: %v", err)
+			}
+			for _, event := range loaded.Loaded {
+				if string(event.GetMetadata()) != tt.metadata {
+					t.Errorf("loaded event metadata = %s, want %s", event.GetMetadata(), tt.metadata)
+				}
+			}
+		})
+	}
+}
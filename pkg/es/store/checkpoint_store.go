@@ -0,0 +1,96 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	checkpointStreamPrefix   = "checkpoint"
+	checkpointEventType      = "CHECKPOINT"
+	checkpointStreamMaxCount = 1
+)
+
+type checkpointStore struct {
+	log logger.Logger
+	db  *esdb.Client
+}
+
+// NewCheckpointStore EventStoreDB checkpoint store, every subscription has own checkpoint-<name> stream
+// which keeps only the latest checkpoint.
+func NewCheckpointStore(log logger.Logger, db *esdb.Client) *checkpointStore {
+	return &checkpointStore{log: log, db: db}
+}
+
+func (c *checkpointStore) SaveCheckpoint(ctx context.Context, checkpoint es.Checkpoint) error {
+	ctx, span := tracing.StartSpan(ctx, "checkpointStore.SaveCheckpoint")
+	defer span.End()
+	span.SetAttributes(attribute.String("Name", checkpoint.Name), attribute.Int64("CommitPosition", int64(checkpoint.CommitPosition)))
+
+	checkpointBytes, err := json.Marshal(checkpoint)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "json.Marshal")
+	}
+
+	eventData := esdb.EventData{EventType: checkpointEventType, ContentType: esdb.JsonContentType, Data: checkpointBytes}
+	writeResult, err := c.db.AppendToStream(ctx, getCheckpointStreamID(checkpoint.Name), esdb.AppendToStreamOptions{}, eventData)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "db.AppendToStream")
+	}
+
+	if writeResult.NextExpectedVersion == 0 {
+		metadata := esdb.StreamMetadata{}
+		metadata.SetMaxCount(checkpointStreamMaxCount)
+		if _, err := c.db.SetStreamMetadata(ctx, getCheckpointStreamID(checkpoint.Name), esdb.AppendToStreamOptions{}, metadata); err != nil {
+			c.log.Warnf("(SetStreamMetadata) Name: {%s}, err: {%v}", checkpoint.Name, err)
+		}
+	}
+
+	c.log.Debugf("(SaveCheckpoint) Name: {%s}, CommitPosition: {%d}", checkpoint.Name, checkpoint.CommitPosition)
+	return nil
+}
+
+func (c *checkpointStore) GetCheckpoint(ctx context.Context, name string) (*es.Checkpoint, error) {
+	ctx, span := tracing.StartSpan(ctx, "checkpointStore.GetCheckpoint")
+	defer span.End()
+	span.SetAttributes(attribute.String("Name", name))
+
+	readOps := esdb.ReadStreamOptions{Direction: esdb.Backwards, From: esdb.End{}}
+	stream, err := c.db.ReadStream(ctx, getCheckpointStreamID(name), readOps, 1)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return nil, errors.Wrap(err, "db.ReadStream")
+	}
+	defer stream.Close()
+
+	event, err := stream.Recv()
+	if errors.Is(err, esdb.ErrStreamNotFound) {
+		return nil, errors.Wrapf(es.ErrCheckpointNotFound, "name: {%s}", name)
+	}
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return nil, errors.Wrap(err, "stream.Recv")
+	}
+
+	var checkpoint es.Checkpoint
+	if err := json.Unmarshal(event.Event.Data, &checkpoint); err != nil {
+		tracing.TraceErr(span, err)
+		return nil, errors.Wrap(err, "json.Unmarshal")
+	}
+
+	return &checkpoint, nil
+}
+
+func getCheckpointStreamID(name string) string {
+	return fmt.Sprintf("%s-%s", checkpointStreamPrefix, name)
+}
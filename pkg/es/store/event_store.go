@@ -6,9 +6,8 @@ import (
 	"github.com/AleksK1NG/es-microservice/pkg/logger"
 	"github.com/AleksK1NG/es-microservice/pkg/tracing"
 	"github.com/EventStore/EventStore-Client-Go/esdb"
-	"github.com/opentracing/opentracing-go"
-	"github.com/opentracing/opentracing-go/log"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 	"io"
 )
 
@@ -22,9 +21,9 @@ func NewEventStore(log logger.Logger, db *esdb.Client) *eventStore {
 }
 
 func (e *eventStore) SaveEvents(ctx context.Context, streamID string, events []es.Event) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "eventStore.SaveEvents")
-	defer span.Finish()
-	span.LogFields(log.String("AggregateID", streamID))
+	ctx, span := tracing.StartSpan(ctx, "eventStore.SaveEvents")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", streamID))
 
 	eventsData := make([]esdb.EventData, 0, len(events))
 	for _, event := range events {
@@ -42,17 +41,17 @@ func (e *eventStore) SaveEvents(ctx context.Context, streamID string, events []e
 }
 
 func (e *eventStore) LoadEvents(ctx context.Context, streamID string) ([]es.Event, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "eventStore.LoadEvents")
-	defer span.Finish()
-	span.LogFields(log.String("AggregateID", streamID))
+	ctx, span := tracing.StartSpan(ctx, "eventStore.LoadEvents")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", streamID))
 
 	stream, err := e.db.ReadStream(ctx, streamID, esdb.ReadStreamOptions{
 		Direction: esdb.Forwards,
-		From:      esdb.Revision(1),
-	}, 100)
+		From:      esdb.Start{},
+	}, count)
 	if err != nil {
 		tracing.TraceErr(span, err)
-		return nil, err
+		return nil, errors.Wrap(err, "db.ReadStream")
 	}
 	defer stream.Close()
 
@@ -64,7 +63,7 @@ func (e *eventStore) LoadEvents(ctx context.Context, streamID string) ([]es.Even
 		}
 		if err != nil {
 			tracing.TraceErr(span, err)
-			return nil, err
+			return nil, errors.Wrap(err, "stream.Recv")
 		}
 		events = append(events, es.NewEventFromRecorded(event.Event))
 	}
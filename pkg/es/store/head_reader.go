@@ -0,0 +1,102 @@
+package store
+
+import (
+	"context"
+	"io"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	headBatchSize = 500
+)
+
+type headReader struct {
+	log logger.Logger
+	db  *esdb.Client
+}
+
+// NewHeadReader EventStoreDB $all head reader, used to measure how far behind the projections are.
+func NewHeadReader(log logger.Logger, db *esdb.Client) *headReader {
+	return &headReader{log: log, db: db}
+}
+
+func (h *headReader) GetStreamsHead(ctx context.Context, prefixes []string, commitPosition uint64, scanLimit uint64) (*es.StreamsHead, error) {
+	ctx, span := tracing.StartSpan(ctx, "headReader.GetStreamsHead")
+	defer span.End()
+	span.SetAttributes(tracing.Object("Prefixes", prefixes), attribute.Int64("CommitPosition", int64(commitPosition)))
+
+	r := &headScanner{db: h.db, prefixes: prefixes, commitPosition: commitPosition, scanLimit: scanLimit, head: &es.StreamsHead{}}
+	var from esdb.AllPosition = esdb.End{}
+	for {
+		done, err := r.scanBatch(ctx, from)
+		if err != nil {
+			tracing.TraceErr(span, err)
+			return nil, err
+		}
+		if done || r.lastPosition == nil {
+			return r.head, nil
+		}
+		from = *r.lastPosition
+	}
+}
+
+type headScanner struct {
+	db             *esdb.Client
+	prefixes       []string
+	commitPosition uint64
+	scanLimit      uint64
+	scanned        uint64
+	head           *es.StreamsHead
+	lastPosition   *esdb.Position
+}
+
+// scanBatch returns true when the projection position, the start of $all or the scan limit is reached.
+func (r *headScanner) scanBatch(ctx context.Context, from esdb.AllPosition) (bool, error) {
+	stream, err := r.db.ReadAll(ctx, esdb.ReadAllOptions{Direction: esdb.Backwards, From: from}, headBatchSize)
+	if err != nil {
+		return false, errors.Wrap(err, "db.ReadAll")
+	}
+	defer stream.Close()
+
+	read := 0
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return read < headBatchSize, nil
+		}
+		if err != nil {
+			return false, errors.Wrap(err, "stream.Recv")
+		}
+		read++
+
+		recordedEvent := event.OriginalEvent()
+		if recordedEvent == nil || (r.lastPosition != nil && recordedEvent.Position == *r.lastPosition) {
+			continue
+		}
+		if recordedEvent.Position.Commit <= r.commitPosition {
+			return true, nil
+		}
+		if r.scanned >= r.scanLimit {
+			r.head.Truncated = true
+			return true, nil
+		}
+		position := recordedEvent.Position
+		r.lastPosition = &position
+		r.scanned++
+
+		if !hasStreamPrefix(recordedEvent.StreamID, r.prefixes) {
+			continue
+		}
+		if r.head.PendingEvents == 0 {
+			r.head.CommitPosition = position.Commit
+		}
+		r.head.PendingEvents++
+		r.head.OldestPending = recordedEvent.CreatedDate
+	}
+}
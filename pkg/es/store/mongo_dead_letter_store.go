@@ -0,0 +1,122 @@
+package store
+
+import (
+	"context"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	deadLetterGroupName = "groupName"
+	deadLetterParkedAt  = "parkedAt"
+	deadLetterID        = "_id"
+)
+
+type mongoDeadLetterStore struct {
+	log        logger.Logger
+	collection *mongo.Collection
+}
+
+// NewMongoDeadLetterStore MongoDB dead letter store, keeps the copies of the parked events.
+func NewMongoDeadLetterStore(log logger.Logger, collection *mongo.Collection) *mongoDeadLetterStore {
+	return &mongoDeadLetterStore{log: log, collection: collection}
+}
+
+func (m *mongoDeadLetterStore) SaveDeadLetter(ctx context.Context, deadLetter es.DeadLetter) error {
+	ctx, span := tracing.StartSpan(ctx, "mongoDeadLetterStore.SaveDeadLetter")
+	defer span.End()
+	span.SetAttributes(attribute.String("ID", deadLetter.ID), attribute.String("GroupName", deadLetter.GroupName))
+
+	ops := options.Replace().SetUpsert(true)
+	if _, err := m.collection.ReplaceOne(ctx, bson.M{"_id": deadLetter.ID}, deadLetter, ops); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "collection.ReplaceOne")
+	}
+
+	m.log.Debugf("(SaveDeadLetter) ID: {%s}, EventType: {%s}", deadLetter.ID, deadLetter.Event.GetEventType())
+	return nil
+}
+
+func (m *mongoDeadLetterStore) GetDeadLetter(ctx context.Context, id string) (*es.DeadLetter, error) {
+	ctx, span := tracing.StartSpan(ctx, "mongoDeadLetterStore.GetDeadLetter")
+	defer span.End()
+	span.SetAttributes(attribute.String("ID", id))
+
+	var deadLetter es.DeadLetter
+	if err := m.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&deadLetter); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.Wrapf(es.ErrDeadLetterNotFound, "id: {%s}", id)
+		}
+		tracing.TraceErr(span, err)
+		return nil, errors.Wrap(err, "collection.FindOne")
+	}
+
+	return &deadLetter, nil
+}
+
+func (m *mongoDeadLetterStore) ListDeadLetters(ctx context.Context, groupName string, after *es.DeadLetterPosition, offset int64, limit int64) ([]es.DeadLetter, int64, error) {
+	ctx, span := tracing.StartSpan(ctx, "mongoDeadLetterStore.ListDeadLetters")
+	defer span.End()
+	span.SetAttributes(attribute.String("GroupName", groupName), attribute.Int64("Offset", offset), attribute.Int64("Limit", limit))
+
+	filter := bson.M{}
+	if groupName != "" {
+		filter[deadLetterGroupName] = groupName
+	}
+
+	totalCount, err := m.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return nil, 0, errors.Wrap(err, "collection.CountDocuments")
+	}
+
+	// the id tiebreaker makes the order of the dead letters parked at the same time stable
+	ops := options.Find().SetSort(bson.D{{Key: deadLetterParkedAt, Value: 1}, {Key: deadLetterID, Value: 1}}).SetLimit(limit)
+	if after != nil {
+		filter["$or"] = bson.A{
+			bson.M{deadLetterParkedAt: bson.M{"$gt": after.ParkedAt}},
+			bson.M{deadLetterParkedAt: after.ParkedAt, deadLetterID: bson.M{"$gt": after.ID}},
+		}
+	} else {
+		ops.SetSkip(offset)
+	}
+
+	cursor, err := m.collection.Find(ctx, filter, ops)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return nil, 0, errors.Wrap(err, "collection.Find")
+	}
+
+	deadLetters := make([]es.DeadLetter, 0, limit)
+	if err := cursor.All(ctx, &deadLetters); err != nil {
+		tracing.TraceErr(span, err)
+		return nil, 0, errors.Wrap(err, "cursor.All")
+	}
+
+	return deadLetters, totalCount, nil
+}
+
+func (m *mongoDeadLetterStore) DeleteDeadLetter(ctx context.Context, id string) error {
+	ctx, span := tracing.StartSpan(ctx, "mongoDeadLetterStore.DeleteDeadLetter")
+	defer span.End()
+	span.SetAttributes(attribute.String("ID", id))
+
+	result, err := m.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "collection.DeleteOne")
+	}
+	if result.DeletedCount == 0 {
+		return errors.Wrapf(es.ErrDeadLetterNotFound, "id: {%s}", id)
+	}
+
+	m.log.Debugf("(DeleteDeadLetter) ID: {%s}", id)
+	return nil
+}
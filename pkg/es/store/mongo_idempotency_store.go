@@ -0,0 +1,100 @@
+package store
+
+import (
+	"context"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	idempotencyExpiresAt      = "expiresAt"
+	idempotencyResult         = "result"
+	idempotencyCompleted      = "completed"
+	idempotencyLeaseID        = "leaseId"
+	idempotencyLeaseExpiresAt = "leaseExpiresAt"
+)
+
+type mongoIdempotencyStore struct {
+	log        logger.Logger
+	collection *mongo.Collection
+}
+
+// NewMongoIdempotencyStore MongoDB idempotency store, expired records are removed by TTL index on expiresAt.
+func NewMongoIdempotencyStore(log logger.Logger, collection *mongo.Collection) *mongoIdempotencyStore {
+	return &mongoIdempotencyStore{log: log, collection: collection}
+}
+
+func (m *mongoIdempotencyStore) ReserveIdempotencyKey(ctx context.Context, record es.IdempotencyRecord) (*es.IdempotencyRecord, error) {
+	ctx, span := tracing.StartSpan(ctx, "mongoIdempotencyStore.ReserveIdempotencyKey")
+	defer span.End()
+	span.SetAttributes(attribute.String("Key", record.Key), attribute.String("Operation", record.Operation))
+
+	_, err := m.collection.InsertOne(ctx, record)
+	if err == nil {
+		return nil, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		tracing.TraceErr(span, err)
+		return nil, errors.Wrap(err, "collection.InsertOne")
+	}
+
+	// TTL monitor removes expired documents periodically, so the expired record can still exist,
+	// the record of the command crashed before completion is reserved again after its lease
+	filter := bson.M{"_id": record.Key, "$or": bson.A{
+		bson.M{idempotencyExpiresAt: bson.M{"$lte": record.CreatedAt}},
+		bson.M{idempotencyCompleted: false, idempotencyLeaseExpiresAt: bson.M{"$lte": record.CreatedAt}},
+	}}
+	result, err := m.collection.ReplaceOne(ctx, filter, record)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return nil, errors.Wrap(err, "collection.ReplaceOne")
+	}
+	if result.ModifiedCount > 0 {
+		return nil, nil
+	}
+
+	var existing es.IdempotencyRecord
+	if err := m.collection.FindOne(ctx, bson.M{"_id": record.Key}).Decode(&existing); err != nil {
+		tracing.TraceErr(span, err)
+		return nil, errors.Wrap(err, "collection.FindOne")
+	}
+
+	m.log.Debugf("(ReserveIdempotencyKey) key: {%s} exists, completed: {%v}", existing.Key, existing.Completed)
+	return &existing, nil
+}
+
+func (m *mongoIdempotencyStore) CompleteIdempotencyKey(ctx context.Context, record es.IdempotencyRecord, result string) error {
+	ctx, span := tracing.StartSpan(ctx, "mongoIdempotencyStore.CompleteIdempotencyKey")
+	defer span.End()
+	span.SetAttributes(attribute.String("Key", record.Key))
+
+	update := bson.M{"$set": bson.M{idempotencyResult: result, idempotencyCompleted: true}}
+	updateResult, err := m.collection.UpdateOne(ctx, bson.M{"_id": record.Key, idempotencyLeaseID: record.LeaseID}, update)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "collection.UpdateOne")
+	}
+	if updateResult.MatchedCount == 0 {
+		m.log.Warnf("(CompleteIdempotencyKey) key: {%s} is reserved by the other lease, leaseExpiresAt: {%s}", record.Key, record.LeaseExpiresAt)
+	}
+	return nil
+}
+
+func (m *mongoIdempotencyStore) ReleaseIdempotencyKey(ctx context.Context, record es.IdempotencyRecord) error {
+	ctx, span := tracing.StartSpan(ctx, "mongoIdempotencyStore.ReleaseIdempotencyKey")
+	defer span.End()
+	span.SetAttributes(attribute.String("Key", record.Key))
+
+	filter := bson.M{"_id": record.Key, idempotencyLeaseID: record.LeaseID, idempotencyCompleted: false}
+	if _, err := m.collection.DeleteOne(ctx, filter); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "collection.DeleteOne")
+	}
+	return nil
+}
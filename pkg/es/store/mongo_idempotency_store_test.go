@@ -0,0 +1,160 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// getCommandFilter returns the query filter of the first statement of the update, delete or find command, nil for the others.
+func getCommandFilter(started *event.CommandStartedEvent) bson.Raw {
+	switch started.CommandName {
+	case "update":
+		return started.Command.Lookup("updates").Array().Index(0).Value().Document().Lookup("q").Document()
+	case "delete":
+		return started.Command.Lookup("deletes").Array().Index(0).Value().Document().Lookup("q").Document()
+	case "find":
+		return started.Command.Lookup("filter").Document()
+	}
+	return nil
+}
+
+func TestMongoIdempotencyStore(t *testing.T) {
+	now := time.Now().UTC()
+	record := es.IdempotencyRecord{
+		Key:            "tenantA:customer-1:key-1",
+		Operation:      "create",
+		RequestHash:    "hash",
+		LeaseID:        "lease-1",
+		LeaseExpiresAt: now.Add(time.Minute),
+		CreatedAt:      now,
+		ExpiresAt:      now.Add(time.Hour),
+	}
+	existing := bson.D{
+		{Key: "_id", Value: record.Key},
+		{Key: "operation", Value: record.Operation},
+		{Key: "requestHash", Value: record.RequestHash},
+		{Key: "result", Value: "order-1"},
+		{Key: "completed", Value: true},
+		{Key: "leaseId", Value: "lease-0"},
+	}
+	duplicateKey := mtest.CreateWriteErrorsResponse(mtest.WriteError{Index: 0, Code: 11000, Message: "duplicate key error"})
+
+	tests := []struct {
+		name      string
+		call      func(ctx context.Context, store *mongoIdempotencyStore) (*es.IdempotencyRecord, error)
+		responses []bson.D
+		// commands sent to MongoDB and the sorted fields of the filter of the last one
+		commands []string
+		filter   []string
+		existing *es.IdempotencyRecord
+	}{
+		{
+			name: "reserve new key",
+			call: func(ctx context.Context, store *mongoIdempotencyStore) (*es.IdempotencyRecord, error) {
+				return store.ReserveIdempotencyKey(ctx, record)
+			},
+			responses: []bson.D{mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1})},
+			commands:  []string{"insert"},
+		},
+		{
+			name: "reserve expired or crashed key",
+			call: func(ctx context.Context, store *mongoIdempotencyStore) (*es.IdempotencyRecord, error) {
+				return store.ReserveIdempotencyKey(ctx, record)
+			},
+			responses: []bson.D{duplicateKey, mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1})},
+			commands:  []string{"insert", "update"},
+			filter:    []string{"$or", "_id"},
+		},
+		{
+			name: "reserve existing key",
+			call: func(ctx context.Context, store *mongoIdempotencyStore) (*es.IdempotencyRecord, error) {
+				return store.ReserveIdempotencyKey(ctx, record)
+			},
+			responses: []bson.D{
+				duplicateKey,
+				mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}, bson.E{Key: "nModified", Value: 0}),
+				mtest.CreateCursorResponse(0, "test.idempotency", mtest.FirstBatch, existing),
+			},
+			commands: []string{"insert", "update", "find"},
+			filter:   []string{"_id"},
+			existing: &es.IdempotencyRecord{Key: record.Key, Operation: record.Operation, RequestHash: record.RequestHash, Result: "order-1", Completed: true, LeaseID: "lease-0"},
+		},
+		{
+			name: "complete",
+			call: func(ctx context.Context, store *mongoIdempotencyStore) (*es.IdempotencyRecord, error) {
+				return nil, store.CompleteIdempotencyKey(ctx, record, "order-1")
+			},
+			responses: []bson.D{mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1})},
+			commands:  []string{"update"},
+			filter:    []string{"_id", "leaseId"},
+		},
+		{
+			name: "complete reserved by other lease",
+			call: func(ctx context.Context, store *mongoIdempotencyStore) (*es.IdempotencyRecord, error) {
+				return nil, store.CompleteIdempotencyKey(ctx, record, "order-1")
+			},
+			responses: []bson.D{mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}, bson.E{Key: "nModified", Value: 0})},
+			commands:  []string{"update"},
+			filter:    []string{"_id", "leaseId"},
+		},
+		{
+			name: "release",
+			call: func(ctx context.Context, store *mongoIdempotencyStore) (*es.IdempotencyRecord, error) {
+				return nil, store.ReleaseIdempotencyKey(ctx, record)
+			},
+			responses: []bson.D{mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1})},
+			commands:  []string{"delete"},
+			filter:    []string{"_id", "completed", "leaseId"},
+		},
+	}
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	appLogger := logger.NewAppLogger(&logger.Config{LogLevel: "error", Encoder: "console"})
+	appLogger.InitLogger()
+
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			store := NewMongoIdempotencyStore(appLogger, mt.Coll)
+			mt.AddMockResponses(tt.responses...)
+
+			existing, err := tt.call(context.Background(), store)
+			if err != nil {
+				mt.Fatalf("err: %v", err)
+			}
+			if fmt.Sprintf("%+v", existing) != fmt.Sprintf("%+v", tt.existing) {
+				mt.Errorf("existing = %+v, want %+v", existing, tt.existing)
+			}
+
+			started := mt.GetAllStartedEvents()
+			commands := make([]string, 0, len(started))
+			for _, command := range started {
+				commands = append(commands, command.CommandName)
+			}
+			if fmt.Sprint(commands) != fmt.Sprint(tt.commands) {
+				mt.Fatalf("commands = %v, want %v", commands, tt.commands)
+			}
+
+			filter := getCommandFilter(started[len(started)-1])
+			elements, _ := filter.Elements()
+			keys := make([]string, 0, len(elements))
+			for _, element := range elements {
+				keys = append(keys, element.Key())
+			}
+			sort.Strings(keys)
+			if fmt.Sprint(keys) != fmt.Sprint(tt.filter) {
+				mt.Errorf("filter = %s, want fields %v", filter, tt.filter)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package store
+
+import (
+	"context"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type mongoSnapshotStore struct {
+	log        logger.Logger
+	collection *mongo.Collection
+}
+
+// NewMongoSnapshotStore MongoDB snapshot store, keeps the latest snapshot document per aggregate.
+func NewMongoSnapshotStore(log logger.Logger, collection *mongo.Collection) *mongoSnapshotStore {
+	return &mongoSnapshotStore{log: log, collection: collection}
+}
+
+func (m *mongoSnapshotStore) SaveSnapshot(ctx context.Context, aggregate es.Aggregate) error {
+	ctx, span := tracing.StartSpan(ctx, "mongoSnapshotStore.SaveSnapshot")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", aggregate.GetID()))
+
+	snapshot, err := es.NewSnapshotFromAggregate(aggregate)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewSnapshotFromAggregate")
+	}
+
+	ops := options.Replace().SetUpsert(true)
+	if _, err := m.collection.ReplaceOne(ctx, bson.M{"_id": snapshot.ID}, snapshot, ops); err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "collection.ReplaceOne")
+	}
+
+	m.log.Debugf("(SaveSnapshot) AggregateID: {%s}, version: {%d}", snapshot.ID, snapshot.Version)
+	return nil
+}
+
+func (m *mongoSnapshotStore) GetSnapshot(ctx context.Context, id string) (*es.Snapshot, error) {
+	ctx, span := tracing.StartSpan(ctx, "mongoSnapshotStore.GetSnapshot")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", id))
+
+	var snapshot es.Snapshot
+	if err := m.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&snapshot); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.Wrapf(es.ErrSnapshotNotFound, "id: {%s}", id)
+		}
+		tracing.TraceErr(span, err)
+		return nil, errors.Wrap(err, "collection.FindOne")
+	}
+
+	return &snapshot, nil
+}
@@ -0,0 +1,71 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestMongoSnapshotStoreRoundTrip(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("save and get snapshot", func(mt *mtest.T) {
+		ctx := context.Background()
+		store := NewMongoSnapshotStore(newTestLogger(), mt.Coll)
+
+		counter := newCounterAggregate("counter-1")
+		if err := counter.increment(3); err != nil {
+			mt.Fatalf("increment() err: %v", err)
+		}
+		counter.ClearUncommittedEvents()
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}))
+		if err := store.SaveSnapshot(ctx, counter); err != nil {
+			mt.Fatalf("SaveSnapshot() err: %v", err)
+		}
+
+		// the replacement upserted by the id is returned by the next find
+		update := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		if id := update.Lookup("q", "_id").StringValue(); id != counter.GetID() {
+			mt.Errorf("replaced snapshot id = %s, want %s", id, counter.GetID())
+		}
+		if !update.Lookup("upsert").Boolean() {
+			mt.Errorf("snapshot replaced without upsert")
+		}
+		var saved bson.D
+		if err := bson.Unmarshal(update.Lookup("u").Document(), &saved); err != nil {
+			mt.Fatalf("bson.Unmarshal() err: %v", err)
+		}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.snapshots", mtest.FirstBatch, saved))
+
+		snapshot, err := store.GetSnapshot(ctx, counter.GetID())
+		if err != nil {
+			mt.Fatalf("GetSnapshot() err: %v", err)
+		}
+		if snapshot.ID != counter.GetID() || snapshot.Type != counter.GetType() || snapshot.Version != 2 {
+			mt.Errorf("GetSnapshot() = id: %s, type: %s, version: %d, want %s, %s, 2", snapshot.ID, snapshot.Type, snapshot.Version, counter.GetID(), counter.GetType())
+		}
+
+		restored := newCounterAggregate("counter-1")
+		if err := es.RestoreAggregateFromSnapshot(restored, snapshot); err != nil {
+			mt.Fatalf("RestoreAggregateFromSnapshot() err: %v", err)
+		}
+		if restored.GetVersion() != 2 || restored.Events != 3 {
+			mt.Errorf("restored version = %d, events = %d, want 2, 3", restored.GetVersion(), restored.Events)
+		}
+	})
+
+	mt.Run("snapshot not found", func(mt *mtest.T) {
+		store := NewMongoSnapshotStore(newTestLogger(), mt.Coll)
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.snapshots", mtest.FirstBatch))
+
+		if _, err := store.GetSnapshot(context.Background(), "counter-counter-1"); !errors.Is(err, es.ErrSnapshotNotFound) {
+			mt.Errorf("GetSnapshot() err = %v, want %v", err, es.ErrSnapshotNotFound)
+		}
+	})
+}
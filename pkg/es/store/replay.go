@@ -0,0 +1,103 @@
+package store
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/pkg/errors"
+)
+
+const (
+	replayBatchSize = 500
+)
+
+// ReplayResult count of the applied events and $all position of the last applied event.
+type ReplayResult struct {
+	Events   uint64
+	Position esdb.Position
+}
+
+// Replay reads $all forwards from the given position until the end and applies events of the streams
+// with given prefixes to the projection, used to rebuild read models from scratch.
+// Event at the from position itself is not applied, it is already processed by the previous Replay.
+func Replay(ctx context.Context, db *esdb.Client, projection es.Projection, from esdb.AllPosition, prefixes []string) (*ReplayResult, error) {
+	ctx, span := tracing.StartSpan(ctx, "store.Replay")
+	defer span.End()
+	span.SetAttributes(tracing.Object("Prefixes", prefixes))
+
+	r := &replayer{db: db, projection: projection, prefixes: prefixes, result: &ReplayResult{}}
+	if position, ok := from.(esdb.Position); ok {
+		r.result.Position = position
+		r.lastPosition = &position
+	}
+
+	for {
+		read, err := r.replayBatch(ctx, from)
+		if err != nil {
+			tracing.TraceErr(span, err)
+			return nil, err
+		}
+		if read < replayBatchSize || r.lastPosition == nil {
+			return r.result, nil
+		}
+		from = *r.lastPosition
+	}
+}
+
+type replayer struct {
+	db           *esdb.Client
+	projection   es.Projection
+	prefixes     []string
+	result       *ReplayResult
+	lastPosition *esdb.Position
+}
+
+func (r *replayer) replayBatch(ctx context.Context, from esdb.AllPosition) (int, error) {
+	stream, err := r.db.ReadAll(ctx, esdb.ReadAllOptions{Direction: esdb.Forwards, From: from}, replayBatchSize)
+	if err != nil {
+		return 0, errors.Wrap(err, "db.ReadAll")
+	}
+	defer stream.Close()
+
+	read := 0
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return read, nil
+		}
+		if err != nil {
+			return read, errors.Wrap(err, "stream.Recv")
+		}
+		read++
+
+		recordedEvent := event.OriginalEvent()
+		if recordedEvent == nil || (r.lastPosition != nil && recordedEvent.Position == *r.lastPosition) {
+			continue
+		}
+		position := recordedEvent.Position
+		r.lastPosition = &position
+
+		if !hasStreamPrefix(recordedEvent.StreamID, r.prefixes) {
+			continue
+		}
+		if err := r.projection.When(ctx, es.NewEventFromRecorded(recordedEvent)); err != nil {
+			return read, errors.Wrapf(err, "projection.When StreamID: {%s}, EventNumber: {%d}", recordedEvent.StreamID, recordedEvent.EventNumber)
+		}
+
+		r.result.Events++
+		r.result.Position = position
+	}
+}
+
+func hasStreamPrefix(streamID string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(streamID, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	snapshotStreamPrefix   = "snapshot"
+	snapshotEventType      = "SNAPSHOT"
+	snapshotStreamMaxCount = 1
+)
+
+type snapshotStore struct {
+	log logger.Logger
+	db  *esdb.Client
+}
+
+// NewSnapshotStore EventStoreDB snapshot store, every aggregate has own snapshot-<aggregateID> stream
+// which keeps only the latest snapshot.
+func NewSnapshotStore(log logger.Logger, db *esdb.Client) *snapshotStore {
+	return &snapshotStore{log: log, db: db}
+}
+
+func (s *snapshotStore) SaveSnapshot(ctx context.Context, aggregate es.Aggregate) error {
+	ctx, span := tracing.StartSpan(ctx, "snapshotStore.SaveSnapshot")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", aggregate.GetID()))
+
+	snapshot, err := es.NewSnapshotFromAggregate(aggregate)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "NewSnapshotFromAggregate")
+	}
+
+	snapshotBytes, err := json.Marshal(snapshot)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "json.Marshal")
+	}
+
+	eventData := esdb.EventData{EventType: snapshotEventType, ContentType: esdb.JsonContentType, Data: snapshotBytes}
+	writeResult, err := s.db.AppendToStream(ctx, getSnapshotStreamID(aggregate.GetID()), esdb.AppendToStreamOptions{}, eventData)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "db.AppendToStream")
+	}
+
+	// first snapshot created the stream, older snapshots are useless, so let eventstoredb scavenge them
+	if writeResult.NextExpectedVersion == 0 {
+		metadata := esdb.StreamMetadata{}
+		metadata.SetMaxCount(snapshotStreamMaxCount)
+		if _, err := s.db.SetStreamMetadata(ctx, getSnapshotStreamID(aggregate.GetID()), esdb.AppendToStreamOptions{}, metadata); err != nil {
+			s.log.Warnf("(SetStreamMetadata) AggregateID: {%s}, err: {%v}", aggregate.GetID(), err)
+		}
+	}
+
+	s.log.Debugf("(SaveSnapshot) AggregateID: {%s}, version: {%d}", snapshot.ID, snapshot.Version)
+	return nil
+}
+
+func (s *snapshotStore) GetSnapshot(ctx context.Context, id string) (*es.Snapshot, error) {
+	ctx, span := tracing.StartSpan(ctx, "snapshotStore.GetSnapshot")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", id))
+
+	readOps := esdb.ReadStreamOptions{Direction: esdb.Backwards, From: esdb.End{}}
+	stream, err := s.db.ReadStream(ctx, getSnapshotStreamID(id), readOps, 1)
+	if errors.Is(err, esdb.ErrStreamNotFound) {
+		return nil, errors.Wrapf(es.ErrSnapshotNotFound, "id: {%s}", id)
+	}
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return nil, errors.Wrap(err, "db.ReadStream")
+	}
+	defer stream.Close()
+
+	event, err := stream.Recv()
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return nil, errors.Wrap(err, "stream.Recv")
+	}
+
+	var snapshot es.Snapshot
+	if err := json.Unmarshal(event.Event.Data, &snapshot); err != nil {
+		tracing.TraceErr(span, err)
+		return nil, errors.Wrap(err, "json.Unmarshal")
+	}
+
+	return &snapshot, nil
+}
+
+func getSnapshotStreamID(aggregateID string) string {
+	return fmt.Sprintf("%s-%s", snapshotStreamPrefix, aggregateID)
+}
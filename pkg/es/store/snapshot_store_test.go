@@ -0,0 +1,293 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	api "github.com/EventStore/EventStore-Client-Go/protos/streams"
+	"github.com/pkg/errors"
+)
+
+// eventStoreServer fake eventstoredb streams service which keeps the appended events in memory,
+// appends check the expected revision and reads support the stream options used by the stores.
+type eventStoreServer struct {
+	api.UnimplementedStreamsServer
+
+	mu      sync.Mutex
+	streams map[string][]*api.ReadResp_ReadEvent_RecordedEvent
+	// reads of the streams with the revision they started from
+	reads []string
+}
+
+func newEventStoreServer() *eventStoreServer {
+	return &eventStoreServer{streams: make(map[string][]*api.ReadResp_ReadEvent_RecordedEvent)}
+}
+
+func (s *eventStoreServer) Append(stream api.Streams_AppendServer) error {
+	var options *api.AppendReq_Options
+	var messages []*api.AppendReq_ProposedMessage
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if req.GetOptions() != nil {
+			options = req.GetOptions()
+			continue
+		}
+		messages = append(messages, req.GetProposedMessage())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	streamID := options.GetStreamIdentifier()
+	events, exists := s.streams[string(streamID.GetStreamName())]
+	currentRevision := uint64(len(events)) - 1
+
+	wrongExpectedVersion := false
+	switch revision := options.GetExpectedStreamRevision().(type) {
+	case *api.AppendReq_Options_NoStream:
+		wrongExpectedVersion = exists
+	case *api.AppendReq_Options_StreamExists:
+		wrongExpectedVersion = !exists
+	case *api.AppendReq_Options_Revision:
+		wrongExpectedVersion = !exists || currentRevision != revision.Revision
+	}
+	if wrongExpectedVersion {
+		return stream.SendAndClose(&api.AppendResp{Result: &api.AppendResp_WrongExpectedVersion_{
+			WrongExpectedVersion: &api.AppendResp_WrongExpectedVersion{},
+		}})
+	}
+
+	for _, message := range messages {
+		metadata := map[string]string{"created": strconv.FormatInt(time.Now().UnixNano()/100, 10)}
+		for key, value := range message.GetMetadata() {
+			metadata[key] = value
+		}
+		events = append(events, &api.ReadResp_ReadEvent_RecordedEvent{
+			Id:               message.GetId(),
+			StreamIdentifier: streamID,
+			StreamRevision:   uint64(len(events)),
+			Metadata:         metadata,
+			CustomMetadata:   message.GetCustomMetadata(),
+			Data:             message.GetData(),
+		})
+	}
+	s.streams[string(streamID.GetStreamName())] = events
+
+	return stream.SendAndClose(&api.AppendResp{Result: &api.AppendResp_Success_{Success: &api.AppendResp_Success{
+		CurrentRevisionOption: &api.AppendResp_Success_CurrentRevision{CurrentRevision: uint64(len(events)) - 1},
+		PositionOption:        &api.AppendResp_Success_NoPosition{},
+	}}})
+}
+
+func (s *eventStoreServer) Read(req *api.ReadReq, stream api.Streams_ReadServer) error {
+	options := req.GetOptions()
+	streamOptions := options.GetStream()
+	streamName := string(streamOptions.GetStreamIdentifier().GetStreamName())
+
+	s.mu.Lock()
+	events, exists := s.streams[streamName]
+	events = append([]*api.ReadResp_ReadEvent_RecordedEvent(nil), events...)
+	s.mu.Unlock()
+
+	if !exists {
+		return stream.Send(&api.ReadResp{Content: &api.ReadResp_StreamNotFound_{StreamNotFound: &api.ReadResp_StreamNotFound{
+			StreamIdentifier: streamOptions.GetStreamIdentifier(),
+		}}})
+	}
+
+	backwards := options.GetReadDirection() == api.ReadReq_Options_Backwards
+	from := 0
+	switch revision := streamOptions.GetRevisionOption().(type) {
+	case *api.ReadReq_Options_StreamOptions_Revision:
+		from = int(revision.Revision)
+	case *api.ReadReq_Options_StreamOptions_End:
+		if backwards {
+			from = len(events) - 1
+		} else {
+			from = len(events)
+		}
+	}
+
+	s.mu.Lock()
+	s.reads = append(s.reads, streamName+"@"+strconv.Itoa(from))
+	s.mu.Unlock()
+
+	count := options.GetCount()
+	for i := from; i >= 0 && i < len(events) && count > 0; count-- {
+		if err := stream.Send(&api.ReadResp{Content: &api.ReadResp_Event{Event: &api.ReadResp_ReadEvent{
+			Event:    events[i],
+			Position: &api.ReadResp_ReadEvent_NoPosition{},
+		}}}); err != nil {
+			return err
+		}
+		if backwards {
+			i--
+		} else {
+			i++
+		}
+	}
+	return nil
+}
+
+// getStream returns the events appended to the stream.
+func (s *eventStoreServer) getStream(streamID string) []*api.ReadResp_ReadEvent_RecordedEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*api.ReadResp_ReadEvent_RecordedEvent(nil), s.streams[streamID]...)
+}
+
+func TestSnapshotStoreRoundTrip(t *testing.T) {
+	tests := []struct {
+		name       string
+		increments []int
+		// version and events of the restored snapshot
+		version int64
+		events  int
+		// snapshots appended to the snapshot stream, metadata is set only once when the stream is created
+		snapshots int
+	}{
+		{name: "first snapshot", increments: []int{3}, version: 2, events: 3, snapshots: 1},
+		{name: "latest snapshot", increments: []int{3, 2}, version: 4, events: 5, snapshots: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			server := newEventStoreServer()
+			store := NewSnapshotStore(newTestLogger(), newTestClient(t, server))
+
+			if _, err := store.GetSnapshot(ctx, "counter-counter-1"); !errors.Is(err, es.ErrSnapshotNotFound) {
+				t.Fatalf("GetSnapshot() err = %v, want %v", err, es.ErrSnapshotNotFound)
+			}
+
+			counter := newCounterAggregate("counter-1")
+			for _, increments := range tt.increments {
+				if err := counter.increment(increments); err != nil {
+					t.Fatalf("increment() err: %v", err)
+				}
+				counter.ClearUncommittedEvents()
+				if err := store.SaveSnapshot(ctx, counter); err != nil {
+					t.Fatalf("SaveSnapshot() err: %v", err)
+				}
+			}
+
+			snapshot, err := store.GetSnapshot(ctx, counter.GetID())
+			if err != nil {
+				t.Fatalf("GetSnapshot() err: %v", err)
+			}
+			if snapshot.ID != counter.GetID() || snapshot.Type != counter.GetType() || int64(snapshot.Version) != tt.version {
+				t.Errorf("GetSnapshot() = id: %s, type: %s, version: %d, want %s, %s, %d", snapshot.ID, snapshot.Type, snapshot.Version, counter.GetID(), counter.GetType(), tt.version)
+			}
+
+			restored := newCounterAggregate("counter-1")
+			if err := es.RestoreAggregateFromSnapshot(restored, snapshot); err != nil {
+				t.Fatalf("RestoreAggregateFromSnapshot() err: %v", err)
+			}
+			if restored.GetVersion() != tt.version || restored.Events != tt.events {
+				t.Errorf("restored version = %d, events = %d, want %d, %d", restored.GetVersion(), restored.Events, tt.version, tt.events)
+			}
+
+			if snapshots := server.getStream(getSnapshotStreamID(counter.GetID())); len(snapshots) != tt.snapshots {
+				t.Errorf("snapshot stream length = %d, want %d", len(snapshots), tt.snapshots)
+			}
+			metadataStream := server.getStream("$$" + getSnapshotStreamID(counter.GetID()))
+			if len(metadataStream) != 1 {
+				t.Fatalf("snapshot stream metadata length = %d, want 1", len(metadataStream))
+			}
+			var metadata map[string]interface{}
+			if err := json.Unmarshal(metadataStream[0].GetData(), &metadata); err != nil {
+				t.Fatalf("json.Unmarshal() err: %v", err)
+			}
+			if metadata["$maxCount"] != float64(snapshotStreamMaxCount) {
+				t.Errorf("snapshot stream metadata = %v, want $maxCount %d", metadata, snapshotStreamMaxCount)
+			}
+		})
+	}
+}
+
+func TestAggregateStoreLoadAfterSnapshot(t *testing.T) {
+	tests := []struct {
+		name              string
+		snapshotFrequency int64
+		increments        []int
+		version           int64
+		snapshotVersion   int64
+		// loaded events replayed from the aggregate stream after the snapshot
+		loaded int
+		read   string
+	}{
+		{name: "snapshots disabled", increments: []int{3, 2}, version: 4, snapshotVersion: -1, loaded: 5, read: "counter-counter-1@0"},
+		{name: "events after snapshot", snapshotFrequency: 3, increments: []int{3, 2}, version: 4, snapshotVersion: 2, loaded: 2, read: "counter-counter-1@3"},
+		{name: "latest snapshot", snapshotFrequency: 2, increments: []int{3, 2}, version: 4, snapshotVersion: 4, loaded: 0, read: "counter-counter-1@5"},
+		{name: "no snapshot yet", snapshotFrequency: 10, increments: []int{3, 2}, version: 4, snapshotVersion: -1, loaded: 5, read: "counter-counter-1@0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			server := newEventStoreServer()
+			db := newTestClient(t, server)
+			snapshotStore := NewSnapshotStore(newTestLogger(), db)
+			store := NewAggregateStore(newTestLogger(), es.Config{SnapshotFrequency: tt.snapshotFrequency}, db, snapshotStore, nil)
+
+			for i, increments := range tt.increments {
+				counter := newCounterAggregate("counter-1")
+				if i > 0 {
+					if err := store.Load(ctx, counter); err != nil {
+						t.Fatalf("Load() err: %v", err)
+					}
+				}
+				if err := counter.increment(increments); err != nil {
+					t.Fatalf("increment() err: %v", err)
+				}
+				if err := store.Save(ctx, counter); err != nil {
+					t.Fatalf("Save() err: %v", err)
+				}
+			}
+
+			snapshot, err := snapshotStore.GetSnapshot(ctx, "counter-counter-1")
+			if tt.snapshotVersion < 0 && !errors.Is(err, es.ErrSnapshotNotFound) {
+				t.Errorf("GetSnapshot() = %+v, err: %v, want none", snapshot, err)
+			}
+			if tt.snapshotVersion >= 0 && (err != nil || int64(snapshot.Version) != tt.snapshotVersion) {
+				t.Errorf("GetSnapshot() = %+v, err: %v, want version %d", snapshot, err, tt.snapshotVersion)
+			}
+
+			server.mu.Lock()
+			server.reads = nil
+			server.mu.Unlock()
+
+			loaded := newCounterAggregate("counter-1")
+			if err := store.Load(ctx, loaded); err != nil {
+				t.Fatalf("Load() err: %v", err)
+			}
+			if loaded.GetVersion() != tt.version || loaded.Events != int(tt.version)+1 {
+				t.Errorf("Load() version = %d, events = %d, want version %d", loaded.GetVersion(), loaded.Events, tt.version)
+			}
+			if len(loaded.Loaded) != tt.loaded {
+				t.Errorf("Load() applied %d events, want %d", len(loaded.Loaded), tt.loaded)
+			}
+			if loaded.GetLoadedVersion() != tt.version {
+				t.Errorf("GetLoadedVersion() = %d, want %d", loaded.GetLoadedVersion(), tt.version)
+			}
+
+			server.mu.Lock()
+			defer server.mu.Unlock()
+			if len(server.reads) == 0 || server.reads[len(server.reads)-1] != tt.read {
+				t.Errorf("reads = %v, want aggregate stream read from %s", server.reads, tt.read)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package store
+
+import (
+	"context"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type streamSubscriber struct {
+	log logger.Logger
+	db  *esdb.Client
+}
+
+// NewStreamSubscriber EventStoreDB catch-up subscriptions to the single stream.
+func NewStreamSubscriber(log logger.Logger, db *esdb.Client) *streamSubscriber {
+	return &streamSubscriber{log: log, db: db}
+}
+
+func (s *streamSubscriber) SubscribeToStream(ctx context.Context, streamID string, afterVersion int64, handler func(ctx context.Context, event es.Event) error) error {
+	ctx, span := tracing.StartSpan(ctx, "streamSubscriber.SubscribeToStream")
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", streamID), attribute.Int64("AfterVersion", afterVersion))
+
+	// catch-up subscription position is exclusive, so it starts right after the afterVersion revision
+	var from esdb.StreamPosition = esdb.Start{}
+	if afterVersion >= 0 {
+		from = esdb.Revision(uint64(afterVersion))
+	}
+
+	subscription, err := s.db.SubscribeToStream(ctx, streamID, esdb.SubscribeToStreamOptions{From: from})
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "db.SubscribeToStream")
+	}
+	defer subscription.Close()
+
+	for {
+		event := subscription.Recv()
+		if ctx.Err() != nil {
+			s.log.Debugf("(SubscribeToStream) stream: {%s} subscription stopped", streamID)
+			return nil
+		}
+
+		if event.SubscriptionDropped != nil {
+			tracing.TraceErr(span, event.SubscriptionDropped.Error)
+			return errors.Wrap(event.SubscriptionDropped.Error, "Subscription Dropped")
+		}
+
+		if event.EventAppeared != nil {
+			if err := handler(ctx, es.NewEventFromRecorded(event.EventAppeared.Event)); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package es
+
+import (
+	"context"
+)
+
+const (
+	// SubjectMetadata key of the authenticated caller subject in the events metadata.
+	SubjectMetadata = "subject"
+)
+
+type subjectCtx struct{}
+
+// WithSubject returns ctx carrying the authenticated caller subject,
+// AggregateStore Save writes it to the metadata of the saved events.
+func WithSubject(ctx context.Context, subject string) context.Context {
+	return context.WithValue(ctx, subjectCtx{}, subject)
+}
+
+// GetSubject returns authenticated caller subject carried by ctx or empty string.
+func GetSubject(ctx context.Context) string {
+	subject, _ := ctx.Value(subjectCtx{}).(string)
+	return subject
+}
+
+// SetSubjectMetadata adds authenticated caller subject carried by ctx to the Event metadata.
+func SetSubjectMetadata(ctx context.Context, event *Event) error {
+	subject := GetSubject(ctx)
+	if subject == "" {
+		return nil
+	}
+	return event.SetMetadataValue(SubjectMetadata, subject)
+}
@@ -0,0 +1,23 @@
+package subscription
+
+import (
+	"time"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+)
+
+// Config of the projection persistent subscription to $all.
+type Config struct {
+	// Name of the projection used in logs and traces, like (MongoDB Projection).
+	Name string
+	// GroupName persistent subscription group name.
+	GroupName string
+	// Prefixes filter of the subscribed streams.
+	Prefixes []string
+	// PoolSize count of the workers receiving events from the subscription.
+	PoolSize int
+	// ReconnectDelay delay before reconnecting to the dropped subscription.
+	ReconnectDelay time.Duration
+	// ProcessingRetry retry policy of the failed events before parking them to the dead letters.
+	ProcessingRetry es.RetryPolicy
+}
@@ -0,0 +1,98 @@
+package subscription
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/pkg/errors"
+)
+
+const (
+	groupFilterStreamPrefix   = "subscription-filter"
+	groupFilterEventType      = "SUBSCRIPTION_FILTER"
+	groupFilterStreamMaxCount = 1
+)
+
+var (
+	ErrGroupFilterChanged = errors.New("subscription group filter is changed, rebuild the projection to apply it")
+)
+
+// groupFilter streams prefixes of the persistent subscription group, the EventStoreDB client can't read the filter
+// of the existing group, so it is saved to the subscription-filter-<group> stream when the group is created.
+type groupFilter struct {
+	GroupName string    `json:"groupName"`
+	Prefixes  []string  `json:"prefixes"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// SaveGroupFilter saves the streams prefixes of the created persistent subscription group.
+func SaveGroupFilter(ctx context.Context, db *esdb.Client, groupName string, prefixes []string) error {
+	filterBytes, err := json.Marshal(groupFilter{GroupName: groupName, Prefixes: prefixes, Timestamp: time.Now().UTC()})
+	if err != nil {
+		return errors.Wrap(err, "json.Marshal")
+	}
+
+	eventData := esdb.EventData{EventType: groupFilterEventType, ContentType: esdb.JsonContentType, Data: filterBytes}
+	writeResult, err := db.AppendToStream(ctx, getGroupFilterStreamID(groupName), esdb.AppendToStreamOptions{}, eventData)
+	if err != nil {
+		return errors.Wrap(err, "db.AppendToStream")
+	}
+
+	if writeResult.NextExpectedVersion == 0 {
+		metadata := esdb.StreamMetadata{}
+		metadata.SetMaxCount(groupFilterStreamMaxCount)
+		if _, err := db.SetStreamMetadata(ctx, getGroupFilterStreamID(groupName), esdb.AppendToStreamOptions{}, metadata); err != nil {
+			return errors.Wrap(err, "db.SetStreamMetadata")
+		}
+	}
+	return nil
+}
+
+// getGroupFilter returns saved streams prefixes of the group, nil if the group was created before they were saved.
+func getGroupFilter(ctx context.Context, db *esdb.Client, groupName string) (*groupFilter, error) {
+	readOps := esdb.ReadStreamOptions{Direction: esdb.Backwards, From: esdb.End{}}
+	stream, err := db.ReadStream(ctx, getGroupFilterStreamID(groupName), readOps, 1)
+	if err != nil {
+		return nil, errors.Wrap(err, "db.ReadStream")
+	}
+	defer stream.Close()
+
+	event, err := stream.Recv()
+	if errors.Is(err, esdb.ErrStreamNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "stream.Recv")
+	}
+
+	var filter groupFilter
+	if err := json.Unmarshal(event.Event.Data, &filter); err != nil {
+		return nil, errors.Wrap(err, "json.Unmarshal")
+	}
+	return &filter, nil
+}
+
+// samePrefixes reports whether the filters subscribe to the same streams regardless of the prefixes order.
+func samePrefixes(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := append([]string(nil), a...)
+	sortedB := append([]string(nil), b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func getGroupFilterStreamID(groupName string) string {
+	return fmt.Sprintf("%s-%s", groupFilterStreamPrefix, groupName)
+}
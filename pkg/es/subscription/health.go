@@ -0,0 +1,42 @@
+package subscription
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrSubscriptionDisconnected = errors.New("subscription is disconnected")
+)
+
+// HealthConfig of the projections subscriptions health checks.
+type HealthConfig struct {
+	// ReadinessTimeout readiness fails if the subscription is disconnected longer, zero fails it as soon as it is disconnected.
+	ReadinessTimeout time.Duration `mapstructure:"readinessTimeout"`
+	// LivenessTimeout liveness fails if the subscription is disconnected longer, not checked if zero.
+	LivenessTimeout time.Duration `mapstructure:"livenessTimeout"`
+}
+
+// Health of the runner persistent subscription.
+type Health struct {
+	Name      string `json:"name"`
+	GroupName string `json:"groupName"`
+	Connected bool   `json:"connected"`
+	// Since time of the last connection or disconnection, the runner is disconnected since the start until it is connected.
+	Since time.Time `json:"since"`
+	// LastEventAt time when the last event was processed, zero if no event is processed since the start.
+	LastEventAt time.Time `json:"lastEventAt"`
+	Reconnects  int       `json:"reconnects"`
+	// LastError error of the last failed connection or dropped subscription.
+	LastError   string    `json:"lastError,omitempty"`
+	LastErrorAt time.Time `json:"lastErrorAt"`
+}
+
+// Check returns ErrSubscriptionDisconnected if the subscription is disconnected longer than timeout.
+func (h Health) Check(timeout time.Duration) error {
+	if h.Connected || time.Since(h.Since) <= timeout {
+		return nil
+	}
+	return errors.Wrapf(ErrSubscriptionDisconnected, "groupName: {%s}, since: {%s}, lastError: {%s}", h.GroupName, h.Since.Format(time.RFC3339), h.LastError)
+}
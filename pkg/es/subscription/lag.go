@@ -0,0 +1,227 @@
+package subscription
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/pkg/errors"
+)
+
+const (
+	// lagCheckpointPrefix prefix of the projections positions checkpoints names.
+	lagCheckpointPrefix = "projection"
+)
+
+var (
+	ErrProjectionLagging = errors.New("projection lag exceeds the threshold")
+)
+
+// LagConfig of the projections lag tracking.
+type LagConfig struct {
+	// Interval between the lag checks, the lag is not tracked if it is zero.
+	Interval time.Duration `mapstructure:"interval"`
+	// ScanLimit max number of $all events read back from the head by one check, bigger lag is reported as truncated.
+	ScanLimit uint64 `mapstructure:"scanLimit" validate:"required_with=Interval"`
+	// MaxEvents readiness fails if any projection is behind by more events, not checked if zero.
+	MaxEvents uint64 `mapstructure:"maxEvents"`
+	// MaxDelay readiness fails if the oldest pending event of any projection is older, not checked if zero.
+	MaxDelay time.Duration `mapstructure:"maxDelay"`
+}
+
+// Lag of the projection behind the head of its streams.
+type Lag struct {
+	GroupName string `json:"groupName"`
+	// Known is false until the projection position is known, it is restored from the checkpoint or set by the first processed event.
+	Known          bool   `json:"known"`
+	CommitPosition uint64 `json:"commitPosition"`
+	HeadPosition   uint64 `json:"headPosition"`
+	// Events number of the events after the projection position, it is the lower bound if Truncated.
+	Events uint64 `json:"events"`
+	// Seconds age of the oldest event after the projection position.
+	Seconds   float64   `json:"seconds"`
+	Truncated bool      `json:"truncated,omitempty"`
+	CheckedAt time.Time `json:"checkedAt"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// LagMetricsCb called after each lag check of the projection with known position.
+type LagMetricsCb func(groupName string, events uint64, seconds float64)
+
+// LagMonitor tracks how far behind the head of $all the projections runners are.
+type LagMonitor interface {
+	// Run checks the lag every interval until ctx is done.
+	Run(ctx context.Context) error
+	// GetLags returns the last checked lag of every projection ordered by the group name.
+	GetLags() []Lag
+	// CheckLag returns ErrProjectionLagging if the last checked lag of any projection exceeds the thresholds.
+	CheckLag() error
+}
+
+type lagMonitor struct {
+	log         logger.Logger
+	cfg         LagConfig
+	heads       es.HeadReader
+	checkpoints es.CheckpointStore
+	runners     map[string]Runner
+	prefixes    []string
+	metricsCb   LagMetricsCb
+	mu          sync.RWMutex
+	lags        map[string]Lag
+	positions   map[string]uint64
+	saved       map[string]uint64
+}
+
+// NewLagMonitor creates lag monitor of the runners by the group names subscribed to the streams with given prefixes,
+// the runners positions are saved to the checkpoints, so they are known after the restart before the first processed event.
+func NewLagMonitor(
+	log logger.Logger,
+	cfg LagConfig,
+	heads es.HeadReader,
+	checkpoints es.CheckpointStore,
+	runners map[string]Runner,
+	prefixes []string,
+	metricsCb LagMetricsCb,
+) *lagMonitor {
+	return &lagMonitor{
+		log:         log,
+		cfg:         cfg,
+		heads:       heads,
+		checkpoints: checkpoints,
+		runners:     runners,
+		prefixes:    prefixes,
+		metricsCb:   metricsCb,
+		lags:        make(map[string]Lag, len(runners)),
+		positions:   make(map[string]uint64, len(runners)),
+		saved:       make(map[string]uint64, len(runners)),
+	}
+}
+
+func (m *lagMonitor) Run(ctx context.Context) error {
+	if m.cfg.Interval <= 0 {
+		m.log.Infof("(lag monitor disabled)")
+		return nil
+	}
+
+	m.restorePositions(ctx)
+
+	ticker := time.NewTicker(m.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		m.checkAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *lagMonitor) GetLags() []Lag {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	lags := make([]Lag, 0, len(m.lags))
+	for _, lag := range m.lags {
+		lags = append(lags, lag)
+	}
+	sort.Slice(lags, func(i, j int) bool { return lags[i].GroupName < lags[j].GroupName })
+	return lags
+}
+
+func (m *lagMonitor) CheckLag() error {
+	for _, lag := range m.GetLags() {
+		if !lag.Known {
+			continue
+		}
+		if m.cfg.MaxEvents > 0 && lag.Events > m.cfg.MaxEvents {
+			return errors.Wrapf(ErrProjectionLagging, "groupName: {%s}, events: {%d}", lag.GroupName, lag.Events)
+		}
+		if m.cfg.MaxDelay > 0 && lag.Seconds > m.cfg.MaxDelay.Seconds() {
+			return errors.Wrapf(ErrProjectionLagging, "groupName: {%s}, seconds: {%.1f}", lag.GroupName, lag.Seconds)
+		}
+	}
+	return nil
+}
+
+// restorePositions loads the positions saved before the restart.
+func (m *lagMonitor) restorePositions(ctx context.Context) {
+	for groupName := range m.runners {
+		checkpoint, err := m.checkpoints.GetCheckpoint(ctx, getLagCheckpointName(groupName))
+		if err != nil {
+			if !errors.Is(err, es.ErrCheckpointNotFound) {
+				m.log.Warnf("(GetCheckpoint) groupName: {%s}, err: {%v}", groupName, err)
+			}
+			continue
+		}
+		m.positions[groupName] = checkpoint.CommitPosition
+		m.saved[groupName] = checkpoint.CommitPosition
+	}
+}
+
+func (m *lagMonitor) checkAll(ctx context.Context) {
+	for groupName, runner := range m.runners {
+		lag := m.check(ctx, groupName, runner)
+
+		m.mu.Lock()
+		m.lags[groupName] = lag
+		m.mu.Unlock()
+
+		if lag.Known && lag.Error == "" && m.metricsCb != nil {
+			m.metricsCb(groupName, lag.Events, lag.Seconds)
+		}
+	}
+}
+
+func (m *lagMonitor) check(ctx context.Context, groupName string, runner Runner) Lag {
+	lag := Lag{GroupName: groupName, CheckedAt: time.Now().UTC()}
+
+	if position, ok := runner.GetPosition(); ok {
+		m.positions[groupName] = position
+	}
+	position, ok := m.positions[groupName]
+	if !ok {
+		return lag
+	}
+	lag.Known = true
+	lag.CommitPosition = position
+	m.savePosition(ctx, groupName, position)
+
+	head, err := m.heads.GetStreamsHead(ctx, m.prefixes, position, m.cfg.ScanLimit)
+	if err != nil {
+		m.log.Warnf("(GetStreamsHead) groupName: {%s}, err: {%v}", groupName, err)
+		lag.Error = err.Error()
+		return lag
+	}
+
+	lag.HeadPosition = position
+	if head.PendingEvents > 0 {
+		lag.HeadPosition = head.CommitPosition
+		lag.Events = head.PendingEvents
+		lag.Seconds = time.Since(head.OldestPending).Seconds()
+		lag.Truncated = head.Truncated
+	}
+	return lag
+}
+
+// savePosition saves the position if it is changed since the last save.
+func (m *lagMonitor) savePosition(ctx context.Context, groupName string, position uint64) {
+	if saved, ok := m.saved[groupName]; ok && saved == position {
+		return
+	}
+	// the runners track only the commit position, the checkpoint is not used to restart the subscription
+	if err := m.checkpoints.SaveCheckpoint(ctx, es.NewCheckpoint(getLagCheckpointName(groupName), position, 0)); err != nil {
+		m.log.Warnf("(SaveCheckpoint) groupName: {%s}, err: {%v}", groupName, err)
+		return
+	}
+	m.saved[groupName] = position
+}
+
+func getLagCheckpointName(groupName string) string {
+	return fmt.Sprintf("%s-%s", lagCheckpointPrefix, groupName)
+}
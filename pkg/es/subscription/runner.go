@@ -0,0 +1,377 @@
+package subscription
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/AleksK1NG/es-microservice/pkg/constants"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// subscriptionExistsCode esdb.PersistentSubscriptionError code returned when the group already exists.
+	subscriptionExistsCode = 6
+)
+
+// subscriptionStream persistent subscription connection shared by the workers.
+type subscriptionStream interface {
+	Recv() *esdb.SubscriptionEvent
+	Ack(messages ...*esdb.ResolvedEvent) error
+	Nack(reason string, action esdb.Nack_Action, messages ...*esdb.ResolvedEvent) error
+	Close() error
+}
+
+// MetricsCb called after each processed event, err is nil on success.
+type MetricsCb func(groupName string, err error)
+
+// Runner runs es.Projection on the persistent subscription to $all.
+type Runner interface {
+	Run(ctx context.Context) error
+	// GetPosition returns $all commit position which all events before are processed, false if no event is processed since the start.
+	GetPosition() (uint64, bool)
+	// GetHealth returns connection state of the subscription.
+	GetHealth() Health
+}
+
+type runner struct {
+	log         logger.Logger
+	cfg         Config
+	db          *esdb.Client
+	projection  es.Projection
+	deadLetters es.DeadLetterStore
+	metricsCb   MetricsCb
+	mu          sync.RWMutex
+	position    *uint64
+	inFlight    map[uint64]int
+	health      Health
+	connections int
+}
+
+// NewRunner creates projection subscription runner, deadLetters and metricsCb are optional,
+// without dead letters store failed events are only parked in the subscription.
+func NewRunner(
+	log logger.Logger,
+	cfg Config,
+	db *esdb.Client,
+	projection es.Projection,
+	deadLetters es.DeadLetterStore,
+	metricsCb MetricsCb,
+) *runner {
+	return &runner{
+		log:         log,
+		cfg:         cfg,
+		db:          db,
+		projection:  projection,
+		deadLetters: deadLetters,
+		metricsCb:   metricsCb,
+		inFlight:    make(map[uint64]int),
+		health:      Health{Name: cfg.Name, GroupName: cfg.GroupName, Since: time.Now().UTC()},
+	}
+}
+
+// Run creates subscription group if not exists and process events with the pool of workers until ctx is done,
+// subscription group is recreated by the projection rebuild, so dropped subscription is reconnected instead of stopping.
+func (r *runner) Run(ctx context.Context) error {
+	r.log.Infof("(starting subscription) name: {%s}, groupName: {%s}, prefixes: {%+v}", r.cfg.Name, r.cfg.GroupName, r.cfg.Prefixes)
+
+	if err := r.createGroup(ctx); err != nil {
+		return err
+	}
+
+	for {
+		err := r.connect(ctx)
+		if ctx.Err() != nil {
+			r.log.Infof("(subscription stopped) groupName: {%s}", r.cfg.GroupName)
+			return nil
+		}
+
+		r.setDisconnected(err)
+		r.log.Warnf("(connect) groupName: {%s}, reconnect after: {%s}, err: {%v}", r.cfg.GroupName, r.cfg.ReconnectDelay, err)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(r.cfg.ReconnectDelay):
+		}
+	}
+}
+
+// GetPosition returns the greatest processed position, but not after the lowest event in progress,
+// the workers process events concurrently and can finish them out of order, so the events before it can be still in progress.
+func (r *runner) GetPosition() (uint64, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.position == nil {
+		return 0, false
+	}
+
+	position := *r.position
+	for inFlight := range r.inFlight {
+		if inFlight > 0 && inFlight-1 < position {
+			position = inFlight - 1
+		}
+	}
+	return position, true
+}
+
+func (r *runner) GetHealth() Health {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.health
+}
+
+// createGroup creates subscription group if not exists, the filter of the existing group can't be updated,
+// so if the prefixes are changed, for example by the new tenant, the projection must be rebuilt to apply them.
+func (r *runner) createGroup(ctx context.Context) error {
+	err := r.db.CreatePersistentSubscriptionAll(ctx, r.cfg.GroupName, esdb.PersistentAllSubscriptionOptions{
+		Filter: &esdb.SubscriptionFilter{Type: esdb.StreamFilterType, Prefixes: r.cfg.Prefixes},
+	})
+	if err == nil {
+		return r.saveGroupFilter(ctx)
+	}
+
+	var subscriptionError *esdb.PersistentSubscriptionError
+	if errors.As(err, &subscriptionError) && subscriptionError.Code == subscriptionExistsCode {
+		return r.checkGroupFilter(ctx)
+	}
+
+	r.log.Errorf("(CreatePersistentSubscriptionAll) groupName: {%s}, err: {%v}", r.cfg.GroupName, err)
+	return errors.Wrap(err, "db.CreatePersistentSubscriptionAll")
+}
+
+// checkGroupFilter returns ErrGroupFilterChanged if the existing group was created with the other prefixes.
+func (r *runner) checkGroupFilter(ctx context.Context) error {
+	filter, err := getGroupFilter(ctx, r.db, r.cfg.GroupName)
+	if err != nil {
+		r.log.Errorf("(getGroupFilter) groupName: {%s}, err: {%v}", r.cfg.GroupName, err)
+		return errors.Wrap(err, "getGroupFilter")
+	}
+
+	// the groups created before the filters were saved are assumed to have the configured prefixes
+	if filter == nil {
+		r.log.Warnf("(checkGroupFilter) groupName: {%s} filter is not saved, saving prefixes: {%+v}", r.cfg.GroupName, r.cfg.Prefixes)
+		return r.saveGroupFilter(ctx)
+	}
+
+	if !samePrefixes(filter.Prefixes, r.cfg.Prefixes) {
+		r.log.Errorf("(checkGroupFilter) groupName: {%s}, prefixes: {%+v}, configured prefixes: {%+v}", r.cfg.GroupName, filter.Prefixes, r.cfg.Prefixes)
+		return errors.Wrapf(ErrGroupFilterChanged, "groupName: {%s}, prefixes: {%+v}, configured prefixes: {%+v}", r.cfg.GroupName, filter.Prefixes, r.cfg.Prefixes)
+	}
+	return nil
+}
+
+func (r *runner) saveGroupFilter(ctx context.Context) error {
+	if err := SaveGroupFilter(ctx, r.db, r.cfg.GroupName, r.cfg.Prefixes); err != nil {
+		r.log.Errorf("(SaveGroupFilter) groupName: {%s}, err: {%v}", r.cfg.GroupName, err)
+		return errors.Wrap(err, "SaveGroupFilter")
+	}
+	return nil
+}
+
+func (r *runner) connect(ctx context.Context) error {
+	stream, err := r.db.ConnectToPersistentSubscription(ctx, constants.EsAll, r.cfg.GroupName, esdb.ConnectToPersistentSubscriptionOptions{})
+	if err != nil {
+		return errors.Wrap(err, "db.ConnectToPersistentSubscription")
+	}
+	defer stream.Close()
+	r.setConnected()
+
+	return r.process(ctx, stream)
+}
+
+// process runs the pool of workers until ctx is done or the first worker fails,
+// then the stream is closed so the workers waiting for the next event return too.
+func (r *runner) process(ctx context.Context, stream subscriptionStream) error {
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		<-ctx.Done()
+		return stream.Close()
+	})
+	for i := 0; i < r.cfg.PoolSize; i++ {
+		workerID := i
+		g.Go(func() error {
+			return r.processEvents(ctx, stream, workerID)
+		})
+	}
+	return g.Wait()
+}
+
+func (r *runner) processEvents(ctx context.Context, stream subscriptionStream, workerID int) error {
+	for {
+		event := stream.Recv()
+		if ctx.Err() != nil {
+			if event.EventAppeared != nil {
+				r.release(stream, event.EventAppeared, ctx.Err())
+			}
+			return ctx.Err()
+		}
+
+		if event.SubscriptionDropped != nil {
+			r.log.Errorf("(SubscriptionDropped) groupName: {%s}, err: {%v}", r.cfg.GroupName, event.SubscriptionDropped.Error)
+			return errors.Wrap(event.SubscriptionDropped.Error, "Subscription Dropped")
+		}
+
+		if event.EventAppeared != nil {
+			if err := r.processEvent(ctx, stream, event.EventAppeared, workerID); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (r *runner) processEvent(ctx context.Context, stream subscriptionStream, event *esdb.ResolvedEvent, workerID int) error {
+	r.log.ProjectionEvent(r.cfg.Name, r.cfg.GroupName, event, workerID)
+	r.startEvent(event)
+	defer r.endEvent(event)
+
+	attempts, err := es.RetryWithBackoff(ctx, r.cfg.ProcessingRetry, func(ctx context.Context) error {
+		return r.when(ctx, es.NewEventFromRecorded(event.Event))
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			r.release(stream, event, ctx.Err())
+			return ctx.Err()
+		}
+		r.log.Errorf("(projection.When) groupName: {%s}, attempts: {%d}, err: {%v}", r.cfg.GroupName, attempts, err)
+		r.onProcessed(err)
+		return r.park(ctx, stream, event, err, attempts)
+	}
+
+	if err := stream.Ack(event); err != nil {
+		r.log.Errorf("(stream.Ack) err: {%v}", err)
+		return errors.Wrap(err, "stream.Ack")
+	}
+	r.onProcessed(nil)
+	r.setPosition(event)
+	r.log.Debugf("(ACK) groupName: {%s}, event commit: {%v}", r.cfg.GroupName, *event.Commit)
+	return nil
+}
+
+func (r *runner) when(ctx context.Context, event es.Event) error {
+	ctx, span := tracing.StartProjectionTracerSpan(ctx, "subscription.runner.When", event)
+	defer span.End()
+	span.SetAttributes(attribute.String("GroupName", r.cfg.GroupName), attribute.String("AggregateID", event.GetAggregateID()), attribute.String("EventType", event.GetEventType()))
+
+	if err := r.projection.When(ctx, event); err != nil {
+		tracing.TraceErr(span, err)
+		return err
+	}
+	return nil
+}
+
+// park saves the failed event to the dead letters and parks it in the subscription,
+// if dead letter is not saved event is left for the eventstoredb retry.
+func (r *runner) park(ctx context.Context, stream subscriptionStream, event *esdb.ResolvedEvent, processErr error, attempts int) error {
+	if r.deadLetters != nil {
+		deadLetter := es.NewDeadLetter(r.cfg.GroupName, event.Event, processErr, attempts)
+		if err := r.deadLetters.SaveDeadLetter(ctx, deadLetter); err != nil {
+			r.log.Errorf("(SaveDeadLetter) err: {%v}", err)
+			return r.nack(stream, event, processErr, esdb.Nack_Retry)
+		}
+		r.log.Warnf("(PARK) groupName: {%s}, dead letter id: {%s}, event commit: {%v}", r.cfg.GroupName, deadLetter.ID, deadLetter.CommitPosition)
+	}
+
+	// parked event is processed, it is not redelivered
+	if err := r.nack(stream, event, processErr, esdb.Nack_Park); err != nil {
+		return err
+	}
+	r.setPosition(event)
+	return nil
+}
+
+func (r *runner) nack(stream subscriptionStream, event *esdb.ResolvedEvent, processErr error, action esdb.Nack_Action) error {
+	if err := stream.Nack(processErr.Error(), action, event); err != nil {
+		r.log.Errorf("(stream.Nack) err: {%v}", err)
+		return errors.Wrap(err, "stream.Nack")
+	}
+	return nil
+}
+
+// release returns the event not processed because of the shutdown to the subscription for the retry,
+// if the stream is already closed the event is redelivered after the message timeout of the group.
+func (r *runner) release(stream subscriptionStream, event *esdb.ResolvedEvent, err error) {
+	if err := stream.Nack(err.Error(), esdb.Nack_Retry, event); err != nil {
+		r.log.Warnf("(release) groupName: {%s}, event is redelivered after the message timeout, err: {%v}", r.cfg.GroupName, err)
+	}
+}
+
+func (r *runner) setConnected() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.connections > 0 {
+		r.health.Reconnects++
+	}
+	r.connections++
+	r.health.Connected = true
+	r.health.Since = time.Now().UTC()
+}
+
+func (r *runner) setDisconnected(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now().UTC()
+	if r.health.Connected {
+		r.health.Since = now
+	}
+	r.health.Connected = false
+	if err != nil {
+		r.health.LastError = err.Error()
+		r.health.LastErrorAt = now
+	}
+}
+
+// startEvent tracks the event in progress until it is acknowledged or parked.
+func (r *runner) startEvent(event *esdb.ResolvedEvent) {
+	recordedEvent := event.OriginalEvent()
+	if recordedEvent == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.inFlight[recordedEvent.Position.Commit]++
+}
+
+func (r *runner) endEvent(event *esdb.ResolvedEvent) {
+	recordedEvent := event.OriginalEvent()
+	if recordedEvent == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	commit := recordedEvent.Position.Commit
+	if r.inFlight[commit] <= 1 {
+		delete(r.inFlight, commit)
+		return
+	}
+	r.inFlight[commit]--
+}
+
+// setPosition keeps the greatest processed position, GetPosition limits it by the events in progress.
+func (r *runner) setPosition(event *esdb.ResolvedEvent) {
+	recordedEvent := event.OriginalEvent()
+	if recordedEvent == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.health.LastEventAt = time.Now().UTC()
+	if r.position == nil || recordedEvent.Position.Commit > *r.position {
+		position := recordedEvent.Position.Commit
+		r.position = &position
+	}
+}
+
+func (r *runner) onProcessed(err error) {
+	if r.metricsCb != nil {
+		r.metricsCb(r.cfg.GroupName, err)
+	}
+}
@@ -0,0 +1,293 @@
+package subscription
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/pkg/errors"
+)
+
+func newResolvedEvent(commit uint64) *esdb.ResolvedEvent {
+	return &esdb.ResolvedEvent{
+		Event: &esdb.RecordedEvent{
+			StreamID: fmt.Sprintf("stream-%d", commit),
+			Position: esdb.Position{Commit: commit, Prepare: commit},
+		},
+		Commit: &commit,
+	}
+}
+
+func TestRunnerGetPosition(t *testing.T) {
+	tests := []struct {
+		name      string
+		started   []uint64
+		processed []uint64
+		position  uint64
+		known     bool
+	}{
+		{name: "nothing processed", started: []uint64{10}, known: false},
+		{name: "processed in order", started: []uint64{10, 20, 30}, processed: []uint64{10, 20, 30}, position: 30, known: true},
+		{name: "later event processed first", started: []uint64{10, 20, 30}, processed: []uint64{30}, position: 9, known: true},
+		{name: "lowest event in progress", started: []uint64{10, 20, 30}, processed: []uint64{10, 30}, position: 19, known: true},
+		{name: "gap processed", started: []uint64{10, 20, 30}, processed: []uint64{30, 20, 10}, position: 30, known: true},
+		{name: "events in progress after processed", started: []uint64{10, 20, 30}, processed: []uint64{10}, position: 10, known: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRunner(nil, Config{GroupName: "test"}, nil, nil, nil, nil)
+			for _, commit := range tt.started {
+				r.startEvent(newResolvedEvent(commit))
+			}
+			for _, commit := range tt.processed {
+				event := newResolvedEvent(commit)
+				r.setPosition(event)
+				r.endEvent(event)
+			}
+
+			position, known := r.GetPosition()
+			if known != tt.known || position != tt.position {
+				t.Errorf("GetPosition() = %d, %v, want %d, %v", position, known, tt.position, tt.known)
+			}
+		})
+	}
+}
+
+func TestRunnerEndEventRedelivered(t *testing.T) {
+	r := NewRunner(nil, Config{GroupName: "test"}, nil, nil, nil, nil)
+	first, redelivered := newResolvedEvent(10), newResolvedEvent(10)
+
+	r.startEvent(first)
+	r.startEvent(redelivered)
+	r.setPosition(newResolvedEvent(20))
+	r.endEvent(first)
+
+	if position, _ := r.GetPosition(); position != 9 {
+		t.Errorf("GetPosition() = %d with redelivered event in progress, want 9", position)
+	}
+
+	r.endEvent(redelivered)
+	if position, _ := r.GetPosition(); position != 20 {
+		t.Errorf("GetPosition() = %d, want 20", position)
+	}
+}
+
+// fakeStream delivers the events of the channel to the workers and records the acknowledgements,
+// Recv is blocked until the next event or Close as of the esdb persistent subscription.
+type fakeStream struct {
+	events    chan *esdb.ResolvedEvent
+	closed    chan struct{}
+	closeOnce sync.Once
+	ackErrs   map[uint64]error
+	mu        sync.Mutex
+	delivered []uint64
+	acked     []uint64
+	nacked    map[esdb.Nack_Action][]uint64
+}
+
+func newFakeStream(ackErrs map[uint64]error, commits ...uint64) *fakeStream {
+	stream := &fakeStream{
+		events:  make(chan *esdb.ResolvedEvent, len(commits)),
+		closed:  make(chan struct{}),
+		ackErrs: ackErrs,
+		nacked:  make(map[esdb.Nack_Action][]uint64),
+	}
+	for _, commit := range commits {
+		stream.events <- newResolvedEvent(commit)
+	}
+	return stream
+}
+
+func (s *fakeStream) Recv() *esdb.SubscriptionEvent {
+	select {
+	case <-s.closed:
+		return &esdb.SubscriptionEvent{SubscriptionDropped: &esdb.SubscriptionDropped{Error: errors.New("subscription has been dropped")}}
+	case event := <-s.events:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.delivered = append(s.delivered, event.Event.Position.Commit)
+		return &esdb.SubscriptionEvent{EventAppeared: event}
+	}
+}
+
+func (s *fakeStream) Ack(messages ...*esdb.ResolvedEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, message := range messages {
+		commit := message.Event.Position.Commit
+		s.acked = append(s.acked, commit)
+		if err := s.ackErrs[commit]; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fakeStream) Nack(reason string, action esdb.Nack_Action, messages ...*esdb.ResolvedEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, message := range messages {
+		s.nacked[action] = append(s.nacked[action], message.Event.Position.Commit)
+	}
+	return nil
+}
+
+func (s *fakeStream) Close() error {
+	s.closeOnce.Do(func() { close(s.closed) })
+	return nil
+}
+
+func (s *fakeStream) isClosed() bool {
+	select {
+	case <-s.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// checkAllHandled fails the test if any delivered event is neither acknowledged nor nacked.
+func (s *fakeStream) checkAllHandled(t *testing.T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	handled := append([]uint64{}, s.acked...)
+	for _, commits := range s.nacked {
+		handled = append(handled, commits...)
+	}
+	delivered := append([]uint64{}, s.delivered...)
+	sort.Slice(handled, func(i, j int) bool { return handled[i] < handled[j] })
+	sort.Slice(delivered, func(i, j int) bool { return delivered[i] < delivered[j] })
+	if fmt.Sprint(handled) != fmt.Sprint(delivered) {
+		t.Errorf("handled events = %v, delivered events = %v", handled, delivered)
+	}
+}
+
+type projectionFunc func(ctx context.Context, event es.Event) error
+
+func (f projectionFunc) When(ctx context.Context, event es.Event) error {
+	return f(ctx, event)
+}
+
+func newTestLogger() logger.Logger {
+	appLogger := logger.NewAppLogger(&logger.Config{LogLevel: "error", Encoder: "console"})
+	appLogger.InitLogger()
+	return appLogger
+}
+
+// runProcess runs the runner workers on the stream and returns the error of process, fails the test if it is not returned in time.
+func runProcess(t *testing.T, ctx context.Context, r *runner, stream *fakeStream, stop func()) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- r.process(ctx, stream)
+	}()
+	if stop != nil {
+		stop()
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatalf("process() is not returned, the workers are blocked")
+		return nil
+	}
+}
+
+func TestRunnerProcessWorkerFails(t *testing.T) {
+	errAck := errors.New("ack failed")
+
+	tests := []struct {
+		name     string
+		poolSize int
+		commits  []uint64
+		ackErrs  map[uint64]error
+		parked   []uint64
+	}{
+		{name: "single worker", poolSize: 1, commits: []uint64{10, 20, 30}, ackErrs: map[uint64]error{20: errAck}},
+		{name: "other workers waiting for events", poolSize: 4, commits: []uint64{10}, ackErrs: map[uint64]error{10: errAck}},
+		{name: "other workers processing events", poolSize: 4, commits: []uint64{10, 20, 30, 40, 50, 60}, ackErrs: map[uint64]error{30: errAck}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := newFakeStream(tt.ackErrs, tt.commits...)
+			r := NewRunner(newTestLogger(), Config{GroupName: "test", PoolSize: tt.poolSize}, nil, projectionFunc(func(ctx context.Context, event es.Event) error {
+				return nil
+			}), nil, nil)
+
+			err := runProcess(t, context.Background(), r, stream, nil)
+			if !errors.Is(err, errAck) {
+				t.Fatalf("process() err = %v, want %v", err, errAck)
+			}
+			if !stream.isClosed() {
+				t.Errorf("stream is not closed after the worker failed")
+			}
+			stream.checkAllHandled(t)
+		})
+	}
+}
+
+func TestRunnerProcessShutdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		poolSize int
+		commits  []uint64
+		// blocked events wait in the projection until the shutdown
+		blocked []uint64
+	}{
+		{name: "waiting for events", poolSize: 2},
+		{name: "event in progress", poolSize: 2, commits: []uint64{10}, blocked: []uint64{10}},
+		{name: "events in progress and processed", poolSize: 3, commits: []uint64{10, 20, 30}, blocked: []uint64{10, 30}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			blocked := make(map[string]bool, len(tt.blocked))
+			for _, commit := range tt.blocked {
+				blocked[fmt.Sprintf("stream-%d", commit)] = true
+			}
+			var wg sync.WaitGroup
+			wg.Add(len(tt.commits))
+			stream := newFakeStream(nil, tt.commits...)
+			r := NewRunner(newTestLogger(), Config{GroupName: "test", PoolSize: tt.poolSize}, nil, projectionFunc(func(ctx context.Context, event es.Event) error {
+				wg.Done()
+				if blocked[event.GetAggregateID()] {
+					<-ctx.Done()
+					return ctx.Err()
+				}
+				return nil
+			}), nil, nil)
+
+			// shutdown after all events are delivered to the projection
+			err := runProcess(t, ctx, r, stream, func() {
+				wg.Wait()
+				cancel()
+			})
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("process() err = %v, want %v", err, context.Canceled)
+			}
+			if !stream.isClosed() {
+				t.Errorf("stream is not closed after the shutdown")
+			}
+			stream.checkAllHandled(t)
+
+			stream.mu.Lock()
+			retried := append([]uint64{}, stream.nacked[esdb.Nack_Retry]...)
+			stream.mu.Unlock()
+			sort.Slice(retried, func(i, j int) bool { return retried[i] < retried[j] })
+			if fmt.Sprint(retried) != fmt.Sprint(tt.blocked) {
+				t.Errorf("retried events = %v, want %v", retried, tt.blocked)
+			}
+		})
+	}
+}
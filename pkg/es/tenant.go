@@ -0,0 +1,82 @@
+package es
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// TenantIDMetadata key of the tenant id in the events metadata.
+	TenantIDMetadata = "tenant-id"
+)
+
+type tenantIDCtx struct{}
+
+// WithTenantID returns ctx carrying the tenant id of the request,
+// AggregateStore Save writes it to the metadata of the saved events.
+func WithTenantID(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantIDCtx{}, tenantID)
+}
+
+// GetTenantID returns tenant id carried by ctx or empty string for the default tenant.
+func GetTenantID(ctx context.Context) string {
+	tenantID, _ := ctx.Value(tenantIDCtx{}).(string)
+	return tenantID
+}
+
+// SetTenantIDMetadata adds tenant id carried by ctx to the Event metadata.
+func SetTenantIDMetadata(ctx context.Context, event *Event) error {
+	tenantID := GetTenantID(ctx)
+	if tenantID == "" {
+		return nil
+	}
+	return event.SetMetadataValue(TenantIDMetadata, tenantID)
+}
+
+// TenancyConfig tenants served by the service.
+type TenancyConfig struct {
+	// Required rejects the requests without tenant id, otherwise they are served as the default tenant with unprefixed streams.
+	Required bool `mapstructure:"required" json:"required"`
+	// Tenants ids of the known tenants, the requests of the other tenants are rejected.
+	// The projections subscriptions filters can't be updated, adding or removing the tenant requires the projections rebuild.
+	Tenants []string `mapstructure:"tenants" json:"tenants" validate:"dive,alphanum,max=32"`
+}
+
+// ValidateTenantID check the request tenant id is one of the known tenants,
+// empty tenant id is the default tenant if the tenant is not required.
+func (c TenancyConfig) ValidateTenantID(tenantID string) error {
+	if tenantID == "" {
+		if c.Required {
+			return ErrTenantRequired
+		}
+		return nil
+	}
+
+	for _, tenant := range c.Tenants {
+		if tenant == tenantID {
+			return nil
+		}
+	}
+	return errors.Wrapf(ErrUnknownTenant, "tenant: {%s}", tenantID)
+}
+
+// GetStreamPrefixes returns the streams prefixes of the default tenant and all known tenants.
+func (c TenancyConfig) GetStreamPrefixes(prefix string) []string {
+	prefixes := make([]string, 0, len(c.Tenants)+1)
+	prefixes = append(prefixes, prefix)
+	for _, tenant := range c.Tenants {
+		prefixes = append(prefixes, GetTenantStreamID(tenant, prefix))
+	}
+	return prefixes
+}
+
+// GetTenantStreamID returns id of the tenant stream, for example tenantA-order-<id>,
+// the default tenant streams are not prefixed.
+func GetTenantStreamID(tenantID string, streamID string) string {
+	if tenantID == "" {
+		return streamID
+	}
+	return fmt.Sprintf("%s-%s", tenantID, streamID)
+}
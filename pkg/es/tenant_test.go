@@ -0,0 +1,77 @@
+package es_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/pkg/errors"
+)
+
+func TestSetTenantIDMetadata(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		metadata []byte
+		// want metadata after stamping
+		want string
+	}{
+		{name: "default tenant", ctx: context.Background(), want: ""},
+		{name: "tenant", ctx: es.WithTenantID(context.Background(), "tenantA"), want: `{"tenant-id":"tenantA"}`},
+		{name: "keeps metadata", ctx: es.WithTenantID(context.Background(), "tenantA"), metadata: []byte(`{"subject":"customer-1"}`), want: `{"subject":"customer-1","tenant-id":"tenantA"}`},
+		{name: "overwrites tenant", ctx: es.WithTenantID(context.Background(), "tenantB"), metadata: []byte(`{"tenant-id":"tenantA"}`), want: `{"tenant-id":"tenantB"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := es.NewBaseEvent(newCounterAggregate("counter-1"), counterIncremented)
+			event.Metadata = tt.metadata
+
+			if err := es.SetTenantIDMetadata(tt.ctx, &event); err != nil {
+				t.Fatalf("SetTenantIDMetadata() err: %v", err)
+			}
+			if string(event.GetMetadata()) != tt.want {
+				t.Errorf("metadata = %s, want %s", event.GetMetadata(), tt.want)
+			}
+		})
+	}
+}
+
+func TestTenancyConfigValidateTenantID(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      es.TenancyConfig
+		tenantID string
+		err      error
+	}{
+		{name: "default tenant", cfg: es.TenancyConfig{Tenants: []string{"tenantA"}}},
+		{name: "known tenant", cfg: es.TenancyConfig{Tenants: []string{"tenantA", "tenantB"}}, tenantID: "tenantB"},
+		{name: "unknown tenant", cfg: es.TenancyConfig{Tenants: []string{"tenantA"}}, tenantID: "tenantC", err: es.ErrUnknownTenant},
+		{name: "no tenants", tenantID: "tenantA", err: es.ErrUnknownTenant},
+		{name: "tenant required", cfg: es.TenancyConfig{Required: true, Tenants: []string{"tenantA"}}, err: es.ErrTenantRequired},
+		{name: "required known tenant", cfg: es.TenancyConfig{Required: true, Tenants: []string{"tenantA"}}, tenantID: "tenantA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.ValidateTenantID(tt.tenantID)
+			if !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+				t.Errorf("ValidateTenantID() err = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestTenantStreams(t *testing.T) {
+	cfg := es.TenancyConfig{Tenants: []string{"tenantA", "tenantB"}}
+	if prefixes := cfg.GetStreamPrefixes("order-"); fmt.Sprint(prefixes) != "[order- tenantA-order- tenantB-order-]" {
+		t.Errorf("GetStreamPrefixes() = %v, want default and tenants order prefixes", prefixes)
+	}
+	if streamID := es.GetTenantStreamID("tenantA", "order-1"); streamID != "tenantA-order-1" {
+		t.Errorf("GetTenantStreamID() = %s, want tenantA-order-1", streamID)
+	}
+	if streamID := es.GetTenantStreamID("", "order-1"); streamID != "order-1" {
+		t.Errorf("GetTenantStreamID() = %s, want order-1", streamID)
+	}
+}
@@ -0,0 +1,65 @@
+package es
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// UpcastFunc transforms the Event of the old schema version to the next one, it must change EventType
+// to the next version type, like V1_ORDER_CREATED to V2_ORDER_CREATED.
+type UpcastFunc func(event Event) (Event, error)
+
+// Upcaster upgrades the events read from the store to the current schema before they are applied
+// to the Aggregate or Projection, so the event schemas can evolve without rewriting the streams.
+type Upcaster interface {
+	Upcast(event Event) (Event, error)
+}
+
+type upcasterRegistry struct {
+	upcasters map[string]UpcastFunc
+}
+
+// NewUpcasterRegistry creates Upcaster which chains registered UpcastFunc's by event types,
+// event without registered UpcastFunc is returned as is.
+func NewUpcasterRegistry() *upcasterRegistry {
+	return &upcasterRegistry{upcasters: make(map[string]UpcastFunc)}
+}
+
+// Register registers UpcastFunc for the old event type, panics if it is already registered.
+func (u *upcasterRegistry) Register(eventType string, upcast UpcastFunc) *upcasterRegistry {
+	if _, ok := u.upcasters[eventType]; ok {
+		panic(fmt.Sprintf("es: upcaster for event type %s is already registered", eventType))
+	}
+	u.upcasters[eventType] = upcast
+	return u
+}
+
+// Upcast runs UpcastFunc's one by one until there is no registered one for the event type.
+func (u *upcasterRegistry) Upcast(event Event) (Event, error) {
+	// every registered upcaster can run only once, otherwise event types make a cycle
+	for steps := 0; steps <= len(u.upcasters); steps++ {
+		upcast, ok := u.upcasters[event.GetEventType()]
+		if !ok {
+			return event, nil
+		}
+
+		upcasted, err := upcast(event)
+		if err != nil {
+			return Event{}, errors.Wrapf(err, "upcast eventType: {%s}", event.GetEventType())
+		}
+		if upcasted.GetEventType() == event.GetEventType() {
+			return Event{}, errors.Wrapf(ErrInvalidUpcaster, "eventType: {%s} is not changed", event.GetEventType())
+		}
+		event = upcasted
+	}
+	return Event{}, errors.Wrapf(ErrInvalidUpcaster, "eventType: {%s} upcasters cycle", event.GetEventType())
+}
+
+// UpcastEvent upcasts the event if upcaster is not nil.
+func UpcastEvent(upcaster Upcaster, event Event) (Event, error) {
+	if upcaster == nil {
+		return event, nil
+	}
+	return upcaster.Upcast(event)
+}
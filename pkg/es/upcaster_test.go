@@ -0,0 +1,224 @@
+package es_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/pkg/errors"
+)
+
+const (
+	counterIncrementedV1 = "V1_COUNTER_INCREMENTED"
+	counterIncrementedV2 = "V2_COUNTER_INCREMENTED"
+	counterIncrementedV3 = "V3_COUNTER_INCREMENTED"
+)
+
+type counterIncrementedEventV1 struct {
+	By float64 `json:"by"`
+}
+
+type counterIncrementedEventV2 struct {
+	By int64 `json:"by"`
+}
+
+type counterIncrementedEventV3 struct {
+	Amount int64  `json:"amount"`
+	Reason string `json:"reason"`
+}
+
+// newCounterUpcaster chains V1 float increment to V2 integer increment and V2 to V3 renamed field with the reason.
+func newCounterUpcaster() es.Upcaster {
+	return es.NewUpcasterRegistry().
+		Register(counterIncrementedV1, func(event es.Event) (es.Event, error) {
+			var eventV1 counterIncrementedEventV1
+			if err := event.GetJsonData(&eventV1); err != nil {
+				return es.Event{}, err
+			}
+			event.EventType = counterIncrementedV2
+			if err := event.SetJsonData(counterIncrementedEventV2{By: int64(eventV1.By)}); err != nil {
+				return es.Event{}, err
+			}
+			return event, nil
+		}).
+		Register(counterIncrementedV2, func(event es.Event) (es.Event, error) {
+			var eventV2 counterIncrementedEventV2
+			if err := event.GetJsonData(&eventV2); err != nil {
+				return es.Event{}, err
+			}
+			event.EventType = counterIncrementedV3
+			if err := event.SetJsonData(counterIncrementedEventV3{Amount: eventV2.By, Reason: "upcasted"}); err != nil {
+				return es.Event{}, err
+			}
+			return event, nil
+		})
+}
+
+// newStoredEvent returns the event read from the store with the json data.
+func newStoredEvent(t *testing.T, eventType string, data interface{}) es.Event {
+	dataBytes, err := json.Marshal(data)
+	if err != nil {
+		t.Fatalf("json.Marshal() err: %v", err)
+	}
+	return es.Event{EventID: "event-1", EventType: eventType, Data: dataBytes, AggregateID: "counter-1", Version: 3, Metadata: []byte(`{"tenantId":"tenantA"}`)}
+}
+
+func TestUpcasterRegistryUpcast(t *testing.T) {
+	errUpcast := errors.New("upcast failed")
+
+	tests := []struct {
+		name      string
+		eventType string
+		data      interface{}
+		upcaster  es.Upcaster
+		// eventType and data of the upcasted event
+		upcastedType string
+		upcasted     string
+		err          error
+	}{
+		{
+			name:         "chain from V1",
+			eventType:    counterIncrementedV1,
+			data:         counterIncrementedEventV1{By: 2},
+			upcaster:     newCounterUpcaster(),
+			upcastedType: counterIncrementedV3,
+			upcasted:     `{"amount":2,"reason":"upcasted"}`,
+		},
+		{
+			name:         "from V2",
+			eventType:    counterIncrementedV2,
+			data:         counterIncrementedEventV2{By: 5},
+			upcaster:     newCounterUpcaster(),
+			upcastedType: counterIncrementedV3,
+			upcasted:     `{"amount":5,"reason":"upcasted"}`,
+		},
+		{
+			name:         "current version",
+			eventType:    counterIncrementedV3,
+			data:         counterIncrementedEventV3{Amount: 1, Reason: "added"},
+			upcaster:     newCounterUpcaster(),
+			upcastedType: counterIncrementedV3,
+			upcasted:     `{"amount":1,"reason":"added"}`,
+		},
+		{
+			name:         "nil upcaster",
+			eventType:    counterIncrementedV1,
+			data:         counterIncrementedEventV1{By: 2},
+			upcastedType: counterIncrementedV1,
+			upcasted:     `{"by":2}`,
+		},
+		{
+			name:      "upcast failed",
+			eventType: counterIncrementedV1,
+			data:      counterIncrementedEventV1{By: 2},
+			upcaster: es.NewUpcasterRegistry().Register(counterIncrementedV1, func(event es.Event) (es.Event, error) {
+				return es.Event{}, errUpcast
+			}),
+			err: errUpcast,
+		},
+		{
+			name:      "event type not changed",
+			eventType: counterIncrementedV1,
+			data:      counterIncrementedEventV1{By: 2},
+			upcaster: es.NewUpcasterRegistry().Register(counterIncrementedV1, func(event es.Event) (es.Event, error) {
+				return event, nil
+			}),
+			err: es.ErrInvalidUpcaster,
+		},
+		{
+			name:      "upcasters cycle",
+			eventType: counterIncrementedV1,
+			data:      counterIncrementedEventV1{By: 2},
+			upcaster: es.NewUpcasterRegistry().
+				Register(counterIncrementedV1, func(event es.Event) (es.Event, error) {
+					event.EventType = counterIncrementedV2
+					return event, nil
+				}).
+				Register(counterIncrementedV2, func(event es.Event) (es.Event, error) {
+					event.EventType = counterIncrementedV1
+					return event, nil
+				}),
+			err: es.ErrInvalidUpcaster,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored := newStoredEvent(t, tt.eventType, tt.data)
+
+			upcasted, err := es.UpcastEvent(tt.upcaster, stored)
+			if !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+				t.Fatalf("UpcastEvent() err = %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				return
+			}
+
+			if upcasted.GetEventType() != tt.upcastedType || string(upcasted.GetData()) != tt.upcasted {
+				t.Errorf("UpcastEvent() = %s %s, want %s %s", upcasted.GetEventType(), upcasted.GetData(), tt.upcastedType, tt.upcasted)
+			}
+			if upcasted.GetEventID() != stored.GetEventID() || upcasted.GetAggregateID() != stored.GetAggregateID() ||
+				upcasted.GetVersion() != stored.GetVersion() || string(upcasted.GetMetadata()) != string(stored.GetMetadata()) {
+				t.Errorf("UpcastEvent() = %s, want the stored event id, aggregate, version and metadata of %s", upcasted.String(), stored.String())
+			}
+			if stored.GetEventType() != tt.eventType {
+				t.Errorf("stored event type changed to %s", stored.GetEventType())
+			}
+		})
+	}
+}
+
+func TestUpcasterRegistryRegisterTwice(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Register() of the registered event type did not panic")
+		}
+	}()
+
+	upcast := func(event es.Event) (es.Event, error) { return event, nil }
+	es.NewUpcasterRegistry().Register(counterIncrementedV1, upcast).Register(counterIncrementedV1, upcast)
+}
+
+// eventTypesProjection records the event types it processed.
+type eventTypesProjection struct {
+	eventTypes []string
+}
+
+func (p *eventTypesProjection) When(ctx context.Context, evt es.Event) error {
+	p.eventTypes = append(p.eventTypes, evt.GetEventType())
+	return nil
+}
+
+func TestUpcastingProjection(t *testing.T) {
+	projection := &eventTypesProjection{}
+	upcasting := es.NewUpcastingProjection(projection, newCounterUpcaster())
+
+	events := []es.Event{
+		newStoredEvent(t, counterIncrementedV1, counterIncrementedEventV1{By: 1}),
+		newStoredEvent(t, counterIncrementedV3, counterIncrementedEventV3{Amount: 1}),
+		newStoredEvent(t, counterIncremented, nil),
+	}
+	for _, event := range events {
+		if err := upcasting.When(context.Background(), event); err != nil {
+			t.Fatalf("When() err: %v", err)
+		}
+	}
+
+	want := []string{counterIncrementedV3, counterIncrementedV3, counterIncremented}
+	if len(projection.eventTypes) != len(want) {
+		t.Fatalf("projection processed %v, want %v", projection.eventTypes, want)
+	}
+	for i := range want {
+		if projection.eventTypes[i] != want[i] {
+			t.Errorf("projection processed %v, want %v", projection.eventTypes, want)
+		}
+	}
+
+	invalid := es.NewUpcastingProjection(projection, es.NewUpcasterRegistry().Register(counterIncrementedV1, func(event es.Event) (es.Event, error) {
+		return event, nil
+	}))
+	if err := invalid.When(context.Background(), events[0]); !errors.Is(err, es.ErrInvalidUpcaster) {
+		t.Errorf("When() err = %v, want %v", err, es.ErrInvalidUpcaster)
+	}
+}
@@ -1,7 +1,11 @@
 package eventstroredb
 
 import (
+	"context"
+	"io"
+
 	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/pkg/errors"
 )
 
 func NewEventStoreDB(cfg EventStoreConfig) (*esdb.Client, error) {
@@ -12,3 +16,17 @@ func NewEventStoreDB(cfg EventStoreConfig) (*esdb.Client, error) {
 
 	return esdb.NewClient(settings)
 }
+
+// Ping checks the connection reading the last event of $all.
+func Ping(ctx context.Context, db *esdb.Client) error {
+	stream, err := db.ReadAll(ctx, esdb.ReadAllOptions{Direction: esdb.Backwards, From: esdb.End{}}, 1)
+	if err != nil {
+		return errors.Wrap(err, "db.ReadAll")
+	}
+	defer stream.Close()
+
+	if _, err := stream.Recv(); err != nil && !errors.Is(err, io.EOF) {
+		return errors.Wrap(err, "stream.Recv")
+	}
+	return nil
+}
@@ -3,7 +3,10 @@ package grpcErrors
 import (
 	"context"
 	"database/sql"
+	"github.com/AleksK1NG/es-microservice/pkg/auth"
 	"github.com/AleksK1NG/es-microservice/pkg/constants"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/ratelimit"
 	"github.com/AleksK1NG/es-microservice/pkg/utils"
 	"github.com/EventStore/EventStore-Client-Go/esdb"
 	"github.com/pkg/errors"
@@ -23,7 +26,7 @@ func ErrResponse(err error) error {
 //GetErrStatusCode get error status code from error
 func GetErrStatusCode(err error) codes.Code {
 	switch {
-	case errors.Is(err, sql.ErrNoRows):
+	case errors.Is(err, sql.ErrNoRows), errors.Is(err, es.ErrAggregateNotFound):
 		return codes.NotFound
 	case errors.Is(err, context.Canceled):
 		return codes.Canceled
@@ -31,6 +34,22 @@ func GetErrStatusCode(err error) codes.Code {
 		return codes.DeadlineExceeded
 	case errors.Is(err, ErrNoCtxMetaData):
 		return codes.Unauthenticated
+	case errors.Is(err, es.ErrConcurrencyConflict), errors.Is(err, es.ErrIdempotencyKeyInProgress):
+		return codes.Aborted
+	case errors.Is(err, es.ErrIdempotencyKeyReused):
+		return codes.FailedPrecondition
+	case errors.Is(err, auth.ErrMissingToken), errors.Is(err, auth.ErrInvalidToken):
+		return codes.Unauthenticated
+	case errors.Is(err, auth.ErrForbidden):
+		return codes.PermissionDenied
+	case errors.Is(err, es.ErrTenantRequired):
+		return codes.InvalidArgument
+	case errors.Is(err, es.ErrUnknownTenant):
+		return codes.PermissionDenied
+	case errors.Is(err, ratelimit.ErrRateLimited):
+		return codes.ResourceExhausted
+	case errors.Is(err, utils.ErrInvalidCursor):
+		return codes.InvalidArgument
 	case CheckErrMessage(err, constants.Validate):
 		return codes.InvalidArgument
 	case CheckErrMessage(err, constants.Redis):
@@ -5,7 +5,11 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"github.com/AleksK1NG/es-microservice/pkg/auth"
 	"github.com/AleksK1NG/es-microservice/pkg/constants"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/ratelimit"
+	"github.com/AleksK1NG/es-microservice/pkg/utils"
 	"github.com/pkg/errors"
 	"net/http"
 	"strings"
@@ -19,7 +23,11 @@ const (
 	ErrBadRequest          = "Bad request"
 	ErrNotFound            = "Not Found"
 	ErrUnauthorized        = "Unauthorized"
+	ErrForbidden           = "Forbidden"
+	ErrConflict            = "Conflict"
+	ErrUnprocessableEntity = "Unprocessable Entity"
 	ErrRequestTimeout      = "Request Timeout"
+	ErrTooManyRequests     = "Too Many Requests"
 	ErrInvalidEmail        = "Invalid email"
 	ErrInvalidPassword     = "Invalid password"
 	ErrInvalidField        = "Invalid field"
@@ -174,7 +182,7 @@ func NewInternalServerError(ctx echo.Context, causes interface{}, debug bool) er
 // ParseErrors Parser of error string messages returns RestError
 func ParseErrors(err error, debug bool) RestErr {
 	switch {
-	case errors.Is(err, sql.ErrNoRows):
+	case errors.Is(err, sql.ErrNoRows), errors.Is(err, es.ErrAggregateNotFound):
 		return NewRestError(http.StatusNotFound, ErrNotFound, err.Error(), debug)
 	case errors.Is(err, context.DeadlineExceeded):
 		return NewRestError(http.StatusRequestTimeout, ErrRequestTimeout, err.Error(), debug)
@@ -182,6 +190,22 @@ func ParseErrors(err error, debug bool) RestErr {
 		return NewRestError(http.StatusUnauthorized, ErrUnauthorized, err.Error(), debug)
 	case errors.Is(err, WrongCredentials):
 		return NewRestError(http.StatusUnauthorized, ErrUnauthorized, err.Error(), debug)
+	case errors.Is(err, es.ErrConcurrencyConflict), errors.Is(err, es.ErrIdempotencyKeyInProgress):
+		return NewRestError(http.StatusConflict, ErrConflict, err.Error(), debug)
+	case errors.Is(err, es.ErrIdempotencyKeyReused):
+		return NewRestError(http.StatusUnprocessableEntity, ErrUnprocessableEntity, err.Error(), debug)
+	case errors.Is(err, auth.ErrMissingToken), errors.Is(err, auth.ErrInvalidToken):
+		return NewRestError(http.StatusUnauthorized, ErrUnauthorized, err.Error(), debug)
+	case errors.Is(err, auth.ErrForbidden):
+		return NewRestError(http.StatusForbidden, ErrForbidden, err.Error(), debug)
+	case errors.Is(err, es.ErrTenantRequired):
+		return NewRestError(http.StatusBadRequest, ErrBadRequest, err.Error(), debug)
+	case errors.Is(err, es.ErrUnknownTenant):
+		return NewRestError(http.StatusForbidden, ErrForbidden, err.Error(), debug)
+	case errors.Is(err, ratelimit.ErrRateLimited):
+		return NewRestError(http.StatusTooManyRequests, ErrTooManyRequests, err.Error(), debug)
+	case errors.Is(err, utils.ErrInvalidCursor):
+		return NewRestError(http.StatusBadRequest, ErrBadRequest, err.Error(), debug)
 	case strings.Contains(strings.ToLower(err.Error()), constants.SQLState):
 		return parseSqlErrors(err, debug)
 	case strings.Contains(strings.ToLower(err.Error()), "field validation"):
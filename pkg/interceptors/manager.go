@@ -2,14 +2,27 @@ package interceptors
 
 import (
 	"context"
+	"github.com/AleksK1NG/es-microservice/config"
+	"github.com/AleksK1NG/es-microservice/pkg/auth"
+	"github.com/AleksK1NG/es-microservice/pkg/constants"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	grpcErrors "github.com/AleksK1NG/es-microservice/pkg/grpc_errors"
 	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/ratelimit"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"net"
+	"strings"
 	"time"
 )
 
 type GrpcMetricsCb func(err error)
 
+// reflectionMethodsPrefix server reflection registered in the development mode doesn't require authentication.
+const reflectionMethodsPrefix = "/grpc.reflection."
+
 type InterceptorManager interface {
 	Logger(
 		ctx context.Context,
@@ -17,6 +30,42 @@ type InterceptorManager interface {
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (resp interface{}, err error)
+	Tenant(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (resp interface{}, err error)
+	StreamTenant(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error
+	Auth(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (resp interface{}, err error)
+	StreamAuth(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error
+	RateLimit(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (resp interface{}, err error)
+	StreamRateLimit(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error
 	ClientRequestLoggerInterceptor() func(
 		ctx context.Context,
 		method string,
@@ -31,12 +80,22 @@ type InterceptorManager interface {
 // InterceptorManager struct
 type interceptorManager struct {
 	log       logger.Logger
+	cfg       *config.Config
+	verifier  auth.Verifier
+	limiter   ratelimit.Limiter
 	metricsCb GrpcMetricsCb
 }
 
-// NewInterceptorManager InterceptorManager constructor
-func NewInterceptorManager(logger logger.Logger, metricsCb GrpcMetricsCb) *interceptorManager {
-	return &interceptorManager{log: logger, metricsCb: metricsCb}
+// NewInterceptorManager InterceptorManager constructor,
+// verifier and limiter are used only if the authentication and the rate limiting are enabled.
+func NewInterceptorManager(
+	logger logger.Logger,
+	cfg *config.Config,
+	verifier auth.Verifier,
+	limiter ratelimit.Limiter,
+	metricsCb GrpcMetricsCb,
+) *interceptorManager {
+	return &interceptorManager{log: logger, cfg: cfg, verifier: verifier, limiter: limiter, metricsCb: metricsCb}
 }
 
 // Logger Interceptor
@@ -57,6 +116,167 @@ func (im *interceptorManager) Logger(
 	return reply, err
 }
 
+// Tenant Interceptor puts the tenant id of the x-tenant-id metadata to the request context, rejects unknown tenants.
+func (im *interceptorManager) Tenant(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (resp interface{}, err error) {
+	ctx, err = im.withTenantID(ctx, info.FullMethod)
+	if err != nil {
+		return nil, grpcErrors.ErrResponse(err)
+	}
+	return handler(ctx, req)
+}
+
+// StreamTenant Interceptor puts the tenant id of the x-tenant-id metadata to the stream context, rejects unknown tenants.
+func (im *interceptorManager) StreamTenant(
+	srv interface{},
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, err := im.withTenantID(stream.Context(), info.FullMethod)
+	if err != nil {
+		return grpcErrors.ErrResponse(err)
+	}
+
+	wrappedStream := grpc_middleware.WrapServerStream(stream)
+	wrappedStream.WrappedContext = ctx
+	return handler(srv, wrappedStream)
+}
+
+func (im *interceptorManager) withTenantID(ctx context.Context, method string) (context.Context, error) {
+	var tenantID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(constants.TenantIDMetadata); len(values) > 0 {
+			tenantID = values[0]
+		}
+	}
+
+	if err := im.cfg.EventSourcing.Tenancy.ValidateTenantID(tenantID); err != nil {
+		im.log.Warnf("(Tenant) method: {%s}, tenantID: {%s}, err: {%v}", method, tenantID, err)
+		return nil, err
+	}
+	if err := auth.CheckTenantAccess(ctx, tenantID); err != nil {
+		im.log.Warnf("(Tenant) method: {%s}, tenantID: {%s}, err: {%v}", method, tenantID, err)
+		return nil, err
+	}
+	return es.WithTenantID(ctx, tenantID), nil
+}
+
+// Auth Interceptor verifies the bearer token of the authorization metadata and puts the caller identity to the request context.
+func (im *interceptorManager) Auth(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (resp interface{}, err error) {
+	ctx, err = im.withIdentity(ctx, info.FullMethod)
+	if err != nil {
+		return nil, grpcErrors.ErrResponse(err)
+	}
+	return handler(ctx, req)
+}
+
+// StreamAuth Interceptor verifies the bearer token of the authorization metadata and puts the caller identity to the stream context.
+func (im *interceptorManager) StreamAuth(
+	srv interface{},
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, err := im.withIdentity(stream.Context(), info.FullMethod)
+	if err != nil {
+		return grpcErrors.ErrResponse(err)
+	}
+
+	wrappedStream := grpc_middleware.WrapServerStream(stream)
+	wrappedStream.WrappedContext = ctx
+	return handler(srv, wrappedStream)
+}
+
+func (im *interceptorManager) withIdentity(ctx context.Context, method string) (context.Context, error) {
+	if !im.cfg.Auth.Enable || strings.HasPrefix(method, reflectionMethodsPrefix) {
+		return ctx, nil
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, grpcErrors.ErrNoCtxMetaData
+	}
+	var authorization string
+	if values := md.Get(constants.AuthorizationMetadata); len(values) > 0 {
+		authorization = values[0]
+	}
+
+	token, err := auth.GetBearerToken(authorization)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := im.verifier.Verify(token)
+	if err != nil {
+		im.log.Warnf("(Auth) method: {%s}, err: {%v}", method, err)
+		return nil, err
+	}
+	return auth.WithIdentity(ctx, identity), nil
+}
+
+// RateLimit Interceptor limits the client requests of the method, must be chained after Auth to limit the authenticated callers by subject.
+func (im *interceptorManager) RateLimit(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (resp interface{}, err error) {
+	if retryAfter, err := im.allow(ctx, info.FullMethod); err != nil {
+		if err := grpc.SetHeader(ctx, metadata.Pairs(constants.RetryAfterMetadata, ratelimit.GetRetryAfter(retryAfter))); err != nil {
+			im.log.Warnf("(RateLimit) [SetHeader] err: {%v}", err)
+		}
+		return nil, grpcErrors.ErrResponse(err)
+	}
+	return handler(ctx, req)
+}
+
+// StreamRateLimit Interceptor limits the client streams of the method, must be chained after StreamAuth to limit the authenticated callers by subject.
+func (im *interceptorManager) StreamRateLimit(
+	srv interface{},
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if retryAfter, err := im.allow(stream.Context(), info.FullMethod); err != nil {
+		if err := stream.SetHeader(metadata.Pairs(constants.RetryAfterMetadata, ratelimit.GetRetryAfter(retryAfter))); err != nil {
+			im.log.Warnf("(StreamRateLimit) [SetHeader] err: {%v}", err)
+		}
+		return grpcErrors.ErrResponse(err)
+	}
+	return handler(srv, stream)
+}
+
+func (im *interceptorManager) allow(ctx context.Context, method string) (time.Duration, error) {
+	if !im.cfg.RateLimit.Enable {
+		return 0, nil
+	}
+
+	var ip string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ip = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+	}
+
+	retryAfter, err := im.limiter.Allow(method, ratelimit.GetClientKey(ctx, ip))
+	if err != nil {
+		im.log.Warnf("(RateLimit) retryAfter: {%s}, err: {%v}", retryAfter, err)
+		return retryAfter, err
+	}
+	return 0, nil
+}
+
 // ClientRequestLoggerInterceptor gRPC client interceptor
 func (im *interceptorManager) ClientRequestLoggerInterceptor() func(
 	ctx context.Context,
@@ -0,0 +1,137 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AleksK1NG/es-microservice/config"
+	"github.com/AleksK1NG/es-microservice/pkg/auth"
+	"github.com/AleksK1NG/es-microservice/pkg/constants"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// tokensVerifier returns identity of the known tokens.
+type tokensVerifier map[string]*auth.Identity
+
+func (v tokensVerifier) Verify(token string) (*auth.Identity, error) {
+	identity, ok := v[token]
+	if !ok {
+		return nil, errors.Wrap(auth.ErrInvalidToken, "unknown token")
+	}
+	return identity, nil
+}
+
+func newTestLogger() logger.Logger {
+	appLogger := logger.NewAppLogger(&logger.Config{LogLevel: "error", Encoder: "console"})
+	appLogger.InitLogger()
+	return appLogger
+}
+
+func TestAuth(t *testing.T) {
+	customer := &auth.Identity{Subject: "customer-1", Email: "customer@mail.com"}
+	verifier := tokensVerifier{"customer-token": customer}
+
+	tests := []struct {
+		name     string
+		enable   bool
+		method   string
+		metadata metadata.MD
+		code     codes.Code
+		identity *auth.Identity
+	}{
+		{name: "valid token", enable: true, metadata: metadata.Pairs(constants.AuthorizationMetadata, "Bearer customer-token"), code: codes.OK, identity: customer},
+		{name: "no metadata", enable: true, code: codes.Unauthenticated},
+		{name: "missing authorization", enable: true, metadata: metadata.Pairs(constants.TenantIDMetadata, "tenantA"), code: codes.Unauthenticated},
+		{name: "empty bearer", enable: true, metadata: metadata.Pairs(constants.AuthorizationMetadata, "Bearer "), code: codes.Unauthenticated},
+		{name: "basic scheme", enable: true, metadata: metadata.Pairs(constants.AuthorizationMetadata, "Basic dXNlcjpwYXNz"), code: codes.Unauthenticated},
+		{name: "token without scheme", enable: true, metadata: metadata.Pairs(constants.AuthorizationMetadata, "customer-token"), code: codes.Unauthenticated},
+		{name: "invalid token", enable: true, metadata: metadata.Pairs(constants.AuthorizationMetadata, "Bearer other-token"), code: codes.Unauthenticated},
+		{name: "server reflection", enable: true, method: "/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo", code: codes.OK},
+		{name: "authentication disabled", code: codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Auth: auth.Config{Enable: tt.enable}}
+			im := NewInterceptorManager(newTestLogger(), cfg, verifier, nil, nil)
+
+			ctx := context.Background()
+			if tt.metadata != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.metadata)
+			}
+			method := tt.method
+			if method == "" {
+				method = "/orderService.orderService/GetOrderByID"
+			}
+
+			var identity *auth.Identity
+			_, err := im.Auth(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				identity = auth.GetIdentity(ctx)
+				return nil, nil
+			})
+
+			if code := status.Code(err); code != tt.code {
+				t.Errorf("Auth() code = %s, want %s, err: %v", code, tt.code, err)
+			}
+			if identity != tt.identity {
+				t.Errorf("Auth() identity = %v, want %v", identity, tt.identity)
+			}
+		})
+	}
+}
+
+func TestTenant(t *testing.T) {
+	tests := []struct {
+		name     string
+		tenancy  es.TenancyConfig
+		metadata metadata.MD
+		identity *auth.Identity
+		code     codes.Code
+		// tenant id put to the request context
+		ctxTenantID string
+	}{
+		{name: "known tenant", tenancy: es.TenancyConfig{Tenants: []string{"tenantA"}}, metadata: metadata.Pairs(constants.TenantIDMetadata, "tenantA"), code: codes.OK, ctxTenantID: "tenantA"},
+		{name: "no metadata", tenancy: es.TenancyConfig{Tenants: []string{"tenantA"}}, code: codes.OK},
+		{name: "unknown tenant", tenancy: es.TenancyConfig{Tenants: []string{"tenantA"}}, metadata: metadata.Pairs(constants.TenantIDMetadata, "tenantB"), code: codes.PermissionDenied},
+		{name: "tenant required", tenancy: es.TenancyConfig{Required: true, Tenants: []string{"tenantA"}}, code: codes.InvalidArgument},
+		{name: "caller of the tenant", tenancy: es.TenancyConfig{Tenants: []string{"tenantA"}}, metadata: metadata.Pairs(constants.TenantIDMetadata, "tenantA"), identity: &auth.Identity{Subject: "customer-1", TenantID: "tenantA"}, code: codes.OK, ctxTenantID: "tenantA"},
+		{name: "caller of other tenant", tenancy: es.TenancyConfig{Tenants: []string{"tenantA", "tenantB"}}, metadata: metadata.Pairs(constants.TenantIDMetadata, "tenantB"), identity: &auth.Identity{Subject: "customer-1", TenantID: "tenantA"}, code: codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{EventSourcing: es.Config{Tenancy: tt.tenancy}}
+			im := NewInterceptorManager(newTestLogger(), cfg, nil, nil, nil)
+
+			ctx := context.Background()
+			if tt.metadata != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.metadata)
+			}
+			if tt.identity != nil {
+				ctx = auth.WithIdentity(ctx, tt.identity)
+			}
+
+			called := false
+			_, err := im.Tenant(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/orderService.orderService/CreateOrder"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				if tenantID := es.GetTenantID(ctx); tenantID != tt.ctxTenantID {
+					t.Errorf("request context tenant id = %q, want %q", tenantID, tt.ctxTenantID)
+				}
+				return nil, nil
+			})
+
+			if code := status.Code(err); code != tt.code {
+				t.Errorf("Tenant() code = %s, want %s, err: %v", code, tt.code, err)
+			}
+			if called != (tt.code == codes.OK) {
+				t.Errorf("Tenant() called handler = %v, want %v", called, tt.code == codes.OK)
+			}
+		})
+	}
+}
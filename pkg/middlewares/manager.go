@@ -1,8 +1,14 @@
 package middlewares
 
 import (
+	"fmt"
 	"github.com/AleksK1NG/es-microservice/config"
+	"github.com/AleksK1NG/es-microservice/pkg/auth"
+	"github.com/AleksK1NG/es-microservice/pkg/constants"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	httpErrors "github.com/AleksK1NG/es-microservice/pkg/http_errors"
 	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/ratelimit"
 	"github.com/labstack/echo/v4"
 	"strings"
 	"time"
@@ -12,16 +18,29 @@ type MiddlewareMetricsCb func(err error)
 
 type MiddlewareManager interface {
 	RequestLoggerMiddleware(next echo.HandlerFunc) echo.HandlerFunc
+	TenantMiddleware(next echo.HandlerFunc) echo.HandlerFunc
+	AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc
+	StaffMiddleware(next echo.HandlerFunc) echo.HandlerFunc
+	RateLimitMiddleware(next echo.HandlerFunc) echo.HandlerFunc
 }
 
 type middlewareManager struct {
 	log       logger.Logger
 	cfg       *config.Config
+	verifier  auth.Verifier
+	limiter   ratelimit.Limiter
 	metricsCb MiddlewareMetricsCb
 }
 
-func NewMiddlewareManager(log logger.Logger, cfg *config.Config, metricsCb MiddlewareMetricsCb) *middlewareManager {
-	return &middlewareManager{log: log, cfg: cfg, metricsCb: metricsCb}
+// NewMiddlewareManager verifier and limiter are used only if the authentication and the rate limiting are enabled.
+func NewMiddlewareManager(
+	log logger.Logger,
+	cfg *config.Config,
+	verifier auth.Verifier,
+	limiter ratelimit.Limiter,
+	metricsCb MiddlewareMetricsCb,
+) *middlewareManager {
+	return &middlewareManager{log: log, cfg: cfg, verifier: verifier, limiter: limiter, metricsCb: metricsCb}
 }
 
 func (mw *middlewareManager) RequestLoggerMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
@@ -45,6 +64,77 @@ func (mw *middlewareManager) RequestLoggerMiddleware(next echo.HandlerFunc) echo
 	}
 }
 
+// TenantMiddleware puts the tenant id of the X-Tenant-ID header to the request context, rejects unknown tenants.
+func (mw *middlewareManager) TenantMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		tenantID := ctx.Request().Header.Get(constants.TenantIDHeader)
+		if err := mw.cfg.EventSourcing.Tenancy.ValidateTenantID(tenantID); err != nil {
+			mw.log.Warnf("(TenantMiddleware) tenantID: {%s}, err: {%v}", tenantID, err)
+			return httpErrors.ErrorCtxResponse(ctx, err, mw.cfg.Http.DebugErrorsResponse)
+		}
+		if err := auth.CheckTenantAccess(ctx.Request().Context(), tenantID); err != nil {
+			mw.log.Warnf("(TenantMiddleware) tenantID: {%s}, err: {%v}", tenantID, err)
+			return httpErrors.ErrorCtxResponse(ctx, err, mw.cfg.Http.DebugErrorsResponse)
+		}
+
+		ctx.SetRequest(ctx.Request().WithContext(es.WithTenantID(ctx.Request().Context(), tenantID)))
+		return next(ctx)
+	}
+}
+
+// AuthMiddleware verifies the bearer token of the Authorization header and puts the caller identity to the request context.
+func (mw *middlewareManager) AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		if !mw.cfg.Auth.Enable {
+			return next(ctx)
+		}
+
+		token, err := auth.GetBearerToken(ctx.Request().Header.Get(echo.HeaderAuthorization))
+		if err != nil {
+			return httpErrors.ErrorCtxResponse(ctx, err, mw.cfg.Http.DebugErrorsResponse)
+		}
+
+		identity, err := mw.verifier.Verify(token)
+		if err != nil {
+			mw.log.Warnf("(AuthMiddleware) uri: {%s}, err: {%v}", ctx.Request().RequestURI, err)
+			return httpErrors.ErrorCtxResponse(ctx, err, mw.cfg.Http.DebugErrorsResponse)
+		}
+
+		ctx.SetRequest(ctx.Request().WithContext(auth.WithIdentity(ctx.Request().Context(), identity)))
+		return next(ctx)
+	}
+}
+
+// StaffMiddleware rejects the callers without staff roles, must be used after AuthMiddleware.
+func (mw *middlewareManager) StaffMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		if err := auth.CheckStaffAccess(ctx.Request().Context()); err != nil {
+			mw.log.Warnf("(StaffMiddleware) uri: {%s}, err: {%v}", ctx.Request().RequestURI, err)
+			return httpErrors.ErrorCtxResponse(ctx, err, mw.cfg.Http.DebugErrorsResponse)
+		}
+		return next(ctx)
+	}
+}
+
+// RateLimitMiddleware limits the client requests of the route, must be used after AuthMiddleware to limit the authenticated callers by subject.
+func (mw *middlewareManager) RateLimitMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		if !mw.cfg.RateLimit.Enable {
+			return next(ctx)
+		}
+
+		route := fmt.Sprintf("%s %s", ctx.Request().Method, ctx.Path())
+		client := ratelimit.GetClientKey(ctx.Request().Context(), ctx.RealIP())
+		retryAfter, err := mw.limiter.Allow(route, client)
+		if err != nil {
+			mw.log.Warnf("(RateLimitMiddleware) retryAfter: {%s}, err: {%v}", retryAfter, err)
+			ctx.Response().Header().Set(constants.RetryAfterHeader, ratelimit.GetRetryAfter(retryAfter))
+			return httpErrors.ErrorCtxResponse(ctx, err, mw.cfg.Http.DebugErrorsResponse)
+		}
+		return next(ctx)
+	}
+}
+
 func (mw *middlewareManager) checkIgnoredURI(requestURI string, uriList []string) bool {
 	for _, s := range uriList {
 		if strings.Contains(requestURI, s) {
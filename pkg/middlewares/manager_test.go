@@ -0,0 +1,171 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/AleksK1NG/es-microservice/config"
+	"github.com/AleksK1NG/es-microservice/pkg/auth"
+	"github.com/AleksK1NG/es-microservice/pkg/constants"
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+// tokensVerifier returns identity of the known tokens.
+type tokensVerifier map[string]*auth.Identity
+
+func (v tokensVerifier) Verify(token string) (*auth.Identity, error) {
+	identity, ok := v[token]
+	if !ok {
+		return nil, errors.Wrap(auth.ErrInvalidToken, "unknown token")
+	}
+	return identity, nil
+}
+
+func newTestLogger() logger.Logger {
+	appLogger := logger.NewAppLogger(&logger.Config{LogLevel: "error", Encoder: "console"})
+	appLogger.InitLogger()
+	return appLogger
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	customer := &auth.Identity{Subject: "customer-1", Email: "customer@mail.com"}
+	verifier := tokensVerifier{"customer-token": customer}
+
+	tests := []struct {
+		name          string
+		enable        bool
+		authorization string
+		status        int
+		identity      *auth.Identity
+	}{
+		{name: "valid token", enable: true, authorization: "Bearer customer-token", status: http.StatusOK, identity: customer},
+		{name: "missing header", enable: true, status: http.StatusUnauthorized},
+		{name: "empty bearer", enable: true, authorization: "Bearer ", status: http.StatusUnauthorized},
+		{name: "basic scheme", enable: true, authorization: "Basic dXNlcjpwYXNz", status: http.StatusUnauthorized},
+		{name: "token without scheme", enable: true, authorization: "customer-token", status: http.StatusUnauthorized},
+		{name: "invalid token", enable: true, authorization: "Bearer other-token", status: http.StatusUnauthorized},
+		{name: "authentication disabled", status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Auth: auth.Config{Enable: tt.enable}}
+			mw := NewMiddlewareManager(newTestLogger(), cfg, verifier, nil, func(err error) {})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/orders", nil)
+			if tt.authorization != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			ctx := echo.New().NewContext(req, rec)
+
+			var identity *auth.Identity
+			err := mw.AuthMiddleware(func(ctx echo.Context) error {
+				identity = auth.GetIdentity(ctx.Request().Context())
+				return ctx.NoContent(http.StatusOK)
+			})(ctx)
+			if err != nil {
+				t.Fatalf("AuthMiddleware() err: %v", err)
+			}
+
+			if rec.Code != tt.status {
+				t.Errorf("AuthMiddleware() status = %d, want %d", rec.Code, tt.status)
+			}
+			if identity != tt.identity {
+				t.Errorf("AuthMiddleware() identity = %v, want %v", identity, tt.identity)
+			}
+		})
+	}
+}
+
+func TestStaffMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		identity *auth.Identity
+		status   int
+	}{
+		{name: "customer", identity: &auth.Identity{Subject: "customer-1"}, status: http.StatusForbidden},
+		{name: "staff", identity: &auth.Identity{Subject: "staff-1", Staff: true}, status: http.StatusOK},
+		{name: "authentication disabled", status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mw := NewMiddlewareManager(newTestLogger(), &config.Config{}, nil, nil, func(err error) {})
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/refund", nil)
+			if tt.identity != nil {
+				req = req.WithContext(auth.WithIdentity(req.Context(), tt.identity))
+			}
+			rec := httptest.NewRecorder()
+
+			err := mw.StaffMiddleware(func(ctx echo.Context) error {
+				return ctx.NoContent(http.StatusOK)
+			})(echo.New().NewContext(req, rec))
+			if err != nil {
+				t.Fatalf("StaffMiddleware() err: %v", err)
+			}
+			if rec.Code != tt.status {
+				t.Errorf("StaffMiddleware() status = %d, want %d", rec.Code, tt.status)
+			}
+		})
+	}
+}
+
+func TestTenantMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		tenancy  es.TenancyConfig
+		tenantID string
+		identity *auth.Identity
+		status   int
+		// tenant id put to the request context
+		ctxTenantID string
+	}{
+		{name: "known tenant", tenancy: es.TenancyConfig{Tenants: []string{"tenantA"}}, tenantID: "tenantA", status: http.StatusOK, ctxTenantID: "tenantA"},
+		{name: "default tenant", tenancy: es.TenancyConfig{Tenants: []string{"tenantA"}}, status: http.StatusOK},
+		{name: "unknown tenant", tenancy: es.TenancyConfig{Tenants: []string{"tenantA"}}, tenantID: "tenantB", status: http.StatusForbidden},
+		{name: "tenant required", tenancy: es.TenancyConfig{Required: true, Tenants: []string{"tenantA"}}, status: http.StatusBadRequest},
+		{name: "caller of the tenant", tenancy: es.TenancyConfig{Tenants: []string{"tenantA"}}, tenantID: "tenantA", identity: &auth.Identity{Subject: "customer-1", TenantID: "tenantA"}, status: http.StatusOK, ctxTenantID: "tenantA"},
+		{name: "caller of other tenant", tenancy: es.TenancyConfig{Tenants: []string{"tenantA", "tenantB"}}, tenantID: "tenantB", identity: &auth.Identity{Subject: "customer-1", TenantID: "tenantA"}, status: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{EventSourcing: es.Config{Tenancy: tt.tenancy}}
+			mw := NewMiddlewareManager(newTestLogger(), cfg, nil, nil, func(err error) {})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/orders", nil)
+			if tt.tenantID != "" {
+				req.Header.Set(constants.TenantIDHeader, tt.tenantID)
+			}
+			if tt.identity != nil {
+				req = req.WithContext(auth.WithIdentity(req.Context(), tt.identity))
+			}
+			rec := httptest.NewRecorder()
+
+			called := false
+			err := mw.TenantMiddleware(func(ctx echo.Context) error {
+				called = true
+				if tenantID := es.GetTenantID(ctx.Request().Context()); tenantID != tt.ctxTenantID {
+					t.Errorf("request context tenant id = %q, want %q", tenantID, tt.ctxTenantID)
+				}
+				return ctx.NoContent(http.StatusOK)
+			})(echo.New().NewContext(req, rec))
+			if err != nil {
+				t.Fatalf("TenantMiddleware() err: %v", err)
+			}
+
+			if rec.Code != tt.status {
+				t.Errorf("TenantMiddleware() status = %d, want %d", rec.Code, tt.status)
+			}
+			if called != (tt.status == http.StatusOK) {
+				t.Errorf("TenantMiddleware() called next = %v, want %v", called, tt.status == http.StatusOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package outbox
+
+import "time"
+
+const (
+	SinkStdout = "stdout"
+	SinkFile   = "file"
+)
+
+type Config struct {
+	Enable             bool          `mapstructure:"enable"`
+	Name               string        `mapstructure:"name"`
+	Topic              string        `mapstructure:"topic"`
+	Sink               string        `mapstructure:"sink" validate:"omitempty,oneof=stdout file"`
+	FilePath           string        `mapstructure:"filePath"`
+	CheckpointInterval int           `mapstructure:"checkpointInterval" validate:"gte=0"`
+	MaxRetries         int           `mapstructure:"maxRetries" validate:"gte=0"`
+	Backoff            time.Duration `mapstructure:"backoff"`
+	RestartDelay       time.Duration `mapstructure:"restartDelay"`
+}
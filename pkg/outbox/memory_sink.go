@@ -0,0 +1,49 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+)
+
+type memorySink struct {
+	mu       sync.RWMutex
+	messages []Message
+	err      error
+}
+
+// NewMemorySink sink which keeps published messages in memory, used by tests and local development.
+func NewMemorySink() *memorySink {
+	return &memorySink{messages: make([]Message, 0)}
+}
+
+func (s *memorySink) Publish(ctx context.Context, messages ...Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	s.messages = append(s.messages, messages...)
+	return nil
+}
+
+// Messages returns copy of the published messages.
+func (s *memorySink) Messages() []Message {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	messages := make([]Message, len(s.messages))
+	copy(messages, s.messages)
+	return messages
+}
+
+// SetError makes the next Publish calls fail with err until it is reset with nil.
+func (s *memorySink) SetError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+func (s *memorySink) Close() error {
+	return nil
+}
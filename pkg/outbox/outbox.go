@@ -0,0 +1,40 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/pkg/errors"
+)
+
+// Message is the integration event message delivered to the Sink, Key keeps aggregate id,
+// so the brokers with partitions like kafka keep per aggregate ordering.
+type Message struct {
+	Topic     string            `json:"topic"`
+	Key       string            `json:"key"`
+	Headers   map[string]string `json:"headers"`
+	Value     []byte            `json:"value"`
+	Timestamp time.Time         `json:"timestamp"`
+}
+
+// Sink is the NATS/Kafka style message producer, Publish must return error unless all the messages are delivered,
+// publisher retries them, so the sinks must tolerate duplicates.
+type Sink interface {
+	Publish(ctx context.Context, messages ...Message) error
+	Close() error
+}
+
+// Mapper maps domain event to the integration event message, nil message means the event is not published.
+type Mapper func(event es.Event) (*Message, error)
+
+// NewUpcastingMapper wraps Mapper to upcast events to the current schema before mapping.
+func NewUpcastingMapper(mapper Mapper, upcaster es.Upcaster) Mapper {
+	return func(event es.Event) (*Message, error) {
+		upcasted, err := es.UpcastEvent(upcaster, event)
+		if err != nil {
+			return nil, errors.Wrap(err, "Upcast")
+		}
+		return mapper(upcasted)
+	}
+}
@@ -0,0 +1,177 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/AleksK1NG/es-microservice/pkg/tracing"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+type MetricsCb func(err error)
+
+type publisher struct {
+	log         logger.Logger
+	cfg         Config
+	db          *esdb.Client
+	sink        Sink
+	checkpoints es.CheckpointStore
+	mapper      Mapper
+	metricsCb   MetricsCb
+}
+
+// NewPublisher integration events publisher, the event store itself is the outbox: publisher reads $all by catch-up subscription
+// from the persisted checkpoint, maps domain events to the messages and publishes them to the sink,
+// checkpoint is saved only after the message is delivered, so every message is delivered at least once.
+func NewPublisher(
+	log logger.Logger,
+	cfg Config,
+	db *esdb.Client,
+	sink Sink,
+	checkpoints es.CheckpointStore,
+	mapper Mapper,
+	metricsCb MetricsCb,
+) *publisher {
+	return &publisher{log: log, cfg: cfg, db: db, sink: sink, checkpoints: checkpoints, mapper: mapper, metricsCb: metricsCb}
+}
+
+// Run publishes events of the streams with given prefixes until ctx is done,
+// on any error subscription is restarted from the last saved checkpoint after RestartDelay.
+func (p *publisher) Run(ctx context.Context, prefixes []string) error {
+	p.log.Infof("(starting publisher) name: {%s}, topic: {%s}, prefixes: {%+v}", p.cfg.Name, p.cfg.Topic, prefixes)
+
+	for {
+		err := p.subscribe(ctx, prefixes)
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		p.log.Errorf("(publisher.subscribe) name: {%s}, err: {%v}", p.cfg.Name, err)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(p.cfg.RestartDelay):
+		}
+	}
+}
+
+func (p *publisher) subscribe(ctx context.Context, prefixes []string) error {
+	from, err := p.getStartPosition(ctx)
+	if err != nil {
+		return err
+	}
+
+	stream, err := p.db.SubscribeToAll(ctx, esdb.SubscribeToAllOptions{
+		From:   from,
+		Filter: &esdb.SubscriptionFilter{Type: esdb.StreamFilterType, Prefixes: prefixes},
+	})
+	if err != nil {
+		return errors.Wrap(err, "db.SubscribeToAll")
+	}
+	defer stream.Close() // nolint: errcheck
+
+	published := 0
+	for {
+		event := stream.Recv()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if event.SubscriptionDropped != nil {
+			return errors.Wrap(event.SubscriptionDropped.Error, "Subscription Dropped")
+		}
+		if event.EventAppeared == nil {
+			continue
+		}
+
+		recordedEvent := event.EventAppeared.OriginalEvent()
+		if err := p.publish(ctx, es.NewEventFromRecorded(recordedEvent)); err != nil {
+			return err
+		}
+
+		published++
+		if published%p.getCheckpointInterval() != 0 {
+			continue
+		}
+		checkpoint := es.NewCheckpoint(p.cfg.Name, recordedEvent.Position.Commit, recordedEvent.Position.Prepare)
+		if err := p.checkpoints.SaveCheckpoint(ctx, checkpoint); err != nil {
+			return errors.Wrap(err, "checkpoints.SaveCheckpoint")
+		}
+	}
+}
+
+func (p *publisher) publish(ctx context.Context, event es.Event) error {
+	ctx, span := tracing.StartProjectionTracerSpan(ctx, "publisher.publish", event)
+	defer span.End()
+	span.SetAttributes(attribute.String("AggregateID", event.GetAggregateID()), attribute.String("EventType", event.GetEventType()))
+
+	message, err := p.mapper(event)
+	if err != nil {
+		// mapping is deterministic, so retry never helps, skip the event instead of blocking the publisher
+		tracing.TraceErr(span, err)
+		p.metricsCb(err)
+		p.log.Errorf("(publisher.mapper) skip AggregateID: {%s}, EventType: {%s}, err: {%v}", event.GetAggregateID(), event.GetEventType(), err)
+		return nil
+	}
+	if message == nil {
+		return nil
+	}
+
+	if message.Topic == "" {
+		message.Topic = p.cfg.Topic
+	}
+	if message.Headers == nil {
+		message.Headers = make(map[string]string)
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(message.Headers))
+
+	err = p.sink.Publish(ctx, *message)
+	for attempt := 1; attempt <= p.cfg.MaxRetries && err != nil; attempt++ {
+		p.metricsCb(err)
+		p.log.Warnf("(sink.Publish) attempt: {%d}, AggregateID: {%s}, err: {%v}", attempt, event.GetAggregateID(), err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(p.cfg.Backoff * time.Duration(attempt)):
+		}
+		err = p.sink.Publish(ctx, *message)
+	}
+	p.metricsCb(err)
+	if err != nil {
+		tracing.TraceErr(span, err)
+		return errors.Wrap(err, "sink.Publish")
+	}
+
+	p.log.Debugf("(published) topic: {%s}, key: {%s}, EventType: {%s}, version: {%d}", message.Topic, message.Key, event.GetEventType(), event.GetVersion())
+	return nil
+}
+
+func (p *publisher) getStartPosition(ctx context.Context) (esdb.AllPosition, error) {
+	checkpoint, err := p.checkpoints.GetCheckpoint(ctx, p.cfg.Name)
+	if errors.Is(err, es.ErrCheckpointNotFound) {
+		return esdb.Start{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "checkpoints.GetCheckpoint")
+	}
+
+	p.log.Infof("(publisher) name: {%s}, restart from CommitPosition: {%d}", p.cfg.Name, checkpoint.CommitPosition)
+	return esdb.Position{Commit: checkpoint.CommitPosition, Prepare: checkpoint.PreparePosition}, nil
+}
+
+func (p *publisher) getCheckpointInterval() int {
+	if p.cfg.CheckpointInterval <= 0 {
+		return 1
+	}
+	return p.cfg.CheckpointInterval
+}
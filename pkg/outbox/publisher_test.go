@@ -0,0 +1,238 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AleksK1NG/es-microservice/pkg/es"
+	"github.com/AleksK1NG/es-microservice/pkg/es/memory"
+	"github.com/AleksK1NG/es-microservice/pkg/logger"
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/EventStore/EventStore-Client-Go/protos/shared"
+	api "github.com/EventStore/EventStore-Client-Go/protos/streams"
+	"google.golang.org/grpc"
+)
+
+const (
+	publisherName = "orders-publisher"
+	orderPrefix   = "order-"
+)
+
+// allServer fake eventstoredb streams service, the $all subscription delivers the events after the requested position
+// of the streams matching the filter prefixes and then waits until the subscription is closed.
+type allServer struct {
+	api.UnimplementedStreamsServer
+	events []*api.ReadResp_ReadEvent_RecordedEvent
+
+	mu sync.Mutex
+	// from commit positions of the subscriptions, 0 for the start of $all
+	from []uint64
+}
+
+// newAllServer fake $all with the events of the streams in order, commit position of every event is its number times 100.
+func newAllServer(streams ...string) *allServer {
+	server := &allServer{}
+	revisions := make(map[string]uint64)
+	for i, stream := range streams {
+		position := uint64(i+1) * 100
+		server.events = append(server.events, &api.ReadResp_ReadEvent_RecordedEvent{
+			StreamIdentifier: &shared.StreamIdentifier{StreamName: []byte(stream)},
+			StreamRevision:   revisions[stream],
+			CommitPosition:   position,
+			PreparePosition:  position,
+			Metadata: map[string]string{
+				"type":         "ORDER_EVENT",
+				"content-type": "application/json",
+				"created":      strconv.FormatInt(time.Now().UnixNano()/100, 10),
+			},
+			Data: []byte(fmt.Sprintf(`{"position":%d}`, position)),
+		})
+		revisions[stream]++
+	}
+	return server
+}
+
+func (s *allServer) Read(req *api.ReadReq, stream api.Streams_ReadServer) error {
+	options := req.GetOptions()
+	from := options.GetAll().GetPosition().GetCommitPosition()
+	prefixes := options.GetFilter().GetStreamIdentifier().GetPrefix()
+
+	s.mu.Lock()
+	s.from = append(s.from, from)
+	s.mu.Unlock()
+
+	if err := stream.Send(&api.ReadResp{Content: &api.ReadResp_Confirmation{Confirmation: &api.ReadResp_SubscriptionConfirmation{SubscriptionId: "subscription"}}}); err != nil {
+		return err
+	}
+
+	for _, event := range s.events {
+		if event.GetCommitPosition() <= from || !hasAnyPrefix(string(event.GetStreamIdentifier().GetStreamName()), prefixes) {
+			continue
+		}
+		if err := stream.Send(&api.ReadResp{Content: &api.ReadResp_Event{Event: &api.ReadResp_ReadEvent{
+			Event:    event,
+			Position: &api.ReadResp_ReadEvent_CommitPosition{CommitPosition: event.GetCommitPosition()},
+		}}}); err != nil {
+			return err
+		}
+	}
+
+	<-stream.Context().Done()
+	return nil
+}
+
+func hasAnyPrefix(stream string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(stream, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func newTestClient(t *testing.T, server api.StreamsServer) *esdb.Client {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() err: %v", err)
+	}
+	grpcServer := grpc.NewServer()
+	api.RegisterStreamsServer(grpcServer, server)
+	go grpcServer.Serve(listener) // nolint: errcheck
+	t.Cleanup(grpcServer.Stop)
+
+	settings, err := esdb.ParseConnectionString(fmt.Sprintf("esdb://%s?tls=false", listener.Addr()))
+	if err != nil {
+		t.Fatalf("ParseConnectionString() err: %v", err)
+	}
+	db, err := esdb.NewClient(settings)
+	if err != nil {
+		t.Fatalf("NewClient() err: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func newTestLogger() logger.Logger {
+	appLogger := logger.NewAppLogger(&logger.Config{LogLevel: "error", Encoder: "console"})
+	appLogger.InitLogger()
+	return appLogger
+}
+
+// crashingSink delivers the messages to the memory sink and stops the publisher after the limit of the messages.
+type crashingSink struct {
+	*memorySink
+	limit int
+	stop  context.CancelFunc
+}
+
+func (s *crashingSink) Publish(ctx context.Context, messages ...Message) error {
+	if err := s.memorySink.Publish(ctx, messages...); err != nil {
+		return err
+	}
+	if len(s.Messages()) >= s.limit {
+		s.stop()
+	}
+	return nil
+}
+
+func testMapper(event es.Event) (*Message, error) {
+	return &Message{Key: event.GetAggregateID(), Value: event.GetData(), Timestamp: event.GetTimeStamp()}, nil
+}
+
+// runPublisher runs the publisher until it delivers limit messages, returns the values of the delivered messages.
+func runPublisher(t *testing.T, db *esdb.Client, checkpoints es.CheckpointStore, cfg Config, limit int) []string {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sink := &crashingSink{memorySink: NewMemorySink(), limit: limit, stop: cancel}
+	publisher := NewPublisher(newTestLogger(), cfg, db, sink, checkpoints, testMapper, func(err error) {})
+	if err := publisher.Run(ctx, []string{orderPrefix}); err != nil {
+		t.Fatalf("Run() err: %v", err)
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		t.Fatalf("Run() delivered %d messages, want %d", len(sink.Messages()), limit)
+	}
+
+	values := make([]string, 0, len(sink.Messages()))
+	for _, message := range sink.Messages() {
+		values = append(values, string(message.Value))
+	}
+	return values
+}
+
+func TestPublisherResumeFromCheckpoint(t *testing.T) {
+	streams := []string{"order-1", "snapshot-order-1", "order-1", "order-2", "order-2"}
+	// messages of the order streams, the snapshot stream is filtered out by the subscription
+	messages := []string{`{"position":100}`, `{"position":300}`, `{"position":400}`, `{"position":500}`}
+
+	tests := []struct {
+		name               string
+		checkpointInterval int
+		// delivered messages before the publisher is stopped
+		delivered int
+		// checkpoint saved before the restart, 0 if there is none
+		checkpoint uint64
+		// republished messages of the restarted publisher
+		republished []string
+	}{
+		{
+			name:               "checkpoint every message",
+			checkpointInterval: 1,
+			delivered:          2,
+			checkpoint:         300,
+			republished:        []string{`{"position":400}`, `{"position":500}`},
+		},
+		{
+			name:               "redelivers after checkpoint",
+			checkpointInterval: 2,
+			delivered:          3,
+			checkpoint:         300,
+			republished:        []string{`{"position":400}`, `{"position":500}`},
+		},
+		{
+			name:               "no checkpoint yet",
+			checkpointInterval: 3,
+			delivered:          2,
+			republished:        messages,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newAllServer(streams...)
+			db := newTestClient(t, server)
+			checkpoints := memory.NewCheckpointStore(newTestLogger(), memory.NewDB())
+			cfg := Config{Name: publisherName, Topic: "orders", CheckpointInterval: tt.checkpointInterval, RestartDelay: time.Millisecond}
+
+			if published := runPublisher(t, db, checkpoints, cfg, tt.delivered); fmt.Sprint(published) != fmt.Sprint(messages[:tt.delivered]) {
+				t.Errorf("published = %v, want %v", published, messages[:tt.delivered])
+			}
+
+			checkpoint, err := checkpoints.GetCheckpoint(context.Background(), publisherName)
+			if tt.checkpoint == 0 && err == nil {
+				t.Errorf("GetCheckpoint() = %+v, want none", checkpoint)
+			}
+			if tt.checkpoint != 0 && (err != nil || checkpoint.CommitPosition != tt.checkpoint) {
+				t.Fatalf("GetCheckpoint() = %+v, err: %v, want CommitPosition %d", checkpoint, err, tt.checkpoint)
+			}
+
+			// publisher restarted with the same checkpoint store delivers the rest of the events
+			republished := runPublisher(t, db, checkpoints, cfg, len(tt.republished))
+			if fmt.Sprint(republished) != fmt.Sprint(tt.republished) {
+				t.Errorf("republished = %v, want %v", republished, tt.republished)
+			}
+
+			server.mu.Lock()
+			defer server.mu.Unlock()
+			if len(server.from) != 2 || server.from[0] != 0 || server.from[1] != tt.checkpoint {
+				t.Errorf("subscriptions from = %v, want [0 %d]", server.from, tt.checkpoint)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// writerMessage is Message with json value written as is instead of base64 string.
+type writerMessage struct {
+	Topic     string            `json:"topic"`
+	Key       string            `json:"key"`
+	Headers   map[string]string `json:"headers"`
+	Value     interface{}       `json:"value"`
+	Timestamp time.Time         `json:"timestamp"`
+}
+
+type writerSink struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+// NewWriterSink sink which writes messages as json lines to the writer.
+func NewWriterSink(w io.Writer) *writerSink {
+	return &writerSink{w: w}
+}
+
+// NewStdoutSink sink which writes messages as json lines to the stdout.
+func NewStdoutSink() *writerSink {
+	return NewWriterSink(os.Stdout)
+}
+
+// NewFileSink sink which appends messages as json lines to the file.
+func NewFileSink(filePath string) (*writerSink, error) {
+	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, errors.Wrap(err, "os.OpenFile")
+	}
+	return &writerSink{w: file, closer: file}, nil
+}
+
+func (s *writerSink) Publish(ctx context.Context, messages ...Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	encoder := json.NewEncoder(s.w)
+	for _, message := range messages {
+		if err := encoder.Encode(newWriterMessage(message)); err != nil {
+			return errors.Wrap(err, "encoder.Encode")
+		}
+	}
+	return nil
+}
+
+func (s *writerSink) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
+
+func newWriterMessage(message Message) *writerMessage {
+	var value interface{} = string(message.Value)
+	if json.Valid(message.Value) {
+		value = json.RawMessage(message.Value)
+	}
+	return &writerMessage{
+		Topic:     message.Topic,
+		Key:       message.Key,
+		Headers:   message.Headers,
+		Value:     value,
+		Timestamp: message.Timestamp,
+	}
+}
@@ -3,6 +3,7 @@ package probes
 type Config struct {
 	ReadinessPath        string `mapstructure:"readinessPath"`
 	LivenessPath         string `mapstructure:"livenessPath"`
+	HealthPath           string `mapstructure:"healthPath"`
 	Port                 string `mapstructure:"port"`
 	Pprof                string `mapstructure:"pprof"`
 	PrometheusPath       string `mapstructure:"prometheusPath"`
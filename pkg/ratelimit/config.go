@@ -0,0 +1,35 @@
+package ratelimit
+
+import "time"
+
+const (
+	defaultIdleTimeout = 10 * time.Minute
+)
+
+// Config of the token bucket rate limiting of the clients requests.
+type Config struct {
+	// Enable limits the requests of each client, identified by the token subject or the ip address if not authenticated.
+	Enable bool `mapstructure:"enable"`
+	// Rate default requests per second of the client on each route, the routes are not limited if zero.
+	Rate float64 `mapstructure:"rate" validate:"gte=0"`
+	// Burst default max requests of the client on each route at once.
+	Burst int `mapstructure:"burst" validate:"gte=0"`
+	// IdleTimeout buckets of the clients without requests are removed after it, 10m by default.
+	IdleTimeout time.Duration `mapstructure:"idleTimeout"`
+	// Routes limits overriding the default one.
+	Routes []RouteConfig `mapstructure:"routes" validate:"dive"`
+}
+
+// RouteConfig limit of the http route, for example POST /api/v1/orders, or gRPC method, for example /orderService.orderService/CreateOrder.
+type RouteConfig struct {
+	Route string  `mapstructure:"route" validate:"required"`
+	Rate  float64 `mapstructure:"rate" validate:"gt=0"`
+	Burst int     `mapstructure:"burst" validate:"gt=0"`
+}
+
+func (c Config) getIdleTimeout() time.Duration {
+	if c.IdleTimeout <= 0 {
+		return defaultIdleTimeout
+	}
+	return c.IdleTimeout
+}
@@ -0,0 +1,133 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/AleksK1NG/es-microservice/pkg/auth"
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+)
+
+var (
+	ErrRateLimited = errors.New("rate limit exceeded")
+)
+
+// MetricsCb called for each rejected request.
+type MetricsCb func(route string)
+
+// Limiter limits the requests of each client per route.
+type Limiter interface {
+	// Allow takes a token of the client bucket of the route,
+	// returns ErrRateLimited and the delay until the next token if the bucket is empty.
+	Allow(route string, client string) (time.Duration, error)
+}
+
+type bucketKey struct {
+	route  string
+	client string
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+type tokenBucketLimiter struct {
+	cfg       Config
+	routes    map[string]RouteConfig
+	metricsCb MetricsCb
+
+	mu          sync.Mutex
+	buckets     map[bucketKey]*bucket
+	lastCleanup time.Time
+}
+
+func NewLimiter(cfg Config, metricsCb MetricsCb) *tokenBucketLimiter {
+	routes := make(map[string]RouteConfig, len(cfg.Routes))
+	for _, route := range cfg.Routes {
+		routes[route.Route] = route
+	}
+	return &tokenBucketLimiter{
+		cfg:         cfg,
+		routes:      routes,
+		metricsCb:   metricsCb,
+		buckets:     make(map[bucketKey]*bucket),
+		lastCleanup: time.Now(),
+	}
+}
+
+func (l *tokenBucketLimiter) Allow(route string, client string) (time.Duration, error) {
+	return l.allowAt(time.Now(), route, client)
+}
+
+func (l *tokenBucketLimiter) allowAt(now time.Time, route string, client string) (time.Duration, error) {
+	limit, burst := l.getLimit(route)
+	if limit == 0 {
+		return 0, nil
+	}
+
+	l.mu.Lock()
+	l.cleanup(now)
+	key := bucketKey{route: route, client: client}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(limit, burst)}
+		l.buckets[key] = b
+	}
+	b.lastSeen = now
+	l.mu.Unlock()
+
+	reservation := b.limiter.ReserveN(now, 1)
+	delay := reservation.DelayFrom(now)
+	if delay == 0 {
+		return 0, nil
+	}
+	reservation.CancelAt(now)
+
+	if l.metricsCb != nil {
+		l.metricsCb(route)
+	}
+	return delay, errors.Wrapf(ErrRateLimited, "route: {%s}, client: {%s}", route, client)
+}
+
+func (l *tokenBucketLimiter) getLimit(route string) (rate.Limit, int) {
+	if routeCfg, ok := l.routes[route]; ok {
+		return rate.Limit(routeCfg.Rate), routeCfg.Burst
+	}
+	if l.cfg.Burst == 0 {
+		return rate.Limit(l.cfg.Rate), 1
+	}
+	return rate.Limit(l.cfg.Rate), l.cfg.Burst
+}
+
+// cleanup removes the idle buckets, they are full again, must be called with locked mu.
+func (l *tokenBucketLimiter) cleanup(now time.Time) {
+	idleTimeout := l.cfg.getIdleTimeout()
+	if now.Sub(l.lastCleanup) < idleTimeout {
+		return
+	}
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) >= idleTimeout {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastCleanup = now
+}
+
+// GetClientKey returns the authenticated caller subject or the ip address of the not authenticated client.
+func GetClientKey(ctx context.Context, ip string) string {
+	if identity := auth.GetIdentity(ctx); identity != nil {
+		return fmt.Sprintf("sub:%s", identity.Subject)
+	}
+	return fmt.Sprintf("ip:%s", ip)
+}
+
+// GetRetryAfter returns Retry-After value, the delay in whole seconds rounded up.
+func GetRetryAfter(delay time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(delay.Seconds())))
+}
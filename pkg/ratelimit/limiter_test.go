@@ -0,0 +1,162 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestLimiterAllow(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		route   string
+		clients []string
+		allowed []bool
+		delay   time.Duration
+	}{
+		{name: "not limited", cfg: Config{Burst: 1}, route: "GET /orders", clients: []string{"a", "a", "a"}, allowed: []bool{true, true, true}},
+		{name: "default burst", cfg: Config{Rate: 2}, route: "GET /orders", clients: []string{"a", "a"}, allowed: []bool{true, false}, delay: 500 * time.Millisecond},
+		{name: "burst", cfg: Config{Rate: 1, Burst: 3}, route: "GET /orders", clients: []string{"a", "a", "a", "a"}, allowed: []bool{true, true, true, false}, delay: time.Second},
+		{name: "clients buckets", cfg: Config{Rate: 1, Burst: 1}, route: "GET /orders", clients: []string{"a", "b", "a", "b"}, allowed: []bool{true, true, false, false}, delay: time.Second},
+		{name: "route limit", cfg: Config{Rate: 1, Burst: 1, Routes: []RouteConfig{{Route: "POST /orders", Rate: 0.5, Burst: 2}}}, route: "POST /orders", clients: []string{"a", "a", "a"}, allowed: []bool{true, true, false}, delay: 2 * time.Second},
+		{name: "route limit of not limited", cfg: Config{Routes: []RouteConfig{{Route: "POST /orders", Rate: 1, Burst: 1}}}, route: "POST /orders", clients: []string{"a", "a"}, allowed: []bool{true, false}, delay: time.Second},
+		{name: "default limit of other route", cfg: Config{Routes: []RouteConfig{{Route: "POST /orders", Rate: 1, Burst: 1}}}, route: "GET /orders", clients: []string{"a", "a"}, allowed: []bool{true, true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rejected := 0
+			limiter := NewLimiter(tt.cfg, func(route string) {
+				if route != tt.route {
+					t.Errorf("metrics route = %s, want %s", route, tt.route)
+				}
+				rejected++
+			})
+
+			now := time.Now()
+			wantRejected := 0
+			for i, client := range tt.clients {
+				delay, err := limiter.allowAt(now, tt.route, client)
+				if tt.allowed[i] {
+					if err != nil || delay != 0 {
+						t.Fatalf("request %d allowAt() = %v, %v, want allowed", i, delay, err)
+					}
+					continue
+				}
+				wantRejected++
+				if !errors.Is(err, ErrRateLimited) {
+					t.Fatalf("request %d allowAt() err = %v, want %v", i, err, ErrRateLimited)
+				}
+				if delay != tt.delay {
+					t.Errorf("request %d allowAt() delay = %v, want %v", i, delay, tt.delay)
+				}
+			}
+			if rejected != wantRejected {
+				t.Errorf("metrics callback called %d times, want %d", rejected, wantRejected)
+			}
+		})
+	}
+}
+
+func TestLimiterRefillAfterRetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		rate       float64
+		retryAfter string
+	}{
+		{name: "delay under a second", rate: 4, retryAfter: "1"},
+		{name: "whole seconds", rate: 1, retryAfter: "1"},
+		{name: "delay over a second", rate: 0.4, retryAfter: "3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := NewLimiter(Config{Rate: tt.rate, Burst: 1}, nil)
+			now := time.Now()
+			if _, err := limiter.allowAt(now, "GET /orders", "a"); err != nil {
+				t.Fatalf("allowAt() err: %v", err)
+			}
+
+			delay, err := limiter.allowAt(now, "GET /orders", "a")
+			if !errors.Is(err, ErrRateLimited) {
+				t.Fatalf("allowAt() err = %v, want %v", err, ErrRateLimited)
+			}
+			if retryAfter := GetRetryAfter(delay); retryAfter != tt.retryAfter {
+				t.Errorf("GetRetryAfter(%v) = %s, want %s", delay, retryAfter, tt.retryAfter)
+			}
+
+			// rejected requests must not take the refilled token
+			if _, err := limiter.allowAt(now.Add(delay/2), "GET /orders", "a"); !errors.Is(err, ErrRateLimited) {
+				t.Fatalf("allowAt() before the delay err = %v, want %v", err, ErrRateLimited)
+			}
+			if _, err := limiter.allowAt(now.Add(delay), "GET /orders", "a"); err != nil {
+				t.Errorf("allowAt() after the delay err: %v", err)
+			}
+		})
+	}
+}
+
+func TestLimiterCleanup(t *testing.T) {
+	limiter := NewLimiter(Config{Rate: 0.001, Burst: 1, IdleTimeout: time.Minute}, nil)
+	now := time.Now()
+	limiter.lastCleanup = now
+
+	for _, client := range []string{"idle", "active"} {
+		if _, err := limiter.allowAt(now, "GET /orders", client); err != nil {
+			t.Fatalf("allowAt() err: %v", err)
+		}
+	}
+	if _, err := limiter.allowAt(now.Add(30*time.Second), "GET /orders", "active"); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("allowAt() active err = %v, want %v", err, ErrRateLimited)
+	}
+
+	tests := []struct {
+		name    string
+		at      time.Duration
+		buckets int
+	}{
+		{name: "before idle timeout", at: 59 * time.Second, buckets: 2},
+		{name: "idle bucket removed", at: 61 * time.Second, buckets: 1},
+		{name: "cleanup once per idle timeout", at: 2 * time.Minute, buckets: 1},
+		{name: "active bucket removed", at: 2*time.Minute + time.Second, buckets: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter.mu.Lock()
+			limiter.cleanup(now.Add(tt.at))
+			buckets := len(limiter.buckets)
+			limiter.mu.Unlock()
+			if buckets != tt.buckets {
+				t.Errorf("cleanup() left %d buckets, want %d", buckets, tt.buckets)
+			}
+		})
+	}
+
+	// the removed bucket is full again
+	if _, err := limiter.allowAt(now.Add(3*time.Minute), "GET /orders", "idle"); err != nil {
+		t.Errorf("allowAt() after cleanup err: %v", err)
+	}
+}
+
+func TestGetRetryAfter(t *testing.T) {
+	tests := []struct {
+		delay time.Duration
+		want  string
+	}{
+		{delay: time.Millisecond, want: "1"},
+		{delay: time.Second, want: "1"},
+		{delay: time.Second + time.Nanosecond, want: "2"},
+		{delay: 2500 * time.Millisecond, want: "3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.delay.String(), func(t *testing.T) {
+			if got := GetRetryAfter(tt.delay); got != tt.want {
+				t.Errorf("GetRetryAfter(%v) = %s, want %s", tt.delay, got, tt.want)
+			}
+		})
+	}
+}
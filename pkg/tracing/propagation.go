@@ -0,0 +1,49 @@
+package tracing
+
+import (
+	"context"
+	"go.opentelemetry.io/contrib/propagators/jaeger"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/grpc/metadata"
+)
+
+// NewPropagator propagates the trace context with W3C traceparent and baggage headers,
+// the legacy uber-trace-id header is extracted too, so the events saved before the migration to OpenTelemetry stay readable.
+func NewPropagator() propagation.TextMapPropagator {
+	// the propagators extract in order, traceparent wins if both headers are present
+	return propagation.NewCompositeTextMapPropagator(legacyPropagator{}, propagation.TraceContext{}, propagation.Baggage{})
+}
+
+// legacyPropagator extracts the trace context from the Jaeger uber-trace-id header, it is never injected.
+type legacyPropagator struct {
+	jaeger.Jaeger
+}
+
+func (p legacyPropagator) Inject(context.Context, propagation.TextMapCarrier) {}
+
+func (p legacyPropagator) Fields() []string {
+	return nil
+}
+
+// MetadataCarrier adapts gRPC metadata to propagation.TextMapCarrier.
+type MetadataCarrier metadata.MD
+
+func (c MetadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c MetadataCarrier) Set(key string, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c MetadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,89 @@
+package tracing
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+
+	instrumentationName = "github.com/AleksK1NG/es-microservice"
+)
+
+type Config struct {
+	ServiceName string `mapstructure:"serviceName" validate:"required_with=Enable"`
+	Enable      bool   `mapstructure:"enable"`
+	// Exporter of the finished spans, otlp sends them to the collector, stdout writes them for the local work.
+	Exporter string `mapstructure:"exporter" validate:"required_with=Enable,omitempty,oneof=otlp stdout"`
+	// Endpoint host:port of the OTLP gRPC collector, the exporter defaults are used if empty.
+	Endpoint string `mapstructure:"endpoint"`
+	Insecure bool   `mapstructure:"insecure"`
+	// SampleRatio of the new traces, the spans of the propagated traces follow the parent sampling decision.
+	SampleRatio float64 `mapstructure:"sampleRatio" validate:"gte=0,lte=1"`
+	PrettyPrint bool    `mapstructure:"prettyPrint"`
+}
+
+// Provider exports the spans of the service tracer.
+type Provider interface {
+	// Shutdown flushes the pending spans and stops the exporter.
+	Shutdown(ctx context.Context) error
+}
+
+// NewTracerProvider creates provider with the configured exporter and registers it with the W3C trace context propagator globally.
+func NewTracerProvider(ctx context.Context, cfg *Config) (Provider, error) {
+	exporter, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "newExporter")
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(cfg.ServiceName)))
+	if err != nil {
+		return nil, errors.Wrap(err, "resource.Merge")
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(NewPropagator())
+	return provider, nil
+}
+
+func newExporter(ctx context.Context, cfg *Config) (sdktrace.SpanExporter, error) {
+	switch cfg.Exporter {
+	case ExporterOTLP:
+		var opts []otlptracegrpc.Option
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(cfg.Endpoint))
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		return otlptracegrpc.New(ctx, opts...)
+	case ExporterStdout:
+		var opts []stdouttrace.Option
+		if cfg.PrettyPrint {
+			opts = append(opts, stdouttrace.WithPrettyPrint())
+		}
+		return stdouttrace.New(opts...)
+	default:
+		return nil, errors.Errorf("unknown exporter: {%s}", cfg.Exporter)
+	}
+}
+
+// StartSpan starts span of the service tracer, it is the child of the span carried by ctx.
+func StartSpan(ctx context.Context, operationName string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, operationName, opts...)
+}
@@ -3,99 +3,61 @@ package tracing
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/AleksK1NG/es-microservice/pkg/es"
 	"github.com/labstack/echo/v4"
-	"github.com/opentracing/opentracing-go"
-	"github.com/opentracing/opentracing-go/ext"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/metadata"
 )
 
-func StartHttpServerTracerSpan(c echo.Context, operationName string) (context.Context, opentracing.Span) {
-	spanCtx, err := opentracing.GlobalTracer().Extract(opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(c.Request().Header))
-	if err != nil {
-		serverSpan := opentracing.GlobalTracer().StartSpan(operationName)
-		ctx := opentracing.ContextWithSpan(c.Request().Context(), serverSpan)
-		return ctx, serverSpan
-	}
-
-	serverSpan := opentracing.GlobalTracer().StartSpan(operationName, ext.RPCServerOption(spanCtx))
-	ctx := opentracing.ContextWithSpan(c.Request().Context(), serverSpan)
-
-	return ctx, serverSpan
+func StartHttpServerTracerSpan(c echo.Context, operationName string) (context.Context, trace.Span) {
+	ctx := otel.GetTextMapPropagator().Extract(c.Request().Context(), propagation.HeaderCarrier(c.Request().Header))
+	return StartSpan(ctx, operationName, trace.WithSpanKind(trace.SpanKindServer))
 }
 
-func GetTextMapCarrierFromEvent(event es.Event) opentracing.TextMapCarrier {
-	metadataMap := make(opentracing.TextMapCarrier)
-	err := json.Unmarshal(event.GetMetadata(), &metadataMap)
-	if err != nil {
+// GetTextMapCarrierFromEvent returns the event metadata, it carries W3C traceparent or legacy uber-trace-id of the events saved before.
+func GetTextMapCarrierFromEvent(event es.Event) propagation.MapCarrier {
+	metadataMap := make(propagation.MapCarrier)
+	if err := json.Unmarshal(event.GetMetadata(), &metadataMap); err != nil {
 		return metadataMap
 	}
 	return metadataMap
 }
 
-func StartProjectionTracerSpan(ctx context.Context, operationName string, event es.Event) (context.Context, opentracing.Span) {
-	textMapCarrierFromMetaData := GetTextMapCarrierFromEvent(event)
-
-	span, err := opentracing.GlobalTracer().Extract(opentracing.TextMap, textMapCarrierFromMetaData)
-	if err != nil {
-		serverSpan := opentracing.GlobalTracer().StartSpan(operationName)
-		ctx = opentracing.ContextWithSpan(ctx, serverSpan)
-		return ctx, serverSpan
-	}
-
-	serverSpan := opentracing.GlobalTracer().StartSpan(operationName, ext.RPCServerOption(span))
-	ctx = opentracing.ContextWithSpan(ctx, serverSpan)
-
-	return ctx, serverSpan
+func StartProjectionTracerSpan(ctx context.Context, operationName string, event es.Event) (context.Context, trace.Span) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, GetTextMapCarrierFromEvent(event))
+	return StartSpan(ctx, operationName, trace.WithSpanKind(trace.SpanKindConsumer))
 }
 
-func GetTextMapCarrierFromMetaData(ctx context.Context) opentracing.TextMapCarrier {
-	metadataMap := make(opentracing.TextMapCarrier)
-	if md, ok := metadata.FromIncomingContext(ctx); ok {
-		for key := range md.Copy() {
-			metadataMap.Set(key, md.Get(key)[0])
-		}
+func GetTextMapCarrierFromMetaData(ctx context.Context) MetadataCarrier {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return make(MetadataCarrier)
 	}
-	return metadataMap
+	return MetadataCarrier(md)
 }
 
-func StartGrpcServerTracerSpan(ctx context.Context, operationName string) (context.Context, opentracing.Span) {
-	textMapCarrierFromMetaData := GetTextMapCarrierFromMetaData(ctx)
-
-	span, err := opentracing.GlobalTracer().Extract(opentracing.TextMap, textMapCarrierFromMetaData)
-	if err != nil {
-		serverSpan := opentracing.GlobalTracer().StartSpan(operationName)
-		ctx = opentracing.ContextWithSpan(ctx, serverSpan)
-		return ctx, serverSpan
-	}
-
-	serverSpan := opentracing.GlobalTracer().StartSpan(operationName, ext.RPCServerOption(span))
-	ctx = opentracing.ContextWithSpan(ctx, serverSpan)
-
-	return ctx, serverSpan
+func StartGrpcServerTracerSpan(ctx context.Context, operationName string) (context.Context, trace.Span) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, GetTextMapCarrierFromMetaData(ctx))
+	return StartSpan(ctx, operationName, trace.WithSpanKind(trace.SpanKindServer))
 }
 
-func InjectTextMapCarrier(spanCtx opentracing.SpanContext) (opentracing.TextMapCarrier, error) {
-	m := make(opentracing.TextMapCarrier)
-	if err := opentracing.GlobalTracer().Inject(spanCtx, opentracing.TextMap, m); err != nil {
-		return nil, err
-	}
-	return m, nil
+func InjectTextMapCarrier(ctx context.Context) propagation.MapCarrier {
+	m := make(propagation.MapCarrier)
+	otel.GetTextMapPropagator().Inject(ctx, m)
+	return m
 }
 
-func ExtractTextMapCarrier(spanCtx opentracing.SpanContext) opentracing.TextMapCarrier {
-	textMapCarrier, err := InjectTextMapCarrier(spanCtx)
-	if err != nil {
-		return make(opentracing.TextMapCarrier)
-	}
-	return textMapCarrier
+func ExtractTextMapCarrier(ctx context.Context) propagation.MapCarrier {
+	return InjectTextMapCarrier(ctx)
 }
 
-func ExtractTextMapCarrierBytes(spanCtx opentracing.SpanContext) []byte {
-	textMapCarrier, err := InjectTextMapCarrier(spanCtx)
-	if err != nil {
-		return []byte("")
-	}
+func ExtractTextMapCarrierBytes(ctx context.Context) []byte {
+	textMapCarrier := InjectTextMapCarrier(ctx)
 
 	dataBytes, err := json.Marshal(&textMapCarrier)
 	if err != nil {
@@ -104,15 +66,23 @@ func ExtractTextMapCarrierBytes(spanCtx opentracing.SpanContext) []byte {
 	return dataBytes
 }
 
-func InjectTextMapCarrierToGrpcMetaData(ctx context.Context, spanCtx opentracing.SpanContext) context.Context {
-	if textMapCarrier, err := InjectTextMapCarrier(spanCtx); err == nil {
-		md := metadata.New(textMapCarrier)
-		ctx = metadata.NewOutgoingContext(ctx, md)
+func InjectTextMapCarrierToGrpcMetaData(ctx context.Context) context.Context {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		md = metadata.New(nil)
+	} else {
+		md = md.Copy()
 	}
-	return ctx
+	otel.GetTextMapPropagator().Inject(ctx, MetadataCarrier(md))
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+func TraceErr(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
 }
 
-func TraceErr(span opentracing.Span, err error) {
-	span.SetTag("error", true)
-	span.LogKV("error_code", err.Error())
+// Object span attribute with the formatted value.
+func Object(key string, value interface{}) attribute.KeyValue {
+	return attribute.String(key, fmt.Sprintf("%+v", value))
 }
@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// cursorSignatureSeparator separates the encoded position and its signature, it is not in the url safe base64 alphabet.
+const cursorSignatureSeparator = "."
+
+// CursorConfig of the pagination cursors.
+type CursorConfig struct {
+	// Secret signs the cursors, so the clients can't change the position of the cursor.
+	Secret string `mapstructure:"secret" validate:"required"`
+	// TTL lifetime of the cursor, the cursors don't expire if zero.
+	TTL time.Duration `mapstructure:"ttl"`
+}
+
+// CursorCodec encodes the next page position into the opaque url safe pagination cursor.
+type CursorCodec struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// cursorPayload signed content of the cursor.
+type cursorPayload struct {
+	Position  json.RawMessage `json:"p"`
+	ExpiresAt int64           `json:"exp,omitempty"`
+}
+
+func NewCursorCodec(cfg CursorConfig) *CursorCodec {
+	return &CursorCodec{secret: []byte(cfg.Secret), ttl: cfg.TTL, now: time.Now}
+}
+
+// Encode returns signed cursor of the next page position, it expires after the configured TTL.
+func (c *CursorCodec) Encode(position interface{}) (string, error) {
+	positionBytes, err := json.Marshal(position)
+	if err != nil {
+		return "", errors.Wrap(err, "json.Marshal")
+	}
+
+	payload := cursorPayload{Position: positionBytes}
+	if c.ttl > 0 {
+		payload.ExpiresAt = c.now().Add(c.ttl).Unix()
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return "", errors.Wrap(err, "json.Marshal")
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payloadBytes)
+	return encoded + cursorSignatureSeparator + base64.RawURLEncoding.EncodeToString(c.sign(encoded)), nil
+}
+
+// Decode decodes the next page position of the Encode cursor, the numbers are decoded as json.Number to keep them exact,
+// returns ErrInvalidCursor if the cursor is malformed, its signature doesn't match or it is expired.
+func (c *CursorCodec) Decode(cursor string, position interface{}) error {
+	parts := strings.Split(cursor, cursorSignatureSeparator)
+	if len(parts) != 2 {
+		return errors.Wrap(ErrInvalidCursor, "malformed cursor")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return errors.Wrapf(ErrInvalidCursor, "err: {%v}", err)
+	}
+	if !hmac.Equal(signature, c.sign(parts[0])) {
+		return errors.Wrap(ErrInvalidCursor, "signature mismatch")
+	}
+
+	payloadBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return errors.Wrapf(ErrInvalidCursor, "err: {%v}", err)
+	}
+	var payload cursorPayload
+	if err := json.Unmarshal(payloadBytes, &payload); err != nil {
+		return errors.Wrapf(ErrInvalidCursor, "err: {%v}", err)
+	}
+	if payload.ExpiresAt != 0 && c.now().Unix() >= payload.ExpiresAt {
+		return errors.Wrapf(ErrInvalidCursor, "expired at: {%s}", time.Unix(payload.ExpiresAt, 0).UTC())
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(payload.Position))
+	decoder.UseNumber()
+	if err := decoder.Decode(position); err != nil {
+		return errors.Wrapf(ErrInvalidCursor, "err: {%v}", err)
+	}
+	return nil
+}
+
+func (c *CursorCodec) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
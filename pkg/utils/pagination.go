@@ -11,11 +11,14 @@ const (
 	defaultPage = 1
 )
 
-// Pagination query params
+// Pagination query params, not empty Cursor continues from the position of the previous page instead of the page offset,
+// UseCursor requests the first page of the cursor pagination.
 type Pagination struct {
-	Size    int    `json:"size,omitempty"`
-	Page    int    `json:"page,omitempty"`
-	OrderBy string `json:"orderBy,omitempty"`
+	Size      int    `json:"size,omitempty"`
+	Page      int    `json:"page,omitempty"`
+	OrderBy   string `json:"orderBy,omitempty"`
+	Cursor    string `json:"cursor,omitempty"`
+	UseCursor bool   `json:"useCursor,omitempty"`
 }
 
 // NewPaginationQuery Pagination query constructor
@@ -75,6 +78,26 @@ func (q *Pagination) SetOrderBy(orderByQuery string) {
 	q.OrderBy = orderByQuery
 }
 
+// SetCursor Set cursor
+func (q *Pagination) SetCursor(cursor string) {
+	q.Cursor = cursor
+}
+
+// GetCursor Get cursor
+func (q *Pagination) GetCursor() string {
+	return q.Cursor
+}
+
+// SetUseCursor Set use cursor
+func (q *Pagination) SetUseCursor(useCursor bool) {
+	q.UseCursor = useCursor
+}
+
+// GetUseCursor returns true if the client uses the cursor pagination, by the cursor of the previous page or by UseCursor.
+func (q *Pagination) GetUseCursor() bool {
+	return q.UseCursor || q.Cursor != ""
+}
+
 // GetOffset Get offset
 func (q *Pagination) GetOffset() int {
 	if q.Page == 0 {
@@ -29,8 +29,16 @@ type OrderServiceClient interface {
 	CancelOrder(ctx context.Context, in *CancelOrderReq, opts ...grpc.CallOption) (*CancelOrderRes, error)
 	CompleteOrder(ctx context.Context, in *CompleteOrderReq, opts ...grpc.CallOption) (*CompleteOrderRes, error)
 	ChangeDeliveryAddress(ctx context.Context, in *ChangeDeliveryAddressReq, opts ...grpc.CallOption) (*ChangeDeliveryAddressRes, error)
+	AddShopItem(ctx context.Context, in *AddShopItemReq, opts ...grpc.CallOption) (*AddShopItemRes, error)
+	RemoveShopItem(ctx context.Context, in *RemoveShopItemReq, opts ...grpc.CallOption) (*RemoveShopItemRes, error)
+	ChangeItemQuantity(ctx context.Context, in *ChangeItemQuantityReq, opts ...grpc.CallOption) (*ChangeItemQuantityRes, error)
+	RefundOrder(ctx context.Context, in *RefundOrderReq, opts ...grpc.CallOption) (*RefundOrderRes, error)
 	GetOrderByID(ctx context.Context, in *GetOrderByIDReq, opts ...grpc.CallOption) (*GetOrderByIDRes, error)
 	Search(ctx context.Context, in *SearchReq, opts ...grpc.CallOption) (*SearchRes, error)
+	ListOrdersByAccount(ctx context.Context, in *ListOrdersByAccountReq, opts ...grpc.CallOption) (*ListOrdersByAccountRes, error)
+	GetOrderHistory(ctx context.Context, in *GetOrderHistoryReq, opts ...grpc.CallOption) (*GetOrderHistoryRes, error)
+	GetOrderAt(ctx context.Context, in *GetOrderAtReq, opts ...grpc.CallOption) (*GetOrderAtRes, error)
+	WatchOrder(ctx context.Context, in *WatchOrderReq, opts ...grpc.CallOption) (OrderService_WatchOrderClient, error)
 }
 
 type orderServiceClient struct {
@@ -104,6 +112,42 @@ func (c *orderServiceClient) ChangeDeliveryAddress(ctx context.Context, in *Chan
 	return out, nil
 }
 
+func (c *orderServiceClient) AddShopItem(ctx context.Context, in *AddShopItemReq, opts ...grpc.CallOption) (*AddShopItemRes, error) {
+	out := new(AddShopItemRes)
+	err := c.cc.Invoke(ctx, "/orderService.orderService/AddShopItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) RemoveShopItem(ctx context.Context, in *RemoveShopItemReq, opts ...grpc.CallOption) (*RemoveShopItemRes, error) {
+	out := new(RemoveShopItemRes)
+	err := c.cc.Invoke(ctx, "/orderService.orderService/RemoveShopItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) ChangeItemQuantity(ctx context.Context, in *ChangeItemQuantityReq, opts ...grpc.CallOption) (*ChangeItemQuantityRes, error) {
+	out := new(ChangeItemQuantityRes)
+	err := c.cc.Invoke(ctx, "/orderService.orderService/ChangeItemQuantity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) RefundOrder(ctx context.Context, in *RefundOrderReq, opts ...grpc.CallOption) (*RefundOrderRes, error) {
+	out := new(RefundOrderRes)
+	err := c.cc.Invoke(ctx, "/orderService.orderService/RefundOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *orderServiceClient) GetOrderByID(ctx context.Context, in *GetOrderByIDReq, opts ...grpc.CallOption) (*GetOrderByIDRes, error) {
 	out := new(GetOrderByIDRes)
 	err := c.cc.Invoke(ctx, "/orderService.orderService/GetOrderByID", in, out, opts...)
@@ -122,6 +166,65 @@ func (c *orderServiceClient) Search(ctx context.Context, in *SearchReq, opts ...
 	return out, nil
 }
 
+func (c *orderServiceClient) ListOrdersByAccount(ctx context.Context, in *ListOrdersByAccountReq, opts ...grpc.CallOption) (*ListOrdersByAccountRes, error) {
+	out := new(ListOrdersByAccountRes)
+	err := c.cc.Invoke(ctx, "/orderService.orderService/ListOrdersByAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetOrderHistory(ctx context.Context, in *GetOrderHistoryReq, opts ...grpc.CallOption) (*GetOrderHistoryRes, error) {
+	out := new(GetOrderHistoryRes)
+	err := c.cc.Invoke(ctx, "/orderService.orderService/GetOrderHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetOrderAt(ctx context.Context, in *GetOrderAtReq, opts ...grpc.CallOption) (*GetOrderAtRes, error) {
+	out := new(GetOrderAtRes)
+	err := c.cc.Invoke(ctx, "/orderService.orderService/GetOrderAt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) WatchOrder(ctx context.Context, in *WatchOrderReq, opts ...grpc.CallOption) (OrderService_WatchOrderClient, error) {
+	stream, err := c.cc.NewStream(ctx, &OrderService_ServiceDesc.Streams[0], "/orderService.orderService/WatchOrder", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &orderServiceWatchOrderClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type OrderService_WatchOrderClient interface {
+	Recv() (*OrderUpdate, error)
+	grpc.ClientStream
+}
+
+type orderServiceWatchOrderClient struct {
+	grpc.ClientStream
+}
+
+func (x *orderServiceWatchOrderClient) Recv() (*OrderUpdate, error) {
+	m := new(OrderUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // OrderServiceServer is the server API for OrderService service.
 // All implementations should embed UnimplementedOrderServiceServer
 // for forward compatibility
@@ -133,8 +236,16 @@ type OrderServiceServer interface {
 	CancelOrder(context.Context, *CancelOrderReq) (*CancelOrderRes, error)
 	CompleteOrder(context.Context, *CompleteOrderReq) (*CompleteOrderRes, error)
 	ChangeDeliveryAddress(context.Context, *ChangeDeliveryAddressReq) (*ChangeDeliveryAddressRes, error)
+	AddShopItem(context.Context, *AddShopItemReq) (*AddShopItemRes, error)
+	RemoveShopItem(context.Context, *RemoveShopItemReq) (*RemoveShopItemRes, error)
+	ChangeItemQuantity(context.Context, *ChangeItemQuantityReq) (*ChangeItemQuantityRes, error)
+	RefundOrder(context.Context, *RefundOrderReq) (*RefundOrderRes, error)
 	GetOrderByID(context.Context, *GetOrderByIDReq) (*GetOrderByIDRes, error)
 	Search(context.Context, *SearchReq) (*SearchRes, error)
+	ListOrdersByAccount(context.Context, *ListOrdersByAccountReq) (*ListOrdersByAccountRes, error)
+	GetOrderHistory(context.Context, *GetOrderHistoryReq) (*GetOrderHistoryRes, error)
+	GetOrderAt(context.Context, *GetOrderAtReq) (*GetOrderAtRes, error)
+	WatchOrder(*WatchOrderReq, OrderService_WatchOrderServer) error
 }
 
 // UnimplementedOrderServiceServer should be embedded to have forward compatible implementations.
@@ -162,12 +273,36 @@ func (UnimplementedOrderServiceServer) CompleteOrder(context.Context, *CompleteO
 func (UnimplementedOrderServiceServer) ChangeDeliveryAddress(context.Context, *ChangeDeliveryAddressReq) (*ChangeDeliveryAddressRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ChangeDeliveryAddress not implemented")
 }
+func (UnimplementedOrderServiceServer) AddShopItem(context.Context, *AddShopItemReq) (*AddShopItemRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddShopItem not implemented")
+}
+func (UnimplementedOrderServiceServer) RemoveShopItem(context.Context, *RemoveShopItemReq) (*RemoveShopItemRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveShopItem not implemented")
+}
+func (UnimplementedOrderServiceServer) ChangeItemQuantity(context.Context, *ChangeItemQuantityReq) (*ChangeItemQuantityRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeItemQuantity not implemented")
+}
+func (UnimplementedOrderServiceServer) RefundOrder(context.Context, *RefundOrderReq) (*RefundOrderRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefundOrder not implemented")
+}
 func (UnimplementedOrderServiceServer) GetOrderByID(context.Context, *GetOrderByIDReq) (*GetOrderByIDRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetOrderByID not implemented")
 }
 func (UnimplementedOrderServiceServer) Search(context.Context, *SearchReq) (*SearchRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
 }
+func (UnimplementedOrderServiceServer) ListOrdersByAccount(context.Context, *ListOrdersByAccountReq) (*ListOrdersByAccountRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrdersByAccount not implemented")
+}
+func (UnimplementedOrderServiceServer) GetOrderHistory(context.Context, *GetOrderHistoryReq) (*GetOrderHistoryRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderHistory not implemented")
+}
+func (UnimplementedOrderServiceServer) GetOrderAt(context.Context, *GetOrderAtReq) (*GetOrderAtRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderAt not implemented")
+}
+func (UnimplementedOrderServiceServer) WatchOrder(*WatchOrderReq, OrderService_WatchOrderServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchOrder not implemented")
+}
 
 // UnsafeOrderServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to OrderServiceServer will
@@ -306,6 +441,78 @@ func _OrderService_ChangeDeliveryAddress_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrderService_AddShopItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddShopItemReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).AddShopItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orderService.orderService/AddShopItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).AddShopItem(ctx, req.(*AddShopItemReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_RemoveShopItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveShopItemReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).RemoveShopItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orderService.orderService/RemoveShopItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).RemoveShopItem(ctx, req.(*RemoveShopItemReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_ChangeItemQuantity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeItemQuantityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ChangeItemQuantity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orderService.orderService/ChangeItemQuantity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ChangeItemQuantity(ctx, req.(*ChangeItemQuantityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_RefundOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundOrderReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).RefundOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orderService.orderService/RefundOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).RefundOrder(ctx, req.(*RefundOrderReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _OrderService_GetOrderByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetOrderByIDReq)
 	if err := dec(in); err != nil {
@@ -342,6 +549,81 @@ func _OrderService_Search_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrderService_ListOrdersByAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrdersByAccountReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).ListOrdersByAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orderService.orderService/ListOrdersByAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).ListOrdersByAccount(ctx, req.(*ListOrdersByAccountReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetOrderHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderHistoryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetOrderHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orderService.orderService/GetOrderHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetOrderHistory(ctx, req.(*GetOrderHistoryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetOrderAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderAtReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetOrderAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orderService.orderService/GetOrderAt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetOrderAt(ctx, req.(*GetOrderAtReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_WatchOrder_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchOrderReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OrderServiceServer).WatchOrder(m, &orderServiceWatchOrderServer{stream})
+}
+
+type OrderService_WatchOrderServer interface {
+	Send(*OrderUpdate) error
+	grpc.ServerStream
+}
+
+type orderServiceWatchOrderServer struct {
+	grpc.ServerStream
+}
+
+func (x *orderServiceWatchOrderServer) Send(m *OrderUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // OrderService_ServiceDesc is the grpc.ServiceDesc for OrderService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -377,6 +659,22 @@ var OrderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ChangeDeliveryAddress",
 			Handler:    _OrderService_ChangeDeliveryAddress_Handler,
 		},
+		{
+			MethodName: "AddShopItem",
+			Handler:    _OrderService_AddShopItem_Handler,
+		},
+		{
+			MethodName: "RemoveShopItem",
+			Handler:    _OrderService_RemoveShopItem_Handler,
+		},
+		{
+			MethodName: "ChangeItemQuantity",
+			Handler:    _OrderService_ChangeItemQuantity_Handler,
+		},
+		{
+			MethodName: "RefundOrder",
+			Handler:    _OrderService_RefundOrder_Handler,
+		},
 		{
 			MethodName: "GetOrderByID",
 			Handler:    _OrderService_GetOrderByID_Handler,
@@ -385,7 +683,25 @@ var OrderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Search",
 			Handler:    _OrderService_Search_Handler,
 		},
+		{
+			MethodName: "ListOrdersByAccount",
+			Handler:    _OrderService_ListOrdersByAccount_Handler,
+		},
+		{
+			MethodName: "GetOrderHistory",
+			Handler:    _OrderService_GetOrderHistory_Handler,
+		},
+		{
+			MethodName: "GetOrderAt",
+			Handler:    _OrderService_GetOrderAt_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchOrder",
+			Handler:       _OrderService_WatchOrder_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "order.proto",
 }